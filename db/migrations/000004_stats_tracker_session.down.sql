@@ -0,0 +1,9 @@
+DROP TABLE stats_tracker_character_loadout;
+
+DROP TABLE stats_tracker_character;
+
+DROP TABLE stats_tracker_session_platform;
+
+DROP INDEX idx_stats_tracker_session;
+
+DROP TABLE stats_tracker_session;
\ No newline at end of file
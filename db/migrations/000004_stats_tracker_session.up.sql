@@ -0,0 +1,40 @@
+CREATE TABLE
+  stats_tracker_session (
+    session_id INTEGER PRIMARY KEY NOT NULL,
+    channel_id TEXT NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    stopped_at TIMESTAMP
+  );
+
+CREATE INDEX idx_stats_tracker_session ON stats_tracker_session (channel_id, started_at);
+
+CREATE TABLE
+  stats_tracker_session_platform (
+    session_id INTEGER NOT NULL REFERENCES stats_tracker_session (session_id) ON DELETE CASCADE,
+    platform TEXT NOT NULL,
+    PRIMARY KEY (session_id, platform)
+  );
+
+CREATE TABLE
+  stats_tracker_character (
+    session_id INTEGER NOT NULL REFERENCES stats_tracker_session (session_id) ON DELETE CASCADE,
+    platform TEXT NOT NULL,
+    character_id TEXT NOT NULL,
+    body_kills INTEGER NOT NULL DEFAULT 0,
+    head_shots_kills INTEGER NOT NULL DEFAULT 0,
+    team_kills INTEGER NOT NULL DEFAULT 0,
+    deaths INTEGER NOT NULL DEFAULT 0,
+    deaths_by_restricted_area INTEGER NOT NULL DEFAULT 0,
+    suicides INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (session_id, platform, character_id)
+  );
+
+CREATE TABLE
+  stats_tracker_character_loadout (
+    session_id INTEGER NOT NULL REFERENCES stats_tracker_session (session_id) ON DELETE CASCADE,
+    platform TEXT NOT NULL,
+    character_id TEXT NOT NULL,
+    loadout_type INTEGER NOT NULL,
+    duration INTEGER NOT NULL,
+    PRIMARY KEY (session_id, platform, character_id, loadout_type)
+  );
\ No newline at end of file
@@ -0,0 +1,20 @@
+ALTER TABLE stats_tracker_character
+DROP COLUMN score;
+
+ALTER TABLE stats_tracker_character
+DROP COLUMN spot_assists;
+
+ALTER TABLE stats_tracker_character
+DROP COLUMN shield_repairs;
+
+ALTER TABLE stats_tracker_character
+DROP COLUMN resupplies;
+
+ALTER TABLE stats_tracker_character
+DROP COLUMN repairs;
+
+ALTER TABLE stats_tracker_character
+DROP COLUMN heals;
+
+ALTER TABLE stats_tracker_character
+DROP COLUMN revives;
\ No newline at end of file
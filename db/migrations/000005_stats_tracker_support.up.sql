@@ -0,0 +1,20 @@
+ALTER TABLE stats_tracker_character
+ADD COLUMN revives INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE stats_tracker_character
+ADD COLUMN heals INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE stats_tracker_character
+ADD COLUMN repairs INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE stats_tracker_character
+ADD COLUMN resupplies INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE stats_tracker_character
+ADD COLUMN shield_repairs INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE stats_tracker_character
+ADD COLUMN spot_assists INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE stats_tracker_character
+ADD COLUMN score INTEGER NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE
+  stats_tracker_character_vehicle (
+    session_id INTEGER NOT NULL REFERENCES stats_tracker_session (session_id) ON DELETE CASCADE,
+    platform TEXT NOT NULL,
+    character_id TEXT NOT NULL,
+    vehicle_id TEXT NOT NULL,
+    destroyed INTEGER NOT NULL DEFAULT 0,
+    lost INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (session_id, platform, character_id, vehicle_id)
+  );
\ No newline at end of file
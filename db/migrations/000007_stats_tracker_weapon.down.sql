@@ -0,0 +1,6 @@
+ALTER TABLE channel
+DROP COLUMN stats_tracker_details;
+
+DROP TABLE stats_tracker_character_weapon;
+
+DROP TABLE item;
\ No newline at end of file
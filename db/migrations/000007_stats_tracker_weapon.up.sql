@@ -0,0 +1,20 @@
+CREATE TABLE
+  item (
+    item_id TEXT PRIMARY KEY NOT NULL,
+    item_name TEXT NOT NULL,
+    item_category TEXT NOT NULL
+  );
+
+CREATE TABLE
+  stats_tracker_character_weapon (
+    session_id INTEGER NOT NULL REFERENCES stats_tracker_session (session_id) ON DELETE CASCADE,
+    platform TEXT NOT NULL,
+    character_id TEXT NOT NULL,
+    weapon_id TEXT NOT NULL,
+    kills INTEGER NOT NULL DEFAULT 0,
+    head_shots_kills INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (session_id, platform, character_id, weapon_id)
+  );
+
+ALTER TABLE channel
+ADD COLUMN stats_tracker_details BOOLEAN NOT NULL DEFAULT FALSE;
\ No newline at end of file
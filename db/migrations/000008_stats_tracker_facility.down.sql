@@ -0,0 +1 @@
+DROP TABLE stats_tracker_character_facility;
\ No newline at end of file
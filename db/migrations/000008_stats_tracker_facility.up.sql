@@ -0,0 +1,10 @@
+CREATE TABLE
+  stats_tracker_character_facility (
+    session_id INTEGER NOT NULL REFERENCES stats_tracker_session (session_id) ON DELETE CASCADE,
+    platform TEXT NOT NULL,
+    character_id TEXT NOT NULL,
+    facility_id TEXT NOT NULL,
+    captures INTEGER NOT NULL DEFAULT 0,
+    defenses INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (session_id, platform, character_id, facility_id)
+  );
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE stats_tracker_session
+DROP COLUMN leaderboard_message_id;
\ No newline at end of file
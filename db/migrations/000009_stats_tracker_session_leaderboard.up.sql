@@ -0,0 +1,2 @@
+ALTER TABLE stats_tracker_session
+ADD COLUMN leaderboard_message_id TEXT NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE stats_tracker_session
+DROP COLUMN task_id;
+
+ALTER TABLE stats_tracker_session
+DROP COLUMN origin;
\ No newline at end of file
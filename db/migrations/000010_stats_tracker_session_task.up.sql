@@ -0,0 +1,5 @@
+ALTER TABLE stats_tracker_session
+ADD COLUMN origin TEXT NOT NULL DEFAULT 'manual';
+
+ALTER TABLE stats_tracker_session
+ADD COLUMN task_id INTEGER;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE
+  stats_tracker_character_zone (
+    session_id INTEGER NOT NULL REFERENCES stats_tracker_session (session_id) ON DELETE CASCADE,
+    platform TEXT NOT NULL,
+    character_id TEXT NOT NULL,
+    zone_id TEXT NOT NULL,
+    kills INTEGER NOT NULL DEFAULT 0,
+    deaths INTEGER NOT NULL DEFAULT 0,
+    duration INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (session_id, platform, character_id, zone_id)
+  );
\ No newline at end of file
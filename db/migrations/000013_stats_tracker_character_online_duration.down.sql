@@ -0,0 +1,5 @@
+ALTER TABLE channel
+DROP COLUMN stats_tracker_sort;
+
+ALTER TABLE stats_tracker_character
+DROP COLUMN online_duration;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE stats_tracker_character
+ADD COLUMN online_duration INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE channel
+ADD COLUMN stats_tracker_sort TEXT NOT NULL DEFAULT 'kills';
\ No newline at end of file
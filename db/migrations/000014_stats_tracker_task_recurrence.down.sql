@@ -0,0 +1,50 @@
+DROP TABLE stats_tracker_task_utc;
+
+DROP INDEX idx_stats_tracker_task_expires_at;
+
+DROP INDEX idx_stats_tracker_task;
+
+CREATE TABLE
+  stats_tracker_task_tmp (
+    task_id INTEGER PRIMARY KEY NOT NULL,
+    channel_id TEXT NOT NULL,
+    utc_start_weekday INTEGER NOT NULL CHECK (utc_start_weekday BETWEEN 0 AND 6),
+    utc_start_time INTEGER NOT NULL,
+    utc_end_weekday INTEGER NOT NULL CHECK (utc_end_weekday BETWEEN 0 AND 6),
+    utc_end_time INTEGER NOT NULL
+  );
+
+-- Local times are kept as is, since timezones can not be resolved here
+INSERT INTO
+  stats_tracker_task_tmp (
+    task_id,
+    channel_id,
+    utc_start_weekday,
+    utc_start_time,
+    utc_end_weekday,
+    utc_end_time
+  )
+SELECT
+  task_id,
+  channel_id,
+  weekday,
+  start_time,
+  (weekday + (start_time + duration) / 86400000000000) % 7,
+  (start_time + duration) % 86400000000000
+FROM
+  stats_tracker_task
+WHERE
+  recurrence = 'weekly';
+
+DROP TABLE stats_tracker_task;
+
+ALTER TABLE stats_tracker_task_tmp
+RENAME TO stats_tracker_task;
+
+CREATE INDEX idx_stats_tracker_task ON stats_tracker_task (
+  channel_id,
+  utc_start_weekday,
+  utc_end_weekday,
+  utc_start_time,
+  utc_end_time
+);
\ No newline at end of file
@@ -0,0 +1,57 @@
+CREATE TABLE
+  stats_tracker_task_tmp (
+    task_id INTEGER PRIMARY KEY NOT NULL,
+    channel_id TEXT NOT NULL,
+    timezone TEXT NOT NULL,
+    recurrence TEXT NOT NULL CHECK (recurrence IN ('weekly', 'interval', 'dates')),
+    weekday INTEGER NOT NULL DEFAULT 0 CHECK (weekday BETWEEN 0 AND 6),
+    interval_days INTEGER NOT NULL DEFAULT 0,
+    dates TEXT NOT NULL DEFAULT '',
+    start_time INTEGER NOT NULL,
+    duration INTEGER NOT NULL,
+    expires_at TIMESTAMP
+  );
+
+INSERT INTO
+  stats_tracker_task_tmp (
+    task_id,
+    channel_id,
+    timezone,
+    recurrence,
+    weekday,
+    start_time,
+    duration
+  )
+SELECT
+  task_id,
+  channel_id,
+  'UTC',
+  'weekly',
+  utc_start_weekday,
+  utc_start_time,
+  ((utc_end_weekday - utc_start_weekday + 7) % 7) * 86400000000000 + utc_end_time - utc_start_time
+FROM
+  stats_tracker_task;
+
+-- Timezones can not be resolved here, so the times are converted
+-- to the channel default timezone by the application
+CREATE TABLE
+  stats_tracker_task_utc (task_id INTEGER PRIMARY KEY NOT NULL);
+
+INSERT INTO
+  stats_tracker_task_utc (task_id)
+SELECT
+  task_id
+FROM
+  stats_tracker_task;
+
+DROP INDEX idx_stats_tracker_task;
+
+DROP TABLE stats_tracker_task;
+
+ALTER TABLE stats_tracker_task_tmp
+RENAME TO stats_tracker_task;
+
+CREATE INDEX idx_stats_tracker_task ON stats_tracker_task (channel_id);
+
+CREATE INDEX idx_stats_tracker_task_expires_at ON stats_tracker_task (expires_at);
\ No newline at end of file
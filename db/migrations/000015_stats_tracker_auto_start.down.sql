@@ -0,0 +1 @@
+DROP TABLE stats_tracker_auto_start;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE
+  stats_tracker_auto_start (
+    channel_id TEXT PRIMARY KEY NOT NULL,
+    min_online INTEGER NOT NULL CHECK (min_online > 0),
+    duration INTEGER NOT NULL,
+    stop_below INTEGER NOT NULL
+  );
\ No newline at end of file
@@ -0,0 +1,10 @@
+DROP TABLE stats_tracker_character_achievement;
+
+ALTER TABLE stats_tracker_character
+DROP COLUMN prestiges;
+
+ALTER TABLE stats_tracker_character
+DROP COLUMN battle_rank;
+
+ALTER TABLE stats_tracker_character
+DROP COLUMN battle_rank_ups;
\ No newline at end of file
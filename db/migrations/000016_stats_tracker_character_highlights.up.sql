@@ -0,0 +1,18 @@
+ALTER TABLE stats_tracker_character
+ADD COLUMN battle_rank_ups INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE stats_tracker_character
+ADD COLUMN battle_rank INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE stats_tracker_character
+ADD COLUMN prestiges INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE
+  stats_tracker_character_achievement (
+    session_id INTEGER NOT NULL REFERENCES stats_tracker_session (session_id) ON DELETE CASCADE,
+    platform TEXT NOT NULL,
+    character_id TEXT NOT NULL,
+    achievement_id TEXT NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (session_id, platform, character_id, achievement_id)
+  );
\ No newline at end of file
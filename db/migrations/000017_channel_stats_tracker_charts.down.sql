@@ -0,0 +1,4 @@
+DROP TABLE population_sample;
+
+ALTER TABLE channel
+DROP COLUMN stats_tracker_charts;
\ No newline at end of file
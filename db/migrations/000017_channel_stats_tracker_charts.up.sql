@@ -0,0 +1,15 @@
+ALTER TABLE channel
+ADD COLUMN stats_tracker_charts BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE
+  population_sample (
+    platform TEXT NOT NULL,
+    world_id TEXT NOT NULL,
+    sampled_at TIMESTAMP NOT NULL,
+    vs INTEGER NOT NULL,
+    nc INTEGER NOT NULL,
+    tr INTEGER NOT NULL,
+    ns INTEGER NOT NULL,
+    other INTEGER NOT NULL,
+    PRIMARY KEY (platform, world_id, sampled_at)
+  );
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE channel
+DROP COLUMN stats_tracker_score_formula;
\ No newline at end of file
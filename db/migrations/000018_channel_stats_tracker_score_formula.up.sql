@@ -0,0 +1,2 @@
+ALTER TABLE channel
+ADD COLUMN stats_tracker_score_formula TEXT NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE stats_tracker_session
+DROP COLUMN filter;
+
+ALTER TABLE stats_tracker_task
+DROP COLUMN filter;
\ No newline at end of file
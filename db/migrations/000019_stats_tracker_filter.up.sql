@@ -0,0 +1,5 @@
+ALTER TABLE stats_tracker_task
+ADD COLUMN filter TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE stats_tracker_session
+ADD COLUMN filter TEXT NOT NULL DEFAULT '';
\ No newline at end of file
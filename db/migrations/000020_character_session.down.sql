@@ -0,0 +1,5 @@
+DROP INDEX idx_character_session_ended_at;
+
+DROP INDEX idx_character_session;
+
+DROP TABLE character_session;
\ No newline at end of file
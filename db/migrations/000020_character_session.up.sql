@@ -0,0 +1,13 @@
+CREATE TABLE
+  character_session (
+    session_id INTEGER PRIMARY KEY NOT NULL,
+    platform TEXT NOT NULL,
+    character_id TEXT NOT NULL,
+    world_id TEXT NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP
+  );
+
+CREATE INDEX idx_character_session ON character_session (platform, character_id, started_at);
+
+CREATE INDEX idx_character_session_ended_at ON character_session (ended_at);
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE channel
+DROP COLUMN outfit_activity_reported_at;
+
+ALTER TABLE channel
+DROP COLUMN outfit_activity_report;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE channel
+ADD COLUMN outfit_activity_report BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE channel
+ADD COLUMN outfit_activity_reported_at TIMESTAMP;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE characters_tracker_player;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE
+  characters_tracker_player (
+    platform TEXT NOT NULL,
+    character_id TEXT NOT NULL,
+    world_id TEXT NOT NULL,
+    zone_id TEXT NOT NULL,
+    faction_id TEXT NOT NULL,
+    character_name TEXT NOT NULL,
+    outfit_id TEXT NOT NULL,
+    outfit_tag TEXT NOT NULL,
+    active_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (platform, character_id)
+  );
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE characters_tracker_player
+DROP COLUMN online_since;
+
+ALTER TABLE characters_tracker_player
+DROP COLUMN loadout;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE characters_tracker_player
+ADD COLUMN loadout TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE characters_tracker_player
+ADD COLUMN online_since TIMESTAMP;
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE known_character;
+
+ALTER TABLE channel
+DROP COLUMN character_changes_notifications;
\ No newline at end of file
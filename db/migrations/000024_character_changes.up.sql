@@ -0,0 +1,15 @@
+ALTER TABLE channel
+ADD COLUMN character_changes_notifications BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE
+  known_character (
+    platform TEXT NOT NULL,
+    character_id TEXT NOT NULL,
+    character_name TEXT NOT NULL,
+    faction_id TEXT NOT NULL,
+    world_id TEXT NOT NULL,
+    outfit_id TEXT NOT NULL,
+    outfit_tag TEXT NOT NULL,
+    refreshed_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (platform, character_id)
+  );
\ No newline at end of file
@@ -243,6 +243,80 @@ UPDATE
 SET
   default_timezone = EXCLUDED.default_timezone;
 
+-- name: UpsertChannelStatsTrackerDetails :exec
+INSERT INTO
+  channel (channel_id, stats_tracker_details)
+VALUES
+  (?, ?) ON CONFLICT (channel_id) DO
+UPDATE
+SET
+  stats_tracker_details = EXCLUDED.stats_tracker_details;
+
+-- name: UpsertChannelStatsTrackerSort :exec
+INSERT INTO
+  channel (channel_id, stats_tracker_sort)
+VALUES
+  (?, ?) ON CONFLICT (channel_id) DO
+UPDATE
+SET
+  stats_tracker_sort = EXCLUDED.stats_tracker_sort;
+
+-- name: UpsertChannelStatsTrackerCharts :exec
+INSERT INTO
+  channel (channel_id, stats_tracker_charts)
+VALUES
+  (?, ?) ON CONFLICT (channel_id) DO
+UPDATE
+SET
+  stats_tracker_charts = EXCLUDED.stats_tracker_charts;
+
+-- name: UpsertChannelStatsTrackerScoreFormula :exec
+INSERT INTO
+  channel (channel_id, stats_tracker_score_formula)
+VALUES
+  (?, ?) ON CONFLICT (channel_id) DO
+UPDATE
+SET
+  stats_tracker_score_formula = EXCLUDED.stats_tracker_score_formula;
+
+-- name: UpsertChannelCharacterChangesNotifications :exec
+INSERT INTO
+  channel (channel_id, character_changes_notifications)
+VALUES
+  (?, ?) ON CONFLICT (channel_id) DO
+UPDATE
+SET
+  character_changes_notifications = EXCLUDED.character_changes_notifications;
+
+-- name: UpsertChannelOutfitActivityReport :exec
+INSERT INTO
+  channel (
+    channel_id,
+    outfit_activity_report,
+    outfit_activity_reported_at
+  )
+VALUES
+  (?, ?, ?) ON CONFLICT (channel_id) DO
+UPDATE
+SET
+  outfit_activity_report = EXCLUDED.outfit_activity_report,
+  outfit_activity_reported_at = EXCLUDED.outfit_activity_reported_at;
+
+-- name: UpdateChannelOutfitActivityReportedAt :exec
+UPDATE channel
+SET
+  outfit_activity_reported_at = ?
+WHERE
+  channel_id = ?;
+
+-- name: ListOutfitActivityReportChannels :many
+SELECT
+  *
+FROM
+  channel
+WHERE
+  outfit_activity_report = TRUE;
+
 -- name: GetChannel :one
 SELECT
   *
@@ -268,32 +342,12 @@ WHERE
 
 -- name: ListActiveStatsTrackerTasks :many
 SELECT
-  channel_id
+  *
 FROM
   stats_tracker_task
 WHERE
-  (
-    (
-      sqlc.arg (utc_weekday) = utc_start_weekday
-      AND sqlc.arg (utc_time) >= utc_start_time
-    )
-    OR (sqlc.arg (utc_weekday) > utc_start_weekday)
-    OR (
-      sqlc.arg (utc_weekday) = 0
-      AND utc_start_weekday = 6
-    )
-  )
-  AND (
-    (
-      sqlc.arg (utc_weekday) = utc_end_weekday
-      AND sqlc.arg (utc_time) < utc_end_time
-    )
-    OR (sqlc.arg (utc_weekday) < utc_end_weekday)
-    OR (
-      sqlc.arg (utc_weekday) = 6
-      AND utc_end_weekday = 0
-    )
-  );
+  expires_at IS NULL
+  OR expires_at > ?;
 
 -- name: ListChannelStatsTrackerTasks :many
 SELECT
@@ -319,42 +373,568 @@ FROM
 WHERE
   task_id = ?;
 
--- name: ListChannelIntersectingStatsTrackerTasks :many
+-- name: InsertChannelStatsTrackerTask :exec
+INSERT INTO
+  stats_tracker_task (
+    channel_id,
+    timezone,
+    recurrence,
+    weekday,
+    interval_days,
+    dates,
+    start_time,
+    duration,
+    expires_at,
+    filter
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: RemoveChannelStatsTrackerTask :exec
+DELETE FROM stats_tracker_task
+WHERE
+  task_id = ?
+  AND channel_id = ?;
+
+-- name: ListUtcStatsTrackerTasks :many
+SELECT
+  stats_tracker_task.task_id,
+  stats_tracker_task.weekday,
+  stats_tracker_task.start_time,
+  channel.default_timezone
+FROM
+  stats_tracker_task_utc
+  JOIN stats_tracker_task ON stats_tracker_task.task_id = stats_tracker_task_utc.task_id
+  JOIN channel ON channel.channel_id = stats_tracker_task.channel_id;
+
+-- name: UpdateStatsTrackerTaskLocalTime :exec
+UPDATE stats_tracker_task
+SET
+  timezone = ?,
+  weekday = ?,
+  start_time = ?
+WHERE
+  task_id = ?;
+
+-- name: DeleteUtcStatsTrackerTasks :exec
+DELETE FROM stats_tracker_task_utc;
+
+-- name: InsertStatsTrackerSession :one
+INSERT INTO
+  stats_tracker_session (channel_id, started_at, origin, filter, task_id)
+VALUES
+  (?, ?, ?, ?, ?) RETURNING session_id;
+
+-- name: InsertStatsTrackerSessionPlatform :exec
+INSERT INTO
+  stats_tracker_session_platform (session_id, platform)
+VALUES
+  (?, ?);
+
+-- name: UpdateStatsTrackerSessionStoppedAt :exec
+UPDATE stats_tracker_session
+SET
+  stopped_at = ?
+WHERE
+  session_id = ?;
+
+-- name: UpdateStatsTrackerSessionLeaderboardMessage :exec
+UPDATE stats_tracker_session
+SET
+  leaderboard_message_id = ?
+WHERE
+  session_id = ?;
+
+-- name: ListActiveStatsTrackerSessions :many
 SELECT
   *
 FROM
-  stats_tracker_task
+  stats_tracker_session
+WHERE
+  stopped_at IS NULL;
+
+-- name: ListStatsTrackerSessionPlatforms :many
+SELECT
+  platform
+FROM
+  stats_tracker_session_platform
+WHERE
+  session_id = ?;
+
+-- name: UpsertStatsTrackerCharacter :exec
+INSERT INTO
+  stats_tracker_character (
+    session_id,
+    platform,
+    character_id,
+    body_kills,
+    head_shots_kills,
+    team_kills,
+    deaths,
+    deaths_by_restricted_area,
+    suicides,
+    revives,
+    heals,
+    repairs,
+    resupplies,
+    shield_repairs,
+    spot_assists,
+    score,
+    online_duration,
+    battle_rank_ups,
+    battle_rank,
+    prestiges
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id) DO
+UPDATE
+SET
+  body_kills = EXCLUDED.body_kills,
+  head_shots_kills = EXCLUDED.head_shots_kills,
+  team_kills = EXCLUDED.team_kills,
+  deaths = EXCLUDED.deaths,
+  deaths_by_restricted_area = EXCLUDED.deaths_by_restricted_area,
+  suicides = EXCLUDED.suicides,
+  revives = EXCLUDED.revives,
+  heals = EXCLUDED.heals,
+  repairs = EXCLUDED.repairs,
+  resupplies = EXCLUDED.resupplies,
+  shield_repairs = EXCLUDED.shield_repairs,
+  spot_assists = EXCLUDED.spot_assists,
+  score = EXCLUDED.score,
+  online_duration = EXCLUDED.online_duration,
+  battle_rank_ups = EXCLUDED.battle_rank_ups,
+  battle_rank = EXCLUDED.battle_rank,
+  prestiges = EXCLUDED.prestiges;
+
+-- name: ListStatsTrackerSessionCharacters :many
+SELECT
+  *
+FROM
+  stats_tracker_character
+WHERE
+  session_id = ?;
+
+-- name: UpsertStatsTrackerCharacterLoadout :exec
+INSERT INTO
+  stats_tracker_character_loadout (
+    session_id,
+    platform,
+    character_id,
+    loadout_type,
+    duration
+  )
+VALUES
+  (?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id, loadout_type) DO
+UPDATE
+SET
+  duration = EXCLUDED.duration;
+
+-- name: ListStatsTrackerSessionCharacterLoadouts :many
+SELECT
+  *
+FROM
+  stats_tracker_character_loadout
+WHERE
+  session_id = ?;
+
+-- name: UpsertStatsTrackerCharacterVehicle :exec
+INSERT INTO
+  stats_tracker_character_vehicle (
+    session_id,
+    platform,
+    character_id,
+    vehicle_id,
+    destroyed,
+    lost
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id, vehicle_id) DO
+UPDATE
+SET
+  destroyed = EXCLUDED.destroyed,
+  lost = EXCLUDED.lost;
+
+-- name: ListStatsTrackerSessionCharacterVehicles :many
+SELECT
+  *
+FROM
+  stats_tracker_character_vehicle
+WHERE
+  session_id = ?;
+
+-- name: ListItems :many
+SELECT
+  *
+FROM
+  item
+WHERE
+  item_id IN (sqlc.slice (item_ids));
+
+-- name: UpsertItem :exec
+INSERT INTO
+  item (item_id, item_name, item_category)
+VALUES
+  (?, ?, ?) ON CONFLICT (item_id) DO
+UPDATE
+SET
+  item_name = EXCLUDED.item_name,
+  item_category = EXCLUDED.item_category;
+
+-- name: UpsertStatsTrackerCharacterWeapon :exec
+INSERT INTO
+  stats_tracker_character_weapon (
+    session_id,
+    platform,
+    character_id,
+    weapon_id,
+    kills,
+    head_shots_kills
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id, weapon_id) DO
+UPDATE
+SET
+  kills = EXCLUDED.kills,
+  head_shots_kills = EXCLUDED.head_shots_kills;
+
+-- name: ListStatsTrackerSessionCharacterWeapons :many
+SELECT
+  *
+FROM
+  stats_tracker_character_weapon
+WHERE
+  session_id = ?;
+
+-- name: UpsertStatsTrackerCharacterFacility :exec
+INSERT INTO
+  stats_tracker_character_facility (
+    session_id,
+    platform,
+    character_id,
+    facility_id,
+    captures,
+    defenses
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id, facility_id) DO
+UPDATE
+SET
+  captures = EXCLUDED.captures,
+  defenses = EXCLUDED.defenses;
+
+-- name: ListStatsTrackerSessionCharacterFacilities :many
+SELECT
+  *
+FROM
+  stats_tracker_character_facility
+WHERE
+  session_id = ?;
+
+-- name: GetPreviousScheduledStatsTrackerSession :one
+SELECT
+  *
+FROM
+  stats_tracker_session
+WHERE
+  channel_id = ?
+  AND task_id = ?
+  AND stopped_at IS NOT NULL
+  AND session_id < ?
+ORDER BY
+  session_id DESC
+LIMIT
+  1;
+
+-- name: ListChannelStatsTrackerSessions :many
+SELECT
+  *
+FROM
+  stats_tracker_session
 WHERE
   channel_id = ?
+  AND stopped_at IS NOT NULL
+ORDER BY
+  session_id DESC
+LIMIT
+  ?;
+
+-- name: GetChannelStatsTrackerSession :one
+SELECT
+  *
+FROM
+  stats_tracker_session
+WHERE
+  session_id = ?
+  AND channel_id = ?
+  AND stopped_at IS NOT NULL;
+
+-- name: UpsertStatsTrackerCharacterRival :exec
+INSERT INTO
+  stats_tracker_character_rival (
+    session_id,
+    platform,
+    character_id,
+    rival_id,
+    kills,
+    deaths
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id, rival_id) DO
+UPDATE
+SET
+  kills = EXCLUDED.kills,
+  deaths = EXCLUDED.deaths;
+
+-- name: ListStatsTrackerSessionCharacterRivals :many
+SELECT
+  *
+FROM
+  stats_tracker_character_rival
+WHERE
+  session_id = ?;
+
+-- name: UpsertStatsTrackerCharacterAchievement :exec
+INSERT INTO
+  stats_tracker_character_achievement (
+    session_id,
+    platform,
+    character_id,
+    achievement_id,
+    count
+  )
+VALUES
+  (?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id, achievement_id) DO
+UPDATE
+SET
+  count = EXCLUDED.count;
+
+-- name: ListStatsTrackerSessionCharacterAchievements :many
+SELECT
+  *
+FROM
+  stats_tracker_character_achievement
+WHERE
+  session_id = ?;
+
+-- name: UpsertStatsTrackerCharacterZone :exec
+INSERT INTO
+  stats_tracker_character_zone (
+    session_id,
+    platform,
+    character_id,
+    zone_id,
+    kills,
+    deaths,
+    duration
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id, zone_id) DO
+UPDATE
+SET
+  kills = EXCLUDED.kills,
+  deaths = EXCLUDED.deaths,
+  duration = EXCLUDED.duration;
+
+-- name: ListStatsTrackerSessionCharacterZones :many
+SELECT
+  *
+FROM
+  stats_tracker_character_zone
+WHERE
+  session_id = ?;
+
+-- name: UpsertStatsTrackerAutoStart :exec
+INSERT INTO
+  stats_tracker_auto_start (channel_id, min_online, duration, stop_below)
+VALUES
+  (?, ?, ?, ?) ON CONFLICT (channel_id) DO
+UPDATE
+SET
+  min_online = EXCLUDED.min_online,
+  duration = EXCLUDED.duration,
+  stop_below = EXCLUDED.stop_below;
+
+-- name: DeleteStatsTrackerAutoStart :exec
+DELETE FROM stats_tracker_auto_start
+WHERE
+  channel_id = ?;
+
+-- name: GetStatsTrackerAutoStart :one
+SELECT
+  *
+FROM
+  stats_tracker_auto_start
+WHERE
+  channel_id = ?;
+
+-- name: ListStatsTrackerAutoStarts :many
+SELECT
+  *
+FROM
+  stats_tracker_auto_start;
+
+-- name: InsertCharacterSession :exec
+INSERT INTO
+  character_session (platform, character_id, world_id, started_at)
+VALUES
+  (?, ?, ?, ?);
+
+-- name: EndCharacterSession :exec
+UPDATE character_session
+SET
+  ended_at = ?
+WHERE
+  platform = ?
+  AND character_id = ?
+  AND ended_at IS NULL;
+
+-- name: EndAllCharacterSessions :exec
+UPDATE character_session
+SET
+  ended_at = ?
+WHERE
+  ended_at IS NULL;
+
+-- name: EndStaleCharacterSessions :exec
+UPDATE character_session
+SET
+  ended_at = started_at
+WHERE
+  ended_at IS NULL;
+
+-- name: ListCharacterSessions :many
+SELECT
+  *
+FROM
+  character_session
+WHERE
+  platform = ?
+  AND character_id = ?
   AND (
-    (?2 - utc_start_weekday IN (1, -6))
-    OR (
-      sqlc.arg (end_weekday) = utc_start_weekday
-      AND sqlc.arg (end_time) > utc_start_time
-    )
+    ended_at IS NULL
+    OR ended_at >= ?
   )
+ORDER BY
+  started_at DESC;
+
+-- name: DeleteCharacterSessionsBefore :exec
+DELETE FROM character_session
+WHERE
+  ended_at < ?;
+
+-- name: ListOutfitMembersCharacterSessions :many
+SELECT
+  character_session.*
+FROM
+  character_session
+  JOIN outfit_to_character ON outfit_to_character.platform = character_session.platform
+  AND outfit_to_character.character_id = character_session.character_id
+WHERE
+  outfit_to_character.platform = ?
+  AND outfit_to_character.outfit_id = ?
+  AND character_session.started_at < ?
   AND (
-    (utc_end_weekday - ?4 IN (1, -6))
-    OR (
-      sqlc.arg (start_weekday) = utc_end_weekday
-      AND sqlc.arg (start_time) < utc_end_time
-    )
+    character_session.ended_at IS NULL
+    OR character_session.ended_at >= ?
   );
 
--- name: InsertChannelStatsTrackerTask :exec
+-- name: InsertCharactersTrackerPlayer :exec
 INSERT INTO
-  stats_tracker_task (
-    channel_id,
-    utc_start_weekday,
-    utc_start_time,
-    utc_end_weekday,
-    utc_end_time
+  characters_tracker_player (
+    platform,
+    character_id,
+    world_id,
+    zone_id,
+    faction_id,
+    character_name,
+    outfit_id,
+    outfit_tag,
+    active_at,
+    loadout,
+    online_since
   )
 VALUES
-  (?, ?, ?, ?, ?);
+  (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
--- name: RemoveChannelStatsTrackerTask :exec
-DELETE FROM stats_tracker_task
+-- name: ListCharactersTrackerPlayers :many
+SELECT
+  *
+FROM
+  characters_tracker_player
 WHERE
-  task_id = ?
-  AND channel_id = ?;
\ No newline at end of file
+  platform = ?
+ORDER BY
+  active_at;
+
+-- name: DeleteCharactersTrackerPlayers :exec
+DELETE FROM characters_tracker_player
+WHERE
+  platform = ?;
+
+-- name: UpsertKnownCharacter :exec
+INSERT INTO
+  known_character (
+    platform,
+    character_id,
+    character_name,
+    faction_id,
+    world_id,
+    outfit_id,
+    outfit_tag,
+    refreshed_at
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (platform, character_id) DO
+UPDATE
+SET
+  character_name = EXCLUDED.character_name,
+  faction_id = EXCLUDED.faction_id,
+  world_id = EXCLUDED.world_id,
+  outfit_id = EXCLUDED.outfit_id,
+  outfit_tag = EXCLUDED.outfit_tag,
+  refreshed_at = EXCLUDED.refreshed_at;
+
+-- name: ListKnownCharacters :many
+SELECT
+  *
+FROM
+  known_character
+WHERE
+  platform = ?;
+
+-- name: DeleteKnownCharactersBefore :exec
+DELETE FROM known_character
+WHERE
+  platform = ?
+  AND refreshed_at < ?;
+
+-- name: InsertPopulationSample :exec
+INSERT INTO
+  population_sample (
+    platform,
+    world_id,
+    sampled_at,
+    vs,
+    nc,
+    tr,
+    ns,
+    other
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListPopulationSamples :many
+SELECT
+  *
+FROM
+  population_sample
+WHERE
+  platform = ?
+ORDER BY
+  sampled_at;
+
+-- name: DeletePopulationSamples :exec
+DELETE FROM population_sample
+WHERE
+  platform = ?;
\ No newline at end of file
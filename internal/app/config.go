@@ -41,6 +41,18 @@ type CensusConfig struct {
 
 type StatsTrackerConfig struct {
 	MaxTrackingDuration time.Duration `yaml:"max_tracking_duration" env:"STATS_TRACKER_MAX_TRACKING_DURATION" env-default:"4h"`
+	UpdateInterval      time.Duration `yaml:"update_interval" env:"STATS_TRACKER_UPDATE_INTERVAL" env-default:"5m"`
+}
+
+type CharactersTrackerConfig struct {
+	ReconcileInterval time.Duration `yaml:"reconcile_interval" env:"CHARACTERS_TRACKER_RECONCILE_INTERVAL" env-default:"1m"`
+	// Census requests per interval for each platform, zero disables reconciliation
+	ReconcileRequestsLimit int `yaml:"reconcile_requests_limit" env:"CHARACTERS_TRACKER_RECONCILE_REQUESTS_LIMIT" env-default:"5"`
+}
+
+type CharactersRefresherConfig struct {
+	// Characters are reloaded from the Census when their data is older than the interval
+	RefreshInterval time.Duration `yaml:"refresh_interval" env:"CHARACTERS_REFRESHER_REFRESH_INTERVAL" env-default:"24h"`
 }
 
 type TrackingConfig struct {
@@ -51,15 +63,17 @@ type TrackingConfig struct {
 type Config struct {
 	AppName string `yaml:"app_name" env:"APP_NAME" env-default:"PS 2 Spy"`
 
-	Logger       LoggerConfig       `yaml:"logger"`
-	Profiler     ProfilerConfig     `yaml:"profiler"`
-	Discord      DiscordConfig      `yaml:"discord"`
-	Metrics      MetricsConfig      `yaml:"metrics"`
-	Storage      StorageConfig      `yaml:"storage"`
-	HttpClient   HttpClientConfig   `yaml:"http_client"`
-	Census       CensusConfig       `yaml:"census"`
-	StatsTracker StatsTrackerConfig `yaml:"stats_tracker"`
-	Tracking     TrackingConfig     `yaml:"tracking"`
+	Logger              LoggerConfig              `yaml:"logger"`
+	Profiler            ProfilerConfig            `yaml:"profiler"`
+	Discord             DiscordConfig             `yaml:"discord"`
+	Metrics             MetricsConfig             `yaml:"metrics"`
+	Storage             StorageConfig             `yaml:"storage"`
+	HttpClient          HttpClientConfig          `yaml:"http_client"`
+	Census              CensusConfig              `yaml:"census"`
+	StatsTracker        StatsTrackerConfig        `yaml:"stats_tracker"`
+	CharactersTracker   CharactersTrackerConfig   `yaml:"characters_tracker"`
+	CharactersRefresher CharactersRefresherConfig `yaml:"characters_refresher"`
+	Tracking            TrackingConfig            `yaml:"tracking"`
 }
 
 func MustLoadConfig(configPath string) *Config {
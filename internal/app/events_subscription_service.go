@@ -53,25 +53,30 @@ func newEventsSubscriptionService(
 				case e := <-playerLogout:
 					charactersTracker.HandleLogout(ctx, e)
 				case e := <-achievementEarned:
-					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID)
+					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID, "")
+					statsTracker.HandleAchievementEarnedEvent(ctx, platform, e)
 				case e := <-battleRankUp:
-					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID)
+					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID, "")
+					statsTracker.HandleBattleRankUpEvent(ctx, platform, e)
 				case e := <-death:
-					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID)
+					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID, e.CharacterLoadoutID)
 					statsTracker.HandleDeathEvent(ctx, platform, e)
 				case e := <-gainExperience:
-					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID)
+					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID, e.LoadoutID)
 					statsTracker.HandleGainExperienceEvent(ctx, platform, e)
 				case e := <-itemAdded:
-					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID)
+					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID, "")
 				case e := <-playerFacilityCapture:
-					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID)
+					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID, "")
+					statsTracker.HandlePlayerFacilityCaptureEvent(ctx, platform, e)
 				case e := <-playerFacilityDefend:
-					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID)
+					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID, "")
+					statsTracker.HandlePlayerFacilityDefendEvent(ctx, platform, e)
 				case e := <-skillAdded:
-					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID)
+					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID, "")
 				case e := <-vehicleDestroy:
-					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID)
+					charactersTracker.HandleWorldZoneAction(ctx, e.WorldID, e.ZoneID, e.CharacterID, "")
+					statsTracker.HandleVehicleDestroyEvent(ctx, platform, e)
 
 				case e := <-metagameEvent:
 					if err := worldsTracker.HandleMetagameEvent(ctx, e); err != nil {
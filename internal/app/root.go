@@ -10,7 +10,10 @@ import (
 
 	"github.com/hashicorp/golang-lru/v2/expirable"
 	sql_facility_cache "github.com/x0k/ps2-spy/internal/cache/facility/sql"
+	sql_item_cache "github.com/x0k/ps2-spy/internal/cache/item/sql"
 	sql_outfits_cache "github.com/x0k/ps2-spy/internal/cache/outfits/sql"
+	"github.com/x0k/ps2-spy/internal/characters_history"
+	"github.com/x0k/ps2-spy/internal/characters_refresher"
 	"github.com/x0k/ps2-spy/internal/characters_tracker"
 	census_data_provider "github.com/x0k/ps2-spy/internal/data_providers/census"
 	fisu_data_provider "github.com/x0k/ps2-spy/internal/data_providers/fisu"
@@ -42,6 +45,7 @@ import (
 	"github.com/x0k/ps2-spy/internal/metrics"
 	discord_module "github.com/x0k/ps2-spy/internal/modules/discord"
 	events_module "github.com/x0k/ps2-spy/internal/modules/events"
+	"github.com/x0k/ps2-spy/internal/outfit_activity"
 	"github.com/x0k/ps2-spy/internal/outfit_members_synchronizer"
 	"github.com/x0k/ps2-spy/internal/ps2"
 	"github.com/x0k/ps2-spy/internal/ps2/census_characters_repo"
@@ -56,6 +60,7 @@ import (
 	"github.com/x0k/ps2-spy/internal/tracking"
 	tracking_settings_data_loader "github.com/x0k/ps2-spy/internal/tracking/settings_data_loader"
 	tracking_settings_diff_view_loader "github.com/x0k/ps2-spy/internal/tracking/settings_diff_view_loader"
+	tracking_settings_filter_loader "github.com/x0k/ps2-spy/internal/tracking/settings_filter_loader"
 	tracking_settings_repo "github.com/x0k/ps2-spy/internal/tracking/settings_repo"
 	tracking_settings_updater "github.com/x0k/ps2-spy/internal/tracking/settings_updater"
 	tracking_settings_view_loader "github.com/x0k/ps2-spy/internal/tracking/settings_view_loader"
@@ -95,6 +100,7 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 		storePubSub,
 	)
 	m.PreStartR("storage", store.Open)
+	m.PreStartR("storage.utc_stats_tracker_tasks", store.ConvertUtcStatsTrackerTasks)
 	m.PostStopR("storage", store.Close)
 
 	httpClient := &http.Client{
@@ -138,9 +144,14 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 		log.With(sl.Component("facility_cache")),
 		store,
 	)
+	itemCache := sql_item_cache.New(
+		log.With(sl.Component("item_cache")),
+		store,
+	)
 
 	characterTrackerSubsMangers := make(map[ps2_platforms.Platform]pubsub.SubscriptionsManager[characters_tracker.EventType], len(ps2_platforms.Platforms))
 	charactersTrackers := make(map[ps2_platforms.Platform]*characters_tracker.CharactersTracker, len(ps2_platforms.Platforms))
+	charactersRefresherSubsManagers := make(map[ps2_platforms.Platform]pubsub.SubscriptionsManager[characters_refresher.EventType], len(ps2_platforms.Platforms))
 	worldTrackerSubsMangers := make(map[ps2_platforms.Platform]pubsub.SubscriptionsManager[worlds_tracker.EventType], len(ps2_platforms.Platforms))
 	worldTrackers := make(map[ps2_platforms.Platform]*worlds_tracker.WorldsTracker, len(ps2_platforms.Platforms))
 	trackingManagers := make(map[ps2_platforms.Platform]*tracking.Manager, len(ps2_platforms.Platforms))
@@ -150,6 +161,37 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 	outfitsLoaders := make(map[ps2_platforms.Platform]loader.Multi[ps2.OutfitId, ps2.Outfit], len(ps2_platforms.Platforms))
 	outfitLoaders := make(map[ps2_platforms.Platform]loader.Keyed[ps2.OutfitId, ps2.Outfit], len(ps2_platforms.Platforms))
 	facilityLoaders := make(map[ps2_platforms.Platform]loader.Keyed[ps2.FacilityId, ps2.Facility], len(ps2_platforms.Platforms))
+	vehiclesLoaders := make(map[ps2_platforms.Platform]loader.Multi[ps2.VehicleId, ps2.Vehicle], len(ps2_platforms.Platforms))
+	itemsLoaders := make(map[ps2_platforms.Platform]loader.Multi[ps2.ItemId, ps2.Item], len(ps2_platforms.Platforms))
+	achievementsLoaders := make(map[ps2_platforms.Platform]loader.Multi[ps2.AchievementId, ps2.Achievement], len(ps2_platforms.Platforms))
+
+	trackingSettingsRepo := tracking_settings_repo.New(store)
+	censusCharactersRepo := census_characters_repo.New(
+		log.With(sl.Component("census_characters_repo")),
+		censusClient,
+	)
+	censusOutfitsRepo := census_outfits_repo.New(
+		log.With(sl.Component("census_outfits_repo")),
+		censusClient,
+	)
+	onlineTrackableEntitiesCountLoader := func(ctx context.Context, channelId discord.ChannelId) (int, error) {
+		count := 0
+		errs := make([]error, 0, len(ps2_platforms.Platforms))
+		for _, platform := range ps2_platforms.Platforms {
+			settings, err := trackingSettingsRepo.Get(ctx, channelId, platform)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			outfits := charactersTrackers[platform].OutfitMembersOnline(settings.Outfits)
+			for _, outfit := range outfits {
+				count += len(outfit)
+			}
+			characters := charactersTrackers[platform].CharactersOnline(settings.Characters)
+			count += len(characters)
+		}
+		return count, errors.Join(errs...)
+	}
 
 	statsTrackerPubSub := pubsub.New[stats_tracker.EventType]()
 
@@ -169,11 +211,45 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 			return channelIds, nil
 		},
 		store.ChannelTrackablePlatforms,
+		tracking_settings_filter_loader.New(
+			trackingSettingsRepo,
+			censusOutfitsRepo,
+			censusCharactersRepo,
+		).Load,
 		store.ActiveStatsTrackerTasks,
+		store.StatsTrackerAutoStarts,
+		onlineTrackableEntitiesCountLoader,
 		charactersLoaders,
+		vehiclesLoaders,
+		itemsLoaders,
+		facilityLoaders,
+		outfitsLoaders,
+		achievementsLoaders,
+		store,
 		cfg.StatsTracker.MaxTrackingDuration,
+		cfg.StatsTracker.UpdateInterval,
 	)
 	m.AppendVR("stats_tracker", statsTracker.Start)
+	m.PreStopR("stats_tracker", statsTracker.SaveSessions)
+
+	charactersHistory := characters_history.New(
+		log.With(sl.Component("characters_history")),
+		store,
+	)
+	m.AppendVR("characters_history", charactersHistory.Start)
+	m.PreStopR("characters_history", charactersHistory.Stop)
+
+	outfitActivityPubSub := pubsub.New[outfit_activity.EventType]()
+
+	outfitActivityReporter := outfit_activity.New(
+		log.With(sl.Component("outfit_activity_reporter")),
+		outfitActivityPubSub,
+		store,
+		trackingSettingsRepo.Get,
+		charactersLoaders,
+		outfitsLoaders,
+	)
+	m.AppendVR("outfit_activity_reporter", outfitActivityReporter.Start)
 
 	for _, platform := range ps2_platforms.Platforms {
 		pl := log.With(slog.String("platform", string(platform)))
@@ -231,6 +307,21 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 		)
 		characterLoaders[platform] = cachedBatchedCharactersLoader
 
+		vehiclesLoaders[platform] = loader.WithMultiCache(
+			pl.Logger.With(sl.Component("vehicles_loader_cache")),
+			func(ctx context.Context, k []ps2.VehicleId) (map[ps2.VehicleId]ps2.Vehicle, error) {
+				return censusDataProvider.Vehicles(ctx, platform, k)
+			},
+			memory.NewMultiExpirableCache(expirable.NewLRU[ps2.VehicleId, ps2.Vehicle](0, nil, 24*time.Hour)),
+		)
+		achievementsLoaders[platform] = loader.WithMultiCache(
+			pl.Logger.With(sl.Component("achievements_loader_cache")),
+			func(ctx context.Context, k []ps2.AchievementId) (map[ps2.AchievementId]ps2.Achievement, error) {
+				return censusDataProvider.Achievements(ctx, platform, k)
+			},
+			memory.NewMultiExpirableCache(expirable.NewLRU[ps2.AchievementId, ps2.Achievement](0, nil, 24*time.Hour)),
+		)
+
 		ps := pubsub.New[characters_tracker.EventType]()
 		charactersTrackerPublisher := metrics.InstrumentPlatformPublisher(
 			mt,
@@ -245,6 +336,7 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 			platform,
 			ps2.PlatformWorldIds[platform],
 			cachedBatchedCharactersLoader,
+			store,
 			charactersTrackerPublisher,
 			mt,
 		)
@@ -255,8 +347,98 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 				return nil
 			},
 		)
+		m.PreStopR(fmt.Sprintf("%s.characters_tracker", platform), charactersTracker.SaveSnapshot)
+		if cfg.CharactersTracker.ReconcileRequestsLimit > 0 {
+			reconciler := characters_tracker.NewReconciler(
+				pl.With(sl.Component("characters_tracker_reconciler")),
+				charactersTracker,
+				func(ctx context.Context) ([]ps2.CharacterId, error) {
+					return store.AllTrackableCharacterIdsWithDuplicationsForPlatform(ctx, platform)
+				},
+				func(ctx context.Context, ids []ps2.CharacterId) (map[ps2.CharacterId]bool, error) {
+					return censusCharactersRepo.OnlineStatusesByIds(ctx, platform, ids)
+				},
+				cfg.CharactersTracker.ReconcileInterval,
+				cfg.CharactersTracker.ReconcileRequestsLimit,
+			)
+			m.AppendVR(fmt.Sprintf("%s.characters_tracker_reconciler", platform), reconciler.Start)
+		}
 		charactersTrackers[platform] = charactersTracker
 
+		charactersRefresherPubSub := pubsub.New[characters_refresher.EventType]()
+		charactersRefresherSubsManagers[platform] = charactersRefresherPubSub
+
+		charactersRefresher := characters_refresher.New(
+			pl.With(sl.Component("characters_refresher")),
+			platform,
+			charactersRefresherPubSub,
+			store,
+			func(ctx context.Context) ([]ps2.CharacterId, error) {
+				return store.AllTrackableCharacterIdsWithDuplicationsForPlatform(ctx, platform)
+			},
+			charactersLoader,
+			memory.NewMultiExpirableCache(charactersCache),
+			cfg.CharactersRefresher.RefreshInterval,
+		)
+		m.AppendVR(fmt.Sprintf("%s.characters_refresher", platform), charactersRefresher.Start)
+
+		characterUpdated := characters_refresher.Subscribe[characters_refresher.CharacterUpdated](m, charactersRefresherPubSub)
+		m.AppendVR(
+			fmt.Sprintf("%s.characters_tracker.characters_refresher_subscription", platform),
+			func(ctx context.Context) {
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case e := <-characterUpdated:
+						charactersTracker.HandleCharacterUpdate(e.New)
+					}
+				}
+			},
+		)
+
+		playerLogin := characters_tracker.Subscribe[characters_tracker.PlayerLogin](m, ps)
+		playerFakeLogin := characters_tracker.Subscribe[characters_tracker.PlayerFakeLogin](m, ps)
+		playerLogout := characters_tracker.Subscribe[characters_tracker.PlayerLogout](m, ps)
+		m.AppendVR(
+			fmt.Sprintf("%s.stats_tracker.characters_subscription", platform),
+			func(ctx context.Context) {
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case e := <-playerLogin:
+						statsTracker.HandlePlayerLogin(ctx, platform, e.Character.Id)
+					case e := <-playerFakeLogin:
+						statsTracker.HandlePlayerLogin(ctx, platform, e.Character.Id)
+					case e := <-playerLogout:
+						statsTracker.HandlePlayerLogout(ctx, platform, e.CharacterId)
+					}
+				}
+			},
+		)
+
+		historyLogin := characters_tracker.Subscribe[characters_tracker.PlayerLogin](m, ps)
+		historyFakeLogin := characters_tracker.Subscribe[characters_tracker.PlayerFakeLogin](m, ps)
+		historyLogout := characters_tracker.Subscribe[characters_tracker.PlayerLogout](m, ps)
+		m.AppendVR(
+			fmt.Sprintf("%s.characters_history.characters_subscription", platform),
+			func(ctx context.Context) {
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case e := <-historyLogin:
+						charactersHistory.HandleLogin(ctx, platform, e.Character, e.Time)
+					case e := <-historyFakeLogin:
+						charactersHistory.HandleLogin(ctx, platform, e.Character, e.Time)
+					case e := <-historyLogout:
+						charactersHistory.HandleLogout(ctx, platform, e.CharacterId, e.Time)
+					}
+				}
+			},
+		)
+
 		worldsTrackerPubSub := pubsub.New[worlds_tracker.EventType]()
 
 		worldsTackerPublisher := metrics.InstrumentPlatformPublisher(
@@ -364,6 +546,14 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 			},
 			facilityCache,
 		)
+
+		itemsLoaders[platform] = loader.WithMultiCache(
+			log.Logger.With(sl.Component("items_loader_cache")),
+			func(ctx context.Context, ids []ps2.ItemId) (map[ps2.ItemId]ps2.Item, error) {
+				return censusDataProvider.Items(ctx, platform, ids)
+			},
+			itemCache,
+		)
 	}
 
 	outfitMemberSaved := storage.Subscribe[storage.OutfitMemberSaved](m, storePubSub)
@@ -427,6 +617,22 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 		"voidwell": voidwellDataProvider.WorldPopulation,
 	}
 
+	populationHistoryLoader := func(ctx context.Context) ([]ps2.PopulationSample, error) {
+		histories := make([][]ps2.PopulationSample, 0, len(charactersTrackers))
+		for _, tracker := range charactersTrackers {
+			histories = append(histories, tracker.WorldsPopulationHistory())
+		}
+		return ps2.MergePopulationHistories(histories...), nil
+	}
+
+	worldPopulationHistoryLoader := func(ctx context.Context, worldId ps2.WorldId) ([]ps2.PopulationSample, error) {
+		platform, ok := ps2.WorldPlatforms[worldId]
+		if !ok {
+			return nil, fmt.Errorf("unknown world %q", worldId)
+		}
+		return charactersTrackers[platform].WorldPopulationHistory(worldId)
+	}
+
 	alertsLoaders := map[string]loader.Simple[meta.Loaded[ps2.Alerts]]{
 		"spy": func(ctx context.Context) (meta.Loaded[ps2.Alerts], error) {
 			alerts := make(ps2.Alerts, 0)
@@ -446,7 +652,6 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 		"voidwell":  voidwellDataProvider.Alerts,
 	}
 
-	trackingSettingsRepo := tracking_settings_repo.New(store)
 	trackingPubSub := pubsub.New[tracking.EventType]()
 
 	settingsUpdate := tracking.Subscribe[tracking.TrackingSettingsUpdated](m, trackingPubSub)
@@ -466,16 +671,8 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 		}
 	})
 
-	censusCharactersRepo := census_characters_repo.New(
-		log.With(sl.Component("census_characters_repo")),
-		censusClient,
-	)
 	charactersTrackerCharactersRepo := characters_tracker_characters_repo.New(charactersTrackers)
 
-	censusOutfitsRepo := census_outfits_repo.New(
-		log.With(sl.Component("census_outfits_repo")),
-		censusClient,
-	)
 	charactersTrackerOutfitsRepo := characters_tracker_outfits_repo.New(charactersTrackers)
 
 	discordMessages := discord_messages.New(
@@ -491,6 +688,8 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 		[]string{"spy", "honu", "ps2live", "saerro", "fisu", "sanctuary", "voidwell"},
 		worldPopulationLoaders,
 		[]string{"spy", "honu", "saerro", "voidwell"},
+		populationHistoryLoader,
+		worldPopulationHistoryLoader,
 		func(ctx context.Context, worldId ps2.WorldId) (meta.Loaded[ps2.WorldTerritoryControl], error) {
 			platform, ok := ps2.WorldPlatforms[worldId]
 			if !ok {
@@ -539,6 +738,26 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 		store.RemoveStatsTrackerTask,
 		store.StatsTrackerTask,
 		store.UpdateStatsTrackerTask,
+		store.ChannelStatsTrackerSessions,
+		store.ChannelStatsTrackerSession,
+		store.ChannelStatsTrackerAutoStart,
+		store.SaveChannelStatsTrackerAutoStart,
+		store.RemoveChannelStatsTrackerAutoStart,
+		store.SaveChannelStatsTrackerDetails,
+		store.SaveChannelStatsTrackerSort,
+		store.SaveChannelStatsTrackerCharts,
+		store.SaveChannelStatsTrackerScoreFormula,
+		censusCharactersRepo.CharacterIdsByNames,
+		charactersHistory.CharacterHistory,
+		store.SaveChannelOutfitActivityReport,
+		store.SaveChannelCharacterChangesNotifications,
+		censusOutfitsRepo.OutfitIdsByTags,
+		outfit_activity.NewInactiveMembersLoader(
+			log.With(sl.Component("inactive_members_loader")),
+			store,
+			charactersLoaders,
+			censusCharactersRepo.LastLoginsByIds,
+		).Load,
 	)
 	m.AppendR("discord.commands", discordCommands.Start)
 
@@ -551,6 +770,7 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 		discordMessages,
 		discordCommands,
 		characterTrackerSubsMangers,
+		charactersRefresherSubsManagers,
 		trackingManagers,
 		storePubSub,
 		trackingPubSub,
@@ -559,30 +779,16 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 		outfitLoaders,
 		charactersLoaders,
 		facilityLoaders,
-		func(ctx context.Context, channelId discord.ChannelId) (int, error) {
-			count := 0
-			errs := make([]error, 0, len(ps2_platforms.Platforms))
-			for _, platform := range ps2_platforms.Platforms {
-				settings, err := trackingSettingsRepo.Get(ctx, channelId, platform)
-				if err != nil {
-					errs = append(errs, err)
-					continue
-				}
-				outfits := charactersTrackers[platform].OutfitMembersOnline(settings.Outfits)
-				for _, outfit := range outfits {
-					count += len(outfit)
-				}
-				characters := charactersTrackers[platform].CharactersOnline(settings.Characters)
-				count += len(characters)
-			}
-			return count, errors.Join(errs...)
-		},
+		onlineTrackableEntitiesCountLoader,
 		statsTrackerPubSub,
+		outfitActivityPubSub,
 		store.Channel,
 		tracking_settings_diff_view_loader.New(
 			censusOutfitsRepo,
 			censusCharactersRepo,
 		).Load,
+		store.ActiveStatsTrackerLeaderboards,
+		store.SaveStatsTrackerSessionLeaderboard,
 	)
 	if err != nil {
 		return nil, err
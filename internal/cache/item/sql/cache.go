@@ -0,0 +1,35 @@
+package sql_item_cache
+
+import (
+	"context"
+
+	"github.com/x0k/ps2-spy/internal/lib/logger"
+	"github.com/x0k/ps2-spy/internal/lib/logger/sl"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	sql_storage "github.com/x0k/ps2-spy/internal/storage/sql"
+)
+
+type Cache struct {
+	log     *logger.Logger
+	storage *sql_storage.Storage
+}
+
+func New(log *logger.Logger, storage *sql_storage.Storage) *Cache {
+	return &Cache{
+		log:     log,
+		storage: storage,
+	}
+}
+
+func (s *Cache) Get(ctx context.Context, itemIds []ps2.ItemId) (map[ps2.ItemId]ps2.Item, bool) {
+	items, err := s.storage.Items(ctx, itemIds)
+	if err != nil {
+		s.log.Error(ctx, "failed to get items", sl.Err(err))
+		return make(map[ps2.ItemId]ps2.Item), false
+	}
+	return items, len(items) == len(itemIds)
+}
+
+func (s *Cache) Add(ctx context.Context, items map[ps2.ItemId]ps2.Item) error {
+	return s.storage.SaveItems(ctx, items)
+}
@@ -0,0 +1,152 @@
+package characters_history
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/lib/logger"
+	"github.com/x0k/ps2-spy/internal/lib/logger/sl"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+// Sessions are kept for the longest period of the history
+const retention = 30 * 24 * time.Hour
+
+const recentSessionsCount = 10
+
+type Session struct {
+	Platform    ps2_platforms.Platform
+	CharacterId ps2.CharacterId
+	WorldId     ps2.WorldId
+	StartedAt   time.Time
+	// Zero value for the ongoing session
+	EndedAt time.Time
+}
+
+func (s Session) IsOngoing() bool {
+	return s.EndedAt.IsZero()
+}
+
+// Part of the session in [from, to)
+func (s Session) Playtime(from, to time.Time) time.Duration {
+	end := s.EndedAt
+	if s.IsOngoing() || end.After(to) {
+		end = to
+	}
+	start := s.StartedAt
+	if start.Before(from) {
+		start = from
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+type History struct {
+	// Most recent sessions first
+	Sessions   []Session
+	Last7Days  time.Duration
+	Last30Days time.Duration
+}
+
+type Repo interface {
+	StartCharacterSession(context.Context, Session) error
+	EndCharacterSession(context.Context, ps2_platforms.Platform, ps2.CharacterId, time.Time) error
+	EndAllCharacterSessions(context.Context, time.Time) error
+	// Sessions that are not ended on startup have an unknown duration
+	EndStaleCharacterSessions(context.Context) error
+	// Returns sessions that are ongoing or ended after `since`, most recent first
+	CharacterSessions(context.Context, ps2_platforms.Platform, ps2.CharacterId, time.Time) ([]Session, error)
+	RemoveCharacterSessionsBefore(context.Context, time.Time) error
+}
+
+type CharactersHistory struct {
+	log  *logger.Logger
+	repo Repo
+}
+
+func New(log *logger.Logger, repo Repo) *CharactersHistory {
+	return &CharactersHistory{
+		log:  log,
+		repo: repo,
+	}
+}
+
+func (h *CharactersHistory) Start(ctx context.Context) {
+	if err := h.repo.EndStaleCharacterSessions(ctx); err != nil {
+		h.log.Error(ctx, "failed to end stale sessions", sl.Err(err))
+	}
+	h.removeExpired(ctx, time.Now())
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			h.removeExpired(ctx, now)
+		}
+	}
+}
+
+// Ends ongoing sessions on shutdown
+func (h *CharactersHistory) Stop(ctx context.Context) error {
+	return h.repo.EndAllCharacterSessions(ctx, time.Now())
+}
+
+func (h *CharactersHistory) HandleLogin(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	char ps2.Character,
+	t time.Time,
+) {
+	if err := h.repo.StartCharacterSession(ctx, Session{
+		Platform:    platform,
+		CharacterId: char.Id,
+		WorldId:     char.WorldId,
+		StartedAt:   t,
+	}); err != nil {
+		h.log.Error(ctx, "failed to start session", slog.String("character_id", string(char.Id)), sl.Err(err))
+	}
+}
+
+func (h *CharactersHistory) HandleLogout(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	characterId ps2.CharacterId,
+	t time.Time,
+) {
+	if err := h.repo.EndCharacterSession(ctx, platform, characterId, t); err != nil {
+		h.log.Error(ctx, "failed to end session", slog.String("character_id", string(characterId)), sl.Err(err))
+	}
+}
+
+func (h *CharactersHistory) CharacterHistory(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	characterId ps2.CharacterId,
+) (History, error) {
+	now := time.Now()
+	sessions, err := h.repo.CharacterSessions(ctx, platform, characterId, now.Add(-retention))
+	if err != nil {
+		return History{}, fmt.Errorf("failed to load sessions: %w", err)
+	}
+	history := History{
+		Sessions: sessions[:min(len(sessions), recentSessionsCount)],
+	}
+	for _, s := range sessions {
+		history.Last7Days += s.Playtime(now.Add(-7*24*time.Hour), now)
+		history.Last30Days += s.Playtime(now.Add(-30*24*time.Hour), now)
+	}
+	return history, nil
+}
+
+func (h *CharactersHistory) removeExpired(ctx context.Context, now time.Time) {
+	if err := h.repo.RemoveCharacterSessionsBefore(ctx, now.Add(-retention)); err != nil {
+		h.log.Error(ctx, "failed to remove expired sessions", sl.Err(err))
+	}
+}
@@ -0,0 +1,60 @@
+package characters_history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionPlaytime(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	cases := []struct {
+		name    string
+		started time.Duration
+		ended   time.Duration
+		ongoing bool
+		want    time.Duration
+	}{
+		{
+			name:    "inside the range",
+			started: time.Hour,
+			ended:   3 * time.Hour,
+			want:    2 * time.Hour,
+		},
+		{
+			name:    "started before the range",
+			started: -time.Hour,
+			ended:   time.Hour,
+			want:    time.Hour,
+		},
+		{
+			name:    "ended after the range",
+			started: 23 * time.Hour,
+			ended:   25 * time.Hour,
+			want:    time.Hour,
+		},
+		{
+			name:    "ongoing session is counted to the end of the range",
+			started: 22 * time.Hour,
+			ongoing: true,
+			want:    2 * time.Hour,
+		},
+		{
+			name:    "ended before the range",
+			started: -3 * time.Hour,
+			ended:   -time.Hour,
+			want:    0,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			session := Session{StartedAt: from.Add(c.started)}
+			if !c.ongoing {
+				session.EndedAt = from.Add(c.ended)
+			}
+			if got := session.Playtime(from, to); got != c.want {
+				t.Errorf("Playtime() = %s, want %s", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package characters_refresher
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/lib/cache"
+	"github.com/x0k/ps2-spy/internal/lib/loader"
+	"github.com/x0k/ps2-spy/internal/lib/logger"
+	"github.com/x0k/ps2-spy/internal/lib/logger/sl"
+	"github.com/x0k/ps2-spy/internal/lib/pubsub"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+const (
+	// Number of characters loaded with a single request
+	refreshBatchSize = 100
+	checkInterval    = time.Hour
+)
+
+type TrackableCharactersLoader = loader.Simple[[]ps2.CharacterId]
+
+// Should bypass the cache
+type CharactersLoader = loader.Multi[ps2.CharacterId, ps2.Character]
+
+type CharactersCache = cache.Multi[ps2.CharacterId, ps2.Character]
+
+type KnownCharacter struct {
+	Character   ps2.Character
+	RefreshedAt time.Time
+}
+
+type Repo interface {
+	KnownCharacters(context.Context, ps2_platforms.Platform) (map[ps2.CharacterId]KnownCharacter, error)
+	SaveKnownCharacters(context.Context, ps2_platforms.Platform, []ps2.Character, time.Time) error
+	RemoveKnownCharactersBefore(context.Context, ps2_platforms.Platform, time.Time) error
+}
+
+// Reloads the trackable characters that were not refreshed for `interval`,
+// updates the cache and reports the changes since the last refresh
+type Refresher struct {
+	log                       *logger.Logger
+	platform                  ps2_platforms.Platform
+	publisher                 pubsub.Publisher[Event]
+	repo                      Repo
+	trackableCharactersLoader TrackableCharactersLoader
+	charactersLoader          CharactersLoader
+	charactersCache           CharactersCache
+	interval                  time.Duration
+}
+
+func New(
+	log *logger.Logger,
+	platform ps2_platforms.Platform,
+	publisher pubsub.Publisher[Event],
+	repo Repo,
+	trackableCharactersLoader TrackableCharactersLoader,
+	charactersLoader CharactersLoader,
+	charactersCache CharactersCache,
+	interval time.Duration,
+) *Refresher {
+	return &Refresher{
+		log:                       log,
+		platform:                  platform,
+		publisher:                 publisher,
+		repo:                      repo,
+		trackableCharactersLoader: trackableCharactersLoader,
+		charactersLoader:          charactersLoader,
+		charactersCache:           charactersCache,
+		interval:                  interval,
+	}
+}
+
+func (r *Refresher) Start(ctx context.Context) {
+	r.refresh(ctx, time.Now())
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.refresh(ctx, now)
+		}
+	}
+}
+
+func (r *Refresher) refresh(ctx context.Context, now time.Time) {
+	ids, err := r.trackableCharactersLoader(ctx)
+	if err != nil {
+		r.log.Error(ctx, "failed to load trackable characters", sl.Err(err))
+		return
+	}
+	known, err := r.repo.KnownCharacters(ctx, r.platform)
+	if err != nil {
+		r.log.Error(ctx, "failed to load known characters", sl.Err(err))
+		return
+	}
+	// The loader may return a shared slice
+	ids = slices.Compact(slices.Sorted(slices.Values(ids)))
+	stale := make([]ps2.CharacterId, 0, len(ids))
+	for _, id := range ids {
+		if k, ok := known[id]; !ok || now.Sub(k.RefreshedAt) >= r.interval {
+			stale = append(stale, id)
+		}
+	}
+	failed := false
+	for batch := range slices.Chunk(stale, refreshBatchSize) {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := r.refreshBatch(ctx, batch, known, now); err != nil {
+			failed = true
+			r.log.Warn(ctx, "failed to refresh characters", slog.Int("count", len(batch)), sl.Err(err))
+		}
+	}
+	// Characters that could not be refreshed during an outage
+	// would be forgotten along with their changes
+	if failed {
+		return
+	}
+	// Characters that are no longer tracked or deleted
+	if err := r.repo.RemoveKnownCharactersBefore(ctx, r.platform, now.Add(-2*r.interval)); err != nil {
+		r.log.Error(ctx, "failed to remove outdated characters", sl.Err(err))
+	}
+	if len(stale) > 0 {
+		r.log.Debug(ctx, "characters refreshed", slog.Int("count", len(stale)))
+	}
+}
+
+func (r *Refresher) refreshBatch(
+	ctx context.Context,
+	ids []ps2.CharacterId,
+	known map[ps2.CharacterId]KnownCharacter,
+	now time.Time,
+) error {
+	chars, err := r.charactersLoader(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to load characters: %w", err)
+	}
+	if len(chars) == 0 {
+		return nil
+	}
+	if err := r.charactersCache.Add(ctx, chars); err != nil {
+		r.log.Warn(ctx, "failed to update cache", sl.Err(err))
+	}
+	if err := r.repo.SaveKnownCharacters(ctx, r.platform, slices.Collect(maps.Values(chars)), now); err != nil {
+		return fmt.Errorf("failed to save characters: %w", err)
+	}
+	for id, char := range chars {
+		// The first refresh only remembers the character
+		if old, ok := known[id]; ok && isUpdated(old.Character, char) {
+			r.publisher.Publish(CharacterUpdated{
+				Time: now,
+				Old:  old.Character,
+				New:  char,
+			})
+		}
+	}
+	return nil
+}
+
+// Changes of the outfit tag are not reported since they concern the outfit
+func isUpdated(old ps2.Character, new ps2.Character) bool {
+	return old.Name != new.Name ||
+		old.FactionId != new.FactionId ||
+		old.WorldId != new.WorldId ||
+		old.OutfitId != new.OutfitId
+}
@@ -0,0 +1,180 @@
+package characters_refresher
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/lib/logger"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+type publisherFunc func(Event)
+
+func (f publisherFunc) Publish(event Event) {
+	f(event)
+}
+
+type repoMock struct {
+	known     map[ps2.CharacterId]KnownCharacter
+	removedAt time.Time
+}
+
+func (r *repoMock) KnownCharacters(context.Context, ps2_platforms.Platform) (map[ps2.CharacterId]KnownCharacter, error) {
+	// The refresher should not depend on the repo state after the load
+	return maps.Clone(r.known), nil
+}
+
+func (r *repoMock) SaveKnownCharacters(_ context.Context, _ ps2_platforms.Platform, chars []ps2.Character, t time.Time) error {
+	for _, char := range chars {
+		r.known[char.Id] = KnownCharacter{Character: char, RefreshedAt: t}
+	}
+	return nil
+}
+
+func (r *repoMock) RemoveKnownCharactersBefore(_ context.Context, _ ps2_platforms.Platform, t time.Time) error {
+	r.removedAt = t
+	return nil
+}
+
+type cacheMock map[ps2.CharacterId]ps2.Character
+
+func (c cacheMock) Get(context.Context, []ps2.CharacterId) (map[ps2.CharacterId]ps2.Character, bool) {
+	return nil, false
+}
+
+func (c cacheMock) Add(_ context.Context, chars map[ps2.CharacterId]ps2.Character) error {
+	for id, char := range chars {
+		c[id] = char
+	}
+	return nil
+}
+
+func TestIsUpdated(t *testing.T) {
+	old := ps2.Character{Id: "1", Name: "Name", FactionId: "1", WorldId: "1", OutfitId: "1", OutfitTag: "TAG"}
+	cases := []struct {
+		name     string
+		update   func(*ps2.Character)
+		expected bool
+	}{
+		{name: "same", update: func(*ps2.Character) {}, expected: false},
+		{name: "name", update: func(c *ps2.Character) { c.Name = "New" }, expected: true},
+		{name: "faction", update: func(c *ps2.Character) { c.FactionId = "4" }, expected: true},
+		{name: "world", update: func(c *ps2.Character) { c.WorldId = "10" }, expected: true},
+		{name: "outfit", update: func(c *ps2.Character) { c.OutfitId = "" }, expected: true},
+		{name: "outfit tag", update: func(c *ps2.Character) { c.OutfitTag = "NEW" }, expected: false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			new := old
+			c.update(&new)
+			if got := isUpdated(old, new); got != c.expected {
+				t.Errorf("isUpdated() = %v, want %v", got, c.expected)
+			}
+		})
+	}
+}
+
+func TestRefresherRefresh(t *testing.T) {
+	const interval = 24 * time.Hour
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	chars := map[ps2.CharacterId]ps2.Character{
+		"1": {Id: "1", Name: "Alpha", WorldId: "1"},
+		"2": {Id: "2", Name: "Bravo", WorldId: "1"},
+	}
+	var loadErr error
+	var loaded []ps2.CharacterId
+	var events []CharacterUpdated
+	repo := &repoMock{known: make(map[ps2.CharacterId]KnownCharacter)}
+	cache := cacheMock{}
+	refresher := New(
+		logger.New(slog.New(slog.DiscardHandler)),
+		ps2_platforms.PC,
+		publisherFunc(func(e Event) {
+			events = append(events, e.(CharacterUpdated))
+		}),
+		repo,
+		func(context.Context) ([]ps2.CharacterId, error) {
+			return []ps2.CharacterId{"2", "1", "2"}, nil
+		},
+		func(_ context.Context, ids []ps2.CharacterId) (map[ps2.CharacterId]ps2.Character, error) {
+			loaded = append(loaded, ids...)
+			if loadErr != nil {
+				return nil, loadErr
+			}
+			result := make(map[ps2.CharacterId]ps2.Character, len(ids))
+			for _, id := range ids {
+				result[id] = chars[id]
+			}
+			return result, nil
+		},
+		cache,
+		interval,
+	)
+	steps := []struct {
+		name    string
+		at      time.Duration
+		change  func()
+		loadErr error
+		loaded  []ps2.CharacterId
+		events  int
+	}{
+		{
+			name:   "first refresh only remembers characters",
+			loaded: []ps2.CharacterId{"1", "2"},
+		},
+		{
+			name:   "fresh characters are not reloaded",
+			at:     time.Hour,
+			change: func() { chars["1"] = ps2.Character{Id: "1", Name: "Renamed", WorldId: "1"} },
+		},
+		{
+			name:   "changes are reported after the interval",
+			at:     interval,
+			loaded: []ps2.CharacterId{"1", "2"},
+			events: 1,
+		},
+		{
+			name:    "failed refresh keeps known characters",
+			at:      2 * interval,
+			loadErr: errors.New("census is down"),
+			loaded:  []ps2.CharacterId{"1", "2"},
+			events:  1,
+		},
+	}
+	for _, s := range steps {
+		if s.change != nil {
+			s.change()
+		}
+		loadErr = s.loadErr
+		loaded = nil
+		repo.removedAt = time.Time{}
+		now := start.Add(s.at)
+		refresher.refresh(context.Background(), now)
+		if !slices.Equal(loaded, s.loaded) {
+			t.Errorf("%s: expected loaded %v, got %v", s.name, s.loaded, loaded)
+		}
+		if len(events) != s.events {
+			t.Errorf("%s: expected %d events, got %d", s.name, s.events, len(events))
+		}
+		if expected := now.Add(-2 * interval); s.loadErr == nil && !repo.removedAt.Equal(expected) {
+			t.Errorf("%s: expected outdated characters to be removed before %v, got %v", s.name, expected, repo.removedAt)
+		}
+		if s.loadErr != nil && !repo.removedAt.IsZero() {
+			t.Errorf("%s: expected known characters to be kept", s.name)
+		}
+	}
+
+	e := events[0]
+	if e.Old.Name != "Alpha" || e.New.Name != "Renamed" || !e.Time.Equal(start.Add(interval)) {
+		t.Errorf("unexpected event %+v", e)
+	}
+	if cache["1"].Name != "Renamed" {
+		t.Errorf("expected the cache to be updated, got %+v", cache["1"])
+	}
+}
@@ -0,0 +1,27 @@
+package characters_refresher
+
+import (
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/lib/pubsub"
+	"github.com/x0k/ps2-spy/internal/ps2"
+)
+
+type EventType string
+
+type Event = pubsub.Event[EventType]
+
+const (
+	CharacterUpdatedType EventType = "character_updated"
+)
+
+// Name, faction, world or outfit of the character has been changed
+type CharacterUpdated struct {
+	Time time.Time
+	Old  ps2.Character
+	New  ps2.Character
+}
+
+func (e CharacterUpdated) Type() EventType {
+	return CharacterUpdatedType
+}
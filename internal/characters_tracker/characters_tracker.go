@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -17,11 +18,20 @@ import (
 	"github.com/x0k/ps2-spy/internal/metrics"
 	"github.com/x0k/ps2-spy/internal/ps2"
 	ps2_factions "github.com/x0k/ps2-spy/internal/ps2/factions"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
 	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
 )
 
 var ErrWorldPopulationTrackerNotFound = fmt.Errorf("world population tracker not found")
 
+const (
+	populationSampleInterval = 5 * time.Minute
+	// One day of samples
+	maxPopulationSamples = 288
+	// Delay of the online statuses from the external source
+	reconcileActivityTimeout = 5 * time.Minute
+)
+
 type player struct {
 	characterId ps2.CharacterId
 	worldId     ps2.WorldId
@@ -35,11 +45,13 @@ type CharactersTracker struct {
 	platform                ps2_platforms.Platform
 	mutex                   sync.RWMutex
 	worldPopulationTrackers map[ps2.WorldId]worldPopulationTracker
+	populationHistory       map[ps2.WorldId][]ps2.PopulationSample
 	onlineCharactersTracker onlineCharactersTracker
 	activePlayers           *containers.ExpirationQueue[player]
 	inactivityCheckInterval time.Duration
 	inactiveTimeout         time.Duration
 	characterLoader         CharacterLoader
+	repo                    SnapshotRepo
 	publisher               pubsub.Publisher[Event]
 	mt                      *metrics.Metrics
 }
@@ -49,6 +61,7 @@ func New(
 	platform ps2_platforms.Platform,
 	worldIds []ps2.WorldId,
 	characterLoader loader.Keyed[ps2.CharacterId, ps2.Character],
+	repo SnapshotRepo,
 	publisher pubsub.Publisher[Event],
 	mt *metrics.Metrics,
 ) *CharactersTracker {
@@ -60,11 +73,13 @@ func New(
 		log:                     log,
 		platform:                platform,
 		worldPopulationTrackers: trackers,
+		populationHistory:       make(map[ps2.WorldId][]ps2.PopulationSample, len(trackers)),
 		onlineCharactersTracker: newOnlineCharactersTracker(),
 		activePlayers:           containers.NewExpirationQueue[player](),
 		inactivityCheckInterval: time.Minute,
 		inactiveTimeout:         10 * time.Minute,
 		characterLoader:         characterLoader,
+		repo:                    repo,
 		publisher:               publisher,
 		mt:                      mt,
 	}
@@ -102,6 +117,12 @@ func (p *CharactersTracker) handleInactive(ctx context.Context, now time.Time) [
 }
 
 func (p *CharactersTracker) Start(ctx context.Context) {
+	if err := p.restoreSnapshot(ctx, time.Now()); err != nil {
+		p.log.Error(ctx, "failed to restore snapshot", sl.Err(err))
+	}
+	if err := p.restorePopulationHistory(ctx, time.Now()); err != nil {
+		p.log.Error(ctx, "failed to restore population history", sl.Err(err))
+	}
 	ticker := time.NewTicker(p.inactivityCheckInterval)
 	defer ticker.Stop()
 	for {
@@ -114,6 +135,7 @@ func (p *CharactersTracker) Start(ctx context.Context) {
 			for _, pl := range removedPlayers {
 				p.publishPlayerLogout(t, pl)
 			}
+			p.samplePopulation(t)
 		}
 	}
 }
@@ -122,9 +144,15 @@ func (p *CharactersTracker) HandleLogin(ctx context.Context, event events.Player
 	p.wg.Add(1)
 	go func() {
 		defer p.wg.Done()
+		var t time.Time
+		if timestamp, err := strconv.ParseInt(event.Timestamp, 10, 64); err == nil {
+			t = time.Unix(timestamp, 0)
+		} else {
+			t = time.Now()
+		}
 		charId := ps2.CharacterId(event.CharacterID)
-		if char, ok := p.handleLogin(ctx, charId); ok {
-			p.publishPlayerLogin(event, char)
+		if char, ok := p.handleLogin(ctx, charId, t); ok {
+			p.publishPlayerLogin(t, char)
 		}
 	}()
 }
@@ -143,23 +171,32 @@ func (p *CharactersTracker) HandleLogout(ctx context.Context, event events.Playe
 	}
 }
 
-func (p *CharactersTracker) HandleWorldZoneAction(ctx context.Context, worldId, zoneId, charId string) {
+// Empty `loadoutId` means that the event has no information about the loadout
+func (p *CharactersTracker) HandleWorldZoneAction(ctx context.Context, worldId, zoneId, charId, loadoutId string) {
 	cId := ps2.CharacterId(charId)
 	if cId == ps2.RestrictedAreaCharacterId {
 		return
 	}
 	wId := ps2.WorldId(worldId)
+	zId := ps2.ZoneId(zoneId)
+	loadout := ps2_loadout.Loadout(loadoutId)
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 	if p.onlineCharactersTracker.isOnline(cId) {
 		p.activePlayers.Push(player{cId, wId})
+		p.onlineCharactersTracker.HandleAction(cId, zId, loadout)
 	} else {
 		p.wg.Add(1)
 		go func() {
 			defer p.wg.Done()
-			if char, ok := p.handleLogin(ctx, cId); ok {
-				p.publishPlayerFakeLogin(char)
+			char, ok := p.handleLogin(ctx, cId, time.Now())
+			if !ok {
+				return
 			}
+			p.mutex.Lock()
+			p.onlineCharactersTracker.HandleAction(cId, zId, loadout)
+			p.mutex.Unlock()
+			p.publishPlayerFakeLogin(char)
 		}()
 	}
 	if w, ok := p.worldPopulationTrackers[wId]; ok {
@@ -169,9 +206,16 @@ func (p *CharactersTracker) HandleWorldZoneAction(ctx context.Context, worldId,
 	}
 }
 
+// Updates data of the online character, e.g. after rename
+func (p *CharactersTracker) HandleCharacterUpdate(char ps2.Character) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.onlineCharactersTracker.HandleUpdate(char)
+}
+
 func (p *CharactersTracker) OutfitMembersOnline(
 	outfitIds []ps2.OutfitId,
-) map[ps2.OutfitId]map[ps2.CharacterId]ps2.Character {
+) map[ps2.OutfitId]map[ps2.CharacterId]ps2.OnlineCharacter {
 	p.mutex.RLock()
 	defer p.mutex.RUnlock()
 	return p.onlineCharactersTracker.OutfitMembersOnline(outfitIds)
@@ -179,7 +223,7 @@ func (p *CharactersTracker) OutfitMembersOnline(
 
 func (p *CharactersTracker) CharactersOnline(
 	characterIds []ps2.CharacterId,
-) map[ps2.CharacterId]ps2.Character {
+) map[ps2.CharacterId]ps2.OnlineCharacter {
 	p.mutex.RLock()
 	defer p.mutex.RUnlock()
 	return p.onlineCharactersTracker.CharactersOnline(characterIds)
@@ -191,25 +235,12 @@ func (p *CharactersTracker) WorldsPopulation() ps2.WorldsPopulation {
 	total := 0
 	worlds := make([]ps2.WorldPopulation, 0, len(p.worldPopulationTrackers))
 	for worldId, worldTracker := range p.worldPopulationTrackers {
-		worldPopulation := worldTracker.Population()
-		other := worldPopulation[ps2_factions.None]
-		vs := worldPopulation[ps2_factions.VS]
-		nc := worldPopulation[ps2_factions.NC]
-		tr := worldPopulation[ps2_factions.TR]
-		ns := worldPopulation[ps2_factions.NSO]
-		all := vs + nc + tr + ns + other
-		total += all
+		stat := statPerFactions(worldTracker.Population())
+		total += stat.All
 		worlds = append(worlds, ps2.WorldPopulation{
-			Id:   worldId,
-			Name: ps2.WorldNames[worldId],
-			StatPerFactions: ps2.StatPerFactions{
-				All:   all,
-				VS:    vs,
-				NC:    nc,
-				TR:    tr,
-				NS:    ns,
-				Other: other,
-			},
+			Id:              worldId,
+			Name:            ps2.WorldNames[worldId],
+			StatPerFactions: stat,
 		})
 	}
 	return ps2.WorldsPopulation{
@@ -218,6 +249,27 @@ func (p *CharactersTracker) WorldsPopulation() ps2.WorldsPopulation {
 	}
 }
 
+// Population samples of all worlds in chronological order
+func (p *CharactersTracker) WorldsPopulationHistory() []ps2.PopulationSample {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	histories := make([][]ps2.PopulationSample, 0, len(p.populationHistory))
+	for _, samples := range p.populationHistory {
+		histories = append(histories, samples)
+	}
+	return ps2.MergePopulationHistories(histories...)
+}
+
+// Population samples of the world in chronological order
+func (p *CharactersTracker) WorldPopulationHistory(worldId ps2.WorldId) ([]ps2.PopulationSample, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	if _, ok := p.worldPopulationTrackers[worldId]; !ok {
+		return nil, ErrWorldPopulationTrackerNotFound
+	}
+	return slices.Clone(p.populationHistory[worldId]), nil
+}
+
 func (p *CharactersTracker) DetailedWorldPopulation(worldId ps2.WorldId) (ps2.DetailedWorldPopulation, error) {
 	p.mutex.RLock()
 	defer p.mutex.RUnlock()
@@ -259,7 +311,45 @@ func (p *CharactersTracker) DetailedWorldPopulation(worldId ps2.WorldId) (ps2.De
 	}, nil
 }
 
-func (p *CharactersTracker) handleLogin(ctx context.Context, charId ps2.CharacterId) (ps2.Character, bool) {
+func (p *CharactersTracker) samplePopulation(now time.Time) {
+	at := now.Truncate(populationSampleInterval)
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for worldId, worldTracker := range p.worldPopulationTrackers {
+		sample := ps2.PopulationSample{
+			At:              at,
+			StatPerFactions: statPerFactions(worldTracker.Population()),
+		}
+		samples := p.populationHistory[worldId]
+		// The latest state within the interval wins
+		if l := len(samples); l > 0 && samples[l-1].At.Equal(at) {
+			samples[l-1] = sample
+			continue
+		}
+		if len(samples) >= maxPopulationSamples {
+			samples = slices.Delete(samples, 0, len(samples)-maxPopulationSamples+1)
+		}
+		p.populationHistory[worldId] = append(samples, sample)
+	}
+}
+
+func statPerFactions(population map[ps2_factions.Id]int) ps2.StatPerFactions {
+	other := population[ps2_factions.None]
+	vs := population[ps2_factions.VS]
+	nc := population[ps2_factions.NC]
+	tr := population[ps2_factions.TR]
+	ns := population[ps2_factions.NSO]
+	return ps2.StatPerFactions{
+		All:   vs + nc + tr + ns + other,
+		VS:    vs,
+		NC:    nc,
+		TR:    tr,
+		NS:    ns,
+		Other: other,
+	}
+}
+
+func (p *CharactersTracker) handleLogin(ctx context.Context, charId ps2.CharacterId, at time.Time) (ps2.Character, bool) {
 	char, err := p.characterLoader(ctx, charId)
 	if err != nil {
 		p.log.Debug(
@@ -278,7 +368,7 @@ func (p *CharactersTracker) handleLogin(ctx context.Context, charId ps2.Characte
 	} else {
 		p.log.Warn(ctx, "world not found", slog.String("world_id", string(char.WorldId)))
 	}
-	return char, p.onlineCharactersTracker.HandleLogin(char)
+	return char, p.onlineCharactersTracker.HandleLogin(char, at)
 }
 
 func (p *CharactersTracker) handleLogout(ctx context.Context, charId ps2.CharacterId, worldId ps2.WorldId) bool {
@@ -293,17 +383,60 @@ func (p *CharactersTracker) handleLogout(ctx context.Context, charId ps2.Charact
 	return p.onlineCharactersTracker.HandleInactive(charId)
 }
 
-func (p *CharactersTracker) publishPlayerLogin(
-	event events.PlayerLogin,
-	char ps2.Character,
+// Corrects the state with the online statuses from the external source,
+// online players are considered active since idle players do not generate events.
+// The statuses lag behind the events stream, so players that were active
+// recently or after `requestedAt` are not logged out
+func (p *CharactersTracker) reconcile(
+	ctx context.Context,
+	statuses map[ps2.CharacterId]bool,
+	requestedAt time.Time,
 ) {
-	var t time.Time
-	// TODO: move this somewhere
-	if timestamp, err := strconv.ParseInt(event.Timestamp, 10, 64); err == nil {
-		t = time.Unix(timestamp, 0)
-	} else {
-		t = time.Now()
+	now := time.Now()
+	activeAfter := now.Add(-reconcileActivityTimeout)
+	if requestedAt.Before(activeAfter) {
+		activeAfter = requestedAt
+	}
+	logins := make([]ps2.CharacterId, 0)
+	logouts := make([]player, 0)
+	p.mutex.Lock()
+	for charId, online := range statuses {
+		char, tracked := p.onlineCharactersTracker.character(charId)
+		pl := player{charId, char.WorldId}
+		switch {
+		case online && tracked:
+			p.activePlayers.Push(pl)
+		case online:
+			logins = append(logins, charId)
+		case tracked:
+			if activeAt, ok := p.activePlayers.UpdatedAt(pl); ok && activeAt.After(activeAfter) {
+				continue
+			}
+			logouts = append(logouts, pl)
+		}
+	}
+	p.mutex.Unlock()
+	for _, pl := range logouts {
+		if p.handleLogout(ctx, pl.characterId, pl.worldId) {
+			p.publishPlayerLogout(now, pl)
+		}
 	}
+	for _, charId := range logins {
+		if char, ok := p.handleLogin(ctx, charId, now); ok {
+			p.publishPlayerFakeLogin(char)
+		}
+	}
+	if len(logins) > 0 || len(logouts) > 0 {
+		p.log.Debug(
+			ctx,
+			"online statuses reconciled",
+			slog.Int("logins", len(logins)),
+			slog.Int("logouts", len(logouts)),
+		)
+	}
+}
+
+func (p *CharactersTracker) publishPlayerLogin(t time.Time, char ps2.Character) {
 	p.publisher.Publish(PlayerLogin{
 		Time:      t,
 		Character: char,
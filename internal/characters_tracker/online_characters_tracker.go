@@ -2,41 +2,90 @@ package characters_tracker
 
 import (
 	"maps"
+	"time"
 
 	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
 )
 
 type onlineCharactersTracker struct {
-	onlineCharactersByOutfit map[ps2.OutfitId]map[ps2.CharacterId]ps2.Character
+	onlineCharactersByOutfit map[ps2.OutfitId]map[ps2.CharacterId]ps2.OnlineCharacter
 	characterOutfitMap       map[ps2.CharacterId]ps2.OutfitId
 }
 
 func newOnlineCharactersTracker() onlineCharactersTracker {
 	return onlineCharactersTracker{
-		onlineCharactersByOutfit: make(map[ps2.OutfitId]map[ps2.CharacterId]ps2.Character),
+		onlineCharactersByOutfit: make(map[ps2.OutfitId]map[ps2.CharacterId]ps2.OnlineCharacter),
 		characterOutfitMap:       make(map[ps2.CharacterId]ps2.OutfitId),
 	}
 }
 
 // Returns `true` if character was added.
-func (o *onlineCharactersTracker) HandleLogin(char ps2.Character) bool {
+func (o *onlineCharactersTracker) HandleLogin(char ps2.Character, at time.Time) bool {
+	online := ps2.OnlineCharacter{
+		Character:   char,
+		OnlineSince: at,
+	}
 	oldOutfitId, isOldOutfitExists := o.characterOutfitMap[char.Id]
 	if isOldOutfitExists {
 		if oldOutfitId == char.OutfitId {
 			return false
 		}
+		// Keep the state of the session
+		online = o.onlineCharactersByOutfit[oldOutfitId][char.Id]
+		online.Character = char
 		delete(o.onlineCharactersByOutfit[oldOutfitId], char.Id)
 	}
 	outfit, ok := o.onlineCharactersByOutfit[char.OutfitId]
 	if !ok {
-		outfit = make(map[ps2.CharacterId]ps2.Character)
+		outfit = make(map[ps2.CharacterId]ps2.OnlineCharacter)
 		o.onlineCharactersByOutfit[char.OutfitId] = outfit
 	}
-	outfit[char.Id] = char
+	outfit[char.Id] = online
 	o.characterOutfitMap[char.Id] = char.OutfitId
 	return !isOldOutfitExists
 }
 
+// Replaces data of the online character keeping the state of the session.
+// Returns `false` if character is not online.
+func (o *onlineCharactersTracker) HandleUpdate(char ps2.Character) bool {
+	oldOutfitId, ok := o.characterOutfitMap[char.Id]
+	if !ok {
+		return false
+	}
+	online := o.onlineCharactersByOutfit[oldOutfitId][char.Id]
+	online.Character = char
+	delete(o.onlineCharactersByOutfit[oldOutfitId], char.Id)
+	outfit, ok := o.onlineCharactersByOutfit[char.OutfitId]
+	if !ok {
+		outfit = make(map[ps2.CharacterId]ps2.OnlineCharacter)
+		o.onlineCharactersByOutfit[char.OutfitId] = outfit
+	}
+	outfit[char.Id] = online
+	o.characterOutfitMap[char.Id] = char.OutfitId
+	return true
+}
+
+// Empty values are ignored
+func (o *onlineCharactersTracker) HandleAction(
+	charId ps2.CharacterId,
+	zoneId ps2.ZoneId,
+	loadout ps2_loadout.Loadout,
+) {
+	outfitId, ok := o.characterOutfitMap[charId]
+	if !ok {
+		return
+	}
+	char := o.onlineCharactersByOutfit[outfitId][charId]
+	if zoneId != "" {
+		char.ZoneId = zoneId
+	}
+	if loadout != "" {
+		char.Loadout = loadout
+	}
+	o.onlineCharactersByOutfit[outfitId][charId] = char
+}
+
 // Returns `true` if character was deleted.
 func (o *onlineCharactersTracker) HandleInactive(charId ps2.CharacterId) bool {
 	if outfitId, ok := o.characterOutfitMap[charId]; ok {
@@ -49,8 +98,8 @@ func (o *onlineCharactersTracker) HandleInactive(charId ps2.CharacterId) bool {
 
 func (o *onlineCharactersTracker) OutfitMembersOnline(
 	outfitIds []ps2.OutfitId,
-) map[ps2.OutfitId]map[ps2.CharacterId]ps2.Character {
-	outfits := make(map[ps2.OutfitId]map[ps2.CharacterId]ps2.Character, len(outfitIds))
+) map[ps2.OutfitId]map[ps2.CharacterId]ps2.OnlineCharacter {
+	outfits := make(map[ps2.OutfitId]map[ps2.CharacterId]ps2.OnlineCharacter, len(outfitIds))
 	for _, outfitId := range outfitIds {
 		if characters, ok := o.onlineCharactersByOutfit[outfitId]; ok {
 			outfits[outfitId] = maps.Clone(characters)
@@ -61,8 +110,8 @@ func (o *onlineCharactersTracker) OutfitMembersOnline(
 
 func (o *onlineCharactersTracker) CharactersOnline(
 	characterIds []ps2.CharacterId,
-) map[ps2.CharacterId]ps2.Character {
-	characters := make(map[ps2.CharacterId]ps2.Character, len(characterIds))
+) map[ps2.CharacterId]ps2.OnlineCharacter {
+	characters := make(map[ps2.CharacterId]ps2.OnlineCharacter, len(characterIds))
 	for _, charId := range characterIds {
 		if outfitId, ok := o.characterOutfitMap[charId]; ok {
 			characters[charId] = o.onlineCharactersByOutfit[outfitId][charId]
@@ -71,6 +120,14 @@ func (o *onlineCharactersTracker) CharactersOnline(
 	return characters
 }
 
+func (o *onlineCharactersTracker) character(charId ps2.CharacterId) (ps2.OnlineCharacter, bool) {
+	outfitId, ok := o.characterOutfitMap[charId]
+	if !ok {
+		return ps2.OnlineCharacter{}, false
+	}
+	return o.onlineCharactersByOutfit[outfitId][charId], true
+}
+
 func (o *onlineCharactersTracker) isOnline(charId ps2.CharacterId) bool {
 	_, ok := o.characterOutfitMap[charId]
 	return ok
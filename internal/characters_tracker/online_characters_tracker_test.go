@@ -0,0 +1,49 @@
+package characters_tracker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/ps2"
+)
+
+func TestOnlineCharactersTrackerSessionState(t *testing.T) {
+	loginAt := time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)
+	tracker := newOnlineCharactersTracker()
+	char := ps2.Character{Id: "1", OutfitId: "outfit"}
+	if !tracker.HandleLogin(char, loginAt) {
+		t.Fatal("expected the character to be added")
+	}
+	// Unknown characters are ignored
+	tracker.HandleAction("2", "2", "1")
+
+	tracker.HandleAction(char.Id, "2", "1")
+	tracker.HandleAction(char.Id, "", "4")
+	tracker.HandleAction(char.Id, "6", "")
+
+	// Outfit change should keep the session state
+	char.OutfitId = "other"
+	if tracker.HandleLogin(char, loginAt.Add(time.Hour)) {
+		t.Error("expected the outfit change not to be reported as a login")
+	}
+
+	online, ok := tracker.character(char.Id)
+	if !ok {
+		t.Fatal("expected the character to be online")
+	}
+	expected := ps2.OnlineCharacter{
+		Character:   char,
+		ZoneId:      "6",
+		Loadout:     "4",
+		OnlineSince: loginAt,
+	}
+	if online != expected {
+		t.Errorf("expected %+v, got %+v", expected, online)
+	}
+	if _, ok := tracker.CharactersOnline([]ps2.CharacterId{"2"})["2"]; ok {
+		t.Error("expected the unknown character to be offline")
+	}
+	if members := tracker.OutfitMembersOnline([]ps2.OutfitId{"outfit", "other"}); len(members["outfit"]) != 0 || len(members["other"]) != 1 {
+		t.Errorf("expected the character to be moved to the new outfit, got %v", members)
+	}
+}
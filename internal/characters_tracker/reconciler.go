@@ -0,0 +1,98 @@
+package characters_tracker
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/lib/loader"
+	"github.com/x0k/ps2-spy/internal/lib/logger"
+	"github.com/x0k/ps2-spy/internal/lib/logger/sl"
+	"github.com/x0k/ps2-spy/internal/ps2"
+)
+
+// Number of characters checked with a single request
+const reconcileBatchSize = 100
+
+type TrackableCharactersLoader = loader.Simple[[]ps2.CharacterId]
+
+// Characters with unknown status should be omitted
+type OnlineStatusesLoader = func(context.Context, []ps2.CharacterId) (map[ps2.CharacterId]bool, error)
+
+// Periodically checks the online status of the trackable characters
+// and corrects the state of the tracker, characters are checked
+// in a round-robin fashion within the requests limit
+type Reconciler struct {
+	log                       *logger.Logger
+	tracker                   *CharactersTracker
+	trackableCharactersLoader TrackableCharactersLoader
+	onlineStatusesLoader      OnlineStatusesLoader
+	interval                  time.Duration
+	requestsLimit             int
+	lastCharacterId           ps2.CharacterId
+}
+
+func NewReconciler(
+	log *logger.Logger,
+	tracker *CharactersTracker,
+	trackableCharactersLoader TrackableCharactersLoader,
+	onlineStatusesLoader OnlineStatusesLoader,
+	interval time.Duration,
+	requestsLimit int,
+) *Reconciler {
+	return &Reconciler{
+		log:                       log,
+		tracker:                   tracker,
+		trackableCharactersLoader: trackableCharactersLoader,
+		onlineStatusesLoader:      onlineStatusesLoader,
+		interval:                  interval,
+		requestsLimit:             requestsLimit,
+	}
+}
+
+func (r *Reconciler) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reconcile(ctx)
+		}
+	}
+}
+
+func (r *Reconciler) reconcile(ctx context.Context) {
+	ids, err := r.trackableCharactersLoader(ctx)
+	if err != nil {
+		r.log.Error(ctx, "failed to load trackable characters", sl.Err(err))
+		return
+	}
+	if len(ids) == 0 {
+		return
+	}
+	// The loader may return a shared slice
+	ids = slices.Compact(slices.Sorted(slices.Values(ids)))
+	// Continue after the last checked character
+	start, found := slices.BinarySearch(ids, r.lastCharacterId)
+	if found {
+		start++
+	}
+	count := min(len(ids), r.requestsLimit*reconcileBatchSize)
+	queue := make([]ps2.CharacterId, 0, count)
+	for i := range count {
+		queue = append(queue, ids[(start+i)%len(ids)])
+	}
+	for batch := range slices.Chunk(queue, reconcileBatchSize) {
+		requestedAt := time.Now()
+		statuses, err := r.onlineStatusesLoader(ctx, batch)
+		if err != nil {
+			r.log.Warn(ctx, "failed to load online statuses", slog.Int("count", len(batch)), sl.Err(err))
+			return
+		}
+		r.tracker.reconcile(ctx, statuses, requestedAt)
+		r.lastCharacterId = batch[len(batch)-1]
+	}
+}
@@ -0,0 +1,180 @@
+package characters_tracker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/lib/logger"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+const testWorldId ps2.WorldId = "1"
+
+type eventsRecorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *eventsRecorder) Publish(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *eventsRecorder) types() []EventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	types := make([]EventType, 0, len(r.events))
+	for _, e := range r.events {
+		types = append(types, e.Type())
+	}
+	return types
+}
+
+func newTestCharactersTracker(publisher *eventsRecorder) *CharactersTracker {
+	return New(
+		logger.New(slog.New(slog.DiscardHandler)),
+		ps2_platforms.PC,
+		[]ps2.WorldId{testWorldId},
+		func(_ context.Context, charId ps2.CharacterId) (ps2.Character, error) {
+			return ps2.Character{Id: charId, WorldId: testWorldId}, nil
+		},
+		nil,
+		publisher,
+		nil,
+	)
+}
+
+func TestCharactersTrackerReconcile(t *testing.T) {
+	const characterId ps2.CharacterId = "character"
+	cases := []struct {
+		name     string
+		tracked  bool
+		activeAt time.Duration
+		statuses map[ps2.CharacterId]bool
+		online   bool
+		events   []EventType
+	}{
+		{
+			name:     "character missing in the statuses is kept online",
+			tracked:  true,
+			activeAt: -time.Hour,
+			statuses: map[ps2.CharacterId]bool{},
+			online:   true,
+		},
+		{
+			name:     "stale online character is logged out",
+			tracked:  true,
+			activeAt: -time.Hour,
+			statuses: map[ps2.CharacterId]bool{characterId: false},
+			online:   false,
+			events:   []EventType{PlayerLogoutType},
+		},
+		{
+			name:     "recently active character is kept online",
+			tracked:  true,
+			activeAt: -time.Minute,
+			statuses: map[ps2.CharacterId]bool{characterId: false},
+			online:   true,
+		},
+		{
+			name:     "untracked online character is logged in",
+			statuses: map[ps2.CharacterId]bool{characterId: true},
+			online:   true,
+			events:   []EventType{PlayerFakeLoginType},
+		},
+		{
+			name:     "untracked offline character is ignored",
+			statuses: map[ps2.CharacterId]bool{characterId: false},
+			online:   false,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			publisher := &eventsRecorder{}
+			tracker := newTestCharactersTracker(publisher)
+			ctx := context.Background()
+			now := time.Now()
+			if c.tracked {
+				if _, ok := tracker.handleLogin(ctx, characterId, now.Add(-2*time.Hour)); !ok {
+					t.Fatal("expected the character to be logged in")
+				}
+				tracker.activePlayers.Remove(player{characterId, testWorldId})
+				tracker.activePlayers.PushAt(player{characterId, testWorldId}, now.Add(c.activeAt))
+			}
+			tracker.reconcile(ctx, c.statuses, now)
+			if online := tracker.onlineCharactersTracker.isOnline(characterId); online != c.online {
+				t.Errorf("online = %v, want %v", online, c.online)
+			}
+			population := 0
+			for _, count := range tracker.worldPopulationTrackers[testWorldId].population {
+				population += count
+			}
+			if (population == 1) != c.online {
+				t.Errorf("world population = %d, want online %v", population, c.online)
+			}
+			if types := publisher.types(); !slices.Equal(types, c.events) {
+				t.Errorf("events = %v, want %v", types, c.events)
+			}
+		})
+	}
+}
+
+func TestCharactersTrackerReconcileRefreshesActivity(t *testing.T) {
+	const characterId ps2.CharacterId = "character"
+	tracker := newTestCharactersTracker(&eventsRecorder{})
+	ctx := context.Background()
+	now := time.Now()
+	tracker.handleLogin(ctx, characterId, now.Add(-2*time.Hour))
+	pl := player{characterId, testWorldId}
+	tracker.activePlayers.Remove(pl)
+	tracker.activePlayers.PushAt(pl, now.Add(-time.Hour))
+	tracker.reconcile(ctx, map[ps2.CharacterId]bool{characterId: true}, now)
+	if activeAt, _ := tracker.activePlayers.UpdatedAt(pl); activeAt.Before(now) {
+		t.Errorf("expected the online character to be active after %v, got %v", now, activeAt)
+	}
+}
+
+func TestReconcilerRoundRobin(t *testing.T) {
+	ids := make([]ps2.CharacterId, 0, reconcileBatchSize+reconcileBatchSize/2)
+	for i := range cap(ids) {
+		ids = append(ids, ps2.CharacterId(fmt.Sprintf("%03d", i)))
+	}
+	var requested [][]ps2.CharacterId
+	reconciler := NewReconciler(
+		logger.New(slog.New(slog.DiscardHandler)),
+		newTestCharactersTracker(&eventsRecorder{}),
+		func(context.Context) ([]ps2.CharacterId, error) {
+			// Reversed to check that the order of the loader is not relied on
+			reversed := slices.Clone(ids)
+			slices.Reverse(reversed)
+			return reversed, nil
+		},
+		func(_ context.Context, batch []ps2.CharacterId) (map[ps2.CharacterId]bool, error) {
+			requested = append(requested, slices.Clone(batch))
+			return map[ps2.CharacterId]bool{}, nil
+		},
+		time.Minute,
+		1,
+	)
+	ctx := context.Background()
+	reconciler.reconcile(ctx)
+	reconciler.reconcile(ctx)
+	if len(requested) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requested))
+	}
+	if first := requested[0]; first[0] != ids[0] || first[len(first)-1] != ids[reconcileBatchSize-1] {
+		t.Errorf("expected the first batch to be %s..%s, got %s..%s", ids[0], ids[reconcileBatchSize-1], first[0], first[len(first)-1])
+	}
+	// The second batch wraps around
+	second := requested[1]
+	if second[0] != ids[reconcileBatchSize] || second[len(second)-1] != ids[reconcileBatchSize/2-1] {
+		t.Errorf("expected the second batch to be %s..%s, got %s..%s", ids[reconcileBatchSize], ids[reconcileBatchSize/2-1], second[0], second[len(second)-1])
+	}
+}
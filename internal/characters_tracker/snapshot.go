@@ -0,0 +1,130 @@
+package characters_tracker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+type PlayerSnapshot struct {
+	Character ps2.OnlineCharacter
+	ActiveAt  time.Time
+}
+
+type SnapshotRepo interface {
+	// Returns snapshots in chronological order of the last activity
+	CharactersTrackerPlayers(context.Context, ps2_platforms.Platform) ([]PlayerSnapshot, error)
+	// Replaces the previous snapshot of the platform
+	SaveCharactersTrackerPlayers(context.Context, ps2_platforms.Platform, []PlayerSnapshot) error
+	// Returns samples of each world in chronological order
+	PopulationHistory(context.Context, ps2_platforms.Platform) (map[ps2.WorldId][]ps2.PopulationSample, error)
+	// Replaces the previous history of the platform
+	SavePopulationHistory(context.Context, ps2_platforms.Platform, map[ps2.WorldId][]ps2.PopulationSample) error
+}
+
+// Saves online players and population history to restore them after restart
+func (p *CharactersTracker) SaveSnapshot(ctx context.Context) error {
+	p.mutex.RLock()
+	snapshots := make([]PlayerSnapshot, 0, p.activePlayers.Len())
+	indexes := make(map[ps2.CharacterId]int, p.activePlayers.Len())
+	for pl, activeAt := range p.activePlayers.All() {
+		// The player may be tracked in several worlds, the latest activity wins
+		if i, ok := indexes[pl.characterId]; ok {
+			snapshots[i].ActiveAt = activeAt
+			continue
+		}
+		char, ok := p.onlineCharactersTracker.character(pl.characterId)
+		if !ok {
+			continue
+		}
+		indexes[char.Id] = len(snapshots)
+		snapshots = append(snapshots, PlayerSnapshot{
+			Character: char,
+			ActiveAt:  activeAt,
+		})
+	}
+	history := make(map[ps2.WorldId][]ps2.PopulationSample, len(p.populationHistory))
+	for worldId, samples := range p.populationHistory {
+		history[worldId] = slices.Clone(samples)
+	}
+	p.mutex.RUnlock()
+	if err := p.repo.SaveCharactersTrackerPlayers(ctx, p.platform, snapshots); err != nil {
+		return fmt.Errorf("failed to save players: %w", err)
+	}
+	p.log.Info(ctx, "online players saved", slog.Int("count", len(snapshots)))
+	if err := p.repo.SavePopulationHistory(ctx, p.platform, history); err != nil {
+		return fmt.Errorf("failed to save population history: %w", err)
+	}
+	return nil
+}
+
+// Players that were inactive for longer than `inactiveTimeout`
+// and players that are already online are skipped.
+// Restored session start and continent are the ones before the downtime,
+// the continent is corrected by the next action of the player and the
+// reconciler logs out players that left, but the session start of players
+// that relogged during the downtime stays stale until their next logout
+func (p *CharactersTracker) restoreSnapshot(ctx context.Context, now time.Time) error {
+	snapshots, err := p.repo.CharactersTrackerPlayers(ctx, p.platform)
+	if err != nil {
+		return fmt.Errorf("failed to load players: %w", err)
+	}
+	expirationTime := now.Add(-p.inactiveTimeout)
+	count := 0
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, s := range snapshots {
+		char := s.Character
+		if s.ActiveAt.Before(expirationTime) || p.onlineCharactersTracker.isOnline(char.Id) {
+			continue
+		}
+		w, ok := p.worldPopulationTrackers[char.WorldId]
+		if !ok {
+			p.log.Warn(ctx, "world not found", slog.String("world_id", string(char.WorldId)))
+			continue
+		}
+		p.activePlayers.PushAt(player{char.Id, char.WorldId}, s.ActiveAt)
+		w.HandleLogin(char.Character)
+		if char.ZoneId != "" {
+			w.HandleZoneAction(char.Id, string(char.ZoneId))
+		}
+		p.onlineCharactersTracker.HandleLogin(char.Character, char.OnlineSince)
+		p.onlineCharactersTracker.HandleAction(char.Id, char.ZoneId, char.Loadout)
+		count++
+	}
+	p.log.Info(ctx, "online players restored", slog.Int("count", count))
+	return nil
+}
+
+// Samples older than the history length are skipped,
+// the downtime is left as a gap in the history
+func (p *CharactersTracker) restorePopulationHistory(ctx context.Context, now time.Time) error {
+	history, err := p.repo.PopulationHistory(ctx, p.platform)
+	if err != nil {
+		return fmt.Errorf("failed to load population history: %w", err)
+	}
+	expirationTime := now.Add(-maxPopulationSamples * populationSampleInterval)
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for worldId, samples := range history {
+		if _, ok := p.worldPopulationTrackers[worldId]; !ok {
+			continue
+		}
+		start, _ := slices.BinarySearchFunc(samples, expirationTime, func(s ps2.PopulationSample, t time.Time) int {
+			return s.At.Compare(t)
+		})
+		samples = samples[start:]
+		if len(samples) > maxPopulationSamples {
+			samples = samples[len(samples)-maxPopulationSamples:]
+		}
+		if len(samples) > 0 {
+			p.populationHistory[worldId] = samples
+		}
+	}
+	return nil
+}
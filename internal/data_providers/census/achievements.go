@@ -0,0 +1,55 @@
+package census_data_provider
+
+import (
+	"context"
+
+	census2_adapters "github.com/x0k/ps2-spy/internal/adapters/census2"
+	"github.com/x0k/ps2-spy/internal/lib/census2"
+	ps2_collections "github.com/x0k/ps2-spy/internal/lib/census2/collections/ps2"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+func (l *DataProvider) achievementsUrl(ns string, values []census2.Str) string {
+	l.achievementsMu.Lock()
+	defer l.achievementsMu.Unlock()
+	l.achievementsQuery.SetLimit(len(values))
+	l.achievementsQuery.SetNamespace(ns)
+	l.achievementsOperand.Set(census2.NewList(values, ","))
+	return l.client.ToURL(l.achievementsQuery)
+}
+
+func (l *DataProvider) Achievements(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	achievementIds []ps2.AchievementId,
+) (map[ps2.AchievementId]ps2.Achievement, error) {
+	if len(achievementIds) == 0 {
+		return nil, nil
+	}
+	values := make([]census2.Str, len(achievementIds))
+	for i, achievementId := range achievementIds {
+		values[i] = census2.Str(achievementId)
+	}
+	url := l.achievementsUrl(ps2_platforms.PlatformNamespace(platform), values)
+	items, err := census2_adapters.RetryableExecutePreparedAndDecode[ps2_collections.AchievementItem](
+		ctx,
+		l.log,
+		l.client,
+		ps2_collections.Achievement,
+		url,
+	)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[ps2.AchievementId]ps2.Achievement, len(items))
+	for _, item := range items {
+		achievementId := ps2.AchievementId(item.AchievementId)
+		res[achievementId] = ps2.Achievement{
+			Id:          achievementId,
+			Name:        item.Name.En,
+			Description: item.Description.En,
+		}
+	}
+	return res, nil
+}
@@ -17,6 +17,10 @@ type DataProvider struct {
 	ps4euUrl string
 	ps4usUrl string
 
+	achievementsMu      sync.Mutex
+	achievementsQuery   *census2.Query
+	achievementsOperand *census2.Ptr[census2.List[census2.Str]]
+
 	charactersMu      sync.Mutex
 	charactersQuery   *census2.Query
 	charactersOperand *census2.Ptr[census2.List[census2.Str]]
@@ -25,6 +29,10 @@ type DataProvider struct {
 	facilityQuery   *census2.Query
 	facilityOperand *census2.Ptr[census2.Str]
 
+	itemsMu      sync.Mutex
+	itemsQuery   *census2.Query
+	itemsOperand *census2.Ptr[census2.List[census2.Str]]
+
 	outfitMemberIdsMu      sync.Mutex
 	outfitMemberIdsQuery   *census2.Query
 	outfitMemberIdsOperand *census2.Ptr[census2.Str]
@@ -33,6 +41,10 @@ type DataProvider struct {
 	outfitsQuery   *census2.Query
 	outfitsOperand *census2.Ptr[census2.List[census2.Str]]
 
+	vehiclesMu      sync.Mutex
+	vehiclesQuery   *census2.Query
+	vehiclesOperand *census2.Ptr[census2.List[census2.Str]]
+
 	worldMapMu      sync.Mutex
 	worldMapQuery   *census2.Query
 	worldMapOperand *census2.Ptr[census2.Str]
@@ -42,10 +54,13 @@ func New(
 	log *logger.Logger,
 	client *census2.Client,
 ) *DataProvider {
+	achievementsOperand := census2.NewPtr(census2.StrList())
 	charactersOperand := census2.NewPtr(census2.StrList())
 	facilityOperand := census2.NewPtr(census2.Str(""))
+	itemsOperand := census2.NewPtr(census2.StrList())
 	outfitMemberIdsOperand := census2.NewPtr(census2.Str(""))
 	outfitsOperand := census2.NewPtr(census2.StrList())
+	vehiclesOperand := census2.NewPtr(census2.StrList())
 	worldMapOperand := census2.NewPtr(census2.Str(""))
 	zoneIds := strings.Builder{}
 	zoneIds.Grow(len(ps2.ZoneIds) * 3)
@@ -70,6 +85,11 @@ func New(
 			Where(census2.Cond("world_id").Equals(census2.Str("1000"))).
 			SetLimit(30)),
 
+		achievementsOperand: &achievementsOperand,
+		achievementsQuery: census2.NewQuery(census2.GetQuery, census2.Ps2_v2_NS, ps2_collections.Achievement).
+			Where(census2.Cond("achievement_id").Equals(&achievementsOperand)).
+			Show("achievement_id", "name.en", "description.en"),
+
 		charactersOperand: &charactersOperand,
 		charactersQuery: census2.NewQuery(census2.GetQuery, census2.Ps2_v2_NS, ps2_collections.Character).
 			Where(census2.Cond("character_id").Equals(&charactersOperand)).
@@ -87,6 +107,16 @@ func New(
 			Where(census2.Cond("facility_id").Equals(&facilityOperand)).
 			Show("facility_id", "facility_name", "facility_type", "zone_id"),
 
+		itemsOperand: &itemsOperand,
+		itemsQuery: census2.NewQuery(census2.GetQuery, census2.Ps2_v2_NS, ps2_collections.Item).
+			Where(census2.Cond("item_id").Equals(&itemsOperand)).
+			Show("item_id", "item_category_id", "name.en").
+			WithJoin(
+				census2.Join(ps2_collections.ItemCategory).
+					InjectAt("item_category").
+					Show("name.en"),
+			),
+
 		outfitMemberIdsOperand: &outfitMemberIdsOperand,
 		outfitMemberIdsQuery: census2.NewQuery(census2.GetQuery, census2.Ps2_v2_NS, ps2_collections.Outfit).
 			Where(census2.Cond("outfit_id").Equals(&outfitMemberIdsOperand)).
@@ -103,6 +133,11 @@ func New(
 			Where(census2.Cond("outfit_id").Equals(&outfitsOperand)).
 			Show("outfit_id", "name", "alias"),
 
+		vehiclesOperand: &vehiclesOperand,
+		vehiclesQuery: census2.NewQuery(census2.GetQuery, census2.Ps2_v2_NS, ps2_collections.Vehicle).
+			Where(census2.Cond("vehicle_id").Equals(&vehiclesOperand)).
+			Show("vehicle_id", "name.en", "type_name"),
+
 		worldMapOperand: &worldMapOperand,
 		worldMapQuery: census2.NewQuery(census2.GetQuery, census2.Ps2_v2_NS, ps2_collections.Map).
 			Where(
@@ -0,0 +1,51 @@
+package census_data_provider
+
+import (
+	"context"
+
+	census2_adapters "github.com/x0k/ps2-spy/internal/adapters/census2"
+	"github.com/x0k/ps2-spy/internal/lib/census2"
+	ps2_collections "github.com/x0k/ps2-spy/internal/lib/census2/collections/ps2"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+func (l *DataProvider) itemsUrl(ns string, values []census2.Str) string {
+	l.itemsMu.Lock()
+	defer l.itemsMu.Unlock()
+	l.itemsQuery.SetLimit(len(values))
+	l.itemsQuery.SetNamespace(ns)
+	l.itemsOperand.Set(census2.NewList(values, ","))
+	return l.client.ToURL(l.itemsQuery)
+}
+
+func (l *DataProvider) Items(ctx context.Context, platform ps2_platforms.Platform, itemIds []ps2.ItemId) (map[ps2.ItemId]ps2.Item, error) {
+	if len(itemIds) == 0 {
+		return nil, nil
+	}
+	values := make([]census2.Str, len(itemIds))
+	for i, itemId := range itemIds {
+		values[i] = census2.Str(itemId)
+	}
+	url := l.itemsUrl(ps2_platforms.PlatformNamespace(platform), values)
+	items, err := census2_adapters.RetryableExecutePreparedAndDecode[ps2_collections.ItemItem](
+		ctx,
+		l.log,
+		l.client,
+		ps2_collections.Item,
+		url,
+	)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[ps2.ItemId]ps2.Item, len(items))
+	for _, item := range items {
+		itemId := ps2.ItemId(item.ItemId)
+		res[itemId] = ps2.Item{
+			Id:       itemId,
+			Name:     item.Name.En,
+			Category: item.ItemCategory.Name.En,
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,51 @@
+package census_data_provider
+
+import (
+	"context"
+
+	census2_adapters "github.com/x0k/ps2-spy/internal/adapters/census2"
+	"github.com/x0k/ps2-spy/internal/lib/census2"
+	ps2_collections "github.com/x0k/ps2-spy/internal/lib/census2/collections/ps2"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+func (l *DataProvider) vehiclesUrl(ns string, values []census2.Str) string {
+	l.vehiclesMu.Lock()
+	defer l.vehiclesMu.Unlock()
+	l.vehiclesQuery.SetLimit(len(values))
+	l.vehiclesQuery.SetNamespace(ns)
+	l.vehiclesOperand.Set(census2.NewList(values, ","))
+	return l.client.ToURL(l.vehiclesQuery)
+}
+
+func (l *DataProvider) Vehicles(ctx context.Context, platform ps2_platforms.Platform, vehicleIds []ps2.VehicleId) (map[ps2.VehicleId]ps2.Vehicle, error) {
+	if len(vehicleIds) == 0 {
+		return nil, nil
+	}
+	values := make([]census2.Str, len(vehicleIds))
+	for i, vehicleId := range vehicleIds {
+		values[i] = census2.Str(vehicleId)
+	}
+	url := l.vehiclesUrl(ps2_platforms.PlatformNamespace(platform), values)
+	vehicles, err := census2_adapters.RetryableExecutePreparedAndDecode[ps2_collections.VehicleItem](
+		ctx,
+		l.log,
+		l.client,
+		ps2_collections.Vehicle,
+		url,
+	)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[ps2.VehicleId]ps2.Vehicle, len(vehicles))
+	for _, vehicle := range vehicles {
+		vehicleId := ps2.VehicleId(vehicle.VehicleId)
+		res[vehicleId] = ps2.Vehicle{
+			Id:   vehicleId,
+			Name: vehicle.Name.En,
+			Type: vehicle.TypeName,
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,128 @@
+package discord
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/x0k/ps2-spy/internal/lib/loader"
+	"github.com/x0k/ps2-spy/internal/lib/logger"
+	"github.com/x0k/ps2-spy/internal/lib/logger/sl"
+)
+
+type TrackedMessagesLoader = loader.Simple[map[ChannelId]MessageId]
+
+// Edits tracked messages in place, only the latest content
+// is applied on each tick to stay within the edit rate limits
+type ChannelMessageUpdater struct {
+	log                   *logger.Logger
+	session               *discordgo.Session
+	trackedMessagesLoader TrackedMessagesLoader
+	interval              time.Duration
+	mu                    sync.Mutex
+	messages              map[ChannelId]MessageId
+	contents              map[ChannelId]string
+}
+
+func NewChannelMessageUpdater(
+	log *logger.Logger,
+	session *discordgo.Session,
+	trackedMessagesLoader TrackedMessagesLoader,
+	interval time.Duration,
+) *ChannelMessageUpdater {
+	return &ChannelMessageUpdater{
+		log:                   log,
+		session:               session,
+		trackedMessagesLoader: trackedMessagesLoader,
+		interval:              interval,
+		messages:              make(map[ChannelId]MessageId),
+		contents:              make(map[ChannelId]string),
+	}
+}
+
+func (c *ChannelMessageUpdater) Start(ctx context.Context) {
+	c.restoreMessages(ctx)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.update(ctx)
+		}
+	}
+}
+
+// Messages of the sessions that survived a restart,
+// their content is replaced with the next update
+func (c *ChannelMessageUpdater) restoreMessages(ctx context.Context) {
+	messages, err := c.trackedMessagesLoader(ctx)
+	if err != nil {
+		c.log.Error(ctx, "failed to load tracked messages", sl.Err(err))
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for channelId, messageId := range messages {
+		if _, ok := c.messages[channelId]; !ok {
+			c.messages[channelId] = messageId
+		}
+	}
+}
+
+func (c *ChannelMessageUpdater) TrackMessage(channelId ChannelId, messageId MessageId) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages[channelId] = messageId
+}
+
+func (c *ChannelMessageUpdater) UntrackMessage(channelId ChannelId) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.messages, channelId)
+	delete(c.contents, channelId)
+}
+
+func (c *ChannelMessageUpdater) UpdateMessage(channelId ChannelId, content string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.messages[channelId]; ok {
+		c.contents[channelId] = content
+	}
+}
+
+type messageUpdate struct {
+	messageId MessageId
+	content   string
+}
+
+func (c *ChannelMessageUpdater) takeUpdates() map[ChannelId]messageUpdate {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	updates := make(map[ChannelId]messageUpdate, len(c.contents))
+	for channelId, content := range c.contents {
+		updates[channelId] = messageUpdate{
+			messageId: c.messages[channelId],
+			content:   content,
+		}
+	}
+	c.contents = make(map[ChannelId]string, len(updates))
+	return updates
+}
+
+func (c *ChannelMessageUpdater) update(ctx context.Context) {
+	for channelId, u := range c.takeUpdates() {
+		if _, err := c.session.ChannelMessageEdit(string(channelId), string(u.messageId), u.content); err != nil {
+			c.log.Error(
+				ctx,
+				"failed to update channel message",
+				slog.String("channel_id", string(channelId)),
+				slog.String("message_id", string(u.messageId)),
+				sl.Err(err),
+			)
+		}
+	}
+}
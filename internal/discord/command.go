@@ -58,16 +58,23 @@ var STATS_TRACKER_TASKS_ADD_BUTTON_CUSTOM_ID = "stats_tracker_tasks_add"
 var STATS_TRACKER_TASKS_EDIT_BUTTON_CUSTOM_ID = "stats_tracker_tasks_edit"
 var STATS_TRACKER_TASKS_REMOVE_BUTTON_CUSTOM_ID = "stats_tracker_tasks_remove"
 var STATS_TRACKER_TASKS_PAGE_BUTTON_CUSTOM_ID = "stats_tracker_tasks_page"
+var STATS_TRACKER_TASKS_ADD_DATED_BUTTON_CUSTOM_ID = "stats_tracker_tasks_add_dated"
+var STATS_TRACKER_TASKS_EDIT_DATED_BUTTON_CUSTOM_ID = "stats_tracker_tasks_edit_dated"
 
 var STATS_TRACKER_TASK_WEEKDAYS_SELECTOR_CUSTOM_ID = "stats_tracker_task_weekdays_selector"
 var STATS_TRACKER_TASK_START_HOUR_SELECTOR_CUSTOM_ID = "stats_tracker_task_start_time_selector"
 var STATS_TRACKER_TASK_START_MINUTE_SELECTOR_CUSTOM_ID = "stats_tracker_task_end_time_selector"
 var STATS_TRACKER_TASK_DURATION_SELECTOR_CUSTOM_ID = "stats_tracker_task_duration_selector"
 var STATS_TRACKER_TASK_CANCEL_BUTTON_CUSTOM_ID = "stats_tracker_task_cancel"
+var STATS_TRACKER_TASK_FILTER_BUTTON_CUSTOM_ID = "stats_tracker_task_filter"
+var STATS_TRACKER_TASK_FILTER_MODAL_CUSTOM_ID = "stats_tracker_task_filter_modal"
 
 var STATS_TRACKER_TASK_CREATE_SUBMIT_BUTTON_CUSTOM_ID = "stats_tracker_task_create_submit"
 var STATS_TRACKER_TASK_UPDATE_SUBMIT_BUTTON_CUSTOM_ID = "stats_tracker_task_update_submit"
 
+var STATS_TRACKER_DATED_TASK_MODAL_CUSTOM_ID = "stats_tracker_dated_task_modal"
+var STATS_TRACKER_DATED_TASK_RETRY_BUTTON_CUSTOM_ID = "stats_tracker_dated_task_retry"
+
 func NewStatsTrackerTaskPageButtonCustomId(
 	page int,
 ) string {
@@ -97,6 +104,22 @@ func CustomIdToTaskIdToEdit(customId string) (StatsTrackerTaskId, error) {
 	return StatsTrackerTaskId(v), err
 }
 
+func NewStatsTrackerDatedTaskEditButtonCustomId(
+	id StatsTrackerTaskId,
+) string {
+	return STATS_TRACKER_TASKS_EDIT_DATED_BUTTON_CUSTOM_ID + customIdSeparator +
+		strconv.FormatInt(int64(id), 10)
+}
+
+func CustomIdToDatedTaskIdToEdit(customId string) (StatsTrackerTaskId, error) {
+	v, err := strconv.ParseInt(
+		customId[len(STATS_TRACKER_TASKS_EDIT_DATED_BUTTON_CUSTOM_ID)+len(customIdSeparator):],
+		10,
+		64,
+	)
+	return StatsTrackerTaskId(v), err
+}
+
 func NewStatsTrackerTaskRemoveButtonCustomId(
 	id StatsTrackerTaskId,
 ) string {
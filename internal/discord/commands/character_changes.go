@@ -0,0 +1,63 @@
+package discord_commands
+
+import (
+	"context"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/x0k/ps2-spy/internal/discord"
+	discord_messages "github.com/x0k/ps2-spy/internal/discord/messages"
+)
+
+type ChannelCharacterChangesNotificationsSaver = func(context.Context, discord.ChannelId, bool) error
+
+func NewCharacterChanges(
+	messages *discord_messages.Messages,
+	channelLoader ChannelLoader,
+	channelCharacterChangesNotificationsSaver ChannelCharacterChangesNotificationsSaver,
+) *discord.Command {
+	return &discord.Command{
+		Cmd: &discordgo.ApplicationCommand{
+			Name:        "character-changes",
+			Description: "Notifications about renames, faction, server and outfit changes of the tracked characters",
+			DescriptionLocalizations: &map[discordgo.Locale]string{
+				discordgo.Russian: "Уведомления о смене имени, фракции, сервера и аутфита отслеживаемых персонажей",
+			},
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "enabled",
+					Description: "Send the notifications, shows the current state when omitted",
+					DescriptionLocalizations: map[discordgo.Locale]string{
+						discordgo.Russian: "Отправлять уведомления, если не указано, показывает текущее состояние",
+					},
+				},
+			},
+		},
+		Handler: discord.DeferredEphemeralResponse(func(
+			ctx context.Context,
+			s *discordgo.Session,
+			i *discordgo.InteractionCreate,
+		) discord.ResponseEdit {
+			channelId := discord.ChannelId(i.ChannelID)
+			options := i.ApplicationCommandData().Options
+			if len(options) == 0 {
+				channel, err := channelLoader(ctx, channelId)
+				if err != nil {
+					return discord_messages.ChannelLoadError[discordgo.WebhookEdit](
+						channelId,
+						err,
+					)
+				}
+				return messages.CharacterChangesNotificationsSetting(channel.CharacterChangesNotifications)
+			}
+			if !discord.IsChannelsManagerOrDM(i) {
+				return discord_messages.MissingPermissionError[discordgo.WebhookEdit]()
+			}
+			enabled := options[0].BoolValue()
+			if err := channelCharacterChangesNotificationsSaver(ctx, channelId, enabled); err != nil {
+				return messages.CharacterChangesNotificationsSaveError(err)
+			}
+			return messages.CharacterChangesNotificationsSetting(enabled)
+		}),
+	}
+}
@@ -0,0 +1,92 @@
+package discord_commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/x0k/ps2-spy/internal/characters_history"
+	"github.com/x0k/ps2-spy/internal/discord"
+	discord_messages "github.com/x0k/ps2-spy/internal/discord/messages"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+type CharacterIdsLoader = func(
+	context.Context, ps2_platforms.Platform, []string,
+) (map[string]ps2.CharacterId, error)
+
+type CharacterHistoryLoader = func(
+	context.Context, ps2_platforms.Platform, ps2.CharacterId,
+) (characters_history.History, error)
+
+func characterHistoryPlatformOption(
+	platform ps2_platforms.Platform,
+	description string,
+	russianDescription string,
+) *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        string(platform),
+		Description: description,
+		DescriptionLocalizations: map[discordgo.Locale]string{
+			discordgo.Russian: russianDescription,
+		},
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "name",
+				Description: "Character name",
+				DescriptionLocalizations: map[discordgo.Locale]string{
+					discordgo.Russian: "Имя персонажа",
+				},
+				Required: true,
+			},
+		},
+	}
+}
+
+func NewCharacterHistory(
+	messages *discord_messages.Messages,
+	characterIdsLoader CharacterIdsLoader,
+	characterHistoryLoader CharacterHistoryLoader,
+) *discord.Command {
+	return &discord.Command{
+		Cmd: &discordgo.ApplicationCommand{
+			Name:        "character-history",
+			Description: "Returns recent sessions and playtime of the character",
+			DescriptionLocalizations: &map[discordgo.Locale]string{
+				discordgo.Russian: "Возвращает последние сессии и время игры персонажа",
+			},
+			Options: []*discordgo.ApplicationCommandOption{
+				characterHistoryPlatformOption(ps2_platforms.PC, "For PC platform", "Для ПК"),
+				characterHistoryPlatformOption(ps2_platforms.PS4_EU, "For PS4 EU platform", "Для PS4 EU"),
+				characterHistoryPlatformOption(ps2_platforms.PS4_US, "For PS4 US platform", "Для PS4 US"),
+			},
+		},
+		Handler: discord.DeferredEphemeralResponse(func(
+			ctx context.Context,
+			s *discordgo.Session,
+			i *discordgo.InteractionCreate,
+		) discord.ResponseEdit {
+			option := i.ApplicationCommandData().Options[0]
+			platform := ps2_platforms.Platform(option.Name)
+			name := strings.TrimSpace(option.Options[0].StringValue())
+			ids, err := characterIdsLoader(ctx, platform, []string{name})
+			if err != nil {
+				return messages.CharacterHistoryLoadError(name, platform, err)
+			}
+			for foundName, characterId := range ids {
+				if !strings.EqualFold(foundName, name) {
+					continue
+				}
+				history, err := characterHistoryLoader(ctx, platform, characterId)
+				if err != nil {
+					return messages.CharacterHistoryLoadError(foundName, platform, err)
+				}
+				return messages.CharacterHistory(foundName, history)
+			}
+			return messages.CharacterNotFound(name, platform)
+		}),
+	}
+}
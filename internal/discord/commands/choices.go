@@ -7,6 +7,7 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/x0k/ps2-spy/internal/lib/iterx"
 	"github.com/x0k/ps2-spy/internal/ps2"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
 )
 
 func serverNames() []*discordgo.ApplicationCommandOptionChoice {
@@ -28,3 +29,29 @@ func providerChoices(providers iter.Seq[string]) []*discordgo.ApplicationCommand
 		}
 	}))
 }
+
+func statsSortOrderChoices() []*discordgo.ApplicationCommandOptionChoice {
+	names := map[stats_tracker.SortOrder]string{
+		stats_tracker.SortByKills:      "Kills",
+		stats_tracker.SortByKPM:        "Kills per minute",
+		stats_tracker.SortByDPM:        "Deaths per minute",
+		stats_tracker.SortByActiveTime: "Active time",
+	}
+	localizations := map[stats_tracker.SortOrder]string{
+		stats_tracker.SortByKills:      "Убийства",
+		stats_tracker.SortByKPM:        "Убийства в минуту",
+		stats_tracker.SortByDPM:        "Смерти в минуту",
+		stats_tracker.SortByActiveTime: "Активное время",
+	}
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(stats_tracker.SortOrders))
+	for _, order := range stats_tracker.SortOrders {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name: names[order],
+			NameLocalizations: map[discordgo.Locale]string{
+				discordgo.Russian: localizations[order],
+			},
+			Value: string(order),
+		})
+	}
+	return choices
+}
@@ -35,6 +35,8 @@ func New(
 	populationLoadersPriority []string,
 	worldPopulationLoaders map[string]loader.Keyed[ps2.WorldId, meta.Loaded[ps2.DetailedWorldPopulation]],
 	worldPopulationLoadersPriority []string,
+	populationHistoryLoader loader.Simple[[]ps2.PopulationSample],
+	worldPopulationHistoryLoader loader.Keyed[ps2.WorldId, []ps2.PopulationSample],
 	worldTerritoryControlLoader loader.Keyed[ps2.WorldId, meta.Loaded[ps2.WorldTerritoryControl]],
 	alertsLoaders map[string]loader.Simple[meta.Loaded[ps2.Alerts]],
 	alertsLoadersPriority []string,
@@ -54,6 +56,21 @@ func New(
 	channelStatsTrackerTaskRemover ChannelStatsTrackerTaskRemover,
 	statsTrackerTaskLoader StatsTrackerTaskLoader,
 	channelStatsTrackerTaskUpdater ChannelStatsTrackerTaskUpdater,
+	channelStatsTrackerSessionsLoader ChannelStatsTrackerSessionsLoader,
+	channelStatsTrackerSessionLoader ChannelStatsTrackerSessionLoader,
+	channelStatsTrackerAutoStartLoader ChannelStatsTrackerAutoStartLoader,
+	channelStatsTrackerAutoStartSaver ChannelStatsTrackerAutoStartSaver,
+	channelStatsTrackerAutoStartRemover ChannelStatsTrackerAutoStartRemover,
+	channelStatsTrackerDetailsSaver ChannelStatsTrackerDetailsSaver,
+	channelStatsTrackerSortSaver ChannelStatsTrackerSortSaver,
+	channelStatsTrackerChartsSaver ChannelStatsTrackerChartsSaver,
+	channelStatsTrackerScoreFormulaSaver ChannelStatsTrackerScoreFormulaSaver,
+	characterIdsLoader CharacterIdsLoader,
+	characterHistoryLoader CharacterHistoryLoader,
+	channelOutfitActivityReportSaver ChannelOutfitActivityReportSaver,
+	channelCharacterChangesNotificationsSaver ChannelCharacterChangesNotificationsSaver,
+	outfitIdsLoader OutfitIdsLoader,
+	inactiveMembersLoader InactiveMembersLoader,
 ) *Commands {
 	populationLoader := newPopulationLoader(
 		log.With(sl.Component("population_loader")),
@@ -85,6 +102,8 @@ func New(
 				slices.Values(populationLoadersPriority),
 				worldPopulationLoader.load,
 				slices.Values(worldPopulationLoadersPriority),
+				populationHistoryLoader,
+				worldPopulationHistoryLoader,
 			),
 			NewTerritories(
 				messages,
@@ -115,6 +134,16 @@ func New(
 				trackingSettingsDataLoader,
 				outfitsLoader,
 			),
+			NewCharacterHistory(
+				messages,
+				characterIdsLoader,
+				characterHistoryLoader,
+			),
+			NewInactive(
+				messages,
+				outfitIdsLoader,
+				inactiveMembersLoader,
+			),
 			NewTracking(
 				messages,
 				trackingSettingsLoader,
@@ -129,6 +158,16 @@ func New(
 				channelTitleUpdatesSaver,
 				channelDefaultTimezoneSaver,
 			),
+			NewOutfitActivityReport(
+				messages,
+				channelLoader,
+				channelOutfitActivityReportSaver,
+			),
+			NewCharacterChanges(
+				messages,
+				channelLoader,
+				channelCharacterChangesNotificationsSaver,
+			),
 			NewStatsTracker(
 				log.With(sl.Component("stats_tracker_command")),
 				messages,
@@ -140,6 +179,15 @@ func New(
 				channelStatsTrackerTaskRemover,
 				statsTrackerTaskLoader,
 				channelStatsTrackerTaskUpdater,
+				channelStatsTrackerSessionsLoader,
+				channelStatsTrackerSessionLoader,
+				channelStatsTrackerAutoStartLoader,
+				channelStatsTrackerAutoStartSaver,
+				channelStatsTrackerAutoStartRemover,
+				channelStatsTrackerDetailsSaver,
+				channelStatsTrackerSortSaver,
+				channelStatsTrackerChartsSaver,
+				channelStatsTrackerScoreFormulaSaver,
 			),
 		},
 	}
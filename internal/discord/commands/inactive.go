@@ -0,0 +1,121 @@
+package discord_commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/x0k/ps2-spy/internal/discord"
+	discord_messages "github.com/x0k/ps2-spy/internal/discord/messages"
+	"github.com/x0k/ps2-spy/internal/outfit_activity"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+// Default inactivity period in days
+const inactiveDays = 30
+
+var minInactiveDays float64 = 1
+
+type OutfitIdsLoader = func(
+	context.Context, ps2_platforms.Platform, []string,
+) (map[string]ps2.OutfitId, error)
+
+type InactiveMembersLoader = func(
+	context.Context, ps2_platforms.Platform, ps2.OutfitId, time.Duration,
+) ([]outfit_activity.InactiveMember, error)
+
+func inactivePlatformOption(
+	platform ps2_platforms.Platform,
+	description string,
+	russianDescription string,
+) *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        string(platform),
+		Description: description,
+		DescriptionLocalizations: map[discordgo.Locale]string{
+			discordgo.Russian: russianDescription,
+		},
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "outfit",
+				Description: "Outfit tag",
+				DescriptionLocalizations: map[discordgo.Locale]string{
+					discordgo.Russian: "Тег аутфита",
+				},
+				Required: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "days",
+				Description: "Minimum number of days without logins, 30 by default",
+				DescriptionLocalizations: map[discordgo.Locale]string{
+					discordgo.Russian: "Минимальное количество дней без входа в игру, по умолчанию 30",
+				},
+				MinValue: &minInactiveDays,
+				MaxValue: 365,
+			},
+		},
+	}
+}
+
+func NewInactive(
+	messages *discord_messages.Messages,
+	outfitIdsLoader OutfitIdsLoader,
+	inactiveMembersLoader InactiveMembersLoader,
+) *discord.Command {
+	return &discord.Command{
+		Cmd: &discordgo.ApplicationCommand{
+			Name:        "inactive",
+			Description: "Returns outfit members who have not logged in for a long time",
+			DescriptionLocalizations: &map[discordgo.Locale]string{
+				discordgo.Russian: "Возвращает участников аутфита, которые давно не заходили в игру",
+			},
+			Options: []*discordgo.ApplicationCommandOption{
+				inactivePlatformOption(ps2_platforms.PC, "For PC platform", "Для ПК"),
+				inactivePlatformOption(ps2_platforms.PS4_EU, "For PS4 EU platform", "Для PS4 EU"),
+				inactivePlatformOption(ps2_platforms.PS4_US, "For PS4 US platform", "Для PS4 US"),
+			},
+		},
+		Handler: discord.DeferredEphemeralResponse(func(
+			ctx context.Context,
+			s *discordgo.Session,
+			i *discordgo.InteractionCreate,
+		) discord.ResponseEdit {
+			option := i.ApplicationCommandData().Options[0]
+			platform := ps2_platforms.Platform(option.Name)
+			var tag string
+			days := inactiveDays
+			for _, opt := range option.Options {
+				switch opt.Name {
+				case "outfit":
+					tag = strings.TrimSpace(opt.StringValue())
+				case "days":
+					days = int(opt.IntValue())
+				}
+			}
+			ids, err := outfitIdsLoader(ctx, platform, []string{tag})
+			if err != nil {
+				return messages.InactiveMembersLoadError(tag, platform, err)
+			}
+			for foundTag, outfitId := range ids {
+				if !strings.EqualFold(foundTag, tag) {
+					continue
+				}
+				members, err := inactiveMembersLoader(ctx, platform, outfitId, time.Duration(days)*24*time.Hour)
+				if errors.Is(err, outfit_activity.ErrOutfitMembersNotSynchronized) {
+					return messages.OutfitMembersNotSynchronized(foundTag, platform)
+				}
+				if err != nil {
+					return messages.InactiveMembersLoadError(foundTag, platform, err)
+				}
+				return messages.InactiveMembers(foundTag, days, members)
+			}
+			return messages.OutfitNotFound(tag, platform)
+		}),
+	}
+}
@@ -0,0 +1,63 @@
+package discord_commands
+
+import (
+	"context"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/x0k/ps2-spy/internal/discord"
+	discord_messages "github.com/x0k/ps2-spy/internal/discord/messages"
+)
+
+type ChannelOutfitActivityReportSaver = func(context.Context, discord.ChannelId, bool) error
+
+func NewOutfitActivityReport(
+	messages *discord_messages.Messages,
+	channelLoader ChannelLoader,
+	channelOutfitActivityReportSaver ChannelOutfitActivityReportSaver,
+) *discord.Command {
+	return &discord.Command{
+		Cmd: &discordgo.ApplicationCommand{
+			Name:        "outfit-activity-report",
+			Description: "Weekly activity report of the tracked outfits",
+			DescriptionLocalizations: &map[discordgo.Locale]string{
+				discordgo.Russian: "Еженедельный отчет об активности отслеживаемых аутфитов",
+			},
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "enabled",
+					Description: "Post the report on Mondays, shows the current state when omitted",
+					DescriptionLocalizations: map[discordgo.Locale]string{
+						discordgo.Russian: "Публиковать отчет по понедельникам, если не указано, показывает текущее состояние",
+					},
+				},
+			},
+		},
+		Handler: discord.DeferredEphemeralResponse(func(
+			ctx context.Context,
+			s *discordgo.Session,
+			i *discordgo.InteractionCreate,
+		) discord.ResponseEdit {
+			channelId := discord.ChannelId(i.ChannelID)
+			options := i.ApplicationCommandData().Options
+			if len(options) == 0 {
+				channel, err := channelLoader(ctx, channelId)
+				if err != nil {
+					return discord_messages.ChannelLoadError[discordgo.WebhookEdit](
+						channelId,
+						err,
+					)
+				}
+				return messages.OutfitActivityReportSetting(channel.OutfitActivityReport)
+			}
+			if !discord.IsChannelsManagerOrDM(i) {
+				return discord_messages.MissingPermissionError[discordgo.WebhookEdit]()
+			}
+			enabled := options[0].BoolValue()
+			if err := channelOutfitActivityReportSaver(ctx, channelId, enabled); err != nil {
+				return messages.OutfitActivityReportSaveError(err)
+			}
+			return messages.OutfitActivityReportSetting(enabled)
+		}),
+	}
+}
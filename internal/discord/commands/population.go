@@ -22,6 +22,8 @@ func NewPopulation(
 	populationProviders iter.Seq[string],
 	worldPopulationLoader loader.Queried[query[ps2.WorldId], meta.Loaded[ps2.DetailedWorldPopulation]],
 	worldPopulationProviders iter.Seq[string],
+	populationHistoryLoader loader.Simple[[]ps2.PopulationSample],
+	worldPopulationHistoryLoader loader.Keyed[ps2.WorldId, []ps2.PopulationSample],
 ) *discord.Command {
 	return &discord.Command{
 		Cmd: &discordgo.ApplicationCommand{
@@ -48,6 +50,14 @@ func NewPopulation(
 							},
 							Choices: providerChoices(populationProviders),
 						},
+						{
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+							Name:        "chart",
+							Description: "Attach a chart of the population tracked by the bot",
+							DescriptionLocalizations: map[discordgo.Locale]string{
+								discordgo.Russian: "Приложить график популяции, отслеживаемой ботом",
+							},
+						},
 					},
 				},
 				{
@@ -77,6 +87,14 @@ func NewPopulation(
 							},
 							Choices: providerChoices(worldPopulationProviders),
 						},
+						{
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+							Name:        "chart",
+							Description: "Attach a chart of the population tracked by the bot",
+							DescriptionLocalizations: map[discordgo.Locale]string{
+								discordgo.Russian: "Приложить график популяции, отслеживаемой ботом",
+							},
+						},
 					},
 				},
 			},
@@ -91,9 +109,9 @@ func NewPopulation(
 			populationType := option.Name
 			switch populationType {
 			case "global":
-				return handleGlobalPopulation(ctx, log, messages, option.Options, populationLoader)
+				return handleGlobalPopulation(ctx, log, messages, option.Options, populationLoader, populationHistoryLoader)
 			case "server":
-				return handleServerPopulation(ctx, log, messages, option.Options, worldPopulationLoader)
+				return handleServerPopulation(ctx, log, messages, option.Options, worldPopulationLoader, worldPopulationHistoryLoader)
 			default:
 				return messages.InvalidPopulationType(
 					populationType,
@@ -110,17 +128,30 @@ func handleGlobalPopulation(
 	messages *discord_messages.Messages,
 	opts []*discordgo.ApplicationCommandInteractionDataOption,
 	popLoader loader.Keyed[string, meta.Loaded[ps2.WorldsPopulation]],
+	historyLoader loader.Simple[[]ps2.PopulationSample],
 ) discord.ResponseEdit {
 	var provider string
-	if len(opts) > 0 {
-		provider = opts[0].StringValue()
+	var chart bool
+	for _, opt := range opts {
+		switch opt.Name {
+		case "provider":
+			provider = opt.StringValue()
+		case "chart":
+			chart = opt.BoolValue()
+		}
 	}
-	log.Debug(ctx, "parsed options", slog.String("provider", provider))
+	log.Debug(ctx, "parsed options", slog.String("provider", provider), slog.Bool("chart", chart))
 	population, err := popLoader(ctx, provider)
 	if err != nil {
 		return messages.GlobalPopulationLoadError(provider, err)
 	}
-	return messages.GlobalPopulation(population)
+	var history []ps2.PopulationSample
+	if chart {
+		if history, err = historyLoader(ctx); err != nil {
+			return messages.PopulationHistoryLoadError(err)
+		}
+	}
+	return messages.GlobalPopulation(population, history)
 }
 
 func handleServerPopulation(
@@ -129,17 +160,36 @@ func handleServerPopulation(
 	messages *discord_messages.Messages,
 	opts []*discordgo.ApplicationCommandInteractionDataOption,
 	worldPopLoader loader.Queried[query[ps2.WorldId], meta.Loaded[ps2.DetailedWorldPopulation]],
+	historyLoader loader.Keyed[ps2.WorldId, []ps2.PopulationSample],
 ) discord.ResponseEdit {
-	server := opts[0].StringValue()
-	var provider string
-	if len(opts) > 1 {
-		provider = opts[1].StringValue()
+	var server, provider string
+	var chart bool
+	for _, opt := range opts {
+		switch opt.Name {
+		case "server":
+			server = opt.StringValue()
+		case "provider":
+			provider = opt.StringValue()
+		case "chart":
+			chart = opt.BoolValue()
+		}
 	}
-	log.Debug(ctx, "parsed options", slog.String("server", server), slog.String("provider", provider))
+	log.Debug(
+		ctx, "parsed options",
+		slog.String("server", server),
+		slog.String("provider", provider),
+		slog.Bool("chart", chart),
+	)
 	worldId := ps2.WorldId(server)
 	population, err := worldPopLoader(ctx, newQuery(provider, worldId))
 	if err != nil {
 		return messages.WorldPopulationLoadError(provider, worldId, err)
 	}
-	return messages.WorldPopulation(population)
+	var history []ps2.PopulationSample
+	if chart {
+		if history, err = historyLoader(ctx, worldId); err != nil {
+			return messages.PopulationHistoryLoadError(err)
+		}
+	}
+	return messages.WorldPopulation(population, history)
 }
@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -14,6 +17,7 @@ import (
 	"github.com/x0k/ps2-spy/internal/lib/loader"
 	"github.com/x0k/ps2-spy/internal/lib/logger"
 	"github.com/x0k/ps2-spy/internal/lib/logger/sl"
+	"github.com/x0k/ps2-spy/internal/lib/stringsx"
 	"github.com/x0k/ps2-spy/internal/shared"
 	"github.com/x0k/ps2-spy/internal/stats_tracker"
 )
@@ -30,6 +34,28 @@ type StatsTrackerTaskLoader = loader.Keyed[discord.StatsTrackerTaskId, discord.S
 type ChannelStatsTrackerTaskUpdater = func(
 	context.Context, discord.ChannelId, discord.StatsTrackerTaskState,
 ) error
+type ChannelStatsTrackerSessionsLoader = func(
+	context.Context, discord.ChannelId, int,
+) ([]stats_tracker.Session, error)
+
+type ChannelStatsTrackerSessionLoader = func(
+	context.Context, discord.ChannelId, stats_tracker.SessionId,
+) (stats_tracker.Session, error)
+
+type ChannelStatsTrackerAutoStartLoader = loader.Keyed[discord.ChannelId, discord.StatsTrackerAutoStart]
+type ChannelStatsTrackerAutoStartSaver = func(context.Context, discord.StatsTrackerAutoStart) error
+type ChannelStatsTrackerAutoStartRemover = func(context.Context, discord.ChannelId) error
+type ChannelStatsTrackerDetailsSaver = func(context.Context, discord.ChannelId, bool) error
+type ChannelStatsTrackerSortSaver = func(context.Context, discord.ChannelId, stats_tracker.SortOrder) error
+type ChannelStatsTrackerChartsSaver = func(context.Context, discord.ChannelId, bool) error
+type ChannelStatsTrackerScoreFormulaSaver = func(context.Context, discord.ChannelId, string) error
+
+const statsTrackerHistorySize = 10
+
+// Default stats tracker auto start duration in minutes
+const statsTrackerAutoStartMinutes = 15
+
+var minStatsTrackerAutoStartMinutes float64 = 1
 
 func newStateId(i *discordgo.InteractionCreate) discord.ChannelAndUserIds {
 	var userId string
@@ -46,6 +72,73 @@ func newStateId(i *discordgo.InteractionCreate) discord.ChannelAndUserIds {
 	)
 }
 
+func modalTextInputValue(data discordgo.ModalSubmitInteractionData, customId string) string {
+	for _, row := range data.Components {
+		for _, c := range row.(*discordgo.ActionsRow).Components {
+			if input, ok := c.(*discordgo.TextInput); ok && input.CustomID == customId {
+				return strings.TrimSpace(input.Value)
+			}
+		}
+	}
+	return ""
+}
+
+// Invalid input is kept in the state, so it can be corrected
+func datedTaskStateFromModal(
+	data discordgo.ModalSubmitInteractionData,
+	state discord.StatsTrackerTaskState,
+) (discord.StatsTrackerTaskState, error) {
+	rawDates := stringsx.SplitAndTrim(modalTextInputValue(data, "dates"), ",")
+	if len(rawDates) == 0 || len(rawDates) > discord.MAX_AMOUNT_OF_DATES_PER_TASK {
+		return state, discord.ErrInvalidStatsTrackerTaskDates
+	}
+	dates := make([]shared.Date, 0, len(rawDates))
+	for _, raw := range rawDates {
+		d, err := shared.ParseDate(raw)
+		if err != nil {
+			return state, fmt.Errorf("%w: %w", discord.ErrInvalidStatsTrackerTaskDates, err)
+		}
+		if !slices.Contains(dates, d) {
+			dates = append(dates, d)
+		}
+	}
+	state.LocalDates = dates
+	state.Recurrence = discord.DatesStatsTrackerTask
+	state.IntervalDays = 0
+	if rawInterval := modalTextInputValue(data, "interval"); rawInterval != "" {
+		interval, err := strconv.Atoi(rawInterval)
+		if err != nil || interval < 1 || interval > discord.MAX_STATS_TRACKER_TASK_INTERVAL_DAYS || len(dates) != 1 {
+			return state, discord.ErrInvalidStatsTrackerTaskInterval
+		}
+		state.Recurrence = discord.IntervalStatsTrackerTask
+		state.IntervalDays = interval
+	}
+	startTime, err := time.Parse("15:04", modalTextInputValue(data, "start_time"))
+	if err != nil {
+		return state, fmt.Errorf("%w: %w", discord.ErrInvalidStatsTrackerTaskStartTime, err)
+	}
+	state.LocalStartHour = startTime.Hour()
+	state.LocalStartMin = startTime.Minute()
+	duration, err := time.ParseDuration(modalTextInputValue(data, "duration"))
+	if err != nil || duration <= 0 {
+		return state, discord.ErrInvalidStatsTrackerTaskDuration
+	}
+	state.Duration = duration
+	return filterStateFromModal(data, state)
+}
+
+func filterStateFromModal(
+	data discordgo.ModalSubmitInteractionData,
+	state discord.StatsTrackerTaskState,
+) (discord.StatsTrackerTaskState, error) {
+	filter, err := discord.ParseStatsTrackerFilter(modalTextInputValue(data, "filter"))
+	if err != nil {
+		return state, err
+	}
+	state.Filter = filter
+	return state, nil
+}
+
 func NewStatsTracker(
 	log *logger.Logger,
 	messages *discord_messages.Messages,
@@ -60,6 +153,15 @@ func NewStatsTracker(
 	channelStatsTrackerTaskRemover ChannelStatsTrackerTaskRemover,
 	statsTrackerTaskLoader StatsTrackerTaskLoader,
 	channelStatsTrackerTaskUpdater ChannelStatsTrackerTaskUpdater,
+	channelStatsTrackerSessionsLoader ChannelStatsTrackerSessionsLoader,
+	channelStatsTrackerSessionLoader ChannelStatsTrackerSessionLoader,
+	channelStatsTrackerAutoStartLoader ChannelStatsTrackerAutoStartLoader,
+	channelStatsTrackerAutoStartSaver ChannelStatsTrackerAutoStartSaver,
+	channelStatsTrackerAutoStartRemover ChannelStatsTrackerAutoStartRemover,
+	channelStatsTrackerDetailsSaver ChannelStatsTrackerDetailsSaver,
+	channelStatsTrackerSortSaver ChannelStatsTrackerSortSaver,
+	channelStatsTrackerChartsSaver ChannelStatsTrackerChartsSaver,
+	channelStatsTrackerScoreFormulaSaver ChannelStatsTrackerScoreFormulaSaver,
 ) *discord.Command {
 	newCreateFormHandler := func(
 		stateUpdater func(*discordgo.InteractionCreate, discord.StatsTrackerTaskState) (discord.StatsTrackerTaskState, error),
@@ -101,6 +203,34 @@ func NewStatsTracker(
 		}
 		return messages.StatsTrackerScheduleUpdated(channel, tasks, zeroIndexedPage)
 	}
+	// Loads the session from the `session` option or the last one
+	loadSession := func(
+		ctx context.Context,
+		channelId discord.ChannelId,
+		options []*discordgo.ApplicationCommandInteractionDataOption,
+	) (stats_tracker.Session, discord.ResponseEdit) {
+		for _, opt := range options {
+			if opt.Name != "session" {
+				continue
+			}
+			sessionId := stats_tracker.SessionId(opt.IntValue())
+			session, err := channelStatsTrackerSessionLoader(ctx, channelId, sessionId)
+			if errors.Is(err, shared.ErrNotFound) {
+				return session, messages.StatsTrackerSessionNotFound(sessionId)
+			} else if err != nil {
+				return session, messages.StatsTrackerSessionLoadError(err)
+			}
+			return session, nil
+		}
+		sessions, err := channelStatsTrackerSessionsLoader(ctx, channelId, 1)
+		if err != nil {
+			return stats_tracker.Session{}, messages.StatsTrackerSessionLoadError(err)
+		}
+		if len(sessions) == 0 {
+			return stats_tracker.Session{}, messages.NoStatsTrackerSessions()
+		}
+		return sessions[0], nil
+	}
 	return &discord.Command{
 		Cmd: &discordgo.ApplicationCommand{
 			Name:        "stats-tracker",
@@ -116,6 +246,16 @@ func NewStatsTracker(
 					DescriptionLocalizations: map[discordgo.Locale]string{
 						discordgo.Russian: "Запустить трекер статистики",
 					},
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "only",
+							Description: "Outfit tags or character names separated by comma",
+							DescriptionLocalizations: map[discordgo.Locale]string{
+								discordgo.Russian: "Теги аутфитов или имена персонажей через запятую",
+							},
+						},
+					},
 				},
 				{
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
@@ -133,6 +273,181 @@ func NewStatsTracker(
 						discordgo.Russian: "Управление расписанием",
 					},
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "history",
+					Description: "Past sessions",
+					DescriptionLocalizations: map[discordgo.Locale]string{
+						discordgo.Russian: "Прошлые сессии",
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "export",
+					Description: "Export session stats as CSV and JSON",
+					DescriptionLocalizations: map[discordgo.Locale]string{
+						discordgo.Russian: "Экспортировать статистику сессии в CSV и JSON",
+					},
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "session",
+							Description: "Session number from the history, the last one by default",
+							DescriptionLocalizations: map[discordgo.Locale]string{
+								discordgo.Russian: "Номер сессии из истории, по умолчанию последняя",
+							},
+							MinValue: new(float64),
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "report",
+					Description: "Session stats report",
+					DescriptionLocalizations: map[discordgo.Locale]string{
+						discordgo.Russian: "Отчет о статистике сессии",
+					},
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "session",
+							Description: "Session number from the history, the last one by default",
+							DescriptionLocalizations: map[discordgo.Locale]string{
+								discordgo.Russian: "Номер сессии из истории, по умолчанию последняя",
+							},
+							MinValue: new(float64),
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "sort",
+							Description: "Sort characters by, the channel sort order by default",
+							DescriptionLocalizations: map[discordgo.Locale]string{
+								discordgo.Russian: "Сортировать персонажей по, по умолчанию порядок канала",
+							},
+							Choices: statsSortOrderChoices(),
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "auto-start",
+					Description: "Start stats tracker when enough tracked characters are online",
+					DescriptionLocalizations: map[discordgo.Locale]string{
+						discordgo.Russian: "Запускать трекер статистики, когда в сети достаточно отслеживаемых персонажей",
+					},
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "members",
+							Description: "Amount of online characters to start, 0 disables auto start",
+							DescriptionLocalizations: map[discordgo.Locale]string{
+								discordgo.Russian: "Количество персонажей в сети для запуска, 0 отключает автозапуск",
+							},
+							MinValue: new(float64),
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "minutes",
+							Description: "How long the amount should be kept, 15 by default",
+							DescriptionLocalizations: map[discordgo.Locale]string{
+								discordgo.Russian: "Как долго должно сохраняться количество, по умолчанию 15",
+							},
+							MinValue: &minStatsTrackerAutoStartMinutes,
+							MaxValue: 24 * 60,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "stop-below",
+							Description: "Stop when less characters are online, the start amount by default, 0 disables auto stop",
+							DescriptionLocalizations: map[discordgo.Locale]string{
+								discordgo.Russian: "Остановить, когда в сети меньше персонажей, по умолчанию количество для запуска, 0 отключает автоостановку",
+							},
+							MinValue: new(float64),
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "details",
+					Description: "Add continents, weapons, rivals, vehicles, facilities, support and highlights to the stop reports",
+					DescriptionLocalizations: map[discordgo.Locale]string{
+						discordgo.Russian: "Добавлять континенты, оружие, соперников, технику, объекты, поддержку и достижения в отчеты",
+					},
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+							Name:        "enabled",
+							Description: "Enable details, shows the current state when omitted",
+							DescriptionLocalizations: map[discordgo.Locale]string{
+								discordgo.Russian: "Включить подробности, если не указано, показывает текущее состояние",
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "sort",
+					Description: "Sort order of the leaderboard and reports, shows the current one when omitted",
+					DescriptionLocalizations: map[discordgo.Locale]string{
+						discordgo.Russian: "Порядок таблицы лидеров и отчетов, если не указан, показывает текущий",
+					},
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "by",
+							Description: "Sort characters by",
+							DescriptionLocalizations: map[discordgo.Locale]string{
+								discordgo.Russian: "Сортировать персонажей по",
+							},
+							Choices: statsSortOrderChoices(),
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "charts",
+					Description: "Attach charts to the stats tracker reports",
+					DescriptionLocalizations: map[discordgo.Locale]string{
+						discordgo.Russian: "Прикладывать графики к отчетам трекера статистики",
+					},
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+							Name:        "enabled",
+							Description: "Enable charts, shows the current state when omitted",
+							DescriptionLocalizations: map[discordgo.Locale]string{
+								discordgo.Russian: "Включить графики, если не указано, показывает текущее состояние",
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "score",
+					Description: "Leaderboard score formula, shows the current one when options are omitted",
+					DescriptionLocalizations: map[discordgo.Locale]string{
+						discordgo.Russian: "Формула очков таблицы лидеров, если параметры не указаны, показывает текущую",
+					},
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "formula",
+							Description: "Expression like: kills + 0.5 * revives - deaths - 2 * team_kills",
+							DescriptionLocalizations: map[discordgo.Locale]string{
+								discordgo.Russian: "Выражение вида: kills + 0.5 * revives - deaths - 2 * team_kills",
+							},
+							MaxLength: stats_tracker.MaxScoreFormulaLength,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+							Name:        "reset",
+							Description: "Rank characters by kills",
+							DescriptionLocalizations: map[discordgo.Locale]string{
+								discordgo.Russian: "Ранжировать персонажей по убийствам",
+							},
+						},
+					},
+				},
 			},
 		},
 		Handler: discord.DeferredEphemeralResponse(func(
@@ -146,8 +461,20 @@ func NewStatsTracker(
 				if !discord.IsChannelsManagerOrDM(i) {
 					return discord_messages.MissingPermissionError[discordgo.WebhookEdit]()
 				}
-				if err := statsTracker.StartChannelTracker(ctx, channelId); errors.Is(err, stats_tracker.ErrNothingToTrack) {
+				var filter discord.StatsTrackerFilter
+				for _, opt := range option.Options {
+					if opt.Name == "only" {
+						var err error
+						if filter, err = discord.ParseStatsTrackerFilter(opt.StringValue()); err != nil {
+							return messages.StatsTrackerFilterError(err)
+						}
+					}
+				}
+				var unknownErr stats_tracker.ErrUnknownFilterNames
+				if err := statsTracker.StartChannelTracker(ctx, channelId, filter); errors.Is(err, stats_tracker.ErrNothingToTrack) {
 					return messages.NothingToTrack()
+				} else if errors.As(err, &unknownErr) {
+					return messages.StatsTrackerFilterError(err)
 				} else if err != nil {
 					return messages.StartChannelStatsTrackerError(err)
 				}
@@ -180,24 +507,232 @@ func NewStatsTracker(
 					return messages.StatsTrackerScheduleEditForm(channel, tasks)
 				}
 				return messages.StatsTrackerSchedule(channel, tasks)
+			case "history":
+				sessions, err := channelStatsTrackerSessionsLoader(ctx, channelId, statsTrackerHistorySize)
+				if err != nil {
+					return messages.StatsTrackerHistoryLoadError(err)
+				}
+				return messages.StatsTrackerHistory(sessions)
+			case "export":
+				session, errMsg := loadSession(ctx, channelId, option.Options)
+				if errMsg != nil {
+					return errMsg
+				}
+				stats, err := statsTracker.SessionStats(ctx, session)
+				if err != nil {
+					log.Warn(ctx, "failed to compute session stats", slog.Int64("session_id", int64(session.Id)), sl.Err(err))
+				}
+				return messages.StatsTrackerExport(session, stats)
+			case "report":
+				session, errMsg := loadSession(ctx, channelId, option.Options)
+				if errMsg != nil {
+					return errMsg
+				}
+				channel, err := channelLoader(ctx, channelId)
+				if err != nil {
+					return discord_messages.ChannelLoadError[discordgo.WebhookEdit](
+						channelId,
+						err,
+					)
+				}
+				order := stats_tracker.ParseSortOrder(channel.StatsTrackerSort)
+				for _, opt := range option.Options {
+					if opt.Name == "sort" {
+						order = stats_tracker.ParseSortOrder(opt.StringValue())
+					}
+				}
+				stats, err := statsTracker.SessionStats(ctx, session)
+				if err != nil {
+					log.Warn(ctx, "failed to compute session stats", slog.Int64("session_id", int64(session.Id)), sl.Err(err))
+				}
+				for _, platformStats := range stats {
+					stats_tracker.SortCharacters(platformStats.Characters, order)
+				}
+				return messages.StatsTrackerReport(session, stats, order, channel.StatsTrackerCharts)
+			case "auto-start":
+				autoStart, err := channelStatsTrackerAutoStartLoader(ctx, channelId)
+				enabled := err == nil
+				if err != nil && !errors.Is(err, shared.ErrNotFound) {
+					return messages.StatsTrackerAutoStartLoadError(err)
+				}
+				if len(option.Options) == 0 {
+					return messages.StatsTrackerAutoStart(autoStart, enabled)
+				}
+				if !discord.IsChannelsManagerOrDM(i) {
+					return discord_messages.MissingPermissionError[discordgo.WebhookEdit]()
+				}
+				if !enabled {
+					autoStart = discord.StatsTrackerAutoStart{
+						ChannelId: channelId,
+						Duration:  statsTrackerAutoStartMinutes * time.Minute,
+						StopBelow: -1,
+					}
+				}
+				for _, opt := range option.Options {
+					switch opt.Name {
+					case "members":
+						autoStart.MinOnline = int(opt.IntValue())
+					case "minutes":
+						autoStart.Duration = time.Duration(opt.IntValue()) * time.Minute
+					case "stop-below":
+						autoStart.StopBelow = int(opt.IntValue())
+					}
+				}
+				if autoStart.MinOnline == 0 {
+					if err := channelStatsTrackerAutoStartRemover(ctx, channelId); err != nil {
+						return messages.StatsTrackerAutoStartSaveError(err)
+					}
+					return messages.StatsTrackerAutoStart(autoStart, false)
+				}
+				if autoStart.StopBelow < 0 {
+					autoStart.StopBelow = autoStart.MinOnline
+				}
+				if err := channelStatsTrackerAutoStartSaver(ctx, autoStart); err != nil {
+					return messages.StatsTrackerAutoStartSaveError(err)
+				}
+				return messages.StatsTrackerAutoStart(autoStart, true)
+			case "details":
+				if len(option.Options) == 0 {
+					channel, err := channelLoader(ctx, channelId)
+					if err != nil {
+						return discord_messages.ChannelLoadError[discordgo.WebhookEdit](
+							channelId,
+							err,
+						)
+					}
+					return messages.StatsTrackerDetails(channel.StatsTrackerDetails)
+				}
+				if !discord.IsChannelsManagerOrDM(i) {
+					return discord_messages.MissingPermissionError[discordgo.WebhookEdit]()
+				}
+				enabled := option.Options[0].BoolValue()
+				if err := channelStatsTrackerDetailsSaver(ctx, channelId, enabled); err != nil {
+					return messages.StatsTrackerDetailsSaveError(err)
+				}
+				return messages.StatsTrackerDetails(enabled)
+			case "sort":
+				if len(option.Options) == 0 {
+					channel, err := channelLoader(ctx, channelId)
+					if err != nil {
+						return discord_messages.ChannelLoadError[discordgo.WebhookEdit](
+							channelId,
+							err,
+						)
+					}
+					return messages.StatsTrackerSort(stats_tracker.ParseSortOrder(channel.StatsTrackerSort))
+				}
+				if !discord.IsChannelsManagerOrDM(i) {
+					return discord_messages.MissingPermissionError[discordgo.WebhookEdit]()
+				}
+				order := stats_tracker.ParseSortOrder(option.Options[0].StringValue())
+				if err := channelStatsTrackerSortSaver(ctx, channelId, order); err != nil {
+					return messages.StatsTrackerSortSaveError(err)
+				}
+				return messages.StatsTrackerSort(order)
+			case "charts":
+				if len(option.Options) == 0 {
+					channel, err := channelLoader(ctx, channelId)
+					if err != nil {
+						return discord_messages.ChannelLoadError[discordgo.WebhookEdit](
+							channelId,
+							err,
+						)
+					}
+					return messages.StatsTrackerCharts(channel.StatsTrackerCharts)
+				}
+				if !discord.IsChannelsManagerOrDM(i) {
+					return discord_messages.MissingPermissionError[discordgo.WebhookEdit]()
+				}
+				enabled := option.Options[0].BoolValue()
+				if err := channelStatsTrackerChartsSaver(ctx, channelId, enabled); err != nil {
+					return messages.StatsTrackerChartsSaveError(err)
+				}
+				return messages.StatsTrackerCharts(enabled)
+			case "score":
+				if len(option.Options) == 0 {
+					channel, err := channelLoader(ctx, channelId)
+					if err != nil {
+						return discord_messages.ChannelLoadError[discordgo.WebhookEdit](
+							channelId,
+							err,
+						)
+					}
+					return messages.StatsTrackerScoreFormula(channel.StatsTrackerScoreFormula)
+				}
+				if !discord.IsChannelsManagerOrDM(i) {
+					return discord_messages.MissingPermissionError[discordgo.WebhookEdit]()
+				}
+				var formula string
+				for _, opt := range option.Options {
+					switch opt.Name {
+					case "formula":
+						formula = opt.StringValue()
+					case "reset":
+						if opt.BoolValue() {
+							formula = ""
+						}
+					}
+				}
+				parsed, err := stats_tracker.ParseScoreFormula(formula)
+				if err != nil {
+					return messages.StatsTrackerScoreFormulaSaveError(err)
+				}
+				if err := channelStatsTrackerScoreFormulaSaver(ctx, channelId, parsed.String()); err != nil {
+					return messages.StatsTrackerScoreFormulaSaveError(err)
+				}
+				return messages.StatsTrackerScoreFormula(parsed.String())
 			}
 			return messages.StatsTrackerInvalidSubcommand(
 				cmd,
 				fmt.Errorf("invalid subcommand: %s", cmd),
 			)
 		}),
-		ComponentHandlers: map[string]discord.InteractionHandler{
-			discord.STATS_TRACKER_TASKS_EDIT_BUTTON_CUSTOM_ID: discord.MessageUpdate(func(
+		SubmitHandlers: map[string]discord.InteractionHandler{
+			discord.STATS_TRACKER_DATED_TASK_MODAL_CUSTOM_ID: discord.MessageUpdate(func(
 				ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate,
 			) discord.Response {
-				channelId := discord.ChannelId(i.ChannelID)
-				channel, err := channelLoader(ctx, channelId)
+				stateId := newStateId(i)
+				state, ok := taskStateContainer.Pop(stateId)
+				if !ok {
+					return messages.ChannelStatsTrackerTaskStateNotFound(
+						fmt.Errorf("failed to find state %q: %w", stateId, shared.ErrNotFound),
+					)
+				}
+				channelId := discord.ChannelId(i.Interaction.ChannelID)
+				state, err := datedTaskStateFromModal(i.ModalSubmitData(), state)
+				if err == nil {
+					if state.SubmitButtonId == discord.STATS_TRACKER_TASK_UPDATE_SUBMIT_BUTTON_CUSTOM_ID {
+						err = channelStatsTrackerTaskUpdater(ctx, channelId, state)
+					} else {
+						err = statsTrackerTaskCreator(ctx, channelId, state)
+					}
+				}
 				if err != nil {
-					return discord_messages.ChannelLoadError[discordgo.InteractionResponseData](
-						channelId,
-						err,
+					taskStateContainer.Store(stateId, state)
+					log.Debug(ctx, "failed to save dated task", sl.Err(err))
+					return messages.StatsTrackerDatedTaskFormError(err)
+				}
+				return updatedSchedule(ctx, i, 0)
+			}),
+			discord.STATS_TRACKER_TASK_FILTER_MODAL_CUSTOM_ID: discord.MessageUpdate(func(
+				ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate,
+			) discord.Response {
+				stateId := newStateId(i)
+				state, ok := taskStateContainer.Pop(stateId)
+				if !ok {
+					return messages.ChannelStatsTrackerTaskStateNotFound(
+						fmt.Errorf("failed to find state %q: %w", stateId, shared.ErrNotFound),
 					)
 				}
+				state, err := filterStateFromModal(i.ModalSubmitData(), state)
+				taskStateContainer.Store(stateId, state)
+				return messages.StatsTrackerTaskForm(state, err)
+			}),
+		},
+		ComponentHandlers: map[string]discord.InteractionHandler{
+			discord.STATS_TRACKER_TASKS_EDIT_BUTTON_CUSTOM_ID: discord.MessageUpdate(func(
+				ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate,
+			) discord.Response {
 				taskId, err := discord.CustomIdToTaskIdToEdit(i.MessageComponentData().CustomID)
 				if err != nil {
 					return messages.FieldValueExtractError(err)
@@ -206,11 +741,26 @@ func NewStatsTracker(
 				if err != nil {
 					return messages.StatsTrackerTaskLoadError(err)
 				}
-				state := discord.NewUpdateStatsTrackerTaskState(task, channel.DefaultTimezone)
+				state := discord.NewUpdateStatsTrackerTaskState(task)
 				stateId := newStateId(i)
 				taskStateContainer.Store(stateId, state)
 				return messages.StatsTrackerTaskForm(state, nil)
 			}),
+			discord.STATS_TRACKER_TASKS_EDIT_DATED_BUTTON_CUSTOM_ID: discord.ShowModal(func(
+				ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate,
+			) discord.Response {
+				taskId, err := discord.CustomIdToDatedTaskIdToEdit(i.MessageComponentData().CustomID)
+				if err != nil {
+					return messages.FieldValueExtractError(err)
+				}
+				task, err := statsTrackerTaskLoader(ctx, taskId)
+				if err != nil {
+					return messages.StatsTrackerTaskLoadError(err)
+				}
+				state := discord.NewUpdateStatsTrackerTaskState(task)
+				taskStateContainer.Store(newStateId(i), state)
+				return messages.StatsTrackerDatedTaskModal(state)
+			}),
 			discord.STATS_TRACKER_TASKS_REMOVE_BUTTON_CUSTOM_ID: discord.MessageUpdate(func(
 				ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate,
 			) discord.Response {
@@ -245,12 +795,45 @@ func NewStatsTracker(
 							err,
 						)
 					}
-					state := discord.NewCreateStatsTrackerTaskState(channel.DefaultTimezone)
+					state := discord.NewCreateStatsTrackerTaskState(
+						channel.DefaultTimezone,
+						discord.WeeklyStatsTrackerTask,
+					)
 					stateId := newStateId(i)
 					taskStateContainer.Store(stateId, state)
 					return messages.StatsTrackerTaskForm(state, nil)
 				},
 			),
+			discord.STATS_TRACKER_TASKS_ADD_DATED_BUTTON_CUSTOM_ID: discord.ShowModal(func(
+				ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate,
+			) discord.Response {
+				channelId := discord.ChannelId(i.ChannelID)
+				channel, err := channelLoader(ctx, channelId)
+				if err != nil {
+					return discord_messages.ChannelLoadError[discordgo.InteractionResponseData](
+						channelId,
+						err,
+					)
+				}
+				state := discord.NewCreateStatsTrackerTaskState(
+					channel.DefaultTimezone,
+					discord.DatesStatsTrackerTask,
+				)
+				taskStateContainer.Store(newStateId(i), state)
+				return messages.StatsTrackerDatedTaskModal(state)
+			}),
+			discord.STATS_TRACKER_DATED_TASK_RETRY_BUTTON_CUSTOM_ID: discord.ShowModal(func(
+				ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate,
+			) discord.Response {
+				stateId := newStateId(i)
+				state, ok := taskStateContainer.Load(stateId)
+				if !ok {
+					return messages.ChannelStatsTrackerTaskStateNotFound(
+						fmt.Errorf("failed to find state %q: %w", stateId, shared.ErrNotFound),
+					)
+				}
+				return messages.StatsTrackerDatedTaskModal(state)
+			}),
 			discord.STATS_TRACKER_TASK_WEEKDAYS_SELECTOR_CUSTOM_ID: newCreateFormHandler(
 				func(i *discordgo.InteractionCreate, state discord.StatsTrackerTaskState) (discord.StatsTrackerTaskState, error) {
 					weekdays := make([]time.Weekday, 0, len(i.MessageComponentData().Values))
@@ -304,6 +887,18 @@ func NewStatsTracker(
 					return state, nil
 				},
 			),
+			discord.STATS_TRACKER_TASK_FILTER_BUTTON_CUSTOM_ID: discord.ShowModal(func(
+				ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate,
+			) discord.Response {
+				stateId := newStateId(i)
+				state, ok := taskStateContainer.Load(stateId)
+				if !ok {
+					return messages.ChannelStatsTrackerTaskStateNotFound(
+						fmt.Errorf("failed to find state %q: %w", stateId, shared.ErrNotFound),
+					)
+				}
+				return messages.StatsTrackerTaskFilterModal(state)
+			}),
 			discord.STATS_TRACKER_TASK_CANCEL_BUTTON_CUSTOM_ID: discord.MessageUpdate(func(
 				ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate,
 			) discord.Response {
@@ -3,6 +3,7 @@ package discord
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"golang.org/x/text/language"
@@ -15,6 +16,8 @@ type ChannelId string
 
 type UserId string
 
+type MessageId string
+
 func MemberOrUserId(i *discordgo.InteractionCreate) UserId {
 	if i.Member != nil {
 		return UserId(i.Member.User.ID)
@@ -53,6 +56,19 @@ type Channel struct {
 	OutfitNotifications    bool
 	TitleUpdates           bool
 	DefaultTimezone        *time.Location
+	// Add breakdowns by continents, weapons, rivals, vehicles,
+	// facilities, support actions and highlights to the stats tracker reports
+	StatsTrackerDetails bool
+	// Sort order of the stats tracker leaderboard and reports
+	StatsTrackerSort string
+	// Attach charts to the stats tracker reports
+	StatsTrackerCharts bool
+	// Leaderboard ranking expression, empty for ranking by kills
+	StatsTrackerScoreFormula string
+	// Post weekly activity reports of the tracked outfits
+	OutfitActivityReport bool
+	// Notify about renames, faction, world and outfit changes of the tracked characters
+	CharacterChangesNotifications bool
 }
 
 func NewChannel(
@@ -62,46 +78,83 @@ func NewChannel(
 	outfitNotifications bool,
 	titleUpdates bool,
 	defaultTimezone *time.Location,
+	statsTrackerDetails bool,
+	statsTrackerSort string,
+	statsTrackerCharts bool,
+	statsTrackerScoreFormula string,
+	outfitActivityReport bool,
+	characterChangesNotifications bool,
 ) Channel {
 	return Channel{
-		Id:                     channelId,
-		Locale:                 locale,
-		CharacterNotifications: characterNotifications,
-		OutfitNotifications:    outfitNotifications,
-		TitleUpdates:           titleUpdates,
-		DefaultTimezone:        defaultTimezone,
+		Id:                            channelId,
+		Locale:                        locale,
+		CharacterNotifications:        characterNotifications,
+		OutfitNotifications:           outfitNotifications,
+		TitleUpdates:                  titleUpdates,
+		DefaultTimezone:               defaultTimezone,
+		StatsTrackerDetails:           statsTrackerDetails,
+		StatsTrackerSort:              statsTrackerSort,
+		StatsTrackerCharts:            statsTrackerCharts,
+		StatsTrackerScoreFormula:      statsTrackerScoreFormula,
+		OutfitActivityReport:          outfitActivityReport,
+		CharacterChangesNotifications: characterChangesNotifications,
 	}
 }
 
 func NewDefaultChannel(channelId ChannelId) Channel {
-	return NewChannel(channelId, DEFAULT_LANG_TAG, true, true, true, time.UTC)
+	return NewChannel(channelId, DEFAULT_LANG_TAG, true, true, true, time.UTC, false, "kills", false, "", false, false)
 }
 
 type StatsTrackerTaskId int64
 
+type StatsTrackerTaskRecurrence string
+
+const (
+	WeeklyStatsTrackerTask   StatsTrackerTaskRecurrence = "weekly"
+	IntervalStatsTrackerTask StatsTrackerTaskRecurrence = "interval"
+	DatesStatsTrackerTask    StatsTrackerTaskRecurrence = "dates"
+)
+
+// Tasks are stored in the local time of the timezone
+// to keep the wall clock time across DST changes
 type StatsTrackerTask struct {
-	Id              StatsTrackerTaskId
-	ChannelId       ChannelId
-	UtcStartWeekday time.Weekday
-	UtcStartTime    time.Duration
-	UtcEndWeekday   time.Weekday
-	UtcEndTime      time.Duration
+	Id         StatsTrackerTaskId
+	ChannelId  ChannelId
+	Timezone   *time.Location
+	Recurrence StatsTrackerTaskRecurrence
+	// For weekly tasks
+	Weekday time.Weekday
+	// For interval tasks, the first date is the starting point
+	IntervalDays int
+	// Dates of one-off occurrences or the starting date of the interval
+	Dates []shared.Date
+	// Time of the day
+	StartTime time.Duration
+	Duration  time.Duration
+	Filter    StatsTrackerFilter
 }
 
 const MAX_AMOUNT_OF_TASKS_PER_CHANNEL = 7
+const MAX_AMOUNT_OF_DATES_PER_TASK = 10
+const MAX_STATS_TRACKER_TASK_INTERVAL_DAYS = 365
 
 type StatsTrackerTaskState struct {
 	SubmitButtonId string
 	TaskId         StatsTrackerTaskId
 	Timezone       *time.Location
+	Recurrence     StatsTrackerTaskRecurrence
 	LocalWeekdays  []time.Weekday
+	IntervalDays   int
+	LocalDates     []shared.Date
 	LocalStartHour int
 	LocalStartMin  int
 	Duration       time.Duration
+	Filter         StatsTrackerFilter
 }
 
 func NewCreateStatsTrackerTaskState(
 	timezone *time.Location,
+	recurrence StatsTrackerTaskRecurrence,
 ) StatsTrackerTaskState {
 	localNow := time.Now().In(timezone)
 	startTime := time.Duration(localNow.Hour())*time.Hour + time.Duration(localNow.Minute()/10)*10*time.Minute
@@ -110,38 +163,67 @@ func NewCreateStatsTrackerTaskState(
 	return StatsTrackerTaskState{
 		SubmitButtonId: STATS_TRACKER_TASK_CREATE_SUBMIT_BUTTON_CUSTOM_ID,
 		Timezone:       timezone,
+		Recurrence:     recurrence,
 		LocalWeekdays: []time.Weekday{
 			localNow.Weekday(),
 		},
+		LocalDates: []shared.Date{
+			shared.DateOf(localNow),
+		},
 		LocalStartHour: hour,
 		LocalStartMin:  min,
 		Duration:       2 * time.Hour,
 	}
 }
 
+// The task keeps its own timezone, so the schedule
+// is not shifted by the channel timezone change
 func NewUpdateStatsTrackerTaskState(
 	task StatsTrackerTask,
-	timezone *time.Location,
 ) StatsTrackerTaskState {
-	_, offsetInSeconds := time.Now().In(timezone).Zone()
-	offset := (time.Duration(offsetInSeconds) * time.Second)
-	startWeekday, startTime := shared.NormalizeDate(task.UtcStartWeekday, task.UtcStartTime+offset)
-	endWeekday, endTime := shared.NormalizeDate(task.UtcEndWeekday, task.UtcEndTime+offset)
-	duration := endTime - startTime
-	if startWeekday != endWeekday {
-		duration += 24 * time.Hour
-	}
 	return StatsTrackerTaskState{
 		SubmitButtonId: STATS_TRACKER_TASK_UPDATE_SUBMIT_BUTTON_CUSTOM_ID,
 		TaskId:         task.Id,
-		Timezone:       timezone,
+		Timezone:       task.Timezone,
+		Recurrence:     task.Recurrence,
 		LocalWeekdays: []time.Weekday{
-			startWeekday,
+			task.Weekday,
 		},
-		LocalStartHour: int(startTime / time.Hour),
-		LocalStartMin:  int((startTime % time.Hour) / time.Minute),
-		Duration:       duration,
+		IntervalDays:   task.IntervalDays,
+		LocalDates:     slices.Clone(task.Dates),
+		LocalStartHour: int(task.StartTime / time.Hour),
+		LocalStartMin:  int((task.StartTime % time.Hour) / time.Minute),
+		Duration:       task.Duration,
+		Filter:         slices.Clone(task.Filter),
+	}
+}
+
+// Weekly state produces a task per weekday
+func (s StatsTrackerTaskState) Tasks(channelId ChannelId) []StatsTrackerTask {
+	task := StatsTrackerTask{
+		Id:         s.TaskId,
+		ChannelId:  channelId,
+		Timezone:   s.Timezone,
+		Recurrence: s.Recurrence,
+		StartTime:  time.Duration(s.LocalStartHour)*time.Hour + time.Duration(s.LocalStartMin)*time.Minute,
+		Duration:   s.Duration,
+		Filter:     s.Filter,
 	}
+	switch s.Recurrence {
+	case IntervalStatsTrackerTask:
+		task.IntervalDays = s.IntervalDays
+		task.Dates = s.LocalDates[:min(len(s.LocalDates), 1)]
+		return []StatsTrackerTask{task}
+	case DatesStatsTrackerTask:
+		task.Dates = slices.Clone(s.LocalDates)
+		return []StatsTrackerTask{task}
+	}
+	tasks := make([]StatsTrackerTask, 0, len(s.LocalWeekdays))
+	for _, weekday := range s.LocalWeekdays {
+		task.Weekday = weekday
+		tasks = append(tasks, task)
+	}
+	return tasks
 }
 
 type ErrStatsTrackerTaskDurationTooLong struct {
@@ -159,23 +241,46 @@ func (e ErrStatsTrackerTaskDurationTooLong) Error() string {
 
 var ErrMaxAmountOfTasksExceeded = errors.New("max amount of tasks exceeded")
 
+var ErrMessageTooLong = errors.New("message is too long")
+
+var ErrInvalidStatsTrackerTaskDates = errors.New("invalid stats tracker task dates")
+var ErrInvalidStatsTrackerTaskInterval = errors.New("invalid stats tracker task interval")
+var ErrInvalidStatsTrackerTaskStartTime = errors.New("invalid stats tracker task start time")
+var ErrInvalidStatsTrackerTaskDuration = errors.New("invalid stats tracker task duration")
+
 type ErrOverlappingTasks struct {
-	Offset         time.Duration
-	LocalWeekday   time.Weekday
-	LocalStartTime time.Duration
-	Duration       time.Duration
-	Tasks          []StatsTrackerTask
+	Task  StatsTrackerTask
+	Tasks []StatsTrackerTask
 }
 
 func (e ErrOverlappingTasks) Error() string {
 	return fmt.Sprintf(
-		"stats tracker task with weekday %d and start time %s intersects with %d existing tasks",
-		e.LocalWeekday,
-		e.LocalStartTime,
+		"stats tracker task with %s recurrence and start time %s intersects with %d existing tasks",
+		e.Task.Recurrence,
+		e.Task.StartTime,
 		len(e.Tasks),
 	)
 }
 
+// The stats tracker is started once at least `MinOnline` tracked characters
+// are online for `Duration` and stopped once the count stays below `StopBelow`
+// for the same duration
+type StatsTrackerAutoStart struct {
+	ChannelId ChannelId
+	MinOnline int
+	Duration  time.Duration
+	StopBelow int
+}
+
+var ErrInvalidStatsTrackerAutoStart = errors.New("invalid stats tracker auto start")
+
+func (a StatsTrackerAutoStart) Validate() error {
+	if a.MinOnline < 1 || a.Duration <= 0 || a.StopBelow < 0 || a.StopBelow > a.MinOnline {
+		return ErrInvalidStatsTrackerAutoStart
+	}
+	return nil
+}
+
 func IsChannelsManagerOrDM(i *discordgo.InteractionCreate) bool {
 	return i.Member == nil || i.Member.Permissions&discordgo.PermissionManageChannels != 0
 }
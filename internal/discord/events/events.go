@@ -1,9 +1,11 @@
 package discord_events
 
 import (
+	"github.com/x0k/ps2-spy/internal/characters_refresher"
 	"github.com/x0k/ps2-spy/internal/characters_tracker"
 	"github.com/x0k/ps2-spy/internal/discord"
 	"github.com/x0k/ps2-spy/internal/lib/pubsub"
+	"github.com/x0k/ps2-spy/internal/outfit_activity"
 	"github.com/x0k/ps2-spy/internal/stats_tracker"
 	"github.com/x0k/ps2-spy/internal/storage"
 	"github.com/x0k/ps2-spy/internal/tracking"
@@ -17,6 +19,7 @@ type Event = pubsub.Event[EventType]
 const (
 	PlayerLoginType                        = EventType(characters_tracker.PlayerLoginType)
 	PlayerLogoutType                       = EventType(characters_tracker.PlayerLogoutType)
+	CharacterUpdatedType                   = EventType(characters_refresher.CharacterUpdatedType)
 	OutfitMembersUpdateType                = EventType(storage.OutfitMembersUpdateType)
 	FacilityControlType                    = EventType(worlds_tracker.FacilityControlType)
 	FacilityLossType                       = EventType(worlds_tracker.FacilityLossType)
@@ -26,7 +29,9 @@ const (
 	ChannelTitleUpdatesSavedType           = EventType(storage.ChannelTitleUpdatesSavedType)
 	ChannelTrackerStartedType              = EventType(stats_tracker.ChannelTrackerStartedType)
 	ChannelTrackerStoppedType              = EventType(stats_tracker.ChannelTrackerStoppedType)
+	ChannelTrackerUpdatedType              = EventType(stats_tracker.ChannelTrackerUpdatedType)
 	ChannelTrackingSettingsUpdatedType     = EventType(tracking.TrackingSettingsUpdatedType)
+	OutfitActivityReportedType             = EventType(outfit_activity.OutfitActivityReportedType)
 )
 
 type channelsEvent[T pubsub.EventType, E pubsub.Event[T]] struct {
@@ -52,11 +57,14 @@ type ChannelTitleUpdatesSaved = channelEvent[storage.EventType, storage.ChannelT
 
 type ChannelTrackerStarted = channelEvent[stats_tracker.EventType, stats_tracker.ChannelTrackerStarted]
 type ChannelTrackerStopped = channelEvent[stats_tracker.EventType, stats_tracker.ChannelTrackerStopped]
+type ChannelTrackerUpdated = channelEvent[stats_tracker.EventType, stats_tracker.ChannelTrackerUpdated]
 type ChannelTrackingSettingsUpdated = channelEvent[tracking.EventType, tracking.TrackingSettingsUpdated]
+type OutfitActivityReported = channelEvent[outfit_activity.EventType, outfit_activity.OutfitActivityReported]
 
 type PlayerLogin = channelsEvent[characters_tracker.EventType, characters_tracker.PlayerLogin]
 type PlayerFakeLogin = channelsEvent[characters_tracker.EventType, characters_tracker.PlayerFakeLogin]
 type PlayerLogout = channelsEvent[characters_tracker.EventType, characters_tracker.PlayerLogout]
+type CharacterUpdated = channelsEvent[characters_refresher.EventType, characters_refresher.CharacterUpdated]
 type OutfitMembersUpdate = channelsEvent[storage.EventType, storage.OutfitMembersUpdate]
 type FacilityControl = channelsEvent[worlds_tracker.EventType, worlds_tracker.FacilityControl]
 type FacilityLoss = channelsEvent[worlds_tracker.EventType, worlds_tracker.FacilityLoss]
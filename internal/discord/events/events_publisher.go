@@ -10,6 +10,7 @@ import (
 	"github.com/x0k/ps2-spy/internal/lib/logger"
 	"github.com/x0k/ps2-spy/internal/lib/logger/sl"
 	"github.com/x0k/ps2-spy/internal/lib/pubsub"
+	"github.com/x0k/ps2-spy/internal/outfit_activity"
 	"github.com/x0k/ps2-spy/internal/stats_tracker"
 	"github.com/x0k/ps2-spy/internal/storage"
 	"github.com/x0k/ps2-spy/internal/tracking"
@@ -73,6 +74,14 @@ func (p *EventsPublisher) PublishChannelTrackerStopped(
 	go publishChannelEventTask(ctx, p, event.ChannelId, event)
 }
 
+func (p *EventsPublisher) PublishChannelTrackerUpdated(
+	ctx context.Context,
+	event stats_tracker.ChannelTrackerUpdated,
+) {
+	p.wg.Add(1)
+	go publishChannelEventTask(ctx, p, event.ChannelId, event)
+}
+
 func (p *EventsPublisher) PublishChannelTrackingSettingsUpdated(
 	ctx context.Context,
 	event tracking.TrackingSettingsUpdated,
@@ -81,6 +90,14 @@ func (p *EventsPublisher) PublishChannelTrackingSettingsUpdated(
 	go publishChannelEventTask(ctx, p, event.ChannelId, event)
 }
 
+func (p *EventsPublisher) PublishOutfitActivityReported(
+	ctx context.Context,
+	event outfit_activity.OutfitActivityReported,
+) {
+	p.wg.Add(1)
+	go publishChannelEventTask(ctx, p, event.ChannelId, event)
+}
+
 func publishChannelEventTask[T pubsub.EventType, E pubsub.Event[T]](
 	ctx context.Context,
 	p *EventsPublisher,
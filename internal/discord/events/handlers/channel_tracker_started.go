@@ -2,26 +2,55 @@ package discord_event_handlers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/x0k/ps2-spy/internal/discord"
 	discord_events "github.com/x0k/ps2-spy/internal/discord/events"
 	discord_messages "github.com/x0k/ps2-spy/internal/discord/messages"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
 )
 
+// Leaderboard messages are saved with the session to be updated after a restart
+type LeaderboardSaver = func(context.Context, stats_tracker.SessionId, discord.MessageId) error
+
 func NewChannelTrackerStarted(
 	m *HandlersManager,
 	messages *discord_messages.Messages,
+	leaderboardUpdater LeaderboardUpdater,
+	leaderboardSaver LeaderboardSaver,
 ) Handler {
 	return newHandler(m, func(
 		ctx context.Context,
 		session *discordgo.Session,
 		e discord_events.ChannelTrackerStarted,
 	) error {
-		return sendSimpleMessage(
+		if err := sendSimpleMessage(
 			session,
 			[]discord.Channel{e.Channel},
 			messages.ChannelTrackerStarted(),
+		); err != nil {
+			return err
+		}
+		messageId, err := sendEditableMessage(
+			session,
+			e.Channel,
+			renderLeaderboard(
+				messages,
+				e.Channel,
+				e.Event.StartedAt,
+				time.Time{},
+				nil,
+			),
 		)
+		if err != nil {
+			return fmt.Errorf("failed to send leaderboard: %w", err)
+		}
+		leaderboardUpdater.TrackMessage(e.Channel.Id, messageId)
+		if err := leaderboardSaver(ctx, e.Event.SessionId, messageId); err != nil {
+			return fmt.Errorf("failed to save leaderboard: %w", err)
+		}
+		return nil
 	})
 }
@@ -8,17 +8,66 @@ import (
 	"github.com/x0k/ps2-spy/internal/discord"
 	discord_events "github.com/x0k/ps2-spy/internal/discord/events"
 	discord_messages "github.com/x0k/ps2-spy/internal/discord/messages"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
 )
 
+// Sections of the platform report are joined to be sent in as few messages
+// as possible, sections without data are skipped
+func channelTrackerStoppedReport(
+	messages *discord_messages.Messages,
+	channel discord.Channel,
+	event stats_tracker.ChannelTrackerStopped,
+	platform ps2_platforms.Platform,
+	stats stats_tracker.PlatformStats,
+) discord.ChunkableMessage {
+	sections := []discord.ChunkableMessage{
+		messages.ChannelTrackerStopped(
+			platform,
+			event.StartedAt,
+			event.StoppedAt,
+			stats,
+			channelScoreFormula(channel),
+			stats_tracker.ParseSortOrder(channel.StatsTrackerSort),
+		),
+	}
+	if channel.StatsTrackerDetails {
+		sections = append(
+			sections,
+			messages.ChannelTrackerZones(platform, stats),
+			messages.ChannelTrackerCharactersZones(platform, stats),
+			messages.ChannelTrackerHighlights(platform, stats),
+			messages.ChannelTrackerTopWeapons(platform, stats),
+			messages.ChannelTrackerRivals(platform, stats),
+			messages.ChannelTrackerOutfitRivalries(platform, stats),
+			messages.ChannelTrackerVehicleStats(platform, stats),
+			messages.ChannelTrackerFacilities(platform, stats),
+			messages.ChannelTrackerCharactersFacilities(platform, stats),
+			messages.ChannelTrackerSupportStats(platform, stats),
+		)
+	}
+	if event.Previous != nil && len(stats.Characters) > 0 {
+		previous := event.Previous.Platforms[platform]
+		sections = append(
+			sections,
+			messages.ChannelTrackerOutfitsComparison(platform, event.Previous.StartedAt, stats, previous),
+			messages.ChannelTrackerCharactersComparison(platform, event.Previous.StartedAt, stats, previous),
+		)
+	}
+	return discord.JoinChunkableMessages(sections...)
+}
+
 func NewChannelTrackerStopped(
 	m *HandlersManager,
 	messages *discord_messages.Messages,
+	leaderboardUpdater LeaderboardUpdater,
 ) Handler {
 	return newHandler(m, func(
 		ctx context.Context,
 		session *discordgo.Session,
 		e discord_events.ChannelTrackerStopped,
 	) error {
+		leaderboardUpdater.UntrackMessage(e.Channel.Id)
 		platforms := e.Event.Platforms
 		// Unreachable
 		if len(platforms) == 0 {
@@ -30,16 +79,32 @@ func NewChannelTrackerStopped(
 			if err := sendChunkableMessage(
 				session,
 				channels,
-				messages.ChannelTrackerStopped(
-					platform,
-					e.Event.StartedAt,
-					e.Event.StoppedAt,
-					stats,
-				),
+				channelTrackerStoppedReport(messages, e.Channel, e.Event, platform, stats),
+			); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if e.Channel.StatsTrackerCharts {
+			if err := sendComplexMessage(
+				session,
+				channels,
+				messages.ChannelTrackerCharts(e.Event.SessionId, platforms),
 			); err != nil {
 				errs = append(errs, err)
 			}
 		}
+		if err := sendComplexMessage(
+			session,
+			channels,
+			messages.ChannelTrackerExport(
+				e.Event.SessionId,
+				e.Event.StartedAt,
+				e.Event.StoppedAt,
+				platforms,
+			),
+		); err != nil {
+			errs = append(errs, err)
+		}
 		return errors.Join(errs...)
 	})
 }
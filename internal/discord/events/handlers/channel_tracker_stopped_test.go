@@ -0,0 +1,181 @@
+package discord_event_handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/discord"
+	discord_messages "github.com/x0k/ps2-spy/internal/discord/messages"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func TestChannelTrackerStoppedReport(t *testing.T) {
+	stoppedAt := time.Now()
+	stats := stats_tracker.PlatformStats{
+		Characters: []stats_tracker.CharacterStats{
+			{
+				Character: ps2.Character{Id: "1", Name: "Alpha", OutfitTag: "TAG"},
+				BodyKills: 10,
+				Deaths:    2,
+				Revives:   3,
+				Weapons: []stats_tracker.WeaponStats{
+					{Weapon: ps2.Item{Id: "1", Name: "Gauss Rifle"}, Kills: 10},
+				},
+			},
+			{
+				Character: ps2.Character{Id: "2", Name: "Bravo", OutfitTag: "TAG"},
+				BodyKills: 4,
+				Deaths:    5,
+			},
+		},
+	}
+	cases := []struct {
+		name     string
+		details  bool
+		previous *stats_tracker.PreviousSession
+		sections []string
+		skipped  []string
+	}{
+		{
+			name:     "details are disabled by default",
+			sections: []string{"started at"},
+			skipped:  []string{"top weapons", "support", "compared to"},
+		},
+		{
+			name:     "details with data",
+			details:  true,
+			sections: []string{"started at", "top weapons", "support"},
+			skipped:  []string{"vehicles", "continents", "highlights", "compared to"},
+		},
+		{
+			name: "comparison with the previous session",
+			previous: &stats_tracker.PreviousSession{
+				StartedAt: stoppedAt.Add(-24 * time.Hour),
+			},
+			sections: []string{"started at", "characters compared to"},
+			skipped:  []string{"top weapons"},
+		},
+	}
+	messages := discord_messages.New(nil, time.Hour, 0, 0)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			channel := discord.NewDefaultChannel("channel")
+			channel.StatsTrackerDetails = c.details
+			event := stats_tracker.ChannelTrackerStopped{
+				StartedAt: stoppedAt.Add(-time.Hour),
+				StoppedAt: stoppedAt,
+				Previous:  c.previous,
+			}
+			msg := channelTrackerStoppedReport(messages, channel, event, ps2_platforms.PC, stats)(
+				message.NewPrinter(language.English),
+			)
+			content, err := msg.Print(0, msg.Chunks)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err.Err)
+			}
+			if len(content) >= discord.MAX_MESSAGE_LENGTH {
+				t.Errorf("expected the report to fit into a single message, got %d characters", len(content))
+			}
+			for _, section := range c.sections {
+				if !strings.Contains(content, section) {
+					t.Errorf("expected the %q section in %q", section, content)
+				}
+			}
+			for _, section := range c.skipped {
+				if strings.Contains(content, section) {
+					t.Errorf("unexpected %q section in %q", section, content)
+				}
+			}
+		})
+	}
+}
+
+func TestChannelSortOrder(t *testing.T) {
+	stoppedAt := time.Now()
+	stats := stats_tracker.PlatformStats{
+		Characters: []stats_tracker.CharacterStats{
+			{
+				Character: ps2.Character{Id: "1", Name: "Alpha"},
+				BodyKills: 10,
+				Deaths:    2,
+			},
+			{
+				Character: ps2.Character{Id: "2", Name: "Bravo"},
+				BodyKills: 4,
+				Deaths:    5,
+			},
+		},
+	}
+	cases := []struct {
+		name   string
+		sort   string
+		header string
+		first  string
+		second string
+	}{
+		{
+			name:   "default order",
+			first:  "Alpha",
+			second: "Bravo",
+		},
+		{
+			name:   "unknown order falls back to kills",
+			sort:   "unknown",
+			first:  "Alpha",
+			second: "Bravo",
+		},
+		{
+			name:   "deaths per minute",
+			sort:   string(stats_tracker.SortByDPM),
+			header: "sorted by: deaths per minute",
+			first:  "Bravo",
+			second: "Alpha",
+		},
+	}
+	messages := discord_messages.New(nil, time.Hour, 0, 0)
+	p := message.NewPrinter(language.English)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			channel := discord.NewDefaultChannel("channel")
+			if c.sort != "" {
+				channel.StatsTrackerSort = c.sort
+			}
+			event := stats_tracker.ChannelTrackerStopped{
+				StartedAt: stoppedAt.Add(-time.Hour),
+				StoppedAt: stoppedAt,
+			}
+			msg := channelTrackerStoppedReport(messages, channel, event, ps2_platforms.PC, stats)(p)
+			report, err := msg.Print(0, msg.Chunks)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err.Err)
+			}
+			leaderboard, err := renderLeaderboard(
+				messages,
+				channel,
+				event.StartedAt,
+				stoppedAt,
+				map[ps2_platforms.Platform]stats_tracker.PlatformStats{ps2_platforms.PC: stats},
+			)(p)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err.Err)
+			}
+			for name, content := range map[string]string{"report": report, "leaderboard": leaderboard} {
+				first, second := strings.Index(content, c.first), strings.Index(content, c.second)
+				if first < 0 || second < 0 || first > second {
+					t.Errorf("%s: expected %q before %q in %q", name, c.first, c.second, content)
+				}
+				if hasHeader := strings.Contains(content, "sorted by"); hasHeader != (c.header != "") {
+					t.Errorf("%s: sort order in the header = %v, want %v", name, hasHeader, c.header != "")
+				}
+				if c.header != "" && !strings.Contains(content, c.header) {
+					t.Errorf("%s: expected %q in %q", name, c.header, content)
+				}
+			}
+		})
+	}
+}
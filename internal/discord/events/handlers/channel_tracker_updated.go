@@ -0,0 +1,85 @@
+package discord_event_handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/x0k/ps2-spy/internal/discord"
+	discord_events "github.com/x0k/ps2-spy/internal/discord/events"
+	discord_messages "github.com/x0k/ps2-spy/internal/discord/messages"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
+	"golang.org/x/text/message"
+)
+
+const leaderboardSize = 10
+
+type LeaderboardUpdater interface {
+	TrackMessage(discord.ChannelId, discord.MessageId)
+	UntrackMessage(discord.ChannelId)
+	UpdateMessage(discord.ChannelId, string)
+}
+
+func NewChannelTrackerUpdated(
+	m *HandlersManager,
+	messages *discord_messages.Messages,
+	leaderboardUpdater LeaderboardUpdater,
+) Handler {
+	return newHandler(m, func(
+		ctx context.Context,
+		session *discordgo.Session,
+		e discord_events.ChannelTrackerUpdated,
+	) error {
+		content, err := renderLeaderboard(
+			messages,
+			e.Channel,
+			e.Event.StartedAt,
+			e.Event.UpdatedAt,
+			e.Event.Platforms,
+		)(message.NewPrinter(e.Channel.Locale))
+		if err != nil {
+			return err.Err
+		}
+		leaderboardUpdater.UpdateMessage(e.Channel.Id, content)
+		return nil
+	})
+}
+
+// Reduces the number of rows until the leaderboard fits into a single message,
+// fails if even a single row per platform does not fit
+func renderLeaderboard(
+	messages *discord_messages.Messages,
+	channel discord.Channel,
+	startedAt time.Time,
+	updatedAt time.Time,
+	platforms map[ps2_platforms.Platform]stats_tracker.PlatformStats,
+) discord.Message {
+	formula := channelScoreFormula(channel)
+	order := stats_tracker.ParseSortOrder(channel.StatsTrackerSort)
+	return func(p *message.Printer) (string, *discord.Error) {
+		for limit := leaderboardSize; limit > 0; limit-- {
+			content, err := messages.ChannelTrackerLeaderboard(startedAt, updatedAt, platforms, limit, formula, order)(p)
+			if err != nil {
+				return "", err
+			}
+			if len(content) < discord.MAX_MESSAGE_LENGTH {
+				return content, nil
+			}
+		}
+		return "", &discord.Error{
+			Msg: p.Sprintf("Leaderboard does not fit into a message"),
+			Err: fmt.Errorf("render leaderboard: %w", discord.ErrMessageTooLong),
+		}
+	}
+}
+
+// Formulas are validated on save, so the default one is used as a fallback
+func channelScoreFormula(channel discord.Channel) stats_tracker.ScoreFormula {
+	formula, err := stats_tracker.ParseScoreFormula(channel.StatsTrackerScoreFormula)
+	if err != nil {
+		return stats_tracker.ScoreFormula{}
+	}
+	return formula
+}
@@ -0,0 +1,92 @@
+package discord_event_handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/discord"
+	discord_messages "github.com/x0k/ps2-spy/internal/discord/messages"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func leaderboardStats(count int, nameLength int) stats_tracker.PlatformStats {
+	chars := make([]stats_tracker.CharacterStats, 0, count)
+	for i := range count {
+		name := fmt.Sprintf("%d", i)
+		chars = append(chars, stats_tracker.CharacterStats{
+			Character: ps2.Character{
+				Id:   ps2.CharacterId(name),
+				Name: name + strings.Repeat("x", nameLength),
+			},
+			BodyKills: uint(count - i),
+		})
+	}
+	return stats_tracker.PlatformStats{Characters: chars}
+}
+
+func TestRenderLeaderboard(t *testing.T) {
+	cases := []struct {
+		name       string
+		platforms  []ps2_platforms.Platform
+		nameLength int
+		reduced    bool
+		err        error
+	}{
+		{
+			name:       "all rows fit",
+			platforms:  []ps2_platforms.Platform{ps2_platforms.PC},
+			nameLength: 10,
+		},
+		{
+			name:       "rows are reduced for long names",
+			platforms:  ps2_platforms.Platforms,
+			nameLength: 100,
+			reduced:    true,
+		},
+		{
+			name:       "single row does not fit",
+			platforms:  ps2_platforms.Platforms,
+			nameLength: 1000,
+			err:        discord.ErrMessageTooLong,
+		},
+	}
+	messages := discord_messages.New(nil, time.Hour, 0, 0)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			platforms := make(map[ps2_platforms.Platform]stats_tracker.PlatformStats, len(c.platforms))
+			for _, platform := range c.platforms {
+				platforms[platform] = leaderboardStats(leaderboardSize, c.nameLength)
+			}
+			now := time.Now()
+			content, err := renderLeaderboard(messages, discord.NewDefaultChannel("channel"), now.Add(-time.Hour), now, platforms)(
+				message.NewPrinter(language.English),
+			)
+			if c.err != nil {
+				if err == nil || !errors.Is(err.Err, c.err) {
+					t.Fatalf("expected error %v, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err.Err)
+			}
+			if len(content) >= discord.MAX_MESSAGE_LENGTH {
+				t.Fatalf("expected the leaderboard to fit into a message, got %d characters", len(content))
+			}
+			if first := "0" + strings.Repeat("x", c.nameLength); !strings.Contains(content, first) {
+				t.Errorf("expected the top character %q", first)
+			}
+			last := fmt.Sprintf("%d%s", leaderboardSize-1, strings.Repeat("x", c.nameLength))
+			if reduced := !strings.Contains(content, last); reduced != c.reduced {
+				t.Errorf("reduced = %v, want %v", reduced, c.reduced)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package discord_event_handlers
+
+import (
+	"context"
+
+	"github.com/bwmarrin/discordgo"
+	discord_events "github.com/x0k/ps2-spy/internal/discord/events"
+	discord_messages "github.com/x0k/ps2-spy/internal/discord/messages"
+	"github.com/x0k/ps2-spy/internal/lib/slicesx"
+)
+
+func NewCharacterUpdated(
+	m *HandlersManager,
+	messages *discord_messages.Messages,
+) Handler {
+	return newHandler(m, func(
+		ctx context.Context,
+		session *discordgo.Session,
+		e discord_events.CharacterUpdated,
+	) error {
+		return sendSimpleMessage(
+			session,
+			slicesx.Filter(e.Channels, func(i int) bool {
+				return e.Channels[i].CharacterChangesNotifications
+			}),
+			messages.CharacterUpdated(e.Event.Old, e.Event.New),
+		)
+	})
+}
@@ -11,13 +11,17 @@ func New(
 	onlineTrackableEntitiesCountLoader OnlineTrackableEntitiesCountLoader,
 	channelTitleUpdater ChannelTitleUpdater,
 	trackingSettingsDiffViewLoader TrackingSettingsDiffViewLoader,
+	leaderboardUpdater LeaderboardUpdater,
+	leaderboardSaver LeaderboardSaver,
 ) []Handler {
 	return []Handler{
 		NewChannelLanguageSaved(m, messages, onlineTrackableEntitiesCountLoader, channelTitleUpdater),
 		NewChannelTitleUpdatesSaved(m, messages, onlineTrackableEntitiesCountLoader, channelTitleUpdater),
-		NewChannelTrackerStarted(m, messages),
-		NewChannelTrackerStopped(m, messages),
+		NewChannelTrackerStarted(m, messages, leaderboardUpdater, leaderboardSaver),
+		NewChannelTrackerUpdated(m, messages, leaderboardUpdater),
+		NewChannelTrackerStopped(m, messages, leaderboardUpdater),
 		NewTrackingSettingsUpdateHandler(m, messages, trackingSettingsDiffViewLoader),
+		NewOutfitActivityReported(m, messages),
 	}
 }
 
@@ -39,5 +43,6 @@ func NewPlatform(
 		NewPlayerLogin(m, messages, onlineTrackableEntitiesCountLoader, channelTitleUpdater),
 		NewPlayerFakeLogin(m, messages, onlineTrackableEntitiesCountLoader, channelTitleUpdater),
 		NewPlayerLogout(m, messages, characterLoader, onlineTrackableEntitiesCountLoader, channelTitleUpdater),
+		NewCharacterUpdated(m, messages),
 	}
 }
@@ -0,0 +1,47 @@
+package discord_event_handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/x0k/ps2-spy/internal/discord"
+	discord_events "github.com/x0k/ps2-spy/internal/discord/events"
+	discord_messages "github.com/x0k/ps2-spy/internal/discord/messages"
+)
+
+func NewOutfitActivityReported(
+	m *HandlersManager,
+	messages *discord_messages.Messages,
+) Handler {
+	return newHandler(m, func(
+		ctx context.Context,
+		session *discordgo.Session,
+		e discord_events.OutfitActivityReported,
+	) error {
+		var errs []error
+		channels := []discord.Channel{e.Channel}
+		for platform, reports := range e.Event.Platforms {
+			for _, report := range reports {
+				if err := sendChunkableMessage(
+					session,
+					channels,
+					messages.OutfitActivityReport(platform, e.Event.From, e.Event.To, report),
+				); err != nil {
+					errs = append(errs, err)
+				}
+				if len(report.Inactive) == 0 {
+					continue
+				}
+				if err := sendChunkableMessage(
+					session,
+					channels,
+					messages.OutfitActivityInactiveMembers(platform, report),
+				); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
@@ -10,8 +10,6 @@ import (
 
 var truncation = []rune("... (truncated)")
 
-const msgMaxLen = 2000
-
 func sendChannelMessage(
 	session *discordgo.Session,
 	channels []discord.Channel,
@@ -21,8 +19,8 @@ func sendChannelMessage(
 	msgRunes := []rune(content)
 	for len(msgRunes) > 0 {
 		toSend := msgRunes
-		if len(toSend) > msgMaxLen {
-			toSend = toSend[:msgMaxLen]
+		if len(toSend) > discord.MAX_MESSAGE_LENGTH {
+			toSend = toSend[:discord.MAX_MESSAGE_LENGTH]
 			lastLineBreak := lastIndexRune(toSend, '\n')
 			if lastLineBreak > 0 {
 				toSend = toSend[:lastLineBreak]
@@ -33,8 +31,8 @@ func sendChannelMessage(
 					toSend = toSend[:lastSpace]
 					msgRunes = msgRunes[lastSpace+1:]
 				} else {
-					toSend = slices.Concat(msgRunes[:msgMaxLen-len(truncation)], truncation)
-					msgRunes = msgRunes[msgMaxLen-len(truncation):]
+					toSend = slices.Concat(msgRunes[:discord.MAX_MESSAGE_LENGTH-len(truncation)], truncation)
+					msgRunes = msgRunes[discord.MAX_MESSAGE_LENGTH-len(truncation):]
 				}
 			}
 		} else {
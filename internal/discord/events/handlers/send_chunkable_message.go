@@ -45,7 +45,7 @@ func sendChunkableMessage(
 				if err != nil {
 					return false
 				}
-				return len(content) < msgMaxLen
+				return len(content) < discord.MAX_MESSAGE_LENGTH
 			})
 			count := max(r, 0) + 1
 			content, err := msg.Print(l, count)
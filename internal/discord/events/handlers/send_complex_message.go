@@ -0,0 +1,33 @@
+package discord_event_handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/x0k/ps2-spy/internal/discord"
+	"golang.org/x/text/message"
+)
+
+// Message is rendered for each channel, since attached files
+// can be read only once
+func sendComplexMessage(session *discordgo.Session, channels []discord.Channel, discordMsg discord.MessageSend) error {
+	errs := make([]error, 0, len(channels))
+	for _, channel := range channels {
+		msg, err := discordMsg(message.NewPrinter(channel.Locale))
+		if err != nil {
+			errs = append(errs, err.Err)
+			if err := sendChannelMessage(session, []discord.Channel{channel}, err.Msg); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if _, err := session.ChannelMessageSendComplex(string(channel.Id), msg); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send message to channel %q: %w", channel.Id, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("send complex message: %w", errors.Join(errs...))
+	}
+	return nil
+}
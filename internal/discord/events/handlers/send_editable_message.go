@@ -0,0 +1,30 @@
+package discord_event_handlers
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/x0k/ps2-spy/internal/discord"
+	"golang.org/x/text/message"
+)
+
+// Sends a single message that can be edited later,
+// the error message is sent as a regular one
+func sendEditableMessage(
+	session *discordgo.Session,
+	channel discord.Channel,
+	discordMsg discord.Message,
+) (discord.MessageId, error) {
+	msgContent, err := discordMsg(message.NewPrinter(channel.Locale))
+	if err != nil {
+		if sendErr := sendChannelMessage(session, []discord.Channel{channel}, err.Msg); sendErr != nil {
+			return "", fmt.Errorf("send editable message: %w", sendErr)
+		}
+		return "", fmt.Errorf("send editable message: %w", err.Err)
+	}
+	msg, sendErr := session.ChannelMessageSend(string(channel.Id), msgContent)
+	if sendErr != nil {
+		return "", fmt.Errorf("send editable message: %w", sendErr)
+	}
+	return discord.MessageId(msg.ID), nil
+}
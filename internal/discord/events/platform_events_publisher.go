@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"sync"
 
+	"github.com/x0k/ps2-spy/internal/characters_refresher"
 	"github.com/x0k/ps2-spy/internal/characters_tracker"
 	"github.com/x0k/ps2-spy/internal/discord"
 	"github.com/x0k/ps2-spy/internal/lib/loader"
@@ -61,6 +62,11 @@ func (p *PlatformEventsPublisher) PublishPlayerLogout(ctx context.Context, e cha
 	go publishCharacterEventTask(ctx, p, e.CharacterId, e)
 }
 
+func (p *PlatformEventsPublisher) PublishCharacterUpdated(ctx context.Context, e characters_refresher.CharacterUpdated) {
+	p.wg.Add(1)
+	go publishCharacterEventTask(ctx, p, e.New.Id, e)
+}
+
 func (p *PlatformEventsPublisher) PublishFacilityControl(ctx context.Context, e worlds_tracker.FacilityControl) {
 	p.wg.Add(1)
 	go publishOutfitEventTask(ctx, p, ps2.OutfitId(e.OutfitID), e)
@@ -1,10 +1,15 @@
 package discord
 
 import (
+	"strings"
+
 	"github.com/bwmarrin/discordgo"
 	"golang.org/x/text/message"
 )
 
+// Discord limit of the message content length
+const MAX_MESSAGE_LENGTH = 2000
+
 type Error struct {
 	Msg string
 	Err error
@@ -33,6 +38,55 @@ func NewChunkableMessage(
 
 type ChunkableMessage = func(*message.Printer) Chunkable
 
+// Chunks of the messages are printed in order, so the short messages
+// are packed together. Messages without chunks are skipped unless
+// all of them are empty, then the first one is used
+func JoinChunkableMessages(msgs ...ChunkableMessage) ChunkableMessage {
+	return func(p *message.Printer) Chunkable {
+		parts := make([]Chunkable, 0, len(msgs))
+		chunks := 0
+		for _, msg := range msgs {
+			part := msg(p)
+			if part.Chunks > 0 {
+				parts = append(parts, part)
+				chunks += part.Chunks
+			}
+		}
+		if chunks == 0 && len(msgs) > 0 {
+			return msgs[0](p)
+		}
+		return Chunkable{
+			Chunks: chunks,
+			Print: func(start, count int) (string, *Error) {
+				sb := strings.Builder{}
+				for _, part := range parts {
+					if count == 0 {
+						break
+					}
+					if start >= part.Chunks {
+						start -= part.Chunks
+						continue
+					}
+					n := min(count, part.Chunks-start)
+					content, err := part.Print(start, n)
+					if err != nil {
+						return content, err
+					}
+					if sb.Len() > 0 {
+						sb.WriteString("\n")
+					}
+					sb.WriteString(content)
+					start = 0
+					count -= n
+				}
+				return sb.String(), nil
+			},
+		}
+	}
+}
+
+type MessageSend = func(*message.Printer) (*discordgo.MessageSend, *Error)
+
 type MessageEdit = func(*message.Printer) (*discordgo.MessageEdit, *Error)
 
 type ResponseEdit = func(*message.Printer) (*discordgo.WebhookEdit, *Error)
@@ -1,11 +1,13 @@
 package discord_messages
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/olekukonko/tablewriter"
+	"github.com/x0k/ps2-spy/internal/ps2"
 	ps2_factions "github.com/x0k/ps2-spy/internal/ps2/factions"
 	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
 	"github.com/x0k/ps2-spy/internal/stats_tracker"
@@ -35,19 +37,28 @@ func renderCharactersStatsTable(
 	sb *strings.Builder,
 	characters []stats_tracker.CharacterStats,
 	initialIndex int,
+	formula stats_tracker.ScoreFormula,
 ) {
 	t := tablewriter.NewWriter(sb)
-	t.SetHeader([]string{
+	header := []string{
 		"№",
 		p.Sprintf("Faction"),
 		p.Sprintf("Outfit"),
 		p.Sprintf("Character"),
 		p.Sprintf("Kills"),
+		p.Sprintf("Vehicles"),
 		p.Sprintf("HS") + "%",
 		p.Sprintf("KD"),
+		p.Sprintf("KPM"),
+		p.Sprintf("DPM"),
 		p.Sprintf("Loadout"),
-		p.Sprintf("Duration"),
-	})
+		p.Sprintf("Active"),
+	}
+	// Score goes after the character name
+	if !formula.IsDefault() {
+		header = slices.Insert(header, 4, p.Sprintf("Score"))
+	}
+	t.SetHeader(header)
 	t.SetBorder(false)
 	for i, char := range characters {
 		allKills := char.BodyKills + char.HeadShotsKills
@@ -61,26 +72,456 @@ func renderCharactersStatsTable(
 			killDeathRatio = float64(allKills) / float64(allDeaths)
 		}
 		mainLoadoutType := ps2_loadout.HeavyAssault
-		totalDuration := time.Duration(0)
 		maxDuration := time.Duration(0)
 		for i, d := range char.LoadoutsDistribution {
-			totalDuration += d
 			if d > maxDuration {
 				maxDuration = d
 				mainLoadoutType = ps2_loadout.LoadoutType(i)
 			}
 		}
-		t.Append([]string{
+		row := []string{
 			strconv.FormatInt(int64(initialIndex+i+1), 10),
 			ps2_factions.FactionNameById(char.Character.FactionId),
 			char.Character.OutfitTag,
 			char.Character.Name,
 			strconv.FormatUint(uint64(allKills), 10),
+			strconv.FormatUint(uint64(char.VehiclesDestroyed), 10) + "/" + strconv.FormatUint(uint64(char.VehiclesLost), 10),
 			strconv.FormatFloat(headShotsRatio, 'f', 2, 64),
 			strconv.FormatFloat(killDeathRatio, 'f', 2, 64),
+			strconv.FormatFloat(char.KPM(), 'f', 2, 64),
+			strconv.FormatFloat(char.DPM(), 'f', 2, 64),
 			renderLoadoutType(p, mainLoadoutType),
-			renderDuration(p, totalDuration),
+			renderDuration(p, char.ActiveTime()),
+		}
+		if !formula.IsDefault() {
+			row = slices.Insert(row, 4, strconv.FormatFloat(formula.Score(char), 'f', 2, 64))
+		}
+		t.Append(row)
+	}
+	t.Render()
+}
+
+func renderCharactersSupportStatsTable(
+	p *message.Printer,
+	sb *strings.Builder,
+	characters []stats_tracker.CharacterStats,
+	initialIndex int,
+) {
+	t := tablewriter.NewWriter(sb)
+	t.SetHeader([]string{
+		"№",
+		p.Sprintf("Outfit"),
+		p.Sprintf("Character"),
+		p.Sprintf("Revives"),
+		p.Sprintf("Heals"),
+		p.Sprintf("Repairs"),
+		p.Sprintf("Resupplies"),
+		p.Sprintf("Shields"),
+		p.Sprintf("Spots"),
+		p.Sprintf("Score"),
+	})
+	t.SetBorder(false)
+	for i, char := range characters {
+		t.Append([]string{
+			strconv.FormatInt(int64(initialIndex+i+1), 10),
+			char.Character.OutfitTag,
+			char.Character.Name,
+			strconv.FormatUint(uint64(char.Revives), 10),
+			strconv.FormatUint(uint64(char.Heals), 10),
+			strconv.FormatUint(uint64(char.Repairs), 10),
+			strconv.FormatUint(uint64(char.Resupplies), 10),
+			strconv.FormatUint(uint64(char.ShieldRepairs), 10),
+			strconv.FormatUint(uint64(char.SpotAssists), 10),
+			strconv.FormatUint(uint64(char.Score), 10),
+		})
+	}
+	t.Render()
+}
+
+type characterVehicleStats struct {
+	character ps2.Character
+	stats_tracker.VehicleStats
+}
+
+func renderCharactersVehicleStatsTable(
+	p *message.Printer,
+	sb *strings.Builder,
+	rows []characterVehicleStats,
+) {
+	t := tablewriter.NewWriter(sb)
+	t.SetHeader([]string{
+		p.Sprintf("Outfit"),
+		p.Sprintf("Character"),
+		p.Sprintf("Vehicle"),
+		p.Sprintf("Destroyed"),
+		p.Sprintf("Lost"),
+	})
+	t.SetBorder(false)
+	for _, row := range rows {
+		t.Append([]string{
+			row.character.OutfitTag,
+			row.character.Name,
+			row.Vehicle.Name,
+			strconv.FormatUint(uint64(row.Destroyed), 10),
+			strconv.FormatUint(uint64(row.Lost), 10),
+		})
+	}
+	t.Render()
+}
+
+const topWeaponsCount = 3
+
+type characterWeaponStats struct {
+	character ps2.Character
+	stats_tracker.WeaponStats
+}
+
+func renderCharactersWeaponStatsTable(
+	p *message.Printer,
+	sb *strings.Builder,
+	rows []characterWeaponStats,
+) {
+	t := tablewriter.NewWriter(sb)
+	t.SetHeader([]string{
+		p.Sprintf("Outfit"),
+		p.Sprintf("Character"),
+		p.Sprintf("Weapon"),
+		p.Sprintf("Category"),
+		p.Sprintf("Kills"),
+		p.Sprintf("HS") + "%",
+	})
+	t.SetBorder(false)
+	for _, row := range rows {
+		headShotsRatio := float64(0)
+		if row.Kills > 0 {
+			headShotsRatio = float64(row.HeadShotsKills) / float64(row.Kills)
+		}
+		t.Append([]string{
+			row.character.OutfitTag,
+			row.character.Name,
+			row.Weapon.Name,
+			row.Weapon.Category,
+			strconv.FormatUint(uint64(row.Kills), 10),
+			strconv.FormatFloat(headShotsRatio, 'f', 2, 64),
+		})
+	}
+	t.Render()
+}
+
+func renderFacilitiesStatsTable(
+	p *message.Printer,
+	sb *strings.Builder,
+	facilities []stats_tracker.FacilityStats,
+) {
+	t := tablewriter.NewWriter(sb)
+	t.SetHeader([]string{
+		p.Sprintf("Facility"),
+		p.Sprintf("Type"),
+		p.Sprintf("Continent"),
+		p.Sprintf("Captures"),
+		p.Sprintf("Defenses"),
+	})
+	t.SetBorder(false)
+	for _, f := range facilities {
+		t.Append([]string{
+			f.Facility.Name,
+			f.Facility.Type,
+			ps2.ZoneNameById(f.Facility.ZoneId),
+			strconv.FormatUint(uint64(f.Captures), 10),
+			strconv.FormatUint(uint64(f.Defenses), 10),
+		})
+	}
+	t.Render()
+}
+
+type characterFacilityStats struct {
+	character ps2.Character
+	stats_tracker.FacilityStats
+}
+
+func renderCharactersFacilityStatsTable(
+	p *message.Printer,
+	sb *strings.Builder,
+	rows []characterFacilityStats,
+) {
+	t := tablewriter.NewWriter(sb)
+	t.SetHeader([]string{
+		p.Sprintf("Outfit"),
+		p.Sprintf("Character"),
+		p.Sprintf("Facility"),
+		p.Sprintf("Captures"),
+		p.Sprintf("Defenses"),
+	})
+	t.SetBorder(false)
+	for _, row := range rows {
+		t.Append([]string{
+			row.character.OutfitTag,
+			row.character.Name,
+			row.Facility.Name,
+			strconv.FormatUint(uint64(row.Captures), 10),
+			strconv.FormatUint(uint64(row.Defenses), 10),
+		})
+	}
+	t.Render()
+}
+
+func renderUintDelta(current, previous uint) string {
+	delta := int64(current) - int64(previous)
+	s := strconv.FormatUint(uint64(current), 10)
+	if delta == 0 {
+		return s
+	}
+	if delta > 0 {
+		return s + " (+" + strconv.FormatInt(delta, 10) + ")"
+	}
+	return s + " (" + strconv.FormatInt(delta, 10) + ")"
+}
+
+func renderRatioDelta(current, previous float64) string {
+	s := strconv.FormatFloat(current, 'f', 2, 64)
+	delta := strconv.FormatFloat(current-previous, 'f', 2, 64)
+	if delta == "0.00" || delta == "-0.00" {
+		return s
+	}
+	if current > previous {
+		return s + " (+" + delta + ")"
+	}
+	return s + " (" + delta + ")"
+}
+
+func renderDurationDelta(p *message.Printer, current, previous time.Duration) string {
+	s := renderDuration(p, current)
+	delta := (current - previous).Truncate(time.Minute)
+	if delta == 0 {
+		return s
+	}
+	if delta > 0 {
+		return s + " (+" + renderDuration(p, delta) + ")"
+	}
+	return s + " (-" + renderDuration(p, -delta) + ")"
+}
+
+type totalsComparison struct {
+	outfitTag string
+	// Empty for outfits
+	name     string
+	current  stats_tracker.Totals
+	previous stats_tracker.Totals
+	// Absent in the previous session
+	isNew bool
+}
+
+func renderTotalsComparisonTable(
+	p *message.Printer,
+	sb *strings.Builder,
+	rows []totalsComparison,
+	withCharacters bool,
+) {
+	t := tablewriter.NewWriter(sb)
+	header := []string{p.Sprintf("Outfit")}
+	if withCharacters {
+		header = append(header, p.Sprintf("Character"))
+	}
+	t.SetHeader(append(
+		header,
+		p.Sprintf("Kills"),
+		p.Sprintf("KD"),
+		p.Sprintf("HS")+"%",
+		p.Sprintf("Duration"),
+	))
+	t.SetBorder(false)
+	for _, row := range rows {
+		cells := []string{row.outfitTag}
+		if withCharacters {
+			cells = append(cells, row.name)
+		}
+		if row.isNew {
+			t.Append(append(
+				cells,
+				strconv.FormatUint(uint64(row.current.Kills), 10)+" "+p.Sprintf("(new)"),
+				strconv.FormatFloat(row.current.KD(), 'f', 2, 64),
+				strconv.FormatFloat(row.current.HSR(), 'f', 2, 64),
+				renderDuration(p, row.current.Playtime),
+			))
+			continue
+		}
+		t.Append(append(
+			cells,
+			renderUintDelta(row.current.Kills, row.previous.Kills),
+			renderRatioDelta(row.current.KD(), row.previous.KD()),
+			renderRatioDelta(row.current.HSR(), row.previous.HSR()),
+			renderDurationDelta(p, row.current.Playtime, row.previous.Playtime),
+		))
+	}
+	t.Render()
+}
+
+func renderRivals(rivals []stats_tracker.RivalStats, count func(stats_tracker.RivalStats) uint) string {
+	parts := make([]string, 0, len(rivals))
+	for _, r := range rivals {
+		parts = append(parts, r.Character.Name+" ("+strconv.FormatUint(uint64(count(r)), 10)+")")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func renderCharactersRivalsTable(
+	p *message.Printer,
+	sb *strings.Builder,
+	characters []stats_tracker.CharacterStats,
+) {
+	t := tablewriter.NewWriter(sb)
+	t.SetHeader([]string{
+		p.Sprintf("Outfit"),
+		p.Sprintf("Character"),
+		p.Sprintf("Victims"),
+		p.Sprintf("Nemeses"),
+	})
+	t.SetBorder(false)
+	for _, char := range characters {
+		t.Append([]string{
+			char.Character.OutfitTag,
+			char.Character.Name,
+			renderRivals(char.Victims, func(r stats_tracker.RivalStats) uint { return r.Kills }),
+			renderRivals(char.Nemeses, func(r stats_tracker.RivalStats) uint { return r.Deaths }),
 		})
 	}
 	t.Render()
 }
+
+func renderAchievements(achievements []stats_tracker.AchievementStats) string {
+	parts := make([]string, 0, len(achievements))
+	for _, a := range achievements {
+		if a.Count > 1 {
+			parts = append(parts, a.Achievement.Name+" ("+strconv.FormatUint(uint64(a.Count), 10)+")")
+		} else {
+			parts = append(parts, a.Achievement.Name)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func renderCharactersHighlightsTable(
+	p *message.Printer,
+	sb *strings.Builder,
+	characters []stats_tracker.CharacterStats,
+) {
+	t := tablewriter.NewWriter(sb)
+	t.SetHeader([]string{
+		p.Sprintf("Outfit"),
+		p.Sprintf("Character"),
+		p.Sprintf("BR ups"),
+		p.Sprintf("BR"),
+		p.Sprintf("ASP"),
+		p.Sprintf("Medals"),
+	})
+	t.SetBorder(false)
+	for _, char := range characters {
+		battleRank := ""
+		if char.BattleRank > 0 {
+			battleRank = strconv.FormatUint(uint64(char.BattleRank), 10)
+		}
+		t.Append([]string{
+			char.Character.OutfitTag,
+			char.Character.Name,
+			strconv.FormatUint(uint64(char.BattleRankUps), 10),
+			battleRank,
+			strconv.FormatUint(uint64(char.Prestiges), 10),
+			renderAchievements(char.Achievements),
+		})
+	}
+	t.Render()
+}
+
+func renderOutfitRivalriesTable(
+	p *message.Printer,
+	sb *strings.Builder,
+	rows []stats_tracker.OutfitRivalryStats,
+) {
+	t := tablewriter.NewWriter(sb)
+	t.SetHeader([]string{
+		p.Sprintf("Outfit"),
+		p.Sprintf("Enemy outfit"),
+		p.Sprintf("Kills"),
+		p.Sprintf("Deaths"),
+		p.Sprintf("KD"),
+	})
+	t.SetBorder(false)
+	for _, row := range rows {
+		killDeathRatio := float64(row.Kills)
+		if row.Deaths > 0 {
+			killDeathRatio = float64(row.Kills) / float64(row.Deaths)
+		}
+		t.Append([]string{
+			row.Outfit.Tag,
+			row.EnemyOutfit.Tag,
+			strconv.FormatUint(uint64(row.Kills), 10),
+			strconv.FormatUint(uint64(row.Deaths), 10),
+			strconv.FormatFloat(killDeathRatio, 'f', 2, 64),
+		})
+	}
+	t.Render()
+}
+
+type characterZoneStats struct {
+	character ps2.Character
+	stats_tracker.ZoneStats
+}
+
+func renderZonesStatsTable(
+	p *message.Printer,
+	sb *strings.Builder,
+	zones []stats_tracker.ZoneStats,
+) {
+	t := tablewriter.NewWriter(sb)
+	t.SetHeader([]string{
+		p.Sprintf("Continent"),
+		p.Sprintf("Duration"),
+		p.Sprintf("Kills"),
+		p.Sprintf("Deaths"),
+		p.Sprintf("KD"),
+	})
+	t.SetBorder(false)
+	for _, z := range zones {
+		t.Append(zoneStatsCells(p, z))
+	}
+	t.Render()
+}
+
+func renderCharactersZoneStatsTable(
+	p *message.Printer,
+	sb *strings.Builder,
+	rows []characterZoneStats,
+) {
+	t := tablewriter.NewWriter(sb)
+	t.SetHeader([]string{
+		p.Sprintf("Outfit"),
+		p.Sprintf("Character"),
+		p.Sprintf("Continent"),
+		p.Sprintf("Duration"),
+		p.Sprintf("Kills"),
+		p.Sprintf("Deaths"),
+		p.Sprintf("KD"),
+	})
+	t.SetBorder(false)
+	for _, row := range rows {
+		t.Append(append(
+			[]string{row.character.OutfitTag, row.character.Name},
+			zoneStatsCells(p, row.ZoneStats)...,
+		))
+	}
+	t.Render()
+}
+
+func zoneStatsCells(p *message.Printer, z stats_tracker.ZoneStats) []string {
+	killDeathRatio := float64(z.Kills)
+	if z.Deaths > 0 {
+		killDeathRatio = float64(z.Kills) / float64(z.Deaths)
+	}
+	return []string{
+		ps2.ZoneNameById(z.ZoneId),
+		renderDuration(p, z.Duration),
+		strconv.FormatUint(uint64(z.Kills), 10),
+		strconv.FormatUint(uint64(z.Deaths), 10),
+		strconv.FormatFloat(killDeathRatio, 'f', 2, 64),
+	}
+}
@@ -0,0 +1,324 @@
+package discord_messages
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_factions "github.com/x0k/ps2-spy/internal/ps2/factions"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
+)
+
+var exportLoadoutKeys = [ps2_loadout.LoadoutTypeCount]string{
+	ps2_loadout.Infiltrator:  "infiltrator",
+	ps2_loadout.LightAssault: "light_assault",
+	ps2_loadout.Medic:        "medic",
+	ps2_loadout.Engineer:     "engineer",
+	ps2_loadout.HeavyAssault: "heavy_assault",
+	ps2_loadout.MAX:          "max",
+}
+
+type exportedVehicle struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Destroyed uint   `json:"destroyed"`
+	Lost      uint   `json:"lost"`
+}
+
+type exportedWeapon struct {
+	Id             string `json:"id"`
+	Name           string `json:"name"`
+	Category       string `json:"category"`
+	Kills          uint   `json:"kills"`
+	HeadShotsKills uint   `json:"head_shots_kills"`
+}
+
+type exportedFacility struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Captures uint   `json:"captures"`
+	Defenses uint   `json:"defenses"`
+}
+
+type exportedZone struct {
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Kills   uint   `json:"kills"`
+	Deaths  uint   `json:"deaths"`
+	Seconds int64  `json:"seconds"`
+}
+
+type exportedAchievement struct {
+	Id    string `json:"id"`
+	Name  string `json:"name"`
+	Count uint   `json:"count"`
+}
+
+type exportedCharacter struct {
+	Platform               string                `json:"platform"`
+	Id                     string                `json:"id"`
+	Name                   string                `json:"name"`
+	OutfitTag              string                `json:"outfit_tag"`
+	Faction                string                `json:"faction"`
+	BodyKills              uint                  `json:"body_kills"`
+	HeadShotsKills         uint                  `json:"head_shots_kills"`
+	TeamKills              uint                  `json:"team_kills"`
+	Deaths                 uint                  `json:"deaths"`
+	DeathsByRestrictedArea uint                  `json:"deaths_by_restricted_area"`
+	Suicides               uint                  `json:"suicides"`
+	Revives                uint                  `json:"revives"`
+	Heals                  uint                  `json:"heals"`
+	Repairs                uint                  `json:"repairs"`
+	Resupplies             uint                  `json:"resupplies"`
+	ShieldRepairs          uint                  `json:"shield_repairs"`
+	SpotAssists            uint                  `json:"spot_assists"`
+	Score                  uint                  `json:"score"`
+	VehiclesDestroyed      uint                  `json:"vehicles_destroyed"`
+	VehiclesLost           uint                  `json:"vehicles_lost"`
+	Captures               uint                  `json:"captures"`
+	Defenses               uint                  `json:"defenses"`
+	ActiveSeconds          int64                 `json:"active_seconds"`
+	KPM                    float64               `json:"kpm"`
+	DPM                    float64               `json:"dpm"`
+	BattleRankUps          uint                  `json:"battle_rank_ups"`
+	BattleRank             uint                  `json:"battle_rank"`
+	Prestiges              uint                  `json:"prestiges"`
+	LoadoutsSeconds        map[string]int64      `json:"loadouts_seconds"`
+	Vehicles               []exportedVehicle     `json:"vehicles"`
+	Weapons                []exportedWeapon      `json:"weapons"`
+	Facilities             []exportedFacility    `json:"facilities"`
+	Zones                  []exportedZone        `json:"zones"`
+	Achievements           []exportedAchievement `json:"achievements"`
+}
+
+type exportedSession struct {
+	SessionId  int64               `json:"session_id"`
+	StartedAt  time.Time           `json:"started_at"`
+	StoppedAt  time.Time           `json:"stopped_at"`
+	Characters []exportedCharacter `json:"characters"`
+}
+
+func newExportedSession(
+	sessionId stats_tracker.SessionId,
+	startedAt time.Time,
+	stoppedAt time.Time,
+	platforms map[ps2_platforms.Platform]stats_tracker.PlatformStats,
+) exportedSession {
+	session := exportedSession{
+		SessionId:  int64(sessionId),
+		StartedAt:  startedAt,
+		StoppedAt:  stoppedAt,
+		Characters: make([]exportedCharacter, 0),
+	}
+	for _, platform := range ps2_platforms.Platforms {
+		stats, ok := platforms[platform]
+		if !ok {
+			continue
+		}
+		for _, c := range stats.Characters {
+			char := exportedCharacter{
+				Platform:               string(platform),
+				Id:                     string(c.Character.Id),
+				Name:                   c.Character.Name,
+				OutfitTag:              c.Character.OutfitTag,
+				Faction:                ps2_factions.FactionNameById(c.Character.FactionId),
+				BodyKills:              c.BodyKills,
+				HeadShotsKills:         c.HeadShotsKills,
+				TeamKills:              c.TeamKills,
+				Deaths:                 c.Deaths,
+				DeathsByRestrictedArea: c.DeathsByRestrictedArea,
+				Suicides:               c.Suicides,
+				Revives:                c.Revives,
+				Heals:                  c.Heals,
+				Repairs:                c.Repairs,
+				Resupplies:             c.Resupplies,
+				ShieldRepairs:          c.ShieldRepairs,
+				SpotAssists:            c.SpotAssists,
+				Score:                  c.Score,
+				VehiclesDestroyed:      c.VehiclesDestroyed,
+				VehiclesLost:           c.VehiclesLost,
+				Captures:               c.Captures,
+				Defenses:               c.Defenses,
+				ActiveSeconds:          int64(c.ActiveTime().Seconds()),
+				KPM:                    c.KPM(),
+				DPM:                    c.DPM(),
+				BattleRankUps:          c.BattleRankUps,
+				BattleRank:             c.BattleRank,
+				Prestiges:              c.Prestiges,
+				LoadoutsSeconds:        make(map[string]int64, len(exportLoadoutKeys)),
+				Vehicles:               make([]exportedVehicle, 0, len(c.Vehicles)),
+				Weapons:                make([]exportedWeapon, 0, len(c.Weapons)),
+				Facilities:             make([]exportedFacility, 0, len(c.Facilities)),
+				Zones:                  make([]exportedZone, 0, len(c.Zones)),
+				Achievements:           make([]exportedAchievement, 0, len(c.Achievements)),
+			}
+			for i, d := range c.LoadoutsDistribution {
+				char.LoadoutsSeconds[exportLoadoutKeys[i]] = int64(d.Seconds())
+			}
+			for _, v := range c.Vehicles {
+				char.Vehicles = append(char.Vehicles, exportedVehicle{
+					Id:        string(v.Vehicle.Id),
+					Name:      v.Vehicle.Name,
+					Destroyed: v.Destroyed,
+					Lost:      v.Lost,
+				})
+			}
+			for _, w := range c.Weapons {
+				char.Weapons = append(char.Weapons, exportedWeapon{
+					Id:             string(w.Weapon.Id),
+					Name:           w.Weapon.Name,
+					Category:       w.Weapon.Category,
+					Kills:          w.Kills,
+					HeadShotsKills: w.HeadShotsKills,
+				})
+			}
+			for _, f := range c.Facilities {
+				char.Facilities = append(char.Facilities, exportedFacility{
+					Id:       string(f.Facility.Id),
+					Name:     f.Facility.Name,
+					Captures: f.Captures,
+					Defenses: f.Defenses,
+				})
+			}
+			for _, z := range c.Zones {
+				char.Zones = append(char.Zones, exportedZone{
+					Id:      string(z.ZoneId),
+					Name:    ps2.ZoneNameById(z.ZoneId),
+					Kills:   z.Kills,
+					Deaths:  z.Deaths,
+					Seconds: int64(z.Duration.Seconds()),
+				})
+			}
+			for _, a := range c.Achievements {
+				char.Achievements = append(char.Achievements, exportedAchievement{
+					Id:    string(a.Achievement.Id),
+					Name:  a.Achievement.Name,
+					Count: a.Count,
+				})
+			}
+			session.Characters = append(session.Characters, char)
+		}
+	}
+	return session
+}
+
+func (s exportedSession) csv() ([]byte, error) {
+	buf := bytes.Buffer{}
+	w := csv.NewWriter(&buf)
+	header := []string{
+		"platform",
+		"character_id",
+		"character_name",
+		"outfit_tag",
+		"faction",
+		"body_kills",
+		"head_shots_kills",
+		"team_kills",
+		"deaths",
+		"deaths_by_restricted_area",
+		"suicides",
+		"revives",
+		"heals",
+		"repairs",
+		"resupplies",
+		"shield_repairs",
+		"spot_assists",
+		"score",
+		"vehicles_destroyed",
+		"vehicles_lost",
+		"captures",
+		"defenses",
+		"active_seconds",
+		"kpm",
+		"dpm",
+		"battle_rank_ups",
+		"battle_rank",
+		"prestiges",
+	}
+	for _, key := range exportLoadoutKeys {
+		header = append(header, key+"_seconds")
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	u := func(v uint) string {
+		return strconv.FormatUint(uint64(v), 10)
+	}
+	for _, c := range s.Characters {
+		record := []string{
+			c.Platform,
+			c.Id,
+			c.Name,
+			c.OutfitTag,
+			c.Faction,
+			u(c.BodyKills),
+			u(c.HeadShotsKills),
+			u(c.TeamKills),
+			u(c.Deaths),
+			u(c.DeathsByRestrictedArea),
+			u(c.Suicides),
+			u(c.Revives),
+			u(c.Heals),
+			u(c.Repairs),
+			u(c.Resupplies),
+			u(c.ShieldRepairs),
+			u(c.SpotAssists),
+			u(c.Score),
+			u(c.VehiclesDestroyed),
+			u(c.VehiclesLost),
+			u(c.Captures),
+			u(c.Defenses),
+			strconv.FormatInt(c.ActiveSeconds, 10),
+			strconv.FormatFloat(c.KPM, 'f', 2, 64),
+			strconv.FormatFloat(c.DPM, 'f', 2, 64),
+			u(c.BattleRankUps),
+			u(c.BattleRank),
+			u(c.Prestiges),
+		}
+		for _, key := range exportLoadoutKeys {
+			record = append(record, strconv.FormatInt(c.LoadoutsSeconds[key], 10))
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func channelTrackerExportFiles(
+	sessionId stats_tracker.SessionId,
+	startedAt time.Time,
+	stoppedAt time.Time,
+	platforms map[ps2_platforms.Platform]stats_tracker.PlatformStats,
+) ([]*discordgo.File, error) {
+	session := newExportedSession(sessionId, startedAt, stoppedAt, platforms)
+	csvData, err := session.csv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode csv: %w", err)
+	}
+	jsonData, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode json: %w", err)
+	}
+	name := fmt.Sprintf("stats_%d_%s", sessionId, startedAt.UTC().Format("20060102_1504"))
+	return []*discordgo.File{
+		{
+			Name:        name + ".csv",
+			ContentType: "text/csv",
+			Reader:      bytes.NewReader(csvData),
+		},
+		{
+			Name:        name + ".json",
+			ContentType: "application/json",
+			Reader:      bytes.NewReader(jsonData),
+		},
+	}, nil
+}
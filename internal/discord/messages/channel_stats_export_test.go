@@ -0,0 +1,108 @@
+package discord_messages
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
+)
+
+func TestChannelTrackerExportFiles(t *testing.T) {
+	startedAt := time.Date(2024, 1, 2, 20, 30, 0, 0, time.UTC)
+	var distribution [ps2_loadout.LoadoutTypeCount]time.Duration
+	distribution[ps2_loadout.Medic] = 10 * time.Minute
+	platforms := map[ps2_platforms.Platform]stats_tracker.PlatformStats{
+		ps2_platforms.PS4_US: {
+			Characters: []stats_tracker.CharacterStats{
+				{Character: ps2.Character{Id: "2", Name: "Bravo, Jr."}, BodyKills: 1},
+			},
+		},
+		ps2_platforms.PC: {
+			Characters: []stats_tracker.CharacterStats{
+				{
+					Character:            ps2.Character{Id: "1", Name: "Alpha", OutfitTag: "TAG"},
+					BodyKills:            15,
+					HeadShotsKills:       5,
+					Deaths:               4,
+					Revives:              3,
+					LoadoutsDistribution: distribution,
+					Weapons: []stats_tracker.WeaponStats{
+						{Weapon: ps2.Item{Id: "80", Name: "Gauss Rifle"}, Kills: 20, HeadShotsKills: 5},
+					},
+				},
+			},
+		},
+	}
+	files, err := channelTrackerExportFiles(7, startedAt, startedAt.Add(time.Hour), platforms)
+	if err != nil {
+		t.Fatalf("channelTrackerExportFiles() error = %v", err)
+	}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, f.Name)
+	}
+	if want := []string{"stats_7_20240102_2030.csv", "stats_7_20240102_2030.json"}; !slices.Equal(names, want) {
+		t.Fatalf("files = %v, want %v", names, want)
+	}
+
+	records, err := csv.NewReader(files[0].Reader).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read csv: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected a header and 2 rows, got %d records", len(records))
+	}
+	column := func(record []string, name string) string {
+		i := slices.Index(records[0], name)
+		if i < 0 {
+			t.Fatalf("column %q is not found", name)
+		}
+		return record[i]
+	}
+	// Platforms are exported in a stable order
+	alpha, bravo := records[1], records[2]
+	for name, want := range map[string]string{
+		"platform":         "pc",
+		"character_name":   "Alpha",
+		"body_kills":       "15",
+		"head_shots_kills": "5",
+		"revives":          "3",
+		"active_seconds":   "600",
+		"kpm":              "2.00",
+		"dpm":              "0.40",
+		"medic_seconds":    "600",
+	} {
+		if got := column(alpha, name); got != want {
+			t.Errorf("csv %s = %q, want %q", name, got, want)
+		}
+	}
+	if got := column(bravo, "character_name"); got != "Bravo, Jr." {
+		t.Errorf("expected the quoted name to be preserved, got %q", got)
+	}
+
+	data, err := io.ReadAll(files[1].Reader)
+	if err != nil {
+		t.Fatalf("failed to read json: %v", err)
+	}
+	var session exportedSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		t.Fatalf("failed to decode json: %v", err)
+	}
+	if session.SessionId != 7 || !session.StartedAt.Equal(startedAt) || len(session.Characters) != 2 {
+		t.Fatalf("unexpected session %+v", session)
+	}
+	weapons := session.Characters[0].Weapons
+	if len(weapons) != 1 || weapons[0].Name != "Gauss Rifle" || weapons[0].Kills != 20 {
+		t.Errorf("unexpected weapons %+v", weapons)
+	}
+	if seconds := session.Characters[0].LoadoutsSeconds["medic"]; seconds != 600 {
+		t.Errorf("medic seconds = %d, want 600", seconds)
+	}
+}
@@ -0,0 +1,28 @@
+package discord_messages
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/x0k/ps2-spy/internal/ps2"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func TestRenderCharactersStatsTableScore(t *testing.T) {
+	formula, err := stats_tracker.ParseScoreFormula("kills / 3")
+	if err != nil {
+		t.Fatalf("ParseScoreFormula() error = %v", err)
+	}
+	sb := strings.Builder{}
+	renderCharactersStatsTable(message.NewPrinter(language.English), &sb, []stats_tracker.CharacterStats{
+		{
+			Character: ps2.Character{Name: "Alpha"},
+			BodyKills: 1,
+		},
+	}, 0, formula)
+	if content := sb.String(); !strings.Contains(content, " 0.33 ") {
+		t.Errorf("expected the score with 2 decimals, got %q", content)
+	}
+}
@@ -0,0 +1,45 @@
+package discord_messages
+
+import (
+	"strings"
+
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_factions "github.com/x0k/ps2-spy/internal/ps2/factions"
+	"golang.org/x/text/message"
+)
+
+func renderCharacterChanges(
+	p *message.Printer,
+	sb *strings.Builder,
+	old ps2.Character,
+	new ps2.Character,
+) {
+	if old.Name != new.Name {
+		sb.WriteString(p.Sprintf("\n- Renamed to %s", new.Name))
+	}
+	if old.FactionId != new.FactionId {
+		sb.WriteString(p.Sprintf(
+			"\n- Faction: %s → %s",
+			ps2_factions.FactionNameById(old.FactionId),
+			ps2_factions.FactionNameById(new.FactionId),
+		))
+	}
+	if old.WorldId != new.WorldId {
+		sb.WriteString(p.Sprintf(
+			"\n- Server transfer: %s → %s",
+			ps2.WorldNameById(old.WorldId),
+			ps2.WorldNameById(new.WorldId),
+		))
+	}
+	if old.OutfitId == new.OutfitId {
+		return
+	}
+	switch {
+	case new.OutfitId == "":
+		sb.WriteString(p.Sprintf("\n- Left the outfit [%s]", old.OutfitTag))
+	case old.OutfitId == "":
+		sb.WriteString(p.Sprintf("\n- Joined the outfit [%s]", new.OutfitTag))
+	default:
+		sb.WriteString(p.Sprintf("\n- Outfit: [%s] → [%s]", old.OutfitTag, new.OutfitTag))
+	}
+}
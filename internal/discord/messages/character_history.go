@@ -0,0 +1,45 @@
+package discord_messages
+
+import (
+	"strings"
+
+	"github.com/x0k/ps2-spy/internal/characters_history"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	"golang.org/x/text/message"
+)
+
+func renderCharacterHistory(
+	p *message.Printer,
+	name string,
+	history characters_history.History,
+) string {
+	sb := strings.Builder{}
+	sb.WriteString(p.Sprintf(
+		"**%s** playtime: %s in the last 7 days, %s in the last 30 days",
+		name,
+		renderDuration(p, history.Last7Days),
+		renderDuration(p, history.Last30Days),
+	))
+	sb.WriteString(p.Sprintf("\nRecent sessions:"))
+	if len(history.Sessions) == 0 {
+		sb.WriteString(p.Sprintf("\n- No sessions were found"))
+		return sb.String()
+	}
+	for _, s := range history.Sessions {
+		if s.IsOngoing() {
+			sb.WriteString(p.Sprintf(
+				"\n- %s, %s, online now",
+				renderDateTime(s.StartedAt),
+				ps2.WorldNameById(s.WorldId),
+			))
+			continue
+		}
+		sb.WriteString(p.Sprintf(
+			"\n- %s, %s, %s",
+			renderDateTime(s.StartedAt),
+			ps2.WorldNameById(s.WorldId),
+			renderDuration(p, s.EndedAt.Sub(s.StartedAt)),
+		))
+	}
+	return sb.String()
+}
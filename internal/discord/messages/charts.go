@@ -0,0 +1,175 @@
+package discord_messages
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/x0k/ps2-spy/internal/lib/chart"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
+	"golang.org/x/text/message"
+)
+
+const chartMaxCharacters = 15
+
+var loadoutColors = [ps2_loadout.LoadoutTypeCount]color.RGBA{
+	ps2_loadout.Infiltrator:  chart.Purple,
+	ps2_loadout.LightAssault: chart.Blue,
+	ps2_loadout.Medic:        chart.Green,
+	ps2_loadout.Engineer:     chart.Yellow,
+	ps2_loadout.HeavyAssault: chart.Red,
+	ps2_loadout.MAX:          chart.Gray,
+}
+
+func pngFile(name string, img image.Image) (*discordgo.File, error) {
+	data, err := chart.EncodePNG(img)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return &discordgo.File{
+		Name:        name,
+		ContentType: "image/png",
+		Reader:      bytes.NewReader(data),
+	}, nil
+}
+
+func populationChartFiles(title string, history []ps2.PopulationSample) ([]*discordgo.File, error) {
+	if len(history) == 0 {
+		return nil, nil
+	}
+	series := []chart.Series{
+		{Name: "VS", Color: chart.Purple},
+		{Name: "NC", Color: chart.Blue},
+		{Name: "TR", Color: chart.Red},
+		{Name: "NS", Color: chart.Gray},
+	}
+	for i := range series {
+		series[i].Points = make([]chart.Point, 0, len(history))
+	}
+	for _, sample := range history {
+		at := sample.At.UTC()
+		series[0].Points = append(series[0].Points, chart.Point{Time: at, Value: float64(sample.VS)})
+		series[1].Points = append(series[1].Points, chart.Point{Time: at, Value: float64(sample.NC)})
+		series[2].Points = append(series[2].Points, chart.Point{Time: at, Value: float64(sample.TR)})
+		series[3].Points = append(series[3].Points, chart.Point{Time: at, Value: float64(sample.NS + sample.Other)})
+	}
+	file, err := pngFile("population.png", chart.Lines(title, series))
+	if err != nil {
+		return nil, err
+	}
+	return []*discordgo.File{file}, nil
+}
+
+func chartCharacterLabel(c stats_tracker.CharacterStats) string {
+	if c.Character.OutfitTag == "" {
+		return c.Character.Name
+	}
+	return fmt.Sprintf("[%s] %s", c.Character.OutfitTag, c.Character.Name)
+}
+
+func renderLoadoutsChart(
+	p *message.Printer,
+	platform ps2_platforms.Platform,
+	characters []stats_tracker.CharacterStats,
+) image.Image {
+	legend := make([]chart.Legend, 0, ps2_loadout.LoadoutTypeCount)
+	for i, c := range loadoutColors {
+		legend = append(legend, chart.Legend{
+			Name:  renderLoadoutType(p, ps2_loadout.LoadoutType(i)),
+			Color: c,
+		})
+	}
+	bars := make([]chart.StackedBar, 0, chartMaxCharacters)
+	for _, c := range characters {
+		if len(bars) == chartMaxCharacters {
+			break
+		}
+		values := make([]float64, 0, len(c.LoadoutsDistribution))
+		total := 0.0
+		for _, d := range c.LoadoutsDistribution {
+			values = append(values, d.Seconds())
+			total += d.Seconds()
+		}
+		if total == 0 {
+			continue
+		}
+		bars = append(bars, chart.StackedBar{
+			Label:  chartCharacterLabel(c),
+			Values: values,
+		})
+	}
+	return chart.StackedBars(
+		p.Sprintf("Platform: %s, loadouts distribution", strings.ToUpper(string(platform))),
+		legend,
+		bars,
+	)
+}
+
+func renderKillDeathChart(
+	p *message.Printer,
+	platform ps2_platforms.Platform,
+	characters []stats_tracker.CharacterStats,
+) image.Image {
+	bars := make([]chart.Bar, 0, chartMaxCharacters)
+	for _, c := range characters {
+		if len(bars) == chartMaxCharacters {
+			break
+		}
+		kills := c.BodyKills + c.HeadShotsKills
+		deaths := c.Deaths + c.Suicides
+		if kills+deaths == 0 {
+			continue
+		}
+		ratio := float64(kills)
+		if deaths > 0 {
+			ratio = float64(kills) / float64(deaths)
+		}
+		barColor := chart.Red
+		if ratio >= 1 {
+			barColor = chart.Green
+		}
+		bars = append(bars, chart.Bar{
+			Label:   chartCharacterLabel(c),
+			Value:   ratio,
+			Color:   barColor,
+			Caption: strconv.FormatFloat(ratio, 'f', 2, 64),
+		})
+	}
+	return chart.Bars(
+		p.Sprintf("Platform: %s, KD", strings.ToUpper(string(platform))),
+		bars,
+	)
+}
+
+// Characters are rendered in the given order
+func channelTrackerChartFiles(
+	p *message.Printer,
+	sessionId stats_tracker.SessionId,
+	platforms map[ps2_platforms.Platform]stats_tracker.PlatformStats,
+) ([]*discordgo.File, error) {
+	files := make([]*discordgo.File, 0, 2*len(platforms))
+	for _, platform := range ps2_platforms.Platforms {
+		stats, ok := platforms[platform]
+		if !ok || len(stats.Characters) == 0 {
+			continue
+		}
+		name := fmt.Sprintf("session_%d_%s", sessionId, platform)
+		loadouts, err := pngFile(name+"_loadouts.png", renderLoadoutsChart(p, platform, stats.Characters))
+		if err != nil {
+			return nil, err
+		}
+		kd, err := pngFile(name+"_kd.png", renderKillDeathChart(p, platform, stats.Characters))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, loadouts, kd)
+	}
+	return files, nil
+}
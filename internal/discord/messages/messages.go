@@ -2,13 +2,17 @@ package discord_messages
 
 import (
 	"errors"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/x0k/ps2-spy/internal/characters_history"
 	"github.com/x0k/ps2-spy/internal/discord"
 	"github.com/x0k/ps2-spy/internal/lib/diff"
+	"github.com/x0k/ps2-spy/internal/lib/expr"
 	"github.com/x0k/ps2-spy/internal/meta"
+	"github.com/x0k/ps2-spy/internal/outfit_activity"
 	"github.com/x0k/ps2-spy/internal/ps2"
 	ps2_factions "github.com/x0k/ps2-spy/internal/ps2/factions"
 	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
@@ -97,6 +101,19 @@ func (m *Messages) CharactersLoadError(characterIds []ps2.CharacterId, platform
 	}
 }
 
+func (m *Messages) CharacterUpdated(old ps2.Character, new ps2.Character) discord.Message {
+	return func(p *message.Printer) (string, *discord.Error) {
+		sb := strings.Builder{}
+		if old.OutfitTag != "" {
+			sb.WriteString(p.Sprintf("[%s] %s has been changed:", old.OutfitTag, old.Name))
+		} else {
+			sb.WriteString(p.Sprintf("%s has been changed:", old.Name))
+		}
+		renderCharacterChanges(p, &sb, old, new)
+		return sb.String(), nil
+	}
+}
+
 func (m *Messages) OutfitMembersUpdate(outfit ps2.Outfit, change diff.Diff[ps2.Character]) discord.Message {
 	return func(p *message.Printer) (string, *discord.Error) {
 		builder := strings.Builder{}
@@ -152,34 +169,508 @@ func (m *Messages) ChannelTrackerStopped(
 	startedAt time.Time,
 	stoppedAt time.Time,
 	stats stats_tracker.PlatformStats,
+	formula stats_tracker.ScoreFormula,
+	order stats_tracker.SortOrder,
 ) discord.ChunkableMessage {
 	sb := strings.Builder{}
+	characters := stats_tracker.RankCharacters(stats.Characters, formula, order)
 	return discord.NewChunkableMessage(
 		len(stats.Characters),
 		func(p *message.Printer, start, count int) (string, *discord.Error) {
 			sb.Reset()
 			if start == 0 {
 				sb.WriteString(p.Sprintf(
-					"Platform: %s, started at: %s, stopped: %s, duration: %s\n```",
+					"Platform: %s, started at: %s, stopped: %s, duration: %s",
 					strings.ToUpper(string(platform)),
 					renderTime(startedAt),
 					renderRelativeTime(stoppedAt),
 					renderDuration(p, stoppedAt.Sub(startedAt)),
 				))
+				if order != stats_tracker.SortByKills {
+					sb.WriteString(p.Sprintf(", sorted by: %s", renderSortOrder(p, order)))
+				}
+				sb.WriteString("\n```")
 			} else {
 				sb.WriteString("```")
 			}
-			chars := stats.Characters[start : start+count]
+			chars := characters[start : start+count]
 			if len(chars) == 0 {
 				sb.WriteString(p.Sprintf("No data collected"))
 			} else {
-				renderCharactersStatsTable(p, &sb, chars, start)
+				renderCharactersStatsTable(p, &sb, chars, start, formula)
+			}
+			sb.WriteString("```")
+			return sb.String(), nil
+		})
+}
+
+func (m *Messages) ChannelTrackerLeaderboard(
+	startedAt time.Time,
+	updatedAt time.Time,
+	platforms map[ps2_platforms.Platform]stats_tracker.PlatformStats,
+	limit int,
+	formula stats_tracker.ScoreFormula,
+	order stats_tracker.SortOrder,
+) discord.Message {
+	return func(p *message.Printer) (string, *discord.Error) {
+		sb := strings.Builder{}
+		if updatedAt.IsZero() {
+			sb.WriteString(p.Sprintf(
+				"Leaderboard, started at: %s",
+				renderTime(startedAt),
+			))
+		} else {
+			sb.WriteString(p.Sprintf(
+				"Leaderboard, started at: %s, updated: %s, duration: %s",
+				renderTime(startedAt),
+				renderRelativeTime(updatedAt),
+				renderDuration(p, updatedAt.Sub(startedAt)),
+			))
+		}
+		if !formula.IsDefault() {
+			sb.WriteString(p.Sprintf(", score: `%s`", formula))
+		}
+		if order != stats_tracker.SortByKills {
+			sb.WriteString(p.Sprintf(", sorted by: %s", renderSortOrder(p, order)))
+		}
+		hasData := false
+		for _, platform := range ps2_platforms.Platforms {
+			stats, ok := platforms[platform]
+			if !ok || len(stats.Characters) == 0 {
+				continue
+			}
+			hasData = true
+			sb.WriteString(p.Sprintf(
+				"\nPlatform: %s\n```",
+				strings.ToUpper(string(platform)),
+			))
+			chars := stats_tracker.RankCharacters(stats.Characters, formula, order)
+			renderCharactersStatsTable(p, &sb, chars[:min(len(chars), limit)], 0, formula)
+			sb.WriteString("```")
+		}
+		if !hasData {
+			sb.WriteString("\n")
+			sb.WriteString(p.Sprintf("No data collected"))
+		}
+		return sb.String(), nil
+	}
+}
+
+func (m *Messages) ChannelTrackerSupportStats(
+	platform ps2_platforms.Platform,
+	stats stats_tracker.PlatformStats,
+) discord.ChunkableMessage {
+	chars := make([]stats_tracker.CharacterStats, 0, len(stats.Characters))
+	for _, char := range stats.Characters {
+		if char.HasSupportActions() {
+			chars = append(chars, char)
+		}
+	}
+	slices.SortFunc(chars, func(a, b stats_tracker.CharacterStats) int {
+		return int(b.Score) - int(a.Score)
+	})
+	sb := strings.Builder{}
+	return discord.NewChunkableMessage(
+		len(chars),
+		func(p *message.Printer, start, count int) (string, *discord.Error) {
+			sb.Reset()
+			if start == 0 {
+				sb.WriteString(p.Sprintf(
+					"Platform: %s, support\n```",
+					strings.ToUpper(string(platform)),
+				))
+			} else {
+				sb.WriteString("```")
+			}
+			renderCharactersSupportStatsTable(p, &sb, chars[start:start+count], start)
+			sb.WriteString("```")
+			return sb.String(), nil
+		})
+}
+
+func (m *Messages) ChannelTrackerVehicleStats(
+	platform ps2_platforms.Platform,
+	stats stats_tracker.PlatformStats,
+) discord.ChunkableMessage {
+	rows := make([]characterVehicleStats, 0, len(stats.Characters))
+	for _, char := range stats.Characters {
+		for _, vehicle := range char.Vehicles {
+			rows = append(rows, characterVehicleStats{
+				character:    char.Character,
+				VehicleStats: vehicle,
+			})
+		}
+	}
+	sb := strings.Builder{}
+	return discord.NewChunkableMessage(
+		len(rows),
+		func(p *message.Printer, start, count int) (string, *discord.Error) {
+			sb.Reset()
+			if start == 0 {
+				sb.WriteString(p.Sprintf(
+					"Platform: %s, vehicles\n```",
+					strings.ToUpper(string(platform)),
+				))
+			} else {
+				sb.WriteString("```")
+			}
+			renderCharactersVehicleStatsTable(p, &sb, rows[start:start+count])
+			sb.WriteString("```")
+			return sb.String(), nil
+		})
+}
+
+func (m *Messages) ChannelTrackerTopWeapons(
+	platform ps2_platforms.Platform,
+	stats stats_tracker.PlatformStats,
+) discord.ChunkableMessage {
+	rows := make([]characterWeaponStats, 0, len(stats.Characters))
+	for _, char := range stats.Characters {
+		for _, weapon := range char.Weapons[:min(len(char.Weapons), topWeaponsCount)] {
+			rows = append(rows, characterWeaponStats{
+				character:   char.Character,
+				WeaponStats: weapon,
+			})
+		}
+	}
+	sb := strings.Builder{}
+	return discord.NewChunkableMessage(
+		len(rows),
+		func(p *message.Printer, start, count int) (string, *discord.Error) {
+			sb.Reset()
+			if start == 0 {
+				sb.WriteString(p.Sprintf(
+					"Platform: %s, top weapons\n```",
+					strings.ToUpper(string(platform)),
+				))
+			} else {
+				sb.WriteString("```")
+			}
+			renderCharactersWeaponStatsTable(p, &sb, rows[start:start+count])
+			sb.WriteString("```")
+			return sb.String(), nil
+		})
+}
+
+func (m *Messages) ChannelTrackerRivals(
+	platform ps2_platforms.Platform,
+	stats stats_tracker.PlatformStats,
+) discord.ChunkableMessage {
+	chars := make([]stats_tracker.CharacterStats, 0, len(stats.Characters))
+	for _, char := range stats.Characters {
+		if char.HasRivals() {
+			chars = append(chars, char)
+		}
+	}
+	sb := strings.Builder{}
+	return discord.NewChunkableMessage(
+		len(chars),
+		func(p *message.Printer, start, count int) (string, *discord.Error) {
+			sb.Reset()
+			if start == 0 {
+				sb.WriteString(p.Sprintf(
+					"Platform: %s, top victims and nemeses\n```",
+					strings.ToUpper(string(platform)),
+				))
+			} else {
+				sb.WriteString("```")
+			}
+			renderCharactersRivalsTable(p, &sb, chars[start:start+count])
+			sb.WriteString("```")
+			return sb.String(), nil
+		})
+}
+
+func (m *Messages) ChannelTrackerHighlights(
+	platform ps2_platforms.Platform,
+	stats stats_tracker.PlatformStats,
+) discord.ChunkableMessage {
+	chars := make([]stats_tracker.CharacterStats, 0, len(stats.Characters))
+	for _, char := range stats.Characters {
+		if char.HasHighlights() {
+			chars = append(chars, char)
+		}
+	}
+	slices.SortFunc(chars, func(a, b stats_tracker.CharacterStats) int {
+		return int(b.Prestiges+b.BattleRankUps) - int(a.Prestiges+a.BattleRankUps)
+	})
+	sb := strings.Builder{}
+	return discord.NewChunkableMessage(
+		len(chars),
+		func(p *message.Printer, start, count int) (string, *discord.Error) {
+			sb.Reset()
+			if start == 0 {
+				sb.WriteString(p.Sprintf(
+					"Platform: %s, highlights\n```",
+					strings.ToUpper(string(platform)),
+				))
+			} else {
+				sb.WriteString("```")
+			}
+			renderCharactersHighlightsTable(p, &sb, chars[start:start+count])
+			sb.WriteString("```")
+			return sb.String(), nil
+		})
+}
+
+func (m *Messages) ChannelTrackerOutfitRivalries(
+	platform ps2_platforms.Platform,
+	stats stats_tracker.PlatformStats,
+) discord.ChunkableMessage {
+	sb := strings.Builder{}
+	return discord.NewChunkableMessage(
+		len(stats.Outfits),
+		func(p *message.Printer, start, count int) (string, *discord.Error) {
+			sb.Reset()
+			if start == 0 {
+				sb.WriteString(p.Sprintf(
+					"Platform: %s, outfits versus enemy outfits\n```",
+					strings.ToUpper(string(platform)),
+				))
+			} else {
+				sb.WriteString("```")
+			}
+			renderOutfitRivalriesTable(p, &sb, stats.Outfits[start:start+count])
+			sb.WriteString("```")
+			return sb.String(), nil
+		})
+}
+
+func (m *Messages) ChannelTrackerZones(
+	platform ps2_platforms.Platform,
+	stats stats_tracker.PlatformStats,
+) discord.ChunkableMessage {
+	sb := strings.Builder{}
+	return discord.NewChunkableMessage(
+		len(stats.Zones),
+		func(p *message.Printer, start, count int) (string, *discord.Error) {
+			sb.Reset()
+			if start == 0 {
+				sb.WriteString(p.Sprintf(
+					"Platform: %s, continents\n```",
+					strings.ToUpper(string(platform)),
+				))
+			} else {
+				sb.WriteString("```")
+			}
+			renderZonesStatsTable(p, &sb, stats.Zones[start:start+count])
+			sb.WriteString("```")
+			return sb.String(), nil
+		})
+}
+
+func (m *Messages) ChannelTrackerCharactersZones(
+	platform ps2_platforms.Platform,
+	stats stats_tracker.PlatformStats,
+) discord.ChunkableMessage {
+	rows := make([]characterZoneStats, 0, len(stats.Characters))
+	for _, char := range stats.Characters {
+		for _, zone := range char.Zones {
+			rows = append(rows, characterZoneStats{
+				character: char.Character,
+				ZoneStats: zone,
+			})
+		}
+	}
+	sb := strings.Builder{}
+	return discord.NewChunkableMessage(
+		len(rows),
+		func(p *message.Printer, start, count int) (string, *discord.Error) {
+			sb.Reset()
+			if start == 0 {
+				sb.WriteString(p.Sprintf(
+					"Platform: %s, continents by character\n```",
+					strings.ToUpper(string(platform)),
+				))
+			} else {
+				sb.WriteString("```")
+			}
+			renderCharactersZoneStatsTable(p, &sb, rows[start:start+count])
+			sb.WriteString("```")
+			return sb.String(), nil
+		})
+}
+
+func (m *Messages) ChannelTrackerFacilities(
+	platform ps2_platforms.Platform,
+	stats stats_tracker.PlatformStats,
+) discord.ChunkableMessage {
+	sb := strings.Builder{}
+	return discord.NewChunkableMessage(
+		len(stats.Facilities),
+		func(p *message.Printer, start, count int) (string, *discord.Error) {
+			sb.Reset()
+			if start == 0 {
+				sb.WriteString(p.Sprintf(
+					"Platform: %s, facilities captured and defended by the group\n```",
+					strings.ToUpper(string(platform)),
+				))
+			} else {
+				sb.WriteString("```")
+			}
+			renderFacilitiesStatsTable(p, &sb, stats.Facilities[start:start+count])
+			sb.WriteString("```")
+			return sb.String(), nil
+		})
+}
+
+func (m *Messages) ChannelTrackerCharactersFacilities(
+	platform ps2_platforms.Platform,
+	stats stats_tracker.PlatformStats,
+) discord.ChunkableMessage {
+	rows := make([]characterFacilityStats, 0, len(stats.Characters))
+	for _, char := range stats.Characters {
+		for _, facility := range char.Facilities {
+			rows = append(rows, characterFacilityStats{
+				character:     char.Character,
+				FacilityStats: facility,
+			})
+		}
+	}
+	sb := strings.Builder{}
+	return discord.NewChunkableMessage(
+		len(rows),
+		func(p *message.Printer, start, count int) (string, *discord.Error) {
+			sb.Reset()
+			if start == 0 {
+				sb.WriteString(p.Sprintf(
+					"Platform: %s, captures and defenses\n```",
+					strings.ToUpper(string(platform)),
+				))
+			} else {
+				sb.WriteString("```")
+			}
+			renderCharactersFacilityStatsTable(p, &sb, rows[start:start+count])
+			sb.WriteString("```")
+			return sb.String(), nil
+		})
+}
+
+func (m *Messages) ChannelTrackerOutfitsComparison(
+	platform ps2_platforms.Platform,
+	previousStartedAt time.Time,
+	stats stats_tracker.PlatformStats,
+	previous stats_tracker.PreviousPlatformStats,
+) discord.ChunkableMessage {
+	rows := make([]totalsComparison, 0)
+	indexes := make(map[ps2.OutfitId]int)
+	for _, char := range stats.Characters {
+		if char.Character.OutfitId == "" {
+			continue
+		}
+		i, ok := indexes[char.Character.OutfitId]
+		if !ok {
+			prev, hasPrev := previous.Outfits[char.Character.OutfitId]
+			i = len(rows)
+			indexes[char.Character.OutfitId] = i
+			rows = append(rows, totalsComparison{
+				outfitTag: char.Character.OutfitTag,
+				previous:  prev,
+				isNew:     !hasPrev,
+			})
+		}
+		rows[i].current = rows[i].current.Add(char.Totals())
+	}
+	slices.SortFunc(rows, func(a, b totalsComparison) int {
+		return int(b.current.Kills) - int(a.current.Kills)
+	})
+	sb := strings.Builder{}
+	return discord.NewChunkableMessage(
+		len(rows),
+		func(p *message.Printer, start, count int) (string, *discord.Error) {
+			sb.Reset()
+			if start == 0 {
+				sb.WriteString(p.Sprintf(
+					"Platform: %s, outfits compared to the session started at %s\n```",
+					strings.ToUpper(string(platform)),
+					renderTime(previousStartedAt),
+				))
+			} else {
+				sb.WriteString("```")
 			}
+			renderTotalsComparisonTable(p, &sb, rows[start:start+count], false)
 			sb.WriteString("```")
 			return sb.String(), nil
 		})
 }
 
+func (m *Messages) ChannelTrackerCharactersComparison(
+	platform ps2_platforms.Platform,
+	previousStartedAt time.Time,
+	stats stats_tracker.PlatformStats,
+	previous stats_tracker.PreviousPlatformStats,
+) discord.ChunkableMessage {
+	rows := make([]totalsComparison, 0, len(stats.Characters))
+	for _, char := range stats.Characters {
+		prev, hasPrev := previous.Characters[char.Character.Id]
+		rows = append(rows, totalsComparison{
+			outfitTag: char.Character.OutfitTag,
+			name:      char.Character.Name,
+			current:   char.Totals(),
+			previous:  prev,
+			isNew:     !hasPrev,
+		})
+	}
+	sb := strings.Builder{}
+	return discord.NewChunkableMessage(
+		len(rows),
+		func(p *message.Printer, start, count int) (string, *discord.Error) {
+			sb.Reset()
+			if start == 0 {
+				sb.WriteString(p.Sprintf(
+					"Platform: %s, characters compared to the session started at %s\n```",
+					strings.ToUpper(string(platform)),
+					renderTime(previousStartedAt),
+				))
+			} else {
+				sb.WriteString("```")
+			}
+			renderTotalsComparisonTable(p, &sb, rows[start:start+count], true)
+			sb.WriteString("```")
+			return sb.String(), nil
+		})
+}
+
+func (m *Messages) ChannelTrackerExport(
+	sessionId stats_tracker.SessionId,
+	startedAt time.Time,
+	stoppedAt time.Time,
+	platforms map[ps2_platforms.Platform]stats_tracker.PlatformStats,
+) discord.MessageSend {
+	return func(p *message.Printer) (*discordgo.MessageSend, *discord.Error) {
+		files, err := channelTrackerExportFiles(sessionId, startedAt, stoppedAt, platforms)
+		if err != nil {
+			return nil, &discord.Error{
+				Msg: p.Sprintf("Failed to export stats"),
+				Err: err,
+			}
+		}
+		return &discordgo.MessageSend{
+			Content: p.Sprintf("Session #%d stats export", sessionId),
+			Files:   files,
+		}, nil
+	}
+}
+
+func (m *Messages) ChannelTrackerCharts(
+	sessionId stats_tracker.SessionId,
+	platforms map[ps2_platforms.Platform]stats_tracker.PlatformStats,
+) discord.MessageSend {
+	return func(p *message.Printer) (*discordgo.MessageSend, *discord.Error) {
+		files, err := channelTrackerChartFiles(p, sessionId, platforms)
+		if err != nil {
+			return nil, &discord.Error{
+				Msg: p.Sprintf("Failed to render stats charts"),
+				Err: err,
+			}
+		}
+		return &discordgo.MessageSend{
+			Content: p.Sprintf("Session #%d charts", sessionId),
+			Files:   files,
+		}, nil
+	}
+}
+
 func (m *Messages) FacilityControl(
 	worldId ps2.WorldId,
 	outfit ps2.Outfit,
@@ -253,97 +744,292 @@ func (m *Messages) GlobalPopulationLoadError(provider string, err error) discord
 	}
 }
 
-func (m *Messages) WorldPopulationLoadError(provider string, worldId ps2.WorldId, err error) discord.ResponseEdit {
+func (m *Messages) WorldPopulationLoadError(provider string, worldId ps2.WorldId, err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return nil, &discord.Error{
+			Msg: p.Sprintf("Failed to load %s population with %s", ps2.WorldNameById(worldId), provider),
+			Err: err,
+		}
+	}
+}
+
+func (m *Messages) PopulationHistoryLoadError(err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return nil, &discord.Error{
+			Msg: p.Sprintf("Failed to load population history"),
+			Err: err,
+		}
+	}
+}
+
+// The chart is attached when the history is not empty
+func (m *Messages) GlobalPopulation(
+	population meta.Loaded[ps2.WorldsPopulation],
+	history []ps2.PopulationSample,
+) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		files, err := populationChartFiles(p.Sprintf("Global population, UTC"), history)
+		if err != nil {
+			return nil, &discord.Error{
+				Msg: p.Sprintf("Failed to render population chart"),
+				Err: err,
+			}
+		}
+		return &discordgo.WebhookEdit{
+			Embeds: &[]*discordgo.MessageEmbed{
+				renderPopulation(p, population),
+			},
+			Files: files,
+		}, nil
+	}
+}
+
+// The chart is attached when the history is not empty
+func (m *Messages) WorldPopulation(
+	population meta.Loaded[ps2.DetailedWorldPopulation],
+	history []ps2.PopulationSample,
+) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		files, err := populationChartFiles(p.Sprintf("%s population, UTC", population.Value.Name), history)
+		if err != nil {
+			return nil, &discord.Error{
+				Msg: p.Sprintf("Failed to render population chart"),
+				Err: err,
+			}
+		}
+		return &discordgo.WebhookEdit{
+			Embeds: &[]*discordgo.MessageEmbed{
+				renderWorldDetailedPopulation(p, population),
+			},
+			Files: files,
+		}, nil
+	}
+}
+
+func (m *Messages) WorldTerritoryControlLoadError(worldId ps2.WorldId, err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return nil, &discord.Error{
+			Msg: p.Sprintf("Failed to load %s territory control", ps2.WorldNameById(worldId)),
+			Err: err,
+		}
+	}
+}
+
+func (m *Messages) WorldTerritoryControl(control meta.Loaded[ps2.WorldTerritoryControl]) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return &discordgo.WebhookEdit{
+			Embeds: &[]*discordgo.MessageEmbed{
+				renderWorldTerritoryControl(p, control),
+			},
+		}, nil
+	}
+}
+
+func (m *Messages) WorldAlertsLoadError(provider string, worldId ps2.WorldId, err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return nil, &discord.Error{
+			Msg: p.Sprintf("Failed to load world alerts for %s from %s", ps2.WorldNameById(worldId), provider),
+			Err: err,
+		}
+	}
+}
+
+func (m *Messages) WorldAlerts(worldName string, worldAlerts meta.Loaded[ps2.Alerts]) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return &discordgo.WebhookEdit{
+			Embeds: &[]*discordgo.MessageEmbed{
+				renderWorldDetailedAlerts(p, worldName, worldAlerts),
+			},
+		}, nil
+	}
+}
+
+func (m *Messages) GlobalAlertsLoadError(provider string, err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return nil, &discord.Error{
+			Msg: p.Sprintf("Failed to load global alerts from %s", provider),
+			Err: err,
+		}
+	}
+}
+
+func (m *Messages) GlobalAlerts(alerts meta.Loaded[ps2.Alerts]) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		embeds := renderAlerts(p, alerts)
+		return &discordgo.WebhookEdit{
+			Embeds: &embeds,
+		}, nil
+	}
+}
+
+func (m *Messages) OnlineMembersLoadError(channelId discord.ChannelId, platform ps2_platforms.Platform, err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return nil, &discord.Error{
+			Msg: p.Sprintf("Failed to load online members for %s channel (%s)", channelId, platform),
+			Err: err,
+		}
+	}
+}
+
+func (m *Messages) CharacterHistory(name string, history characters_history.History) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		content := renderCharacterHistory(p, name, history)
+		return &discordgo.WebhookEdit{
+			Content: &content,
+		}, nil
+	}
+}
+
+func (m *Messages) CharacterHistoryLoadError(name string, platform ps2_platforms.Platform, err error) discord.ResponseEdit {
 	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
 		return nil, &discord.Error{
-			Msg: p.Sprintf("Failed to load %s population with %s", ps2.WorldNameById(worldId), provider),
+			Msg: p.Sprintf("Failed to load history of %s (%s)", name, platform),
 			Err: err,
 		}
 	}
 }
 
-func (m *Messages) GlobalPopulation(population meta.Loaded[ps2.WorldsPopulation]) discord.ResponseEdit {
+func (m *Messages) CharacterNotFound(name string, platform ps2_platforms.Platform) discord.ResponseEdit {
 	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		content := p.Sprintf("Character %s is not found (%s)", name, platform)
 		return &discordgo.WebhookEdit{
-			Embeds: &[]*discordgo.MessageEmbed{
-				renderPopulation(p, population),
-			},
+			Content: &content,
 		}, nil
 	}
 }
 
-func (m *Messages) WorldPopulation(population meta.Loaded[ps2.DetailedWorldPopulation]) discord.ResponseEdit {
+func (m *Messages) OutfitActivityReport(
+	platform ps2_platforms.Platform,
+	from time.Time,
+	to time.Time,
+	report outfit_activity.OutfitReport,
+) discord.ChunkableMessage {
+	sb := strings.Builder{}
+	return discord.NewChunkableMessage(
+		len(report.Active),
+		func(p *message.Printer, start, count int) (string, *discord.Error) {
+			sb.Reset()
+			if start == 0 {
+				sb.WriteString(renderOutfitActivityTitle(p, platform, from, to, report))
+				renderOutfitActivitySummary(p, &sb, report)
+				if count == 0 {
+					return sb.String(), nil
+				}
+				sb.WriteString("\n```")
+			} else {
+				sb.WriteString("```")
+			}
+			renderOutfitActivityTable(p, &sb, report.Active[start:start+count])
+			sb.WriteString("```")
+			return sb.String(), nil
+		})
+}
+
+func (m *Messages) OutfitActivityInactiveMembers(
+	platform ps2_platforms.Platform,
+	report outfit_activity.OutfitReport,
+) discord.ChunkableMessage {
+	sb := strings.Builder{}
+	return discord.NewChunkableMessage(
+		len(report.Inactive),
+		func(p *message.Printer, start, count int) (string, *discord.Error) {
+			sb.Reset()
+			if start == 0 {
+				sb.WriteString(p.Sprintf(
+					"Platform: %s, [%s] inactive members:\n",
+					strings.ToUpper(string(platform)),
+					report.Outfit.Tag,
+				))
+			}
+			for i, char := range report.Inactive[start : start+count] {
+				if i > 0 {
+					sb.WriteString(", ")
+				}
+				sb.WriteString(char.Name)
+			}
+			return sb.String(), nil
+		})
+}
+
+func (m *Messages) CharacterChangesNotificationsSetting(enabled bool) discord.ResponseEdit {
 	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		content := p.Sprintf("Notifications about character changes are disabled")
+		if enabled {
+			content = p.Sprintf("Notifications about renames, faction, server and outfit changes of the tracked characters are enabled")
+		}
 		return &discordgo.WebhookEdit{
-			Embeds: &[]*discordgo.MessageEmbed{
-				renderWorldDetailedPopulation(p, population),
-			},
+			Content: &content,
 		}, nil
 	}
 }
 
-func (m *Messages) WorldTerritoryControlLoadError(worldId ps2.WorldId, err error) discord.ResponseEdit {
+func (m *Messages) CharacterChangesNotificationsSaveError(err error) discord.ResponseEdit {
 	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
 		return nil, &discord.Error{
-			Msg: p.Sprintf("Failed to load %s territory control", ps2.WorldNameById(worldId)),
+			Msg: p.Sprintf("Failed to save character changes notifications setting"),
 			Err: err,
 		}
 	}
 }
 
-func (m *Messages) WorldTerritoryControl(control meta.Loaded[ps2.WorldTerritoryControl]) discord.ResponseEdit {
+func (m *Messages) OutfitActivityReportSetting(enabled bool) discord.ResponseEdit {
 	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		content := p.Sprintf("Weekly outfit activity reports are disabled")
+		if enabled {
+			content = p.Sprintf("Weekly outfit activity reports are enabled, they are posted on Mondays")
+		}
 		return &discordgo.WebhookEdit{
-			Embeds: &[]*discordgo.MessageEmbed{
-				renderWorldTerritoryControl(p, control),
-			},
+			Content: &content,
 		}, nil
 	}
 }
 
-func (m *Messages) WorldAlertsLoadError(provider string, worldId ps2.WorldId, err error) discord.ResponseEdit {
+func (m *Messages) OutfitActivityReportSaveError(err error) discord.ResponseEdit {
 	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
 		return nil, &discord.Error{
-			Msg: p.Sprintf("Failed to load world alerts for %s from %s", ps2.WorldNameById(worldId), provider),
+			Msg: p.Sprintf("Failed to save outfit activity report setting"),
 			Err: err,
 		}
 	}
 }
 
-func (m *Messages) WorldAlerts(worldName string, worldAlerts meta.Loaded[ps2.Alerts]) discord.ResponseEdit {
+func (m *Messages) InactiveMembers(
+	tag string,
+	days int,
+	members []outfit_activity.InactiveMember,
+) discord.ResponseEdit {
 	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		content := renderInactiveMembers(p, tag, days, members)
 		return &discordgo.WebhookEdit{
-			Embeds: &[]*discordgo.MessageEmbed{
-				renderWorldDetailedAlerts(p, worldName, worldAlerts),
-			},
+			Content: &content,
 		}, nil
 	}
 }
 
-func (m *Messages) GlobalAlertsLoadError(provider string, err error) discord.ResponseEdit {
+func (m *Messages) InactiveMembersLoadError(tag string, platform ps2_platforms.Platform, err error) discord.ResponseEdit {
 	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
 		return nil, &discord.Error{
-			Msg: p.Sprintf("Failed to load global alerts from %s", provider),
+			Msg: p.Sprintf("Failed to load inactive members of [%s] (%s)", tag, platform),
 			Err: err,
 		}
 	}
 }
 
-func (m *Messages) GlobalAlerts(alerts meta.Loaded[ps2.Alerts]) discord.ResponseEdit {
+func (m *Messages) OutfitMembersNotSynchronized(tag string, platform ps2_platforms.Platform) discord.ResponseEdit {
 	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
-		embeds := renderAlerts(p, alerts)
+		content := p.Sprintf("Members of [%s] are not synchronized yet (%s), track the outfit to synchronize them", tag, platform)
 		return &discordgo.WebhookEdit{
-			Embeds: &embeds,
+			Content: &content,
 		}, nil
 	}
 }
 
-func (m *Messages) OnlineMembersLoadError(channelId discord.ChannelId, platform ps2_platforms.Platform, err error) discord.ResponseEdit {
+func (m *Messages) OutfitNotFound(tag string, platform ps2_platforms.Platform) discord.ResponseEdit {
 	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
-		return nil, &discord.Error{
-			Msg: p.Sprintf("Failed to load online members for %s channel (%s)", channelId, platform),
-			Err: err,
-		}
+		content := p.Sprintf("Outfit %s is not found (%s)", tag, platform)
+		return &discordgo.WebhookEdit{
+			Content: &content,
+		}, nil
 	}
 }
 
@@ -357,12 +1043,12 @@ func (m *Messages) OutfitsLoadError(outfitIds []ps2.OutfitId, platform ps2_platf
 }
 
 func (m *Messages) MembersOnline(
-	outfitCharacters map[ps2.OutfitId][]ps2.Character,
-	characters []ps2.Character,
+	outfitCharacters map[ps2.OutfitId][]ps2.OnlineCharacter,
+	characters []ps2.OnlineCharacter,
 	outfits map[ps2.OutfitId]ps2.Outfit,
 ) discord.ResponseEdit {
 	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
-		content := renderOnline(p, outfitCharacters, characters, outfits)
+		content := renderOnline(p, time.Now(), outfitCharacters, characters, outfits)
 		return &discordgo.WebhookEdit{
 			Content: &content,
 		}, nil
@@ -450,6 +1136,15 @@ func (m *Messages) StartChannelStatsTrackerError(err error) discord.ResponseEdit
 	}
 }
 
+func (m *Messages) StatsTrackerFilterError(err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return nil, &discord.Error{
+			Msg: renderStatsTrackerFilterError(p, err),
+			Err: err,
+		}
+	}
+}
+
 func (m *Messages) NoChannelTrackerToStop() discord.ResponseEdit {
 	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
 		content := p.Sprintf("There is no stats tracker to stop")
@@ -495,14 +1190,234 @@ func ChannelStatsTrackerTasksLoadError[R any](err error) func(*message.Printer)
 	}
 }
 
+func (m *Messages) StatsTrackerHistory(sessions []stats_tracker.Session) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		content := renderStatsTrackerHistory(p, sessions)
+		return &discordgo.WebhookEdit{
+			Content: &content,
+		}, nil
+	}
+}
+
+func (m *Messages) StatsTrackerHistoryLoadError(err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return nil, &discord.Error{
+			Msg: p.Sprintf("Failed to load stats tracker history"),
+			Err: err,
+		}
+	}
+}
+
+func (m *Messages) StatsTrackerExport(
+	session stats_tracker.Session,
+	platforms map[ps2_platforms.Platform]stats_tracker.PlatformStats,
+) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		files, err := channelTrackerExportFiles(session.Id, session.StartedAt, session.StoppedAt, platforms)
+		if err != nil {
+			return nil, &discord.Error{
+				Msg: p.Sprintf("Failed to export stats"),
+				Err: err,
+			}
+		}
+		content := p.Sprintf("Session #%d stats export", session.Id)
+		return &discordgo.WebhookEdit{
+			Content: &content,
+			Files:   files,
+		}, nil
+	}
+}
+
+func (m *Messages) StatsTrackerReport(
+	session stats_tracker.Session,
+	platforms map[ps2_platforms.Platform]stats_tracker.PlatformStats,
+	order stats_tracker.SortOrder,
+	charts bool,
+) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		content := renderStatsTrackerReport(p, session, platforms, order)
+		var files []*discordgo.File
+		if charts {
+			var err error
+			if files, err = channelTrackerChartFiles(p, session.Id, platforms); err != nil {
+				return nil, &discord.Error{
+					Msg: p.Sprintf("Failed to render stats charts"),
+					Err: err,
+				}
+			}
+		}
+		return &discordgo.WebhookEdit{
+			Content: &content,
+			Files:   files,
+		}, nil
+	}
+}
+
+func (m *Messages) StatsTrackerSessionNotFound(sessionId stats_tracker.SessionId) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		content := p.Sprintf("Stats tracker session #%d is not found", sessionId)
+		return &discordgo.WebhookEdit{
+			Content: &content,
+		}, nil
+	}
+}
+
+func (m *Messages) NoStatsTrackerSessions() discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		content := p.Sprintf("There are no stats tracker sessions")
+		return &discordgo.WebhookEdit{
+			Content: &content,
+		}, nil
+	}
+}
+
+func (m *Messages) StatsTrackerSessionLoadError(err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return nil, &discord.Error{
+			Msg: p.Sprintf("Failed to load stats tracker session"),
+			Err: err,
+		}
+	}
+}
+
+func (m *Messages) StatsTrackerAutoStart(autoStart discord.StatsTrackerAutoStart, enabled bool) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		content := p.Sprintf("Stats tracker auto start is disabled")
+		if enabled {
+			content = renderStatsTrackerAutoStart(p, autoStart)
+		}
+		return &discordgo.WebhookEdit{
+			Content: &content,
+		}, nil
+	}
+}
+
+func (m *Messages) StatsTrackerAutoStartLoadError(err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return nil, &discord.Error{
+			Msg: p.Sprintf("Failed to load stats tracker auto start"),
+			Err: err,
+		}
+	}
+}
+
+func (m *Messages) StatsTrackerAutoStartSaveError(err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		if errors.Is(err, discord.ErrInvalidStatsTrackerAutoStart) {
+			content := p.Sprintf("The stop threshold must not exceed the amount of online members required to start")
+			return &discordgo.WebhookEdit{
+				Content: &content,
+			}, nil
+		}
+		return nil, &discord.Error{
+			Msg: p.Sprintf("Failed to save stats tracker auto start"),
+			Err: err,
+		}
+	}
+}
+
+func (m *Messages) StatsTrackerDetails(enabled bool) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		content := p.Sprintf("Stats tracker report details are disabled")
+		if enabled {
+			content = p.Sprintf("Stats tracker report details are enabled")
+		}
+		return &discordgo.WebhookEdit{
+			Content: &content,
+		}, nil
+	}
+}
+
+func (m *Messages) StatsTrackerDetailsSaveError(err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return nil, &discord.Error{
+			Msg: p.Sprintf("Failed to save stats tracker details setting"),
+			Err: err,
+		}
+	}
+}
+
+func (m *Messages) StatsTrackerSort(order stats_tracker.SortOrder) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		content := p.Sprintf("Characters are sorted by %s", renderSortOrder(p, order))
+		return &discordgo.WebhookEdit{
+			Content: &content,
+		}, nil
+	}
+}
+
+func (m *Messages) StatsTrackerSortSaveError(err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return nil, &discord.Error{
+			Msg: p.Sprintf("Failed to save stats tracker sort order"),
+			Err: err,
+		}
+	}
+}
+
+func (m *Messages) StatsTrackerCharts(enabled bool) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		content := p.Sprintf("Stats tracker charts are disabled")
+		if enabled {
+			content = p.Sprintf("Stats tracker charts are enabled")
+		}
+		return &discordgo.WebhookEdit{
+			Content: &content,
+		}, nil
+	}
+}
+
+func (m *Messages) StatsTrackerChartsSaveError(err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		return nil, &discord.Error{
+			Msg: p.Sprintf("Failed to save stats tracker charts setting"),
+			Err: err,
+		}
+	}
+}
+
+func (m *Messages) StatsTrackerScoreFormula(formula string) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		content := p.Sprintf("Characters are ranked by kills")
+		if formula != "" {
+			content = p.Sprintf("Characters are ranked by score: `%s`", formula)
+		}
+		content += "\n" + p.Sprintf(
+			"Available counters: %s",
+			"`"+strings.Join(stats_tracker.ScoreVariables, "`, `")+"`",
+		)
+		return &discordgo.WebhookEdit{
+			Content: &content,
+		}, nil
+	}
+}
+
+func (m *Messages) StatsTrackerScoreFormulaSaveError(err error) discord.ResponseEdit {
+	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
+		if errors.Is(err, stats_tracker.ErrInvalidScoreFormula) {
+			details := err.Error()
+			if syntaxErr := (*expr.SyntaxError)(nil); errors.As(err, &syntaxErr) {
+				details = syntaxErr.Error()
+			}
+			content := p.Sprintf("Invalid score formula: %s", details)
+			return &discordgo.WebhookEdit{
+				Content: &content,
+			}, nil
+		}
+		return nil, &discord.Error{
+			Msg: p.Sprintf("Failed to save stats tracker score formula"),
+			Err: err,
+		}
+	}
+}
+
 func (m *Messages) StatsTrackerScheduleEditForm(
 	channel discord.Channel,
 	tasks []discord.StatsTrackerTask,
 ) discord.ResponseEdit {
 	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
 		content := scheduleNotes(p, channel.DefaultTimezone)
-		localTasks := newLocalTasks(tasks, channel.DefaultTimezone)
-		components := statsTrackerScheduleEditForm(p, localTasks, 0)
+		components := statsTrackerScheduleEditForm(p, sortTasks(tasks, time.Now()), channel.DefaultTimezone, 0)
 		return &discordgo.WebhookEdit{
 			Content:    &content,
 			Components: &components,
@@ -515,8 +1430,7 @@ func (m *Messages) StatsTrackerSchedule(
 	tasks []discord.StatsTrackerTask,
 ) discord.ResponseEdit {
 	return func(p *message.Printer) (*discordgo.WebhookEdit, *discord.Error) {
-		localTasks := newLocalTasks(tasks, channel.DefaultTimezone)
-		content := renderStatsTrackerSchedule(p, localTasks)
+		content := renderStatsTrackerSchedule(p, sortTasks(tasks, time.Now()), channel.DefaultTimezone)
 		return &discordgo.WebhookEdit{
 			Content: &content,
 		}, nil
@@ -530,8 +1444,12 @@ func (m *Messages) StatsTrackerScheduleUpdated(
 ) discord.Response {
 	return func(p *message.Printer) (*discordgo.InteractionResponseData, *discord.Error) {
 		content := scheduleNotes(p, channel.DefaultTimezone)
-		localTasks := newLocalTasks(tasks, channel.DefaultTimezone)
-		components := statsTrackerScheduleEditForm(p, localTasks, zeroIndexedPage)
+		components := statsTrackerScheduleEditForm(
+			p,
+			sortTasks(tasks, time.Now()),
+			channel.DefaultTimezone,
+			zeroIndexedPage,
+		)
 		return &discordgo.InteractionResponseData{
 			Content:    content,
 			Components: components,
@@ -565,6 +1483,54 @@ func (m *Messages) StatsTrackerTaskForm(
 	}
 }
 
+func (m *Messages) StatsTrackerTaskFilterModal(
+	state discord.StatsTrackerTaskState,
+) discord.Response {
+	return func(p *message.Printer) (*discordgo.InteractionResponseData, *discord.Error) {
+		return &discordgo.InteractionResponseData{
+			CustomID:   discord.STATS_TRACKER_TASK_FILTER_MODAL_CUSTOM_ID,
+			Title:      p.Sprintf("Task filter"),
+			Components: statsTrackerTaskFilterModal(p, state),
+		}, nil
+	}
+}
+
+func (m *Messages) StatsTrackerDatedTaskModal(
+	state discord.StatsTrackerTaskState,
+) discord.Response {
+	return func(p *message.Printer) (*discordgo.InteractionResponseData, *discord.Error) {
+		return &discordgo.InteractionResponseData{
+			CustomID:   discord.STATS_TRACKER_DATED_TASK_MODAL_CUSTOM_ID,
+			Title:      p.Sprintf("Dated task (%s)", state.Timezone.String()),
+			Components: statsTrackerDatedTaskModal(p, state),
+		}, nil
+	}
+}
+
+func (m *Messages) StatsTrackerDatedTaskFormError(err error) discord.Response {
+	return func(p *message.Printer) (*discordgo.InteractionResponseData, *discord.Error) {
+		return &discordgo.InteractionResponseData{
+			Content: renderTaskFormError(p, err),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.Button{
+							CustomID: discord.STATS_TRACKER_DATED_TASK_RETRY_BUTTON_CUSTOM_ID,
+							Label:    p.Sprintf("Edit"),
+							Style:    discordgo.PrimaryButton,
+						},
+						discordgo.Button{
+							CustomID: discord.STATS_TRACKER_TASK_CANCEL_BUTTON_CUSTOM_ID,
+							Label:    p.Sprintf("Cancel"),
+							Style:    discordgo.DangerButton,
+						},
+					},
+				},
+			},
+		}, nil
+	}
+}
+
 func (m *Messages) ChannelStatsTrackerTaskRemoveError(err error) discord.Response {
 	return func(p *message.Printer) (*discordgo.InteractionResponseData, *discord.Error) {
 		return nil, &discord.Error{
@@ -1,17 +1,87 @@
 package discord_messages
 
 import (
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/x0k/ps2-spy/internal/ps2"
 	factions "github.com/x0k/ps2-spy/internal/ps2/factions"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
 	"golang.org/x/text/message"
 )
 
+func renderLoadoutIcon(loadout ps2_loadout.Loadout) string {
+	t, err := ps2_loadout.GetType(loadout)
+	if err != nil {
+		return ""
+	}
+	switch t {
+	case ps2_loadout.Infiltrator:
+		return "🎯"
+	case ps2_loadout.LightAssault:
+		return "🚀"
+	case ps2_loadout.Medic:
+		return "⛑️"
+	case ps2_loadout.Engineer:
+		return "🔧"
+	case ps2_loadout.HeavyAssault:
+		return "🛡️"
+	case ps2_loadout.MAX:
+		return "🤖"
+	}
+	return ""
+}
+
+// Characters are grouped by continent, characters outside
+// of the continents are rendered last
+func renderOnlineCharacters(
+	p *message.Printer,
+	builder *strings.Builder,
+	now time.Time,
+	characters []ps2.OnlineCharacter,
+	renderName func(ps2.OnlineCharacter) string,
+) {
+	continents := make(map[string][]ps2.OnlineCharacter)
+	other := make([]ps2.OnlineCharacter, 0)
+	for _, char := range characters {
+		if name, ok := ps2.ZoneNames[char.ZoneId]; ok {
+			continents[name] = append(continents[name], char)
+		} else {
+			other = append(other, char)
+		}
+	}
+	renderGroup := func(title string, characters []ps2.OnlineCharacter) {
+		slices.SortFunc(characters, func(a, b ps2.OnlineCharacter) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		builder.WriteString("\n__")
+		builder.WriteString(title)
+		builder.WriteString("__")
+		for _, char := range characters {
+			builder.WriteString("\n- ")
+			if icon := renderLoadoutIcon(char.Loadout); icon != "" {
+				builder.WriteString(icon)
+				builder.WriteByte(' ')
+			}
+			builder.WriteString(renderName(char))
+			builder.WriteString(p.Sprintf(", online for %s", renderDuration(p, now.Sub(char.OnlineSince))))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(continents)) {
+		renderGroup(name, continents[name])
+	}
+	if len(other) > 0 {
+		renderGroup(p.Sprintf("Other zones"), other)
+	}
+}
+
 func renderOnline(
 	p *message.Printer,
-	outfitCharacters map[ps2.OutfitId][]ps2.Character,
-	characters []ps2.Character,
+	now time.Time,
+	outfitCharacters map[ps2.OutfitId][]ps2.OnlineCharacter,
+	characters []ps2.OnlineCharacter,
 	outfits map[ps2.OutfitId]ps2.Outfit,
 ) string {
 	if len(outfitCharacters) == 0 && len(characters) == 0 {
@@ -39,27 +109,27 @@ func renderOnline(
 		builder.WriteString(", ")
 		builder.WriteString(ps2.WorldNameById(characters[0].WorldId))
 		builder.WriteString("):**")
-		for _, char := range characters {
-			builder.WriteString("\n- ")
-			builder.WriteString(char.Name)
-		}
+		renderOnlineCharacters(p, &builder, now, characters, func(char ps2.OnlineCharacter) string {
+			return char.Name
+		})
 	}
 	if len(characters) > 0 {
 		builder.WriteString(p.Sprintf("\n**Other characters:**"))
-		for _, char := range characters {
-			builder.WriteString("\n- ")
+		renderOnlineCharacters(p, &builder, now, characters, func(char ps2.OnlineCharacter) string {
+			sb := strings.Builder{}
 			if char.OutfitTag != "" {
-				builder.WriteByte('[')
-				builder.WriteString(char.OutfitTag)
-				builder.WriteString("] ")
+				sb.WriteByte('[')
+				sb.WriteString(char.OutfitTag)
+				sb.WriteString("] ")
 			}
-			builder.WriteString(char.Name)
-			builder.WriteString(" (")
-			builder.WriteString(factions.FactionNameById(char.FactionId))
-			builder.WriteString(", ")
-			builder.WriteString(ps2.WorldNameById(char.WorldId))
-			builder.WriteByte(')')
-		}
+			sb.WriteString(char.Name)
+			sb.WriteString(" (")
+			sb.WriteString(factions.FactionNameById(char.FactionId))
+			sb.WriteString(", ")
+			sb.WriteString(ps2.WorldNameById(char.WorldId))
+			sb.WriteByte(')')
+			return sb.String()
+		})
 	}
 	return builder.String()
 }
@@ -0,0 +1,83 @@
+package discord_messages
+
+import (
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_factions "github.com/x0k/ps2-spy/internal/ps2/factions"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func TestRenderOnline(t *testing.T) {
+	now := time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)
+	member := func(name string, zoneId ps2.ZoneId, loadout ps2_loadout.Loadout, onlineFor time.Duration) ps2.OnlineCharacter {
+		return ps2.OnlineCharacter{
+			Character: ps2.Character{
+				Id:        ps2.CharacterId(name),
+				Name:      name,
+				FactionId: ps2_factions.VS,
+				OutfitId:  "outfit",
+				OutfitTag: "TAG",
+				WorldId:   "1",
+			},
+			ZoneId:      zoneId,
+			Loadout:     loadout,
+			OnlineSince: now.Add(-onlineFor),
+		}
+	}
+	p := message.NewPrinter(language.English)
+	cases := []struct {
+		name             string
+		outfitCharacters map[ps2.OutfitId][]ps2.OnlineCharacter
+		characters       []ps2.OnlineCharacter
+		expected         string
+	}{
+		{
+			name:     "nobody online",
+			expected: "No characters online",
+		},
+		{
+			name: "outfit members grouped by continent",
+			outfitCharacters: map[ps2.OutfitId][]ps2.OnlineCharacter{
+				"outfit": {
+					member("Zulu", "2", "1", 83*time.Minute),
+					member("Alpha", "2", "", 5*time.Minute),
+					member("Bravo", "", "7", 2*time.Hour),
+					member("Charlie", "6", "4", time.Hour),
+				},
+			},
+			expected: "Characters online:" +
+				"\n**Outfit [TAG] outfit (VS, Connery):**" +
+				"\n__Amerish__" +
+				"\n- ⛑️ Charlie, online for 1h 0m" +
+				"\n__Indar__" +
+				"\n- Alpha, online for 5m" +
+				"\n- 🎯 Zulu, online for 1h 23m" +
+				"\n__Other zones__" +
+				"\n- 🤖 Bravo, online for 2h 0m",
+		},
+		{
+			name: "other characters",
+			characters: []ps2.OnlineCharacter{
+				member("Alpha", "8", "6", 90*time.Minute),
+			},
+			expected: "Characters online:" +
+				"\n**Other characters:**" +
+				"\n__Esamir__" +
+				"\n- 🛡️ [TAG] Alpha (VS, Connery), online for 1h 30m",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := renderOnline(p, now, c.outfitCharacters, c.characters, map[ps2.OutfitId]ps2.Outfit{
+				"outfit": {Id: "outfit", Name: "Outfit", Tag: "TAG"},
+			})
+			if got != c.expected {
+				t.Errorf("renderOnline() = %q, want %q", got, c.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package discord_messages
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/x0k/ps2-spy/internal/discord"
+	"github.com/x0k/ps2-spy/internal/outfit_activity"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"golang.org/x/text/message"
+)
+
+func renderOutfitActivityTitle(
+	p *message.Printer,
+	platform ps2_platforms.Platform,
+	from time.Time,
+	to time.Time,
+	report outfit_activity.OutfitReport,
+) string {
+	return p.Sprintf(
+		"Platform: %s, [%s] %s activity from %s to %s",
+		strings.ToUpper(string(platform)),
+		report.Outfit.Tag,
+		report.Outfit.Name,
+		renderDate(from),
+		renderDate(to.AddDate(0, 0, -1)),
+	)
+}
+
+func renderOutfitActivitySummary(
+	p *message.Printer,
+	sb *strings.Builder,
+	report outfit_activity.OutfitReport,
+) {
+	var playtime time.Duration
+	for _, a := range report.Active {
+		playtime += a.Playtime
+	}
+	sb.WriteString(p.Sprintf(
+		"\nActive members: %d of %d, total playtime: %s",
+		len(report.Active),
+		len(report.Active)+len(report.Inactive),
+		renderDuration(p, playtime),
+	))
+	sb.WriteString(p.Sprintf("\nPeak online:"))
+	for _, day := range report.Days {
+		sb.WriteString(fmt.Sprintf(
+			"\n- %s %s: %d",
+			renderWeekday(p, day.Day.Weekday()),
+			renderDate(day.Day),
+			day.Online,
+		))
+	}
+}
+
+func renderOutfitActivityTable(
+	p *message.Printer,
+	sb *strings.Builder,
+	members []outfit_activity.MemberActivity,
+) {
+	t := tablewriter.NewWriter(sb)
+	t.SetHeader([]string{
+		p.Sprintf("Character"),
+		p.Sprintf("Playtime"),
+		p.Sprintf("Sessions"),
+	})
+	t.SetBorder(false)
+	for _, m := range members {
+		t.Append([]string{
+			m.Character.Name,
+			renderDuration(p, m.Playtime),
+			strconv.Itoa(m.Sessions),
+		})
+	}
+	t.Render()
+}
+
+// Renders as many members as fit into a single message
+func renderInactiveMembers(
+	p *message.Printer,
+	tag string,
+	days int,
+	members []outfit_activity.InactiveMember,
+) string {
+	sb := strings.Builder{}
+	sb.WriteString(p.Sprintf(
+		"[%s] members inactive for more than %d days: %d",
+		tag,
+		days,
+		len(members),
+	))
+	for i, member := range members {
+		line := p.Sprintf("\n- %s, never seen", member.Character.Name)
+		if !member.LastSeen.IsZero() {
+			line = p.Sprintf("\n- %s, last seen %s", member.Character.Name, renderRelativeTime(member.LastSeen))
+		}
+		more := p.Sprintf("\n...and %d more", len(members)-i)
+		if sb.Len()+len(line)+len(more) > discord.MAX_MESSAGE_LENGTH {
+			sb.WriteString(more)
+			break
+		}
+		sb.WriteString(line)
+	}
+	return sb.String()
+}
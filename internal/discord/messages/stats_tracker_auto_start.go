@@ -0,0 +1,24 @@
+package discord_messages
+
+import (
+	"strings"
+
+	"github.com/x0k/ps2-spy/internal/discord"
+	"golang.org/x/text/message"
+)
+
+func renderStatsTrackerAutoStart(p *message.Printer, autoStart discord.StatsTrackerAutoStart) string {
+	sb := strings.Builder{}
+	sb.WriteString(p.Sprintf(
+		"Stats tracker starts when at least %d tracked characters are online for %s",
+		autoStart.MinOnline,
+		renderDuration(p, autoStart.Duration),
+	))
+	if autoStart.StopBelow > 0 {
+		sb.WriteString(p.Sprintf(
+			" and stops when less than %d of them stay online for the same time",
+			autoStart.StopBelow,
+		))
+	}
+	return sb.String()
+}
@@ -0,0 +1,43 @@
+package discord_messages
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
+	"golang.org/x/text/message"
+)
+
+func renderStatsTrackerHistory(
+	p *message.Printer,
+	sessions []stats_tracker.Session,
+) string {
+	sb := strings.Builder{}
+	sb.WriteString(p.Sprintf("Stats tracker history:"))
+	if len(sessions) == 0 {
+		sb.WriteString(p.Sprintf("\n- No sessions were found"))
+		return sb.String()
+	}
+	for _, s := range sessions {
+		kind := p.Sprintf("manual")
+		switch s.Origin {
+		case stats_tracker.ScheduledSession:
+			kind = p.Sprintf("scheduled")
+		case stats_tracker.AutoSession:
+			kind = p.Sprintf("auto")
+		}
+		totals := s.Totals()
+		sb.WriteString(p.Sprintf(
+			"\n- #%s, %s, %s, %s, characters: %d, kills: %d, KD: %s, HS%%: %s",
+			strconv.FormatInt(int64(s.Id), 10),
+			renderDateTime(s.StartedAt),
+			renderDuration(p, s.StoppedAt.Sub(s.StartedAt)),
+			kind,
+			len(s.Characters),
+			totals.Kills,
+			strconv.FormatFloat(totals.KD(), 'f', 2, 64),
+			strconv.FormatFloat(totals.HSR(), 'f', 2, 64),
+		))
+	}
+	return sb.String()
+}
@@ -0,0 +1,79 @@
+package discord_messages
+
+import (
+	"strings"
+
+	"github.com/x0k/ps2-spy/internal/discord"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
+	"golang.org/x/text/message"
+)
+
+const statsTrackerReportMaxSize = 25
+
+func renderSortOrder(p *message.Printer, order stats_tracker.SortOrder) string {
+	switch order {
+	case stats_tracker.SortByKills:
+		return p.Sprintf("kills")
+	case stats_tracker.SortByKPM:
+		return p.Sprintf("kills per minute")
+	case stats_tracker.SortByDPM:
+		return p.Sprintf("deaths per minute")
+	case stats_tracker.SortByActiveTime:
+		return p.Sprintf("active time")
+	}
+	return string(order)
+}
+
+// Renders as many top characters as fit into a single message
+func renderStatsTrackerReport(
+	p *message.Printer,
+	session stats_tracker.Session,
+	platforms map[ps2_platforms.Platform]stats_tracker.PlatformStats,
+	order stats_tracker.SortOrder,
+) string {
+	var content string
+	for limit := statsTrackerReportMaxSize; limit > 0; limit-- {
+		content = renderStatsTrackerReportWithLimit(p, session, platforms, order, limit)
+		if len(content) <= discord.MAX_MESSAGE_LENGTH {
+			break
+		}
+	}
+	return content
+}
+
+func renderStatsTrackerReportWithLimit(
+	p *message.Printer,
+	session stats_tracker.Session,
+	platforms map[ps2_platforms.Platform]stats_tracker.PlatformStats,
+	order stats_tracker.SortOrder,
+	limit int,
+) string {
+	sb := strings.Builder{}
+	sb.WriteString(p.Sprintf(
+		"Session #%d, started at: %s, duration: %s, sorted by: %s",
+		session.Id,
+		renderTime(session.StartedAt),
+		renderDuration(p, session.StoppedAt.Sub(session.StartedAt)),
+		renderSortOrder(p, order),
+	))
+	hasData := false
+	for _, platform := range ps2_platforms.Platforms {
+		stats, ok := platforms[platform]
+		if !ok || len(stats.Characters) == 0 {
+			continue
+		}
+		hasData = true
+		sb.WriteString(p.Sprintf(
+			"\nPlatform: %s\n```",
+			strings.ToUpper(string(platform)),
+		))
+		renderCharactersStatsTable(p, &sb, stats.Characters[:min(len(stats.Characters), limit)], 0, stats_tracker.ScoreFormula{})
+		sb.WriteString("```")
+	}
+	if !hasData {
+		sb.WriteString("\n")
+		sb.WriteString(p.Sprintf("No data collected"))
+	}
+	return sb.String()
+}
@@ -11,7 +11,7 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/x0k/ps2-spy/internal/discord"
-	"github.com/x0k/ps2-spy/internal/shared"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
 	"golang.org/x/text/message"
 )
 
@@ -30,53 +30,96 @@ func scheduleNotes(p *message.Printer, loc *time.Location) string {
 
 const pageSize = 4
 
-func newLocalTasks(tasks []discord.StatsTrackerTask, timezone *time.Location) []discord.StatsTrackerTaskState {
-	localTasks := make([]discord.StatsTrackerTaskState, 0, len(tasks))
-	for _, t := range tasks {
-		localTasks = append(localTasks, discord.NewUpdateStatsTrackerTaskState(
-			t, timezone,
-		))
-	}
-	slices.SortFunc(localTasks, func(a, b discord.StatsTrackerTaskState) int {
-		w := a.LocalWeekdays[0] - b.LocalWeekdays[0]
-		if w != 0 {
-			return int(w)
-		}
-		h := (a.LocalStartHour - b.LocalStartHour)
-		if h != 0 {
-			return h
+const maxButtonLabelLength = 80
+
+// Sorts tasks by the next start, tasks that will not occur anymore are the last
+func sortTasks(tasks []discord.StatsTrackerTask, now time.Time) []discord.StatsTrackerTask {
+	sorted := slices.Clone(tasks)
+	slices.SortStableFunc(sorted, func(a, b discord.StatsTrackerTask) int {
+		aStart, aOk := a.NextStart(now)
+		bStart, bOk := b.NextStart(now)
+		if aOk != bOk {
+			if aOk {
+				return -1
+			}
+			return 1
 		}
-		return a.LocalStartMin - b.LocalStartMin
+		return aStart.Compare(bStart)
 	})
-	return localTasks
+	return sorted
+}
+
+func renderStatsTrackerTask(
+	p *message.Printer,
+	t discord.StatsTrackerTask,
+	channelTimezone *time.Location,
+) string {
+	var days string
+	switch t.Recurrence {
+	case discord.WeeklyStatsTrackerTask:
+		days = renderWeekday(p, t.Weekday)
+	case discord.IntervalStatsTrackerTask:
+		var from string
+		if len(t.Dates) > 0 {
+			from = t.Dates[0].String()
+		}
+		days = p.Sprintf("Every %d days from %s", t.IntervalDays, from)
+	case discord.DatesStatsTrackerTask:
+		dates := make([]string, 0, len(t.Dates))
+		for _, d := range t.Dates {
+			dates = append(dates, d.String())
+		}
+		days = strings.Join(dates, ", ")
+	}
+	s := fmt.Sprintf(
+		"%s, %s, %s",
+		days,
+		renderDurationAsTime(t.StartTime),
+		renderDuration(p, t.Duration),
+	)
+	if t.Timezone.String() != channelTimezone.String() {
+		s += ", " + t.Timezone.String()
+	}
+	if len(t.Filter) > 0 {
+		s += ", " + p.Sprintf("only %s", t.Filter.String())
+	}
+	return s
+}
+
+func truncateButtonLabel(label string) string {
+	runes := []rune(label)
+	if len(runes) <= maxButtonLabelLength {
+		return label
+	}
+	return string(runes[:maxButtonLabelLength-1]) + "…"
 }
 
 func statsTrackerScheduleEditForm(
 	p *message.Printer,
-	localTasks []discord.StatsTrackerTaskState,
+	tasks []discord.StatsTrackerTask,
+	channelTimezone *time.Location,
 	zeroIndexedPage int,
 ) []discordgo.MessageComponent {
-	if len(localTasks) > pageSize {
+	allTasksCount := len(tasks)
+	if len(tasks) > pageSize {
 		shift := zeroIndexedPage * pageSize
-		localTasks = localTasks[shift:min(shift+pageSize, len(localTasks))]
+		tasks = tasks[shift:min(shift+pageSize, len(tasks))]
 	}
-	rows := make([]discordgo.MessageComponent, 0, len(localTasks)+1)
-	for _, t := range localTasks {
+	rows := make([]discordgo.MessageComponent, 0, len(tasks)+1)
+	for _, t := range tasks {
+		editButtonId := discord.NewStatsTrackerTaskEditButtonCustomId(t.Id)
+		if t.Recurrence != discord.WeeklyStatsTrackerTask {
+			editButtonId = discord.NewStatsTrackerDatedTaskEditButtonCustomId(t.Id)
+		}
 		rows = append(rows, discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
 				discordgo.Button{
-					CustomID: discord.NewStatsTrackerTaskEditButtonCustomId(t.TaskId),
-					Label: fmt.Sprintf(
-						"%s, %02d:%02d, %s",
-						renderWeekday(p, t.LocalWeekdays[0]),
-						t.LocalStartHour,
-						t.LocalStartMin,
-						renderDuration(p, t.Duration),
-					),
-					Style: discordgo.SecondaryButton,
+					CustomID: editButtonId,
+					Label:    truncateButtonLabel(renderStatsTrackerTask(p, t, channelTimezone)),
+					Style:    discordgo.SecondaryButton,
 				},
 				discordgo.Button{
-					CustomID: discord.NewStatsTrackerTaskRemoveButtonCustomId(t.TaskId),
+					CustomID: discord.NewStatsTrackerTaskRemoveButtonCustomId(t.Id),
 					Label:    p.Sprintf("Remove"),
 					Style:    discordgo.DangerButton,
 				},
@@ -88,8 +131,13 @@ func statsTrackerScheduleEditForm(
 		Label:    p.Sprintf("Add new task"),
 		Style:    discordgo.PrimaryButton,
 	}
-	lastRow := []discordgo.MessageComponent{addButton}
-	if len(localTasks) > 4 {
+	addDatedButton := discordgo.Button{
+		CustomID: discord.STATS_TRACKER_TASKS_ADD_DATED_BUTTON_CUSTOM_ID,
+		Label:    p.Sprintf("Add dated task"),
+		Style:    discordgo.PrimaryButton,
+	}
+	lastRow := []discordgo.MessageComponent{addButton, addDatedButton}
+	if allTasksCount > pageSize {
 		if zeroIndexedPage > 0 {
 			lastRow = []discordgo.MessageComponent{
 				discordgo.Button{
@@ -98,9 +146,10 @@ func statsTrackerScheduleEditForm(
 					Style:    discordgo.SecondaryButton,
 				},
 				addButton,
+				addDatedButton,
 			}
 		}
-		if zeroIndexedPage < int(math.Ceil(float64(len(localTasks))/float64(pageSize)))-1 {
+		if zeroIndexedPage < int(math.Ceil(float64(allTasksCount)/float64(pageSize)))-1 {
 			lastRow = append(lastRow, discordgo.Button{
 				CustomID: discord.NewStatsTrackerTaskPageButtonCustomId(zeroIndexedPage + 1),
 				Label:    p.Sprintf("Next"),
@@ -116,22 +165,18 @@ func statsTrackerScheduleEditForm(
 
 func renderStatsTrackerSchedule(
 	p *message.Printer,
-	localTasks []discord.StatsTrackerTaskState,
+	tasks []discord.StatsTrackerTask,
+	channelTimezone *time.Location,
 ) string {
 	sb := strings.Builder{}
 	sb.WriteString(p.Sprintf("Schedule:"))
-	if len(localTasks) == 0 {
+	if len(tasks) == 0 {
 		sb.WriteString(p.Sprintf("\n- No tasks were found"))
 		return sb.String()
 	}
-	for _, t := range localTasks {
-		sb.WriteString(p.Sprintf(
-			"\n- %s, %02d:%02d, %s",
-			renderWeekday(p, t.LocalWeekdays[0]),
-			t.LocalStartHour,
-			t.LocalStartMin,
-			renderDuration(p, t.Duration),
-		))
+	for _, t := range tasks {
+		sb.WriteString("\n- ")
+		sb.WriteString(renderStatsTrackerTask(p, t, channelTimezone))
 	}
 	return sb.String()
 }
@@ -237,6 +282,11 @@ func (m *Messages) statsTrackerCreateTaskForm(
 					Label:    p.Sprintf("Submit"),
 					Style:    discordgo.SuccessButton,
 				},
+				discordgo.Button{
+					CustomID: discord.STATS_TRACKER_TASK_FILTER_BUTTON_CUSTOM_ID,
+					Label:    truncateButtonLabel(renderStatsTrackerFilterLabel(p, s.Filter)),
+					Style:    discordgo.SecondaryButton,
+				},
 				discordgo.Button{
 					CustomID: discord.STATS_TRACKER_TASK_CANCEL_BUTTON_CUSTOM_ID,
 					Label:    p.Sprintf("Cancel"),
@@ -247,6 +297,112 @@ func (m *Messages) statsTrackerCreateTaskForm(
 	}
 }
 
+func renderStatsTrackerFilterLabel(p *message.Printer, filter discord.StatsTrackerFilter) string {
+	if len(filter) == 0 {
+		return p.Sprintf("Track everything")
+	}
+	return p.Sprintf("Only %s", filter.String())
+}
+
+func statsTrackerFilterInput(p *message.Printer, filter discord.StatsTrackerFilter) discordgo.ActionsRow {
+	return discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.TextInput{
+				CustomID:    "filter",
+				Label:       p.Sprintf("Only outfits or characters"),
+				Placeholder: p.Sprintf("Outfit tags or character names separated by comma"),
+				Style:       discordgo.TextInputShort,
+				Value:       filter.String(),
+			},
+		},
+	}
+}
+
+func statsTrackerTaskFilterModal(
+	p *message.Printer,
+	s discord.StatsTrackerTaskState,
+) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		statsTrackerFilterInput(p, s.Filter),
+	}
+}
+
+func renderDurationInput(d time.Duration) string {
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+	if minutes == 0 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%dm", hours, minutes)
+}
+
+func statsTrackerDatedTaskModal(
+	p *message.Printer,
+	s discord.StatsTrackerTaskState,
+) []discordgo.MessageComponent {
+	dates := make([]string, 0, len(s.LocalDates))
+	for _, d := range s.LocalDates {
+		dates = append(dates, d.String())
+	}
+	interval := ""
+	if s.Recurrence == discord.IntervalStatsTrackerTask {
+		interval = strconv.Itoa(s.IntervalDays)
+	}
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.TextInput{
+					CustomID:    "dates",
+					Label:       p.Sprintf("Dates"),
+					Placeholder: p.Sprintf("YYYY-MM-DD separated by comma, a single date for a one-off task"),
+					Style:       discordgo.TextInputShort,
+					Value:       strings.Join(dates, ", "),
+					Required:    true,
+				},
+			},
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.TextInput{
+					CustomID:    "interval",
+					Label:       p.Sprintf("Repeat every N days"),
+					Placeholder: p.Sprintf("Leave empty to run only on the dates"),
+					Style:       discordgo.TextInputShort,
+					Value:       interval,
+				},
+			},
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.TextInput{
+					CustomID:    "start_time",
+					Label:       p.Sprintf("Start time"),
+					Placeholder: "HH:MM",
+					Style:       discordgo.TextInputShort,
+					Value:       fmt.Sprintf("%02d:%02d", s.LocalStartHour, s.LocalStartMin),
+					Required:    true,
+				},
+			},
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.TextInput{
+					CustomID:    "duration",
+					Label:       p.Sprintf("Duration"),
+					Placeholder: "1h30m",
+					Style:       discordgo.TextInputShort,
+					Value:       renderDurationInput(s.Duration),
+					Required:    true,
+				},
+			},
+		},
+		statsTrackerFilterInput(p, s.Filter),
+	}
+}
+
 func renderTaskFormError(p *message.Printer, err error) string {
 	if errors.Is(err, discord.ErrMaxAmountOfTasksExceeded) {
 		return p.Sprintf(
@@ -264,22 +420,46 @@ func renderTaskFormError(p *message.Printer, err error) string {
 	}
 	var o discord.ErrOverlappingTasks
 	if errors.As(err, &o) {
-		t := o.Tasks[0]
-		tStartWeekday, tStartTime := shared.NormalizeDate(t.UtcStartWeekday, t.UtcStartTime-o.Offset)
-		tEndWeekday, tEndTime := shared.NormalizeDate(t.UtcStartWeekday, t.UtcStartTime+o.Duration-o.Offset)
-		tDuration := tEndTime - tStartTime
-		if tStartWeekday != tEndWeekday {
-			tDuration += 24 * time.Hour
-		}
 		return p.Sprintf(
-			"Your task (%s, %s, %s) overlaps with existing task (%s, %s, %s)",
-			renderWeekday(p, o.LocalWeekday),
-			renderDurationAsTime(o.LocalStartTime),
-			renderDuration(p, o.Duration),
-			renderWeekday(p, tStartWeekday),
-			renderDurationAsTime(tStartTime),
-			renderDuration(p, tDuration),
+			"Your task (%s) overlaps with existing task (%s)",
+			renderStatsTrackerTask(p, o.Task, o.Task.Timezone),
+			renderStatsTrackerTask(p, o.Tasks[0], o.Task.Timezone),
+		)
+	}
+	if errors.Is(err, discord.ErrInvalidStatsTrackerTaskDates) {
+		return p.Sprintf(
+			"Dates should be in the YYYY-MM-DD format separated by comma, maximum %d",
+			discord.MAX_AMOUNT_OF_DATES_PER_TASK,
+		)
+	}
+	if errors.Is(err, discord.ErrInvalidStatsTrackerTaskInterval) {
+		return p.Sprintf(
+			"Interval should be a number of days from 1 to %d with a single starting date",
+			discord.MAX_STATS_TRACKER_TASK_INTERVAL_DAYS,
 		)
 	}
+	if errors.Is(err, discord.ErrInvalidStatsTrackerTaskStartTime) {
+		return p.Sprintf("Start time should be in the HH:MM format")
+	}
+	if errors.Is(err, discord.ErrInvalidStatsTrackerTaskDuration) {
+		return p.Sprintf("Duration should be like 1h30m")
+	}
+	if errors.Is(err, discord.ErrInvalidStatsTrackerFilter) {
+		return renderStatsTrackerFilterError(p, err)
+	}
 	return p.Sprintf("Something went wrong")
 }
+
+func renderStatsTrackerFilterError(p *message.Printer, err error) string {
+	var unknown stats_tracker.ErrUnknownFilterNames
+	if errors.As(err, &unknown) {
+		return p.Sprintf(
+			"These names are not tracked by the channel: %s",
+			strings.Join(unknown.Names, ", "),
+		)
+	}
+	return p.Sprintf(
+		"Filter should contain up to %d outfit tags or character names separated by comma",
+		discord.MAX_STATS_TRACKER_FILTER_SIZE,
+	)
+}
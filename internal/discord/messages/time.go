@@ -16,6 +16,14 @@ func renderRelativeTime(t time.Time) string {
 	return fmt.Sprintf("<t:%d:R>", t.Unix())
 }
 
+func renderDate(t time.Time) string {
+	return fmt.Sprintf("<t:%d:d>", t.Unix())
+}
+
+func renderDateTime(t time.Time) string {
+	return fmt.Sprintf("<t:%d:f>", t.Unix())
+}
+
 func renderDuration(p *message.Printer, d time.Duration) string {
 	h := int(d.Hours())
 	m := int(d.Minutes()) % 60
@@ -0,0 +1,86 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func testChunkableMessage(name string, chunks int) ChunkableMessage {
+	return NewChunkableMessage(chunks, func(p *message.Printer, start, count int) (string, *Error) {
+		rows := make([]string, 0, count)
+		for i := start; i < start+count; i++ {
+			rows = append(rows, fmt.Sprintf("%s%d", name, i))
+		}
+		return strings.Join(rows, ","), nil
+	})
+}
+
+func TestJoinChunkableMessages(t *testing.T) {
+	msg := JoinChunkableMessages(
+		testChunkableMessage("a", 2),
+		testChunkableMessage("empty", 0),
+		testChunkableMessage("b", 3),
+	)(message.NewPrinter(language.English))
+	if msg.Chunks != 5 {
+		t.Fatalf("expected 5 chunks, got %d", msg.Chunks)
+	}
+	cases := []struct {
+		name     string
+		start    int
+		count    int
+		expected string
+	}{
+		{
+			name:     "all chunks",
+			start:    0,
+			count:    5,
+			expected: "a0,a1\nb0,b1,b2",
+		},
+		{
+			name:     "inside the first message",
+			start:    1,
+			count:    1,
+			expected: "a1",
+		},
+		{
+			name:     "across the messages",
+			start:    1,
+			count:    2,
+			expected: "a1\nb0",
+		},
+		{
+			name:     "inside the last message",
+			start:    3,
+			count:    2,
+			expected: "b1,b2",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			content, err := msg.Print(c.start, c.count)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err.Err)
+			}
+			if content != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, content)
+			}
+		})
+	}
+}
+
+func TestJoinChunkableMessagesWithoutChunks(t *testing.T) {
+	empty := NewChunkableMessage(0, func(p *message.Printer, start, count int) (string, *Error) {
+		return "No data collected", nil
+	})
+	msg := JoinChunkableMessages(empty, testChunkableMessage("b", 0))(message.NewPrinter(language.English))
+	if msg.Chunks != 0 {
+		t.Fatalf("expected no chunks, got %d", msg.Chunks)
+	}
+	if content, _ := msg.Print(0, 0); content != "No data collected" {
+		t.Errorf("expected the first message, got %q", content)
+	}
+}
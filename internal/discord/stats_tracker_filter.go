@@ -0,0 +1,41 @@
+package discord
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+const MAX_STATS_TRACKER_FILTER_SIZE = 10
+const maxStatsTrackerFilterNameLength = 32
+
+var ErrInvalidStatsTrackerFilter = errors.New("invalid stats tracker filter")
+
+// Outfit tags and character names the stats tracker is limited to,
+// an empty filter tracks everything the channel tracks
+type StatsTrackerFilter []string
+
+func ParseStatsTrackerFilter(raw string) (StatsTrackerFilter, error) {
+	var filter StatsTrackerFilter
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if len(name) > maxStatsTrackerFilterNameLength || strings.ContainsAny(name, " \t\n") {
+			return nil, ErrInvalidStatsTrackerFilter
+		}
+		if slices.ContainsFunc(filter, func(n string) bool { return strings.EqualFold(n, name) }) {
+			continue
+		}
+		filter = append(filter, name)
+	}
+	if len(filter) > MAX_STATS_TRACKER_FILTER_SIZE {
+		return nil, ErrInvalidStatsTrackerFilter
+	}
+	return filter, nil
+}
+
+func (f StatsTrackerFilter) String() string {
+	return strings.Join(f, ", ")
+}
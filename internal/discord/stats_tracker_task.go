@@ -0,0 +1,129 @@
+package discord
+
+import (
+	"iter"
+	"slices"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/shared"
+)
+
+// Recurrences are checked over a year ahead to find overlaps
+const statsTrackerTaskOverlapHorizon = 366 * 24 * time.Hour
+
+func (t StatsTrackerTask) OccursOn(date shared.Date) bool {
+	switch t.Recurrence {
+	case WeeklyStatsTrackerTask:
+		return date.Weekday() == t.Weekday
+	case IntervalStatsTrackerTask:
+		if len(t.Dates) == 0 || t.IntervalDays < 1 {
+			return false
+		}
+		days := date.DaysSince(t.Dates[0])
+		return days >= 0 && days%t.IntervalDays == 0
+	case DatesStatsTrackerTask:
+		return slices.Contains(t.Dates, date)
+	}
+	return false
+}
+
+// Windows of the task that intersect with [from, to) in chronological order
+func (t StatsTrackerTask) Windows(from, to time.Time) iter.Seq2[time.Time, time.Time] {
+	return func(yield func(time.Time, time.Time) bool) {
+		// The window may start on one of the previous days
+		date := shared.DateOf(from.In(t.Timezone).Add(-t.Duration)).AddDays(-1)
+		last := shared.DateOf(to.In(t.Timezone))
+		for ; !last.Before(date); date = date.AddDays(1) {
+			if !t.OccursOn(date) {
+				continue
+			}
+			start := date.At(t.StartTime, t.Timezone)
+			end := start.Add(t.Duration)
+			if !end.After(from) || !start.Before(to) {
+				continue
+			}
+			if !yield(start, end) {
+				return
+			}
+		}
+	}
+}
+
+func (t StatsTrackerTask) IsActive(now time.Time) bool {
+	for range t.Windows(now, now.Add(time.Nanosecond)) {
+		return true
+	}
+	return false
+}
+
+// Returns false for tasks without the last occurrence
+func (t StatsTrackerTask) ExpiresAt() (time.Time, bool) {
+	if t.Recurrence != DatesStatsTrackerTask || len(t.Dates) == 0 {
+		return time.Time{}, false
+	}
+	last := t.Dates[0]
+	for _, d := range t.Dates[1:] {
+		if last.Before(d) {
+			last = d
+		}
+	}
+	return last.At(t.StartTime, t.Timezone).Add(t.Duration), true
+}
+
+func (t StatsTrackerTask) Overlaps(other StatsTrackerTask, from time.Time) bool {
+	// Dated tasks may be scheduled beyond the horizon, so their own windows are checked
+	if other.Recurrence == DatesStatsTrackerTask && t.Recurrence != DatesStatsTrackerTask {
+		t, other = other, t
+	}
+	if t.Recurrence == DatesStatsTrackerTask {
+		for _, date := range t.Dates {
+			start := date.At(t.StartTime, t.Timezone)
+			end := start.Add(t.Duration)
+			if !end.After(from) {
+				continue
+			}
+			for range other.Windows(start, end) {
+				return true
+			}
+		}
+		return false
+	}
+	to := from.Add(statsTrackerTaskOverlapHorizon)
+	type window struct {
+		start time.Time
+		end   time.Time
+	}
+	otherWindows := make([]window, 0)
+	for start, end := range other.Windows(from, to) {
+		otherWindows = append(otherWindows, window{start, end})
+	}
+	i := 0
+	for start, end := range t.Windows(from, to) {
+		for i < len(otherWindows) && !otherWindows[i].end.After(start) {
+			i++
+		}
+		if i < len(otherWindows) && otherWindows[i].start.Before(end) {
+			return true
+		}
+	}
+	return false
+}
+
+// Start of the current or the next window,
+// returns false if the task will not occur anymore
+func (t StatsTrackerTask) NextStart(now time.Time) (time.Time, bool) {
+	if t.Recurrence == DatesStatsTrackerTask {
+		next, ok := time.Time{}, false
+		for _, date := range t.Dates {
+			start := date.At(t.StartTime, t.Timezone)
+			if start.Add(t.Duration).After(now) && (!ok || start.Before(next)) {
+				next, ok = start, true
+			}
+		}
+		return next, ok
+	}
+	for start := range t.Windows(now, now.Add(statsTrackerTaskOverlapHorizon)) {
+		return start, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,434 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/shared"
+)
+
+func loadBerlin(t *testing.T) *time.Location {
+	t.Helper()
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("timezone database is not available")
+	}
+	return berlin
+}
+
+func date(year int, month time.Month, day int) shared.Date {
+	return shared.Date{Year: year, Month: month, Day: day}
+}
+
+func utc(month time.Month, day, hour, minute int) time.Time {
+	return time.Date(2024, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestStatsTrackerTaskOccursOn(t *testing.T) {
+	weekly := StatsTrackerTask{
+		Recurrence: WeeklyStatsTrackerTask,
+		Weekday:    time.Sunday,
+	}
+	interval := StatsTrackerTask{
+		Recurrence:   IntervalStatsTrackerTask,
+		IntervalDays: 3,
+		Dates:        []shared.Date{date(2024, time.October, 25)},
+	}
+	dated := StatsTrackerTask{
+		Recurrence: DatesStatsTrackerTask,
+		Dates:      []shared.Date{date(2024, time.October, 25), date(2024, time.November, 1)},
+	}
+	tests := []struct {
+		name string
+		task StatsTrackerTask
+		date shared.Date
+		want bool
+	}{
+		{
+			name: "weekly on the weekday",
+			task: weekly,
+			date: date(2024, time.October, 27),
+			want: true,
+		},
+		{
+			name: "weekly on another weekday",
+			task: weekly,
+			date: date(2024, time.October, 28),
+			want: false,
+		},
+		{
+			name: "interval on the starting date",
+			task: interval,
+			date: date(2024, time.October, 25),
+			want: true,
+		},
+		{
+			name: "interval across the month end",
+			task: interval,
+			date: date(2024, time.November, 3),
+			want: true,
+		},
+		{
+			name: "interval between occurrences",
+			task: interval,
+			date: date(2024, time.October, 29),
+			want: false,
+		},
+		{
+			name: "interval before the starting date",
+			task: interval,
+			date: date(2024, time.October, 22),
+			want: false,
+		},
+		{
+			name: "interval without days",
+			task: StatsTrackerTask{
+				Recurrence: IntervalStatsTrackerTask,
+				Dates:      interval.Dates,
+			},
+			date: date(2024, time.October, 25),
+			want: false,
+		},
+		{
+			name: "dated on the date",
+			task: dated,
+			date: date(2024, time.November, 1),
+			want: true,
+		},
+		{
+			name: "dated on another date",
+			task: dated,
+			date: date(2024, time.October, 26),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.OccursOn(tt.date); got != tt.want {
+				t.Errorf("StatsTrackerTask.OccursOn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatsTrackerTaskWindows(t *testing.T) {
+	berlin := loadBerlin(t)
+	type window struct {
+		start time.Time
+		end   time.Time
+	}
+	tests := []struct {
+		name string
+		task StatsTrackerTask
+		from time.Time
+		to   time.Time
+		want []window
+	}{
+		{
+			name: "weekly keeps the local time across DST end",
+			task: StatsTrackerTask{
+				Timezone:   berlin,
+				Recurrence: WeeklyStatsTrackerTask,
+				Weekday:    time.Saturday,
+				StartTime:  20*time.Hour + 30*time.Minute,
+				Duration:   2 * time.Hour,
+			},
+			from: utc(time.October, 19, 0, 0),
+			to:   utc(time.November, 3, 0, 0),
+			want: []window{
+				{utc(time.October, 19, 18, 30), utc(time.October, 19, 20, 30)},
+				{utc(time.October, 26, 18, 30), utc(time.October, 26, 20, 30)},
+				{utc(time.November, 2, 19, 30), utc(time.November, 2, 21, 30)},
+			},
+		},
+		{
+			name: "weekly keeps the local time across DST start",
+			task: StatsTrackerTask{
+				Timezone:   berlin,
+				Recurrence: WeeklyStatsTrackerTask,
+				Weekday:    time.Sunday,
+				StartTime:  20 * time.Hour,
+				Duration:   time.Hour,
+			},
+			from: utc(time.March, 24, 0, 0),
+			to:   utc(time.April, 1, 0, 0),
+			want: []window{
+				{utc(time.March, 24, 19, 0), utc(time.March, 24, 20, 0)},
+				{utc(time.March, 31, 18, 0), utc(time.March, 31, 19, 0)},
+			},
+		},
+		{
+			name: "duration is not affected by the repeated hour",
+			task: StatsTrackerTask{
+				Timezone:   berlin,
+				Recurrence: WeeklyStatsTrackerTask,
+				Weekday:    time.Sunday,
+				StartTime:  time.Hour,
+				Duration:   3 * time.Hour,
+			},
+			from: utc(time.October, 26, 0, 0),
+			to:   utc(time.October, 28, 0, 0),
+			want: []window{
+				{utc(time.October, 26, 23, 0), utc(time.October, 27, 2, 0)},
+			},
+		},
+		{
+			name: "window started on the previous day",
+			task: StatsTrackerTask{
+				Timezone:   berlin,
+				Recurrence: WeeklyStatsTrackerTask,
+				Weekday:    time.Friday,
+				StartTime:  23 * time.Hour,
+				Duration:   2 * time.Hour,
+			},
+			from: utc(time.October, 25, 22, 30),
+			to:   utc(time.October, 25, 22, 31),
+			want: []window{
+				{utc(time.October, 25, 21, 0), utc(time.October, 25, 23, 0)},
+			},
+		},
+		{
+			name: "interval occurrences",
+			task: StatsTrackerTask{
+				Timezone:     berlin,
+				Recurrence:   IntervalStatsTrackerTask,
+				IntervalDays: 2,
+				Dates:        []shared.Date{date(2024, time.October, 26)},
+				StartTime:    12 * time.Hour,
+				Duration:     time.Hour,
+			},
+			from: utc(time.October, 20, 0, 0),
+			to:   utc(time.October, 31, 0, 0),
+			want: []window{
+				{utc(time.October, 26, 10, 0), utc(time.October, 26, 11, 0)},
+				{utc(time.October, 28, 11, 0), utc(time.October, 28, 12, 0)},
+				{utc(time.October, 30, 11, 0), utc(time.October, 30, 12, 0)},
+			},
+		},
+		{
+			name: "dated occurrences within the range",
+			task: StatsTrackerTask{
+				Timezone:   berlin,
+				Recurrence: DatesStatsTrackerTask,
+				Dates: []shared.Date{
+					date(2024, time.November, 1),
+					date(2024, time.October, 1),
+					date(2024, time.December, 1),
+				},
+				StartTime: 18 * time.Hour,
+				Duration:  time.Hour,
+			},
+			from: utc(time.September, 15, 0, 0),
+			to:   utc(time.November, 15, 0, 0),
+			want: []window{
+				{utc(time.October, 1, 16, 0), utc(time.October, 1, 17, 0)},
+				{utc(time.November, 1, 17, 0), utc(time.November, 1, 18, 0)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]window, 0)
+			for start, end := range tt.task.Windows(tt.from, tt.to) {
+				got = append(got, window{start, end})
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("StatsTrackerTask.Windows() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].start.Equal(tt.want[i].start) || !got[i].end.Equal(tt.want[i].end) {
+					t.Errorf("window %d = [%v, %v), want [%v, %v)", i, got[i].start.UTC(), got[i].end.UTC(), tt.want[i].start, tt.want[i].end)
+				}
+			}
+		})
+	}
+}
+
+func TestStatsTrackerTaskNextStart(t *testing.T) {
+	berlin := loadBerlin(t)
+	weekly := StatsTrackerTask{
+		Timezone:   berlin,
+		Recurrence: WeeklyStatsTrackerTask,
+		Weekday:    time.Saturday,
+		StartTime:  20 * time.Hour,
+		Duration:   2 * time.Hour,
+	}
+	dated := StatsTrackerTask{
+		Timezone:   berlin,
+		Recurrence: DatesStatsTrackerTask,
+		Dates:      []shared.Date{date(2024, time.November, 2), date(2024, time.October, 26)},
+		StartTime:  20 * time.Hour,
+		Duration:   2 * time.Hour,
+	}
+	tests := []struct {
+		name   string
+		task   StatsTrackerTask
+		now    time.Time
+		want   time.Time
+		wantOk bool
+	}{
+		{
+			name:   "weekly after DST end",
+			task:   weekly,
+			now:    utc(time.October, 27, 0, 0),
+			want:   utc(time.November, 2, 19, 0),
+			wantOk: true,
+		},
+		{
+			name:   "weekly during the window",
+			task:   weekly,
+			now:    utc(time.October, 26, 19, 0),
+			want:   utc(time.October, 26, 18, 0),
+			wantOk: true,
+		},
+		{
+			name:   "dated picks the earliest pending date",
+			task:   dated,
+			now:    utc(time.October, 1, 0, 0),
+			want:   utc(time.October, 26, 18, 0),
+			wantOk: true,
+		},
+		{
+			name:   "dated skips passed dates",
+			task:   dated,
+			now:    utc(time.October, 27, 0, 0),
+			want:   utc(time.November, 2, 19, 0),
+			wantOk: true,
+		},
+		{
+			name:   "dated without pending dates",
+			task:   dated,
+			now:    utc(time.November, 3, 0, 0),
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.task.NextStart(tt.now)
+			if ok != tt.wantOk {
+				t.Fatalf("StatsTrackerTask.NextStart() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && !got.Equal(tt.want) {
+				t.Errorf("StatsTrackerTask.NextStart() = %v, want %v", got.UTC(), tt.want)
+			}
+		})
+	}
+}
+
+func TestStatsTrackerTaskOverlaps(t *testing.T) {
+	berlin := loadBerlin(t)
+	weekly := StatsTrackerTask{
+		Timezone:   berlin,
+		Recurrence: WeeklyStatsTrackerTask,
+		Weekday:    time.Saturday,
+		StartTime:  20 * time.Hour,
+		Duration:   2 * time.Hour,
+	}
+	tests := []struct {
+		name  string
+		task  StatsTrackerTask
+		other StatsTrackerTask
+		from  time.Time
+		want  bool
+	}{
+		{
+			name: "weekly on different weekdays",
+			task: weekly,
+			other: StatsTrackerTask{
+				Timezone:   berlin,
+				Recurrence: WeeklyStatsTrackerTask,
+				Weekday:    time.Sunday,
+				StartTime:  20 * time.Hour,
+				Duration:   2 * time.Hour,
+			},
+			from: utc(time.October, 1, 0, 0),
+			want: false,
+		},
+		{
+			name: "adjacent windows",
+			task: weekly,
+			other: StatsTrackerTask{
+				Timezone:   berlin,
+				Recurrence: WeeklyStatsTrackerTask,
+				Weekday:    time.Saturday,
+				StartTime:  22 * time.Hour,
+				Duration:   time.Hour,
+			},
+			from: utc(time.October, 1, 0, 0),
+			want: false,
+		},
+		{
+			name: "other timezone overlaps only after DST end",
+			task: weekly,
+			other: StatsTrackerTask{
+				Timezone:   time.UTC,
+				Recurrence: WeeklyStatsTrackerTask,
+				Weekday:    time.Saturday,
+				StartTime:  20*time.Hour + 30*time.Minute,
+				Duration:   time.Hour,
+			},
+			from: utc(time.October, 1, 0, 0),
+			want: true,
+		},
+		{
+			name: "interval meets the weekday",
+			task: weekly,
+			other: StatsTrackerTask{
+				Timezone:     berlin,
+				Recurrence:   IntervalStatsTrackerTask,
+				IntervalDays: 5,
+				Dates:        []shared.Date{date(2024, time.October, 1)},
+				StartTime:    21 * time.Hour,
+				Duration:     time.Hour,
+			},
+			from: utc(time.October, 1, 0, 0),
+			want: true,
+		},
+		{
+			name: "dated on the weekday",
+			task: StatsTrackerTask{
+				Timezone:   berlin,
+				Recurrence: DatesStatsTrackerTask,
+				Dates:      []shared.Date{date(2024, time.November, 2)},
+				StartTime:  21 * time.Hour,
+				Duration:   time.Hour,
+			},
+			other: weekly,
+			from:  utc(time.October, 1, 0, 0),
+			want:  true,
+		},
+		{
+			name: "passed dates are ignored",
+			task: weekly,
+			other: StatsTrackerTask{
+				Timezone:   berlin,
+				Recurrence: DatesStatsTrackerTask,
+				Dates:      []shared.Date{date(2024, time.September, 28)},
+				StartTime:  21 * time.Hour,
+				Duration:   time.Hour,
+			},
+			from: utc(time.October, 1, 0, 0),
+			want: false,
+		},
+		{
+			name: "dates beyond the horizon",
+			task: weekly,
+			other: StatsTrackerTask{
+				Timezone:   berlin,
+				Recurrence: DatesStatsTrackerTask,
+				Dates:      []shared.Date{date(2026, time.October, 3)},
+				StartTime:  21 * time.Hour,
+				Duration:   time.Hour,
+			},
+			from: utc(time.October, 1, 0, 0),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.Overlaps(tt.other, tt.from); got != tt.want {
+				t.Errorf("StatsTrackerTask.Overlaps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -182,7 +182,53 @@ type CharactersWorldItem struct {
 	WorldId     string `json:"world_id"`
 }
 
+type LocalizedName struct {
+	En string `json:"en"`
+}
+
+const Vehicle = "vehicle"
+
+type VehicleItem struct {
+	VehicleId string        `json:"vehicle_id"`
+	Name      LocalizedName `json:"name"`
+	TypeId    string        `json:"type_id"`
+	TypeName  string        `json:"type_name"`
+}
+
+const ItemCategory = "item_category"
+
+type ItemCategoryItem struct {
+	ItemCategoryId string        `json:"item_category_id"`
+	Name           LocalizedName `json:"name"`
+}
+
+const Item = "item"
+
+type ItemItem struct {
+	ItemId         string        `json:"item_id"`
+	ItemTypeId     string        `json:"item_type_id"`
+	ItemCategoryId string        `json:"item_category_id"`
+	Name           LocalizedName `json:"name"`
+	// Joinable
+	ItemCategory ItemCategoryItem `json:"item_category"`
+}
+
+const Achievement = "achievement"
+
+type AchievementItem struct {
+	AchievementId string        `json:"achievement_id"`
+	Name          LocalizedName `json:"name"`
+	Description   LocalizedName `json:"description"`
+}
+
 const CharactersOnlineStatus = "characters_online_status"
+
+type CharactersOnlineStatusItem struct {
+	CharacterId string `json:"character_id"`
+	// "0" if offline, otherwise the world id
+	OnlineStatus string `json:"online_status"`
+}
+
 const CharactersFriend = "characters_friend"
 const Leaderboard = "leaderboard"
 const CharactersLeaderboard = "characters_leaderboard"
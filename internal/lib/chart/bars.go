@@ -0,0 +1,50 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+)
+
+const (
+	barHeight      = 16
+	barRowHeight   = 24
+	maxLabelLength = 24
+)
+
+type Bar struct {
+	Label string
+	Value float64
+	Color color.Color
+	// Text next to the bar
+	Caption string
+}
+
+// Horizontal bars scaled to the maximum value, negative values are rendered as zero
+func Bars(title string, bars []Bar) *image.RGBA {
+	height := padding + lineHeight + len(bars)*barRowHeight + padding
+	img := newImage(height)
+	y := drawTitle(img, title)
+	labelWidth, captionWidth := 0, 0
+	maxValue := 0.0
+	for _, bar := range bars {
+		labelWidth = max(labelWidth, textWidth(bar.Label))
+		captionWidth = max(captionWidth, textWidth(bar.Caption))
+		maxValue = max(maxValue, bar.Value)
+	}
+	labelWidth = min(labelWidth, maxLabelLength*charWidth)
+	barsX := padding + labelWidth + 10
+	barsWidth := width - padding - captionWidth - 10 - barsX
+	for _, bar := range bars {
+		textY := y + (barRowHeight-textHeight)/2
+		drawText(img, padding, textY, fitText(bar.Label, labelWidth), Foreground)
+		w := 0
+		if maxValue > 0 && bar.Value > 0 {
+			w = max(int(float64(barsWidth)*bar.Value/maxValue), 1)
+		}
+		barY := y + (barRowHeight-barHeight)/2
+		fillRect(img, barsX, barY, barsX+w, barY+barHeight, bar.Color)
+		drawText(img, barsX+w+10, textY, bar.Caption, Muted)
+		y += barRowHeight
+	}
+	return img
+}
@@ -0,0 +1,154 @@
+// Package chart renders simple PNG charts without external services.
+package chart
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+)
+
+const (
+	width      = 800
+	padding    = 20
+	lineHeight = textHeight + 8
+)
+
+var (
+	Background = color.RGBA{0x2b, 0x2d, 0x31, 0xff}
+	Foreground = color.RGBA{0xdb, 0xde, 0xe1, 0xff}
+	Grid       = color.RGBA{0x4e, 0x50, 0x58, 0xff}
+	Muted      = color.RGBA{0x94, 0x9b, 0xa4, 0xff}
+
+	Red    = color.RGBA{0xe0, 0x4f, 0x4f, 0xff}
+	Green  = color.RGBA{0x4f, 0xc0, 0x6a, 0xff}
+	Blue   = color.RGBA{0x3d, 0x8b, 0xe0, 0xff}
+	Purple = color.RGBA{0x9b, 0x59, 0xd0, 0xff}
+	Yellow = color.RGBA{0xe8, 0xc2, 0x3a, 0xff}
+	Orange = color.RGBA{0xe6, 0x7e, 0x22, 0xff}
+	Teal   = color.RGBA{0x1a, 0xbc, 0x9c, 0xff}
+	Gray   = color.RGBA{0xa4, 0xab, 0xb3, 0xff}
+)
+
+// Colors for series without a specific meaning
+var Palette = []color.RGBA{Blue, Orange, Green, Red, Purple, Yellow, Teal, Gray}
+
+type Legend struct {
+	Name  string
+	Color color.Color
+}
+
+func newImage(height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(Background), image.Point{}, draw.Src)
+	return img
+}
+
+func fillRect(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	draw.Draw(img, image.Rect(x0, y0, x1, y1), image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// Draws the line of two pixels width
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		img.SetRGBA(x0, y0, rgba)
+		img.SetRGBA(x0+1, y0, rgba)
+		img.SetRGBA(x0, y0+1, rgba)
+		img.SetRGBA(x0+1, y0+1, rgba)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Returns the height of the legend that is rendered into the given width
+func legendHeight(items []Legend, w int) int {
+	if len(items) == 0 {
+		return 0
+	}
+	rows := 1
+	x := 0
+	for _, item := range items {
+		itemWidth := legendItemWidth(item)
+		if x > 0 && x+itemWidth > w {
+			rows++
+			x = 0
+		}
+		x += itemWidth
+	}
+	return rows * lineHeight
+}
+
+func legendItemWidth(item Legend) int {
+	return textHeight + 6 + textWidth(item.Name) + 16
+}
+
+func drawLegend(img *image.RGBA, x, y, w int, items []Legend) {
+	startX := x
+	for _, item := range items {
+		itemWidth := legendItemWidth(item)
+		if x > startX && x+itemWidth > startX+w {
+			x = startX
+			y += lineHeight
+		}
+		fillRect(img, x, y, x+textHeight, y+textHeight, item.Color)
+		drawText(img, x+textHeight+6, y, item.Name, Foreground)
+		x += itemWidth
+	}
+}
+
+func drawTitle(img *image.RGBA, title string) int {
+	drawText(img, padding, padding, fitText(title, width-2*padding), Foreground)
+	return padding + lineHeight
+}
+
+// Rounds the value up to 1, 2 or 5 multiplied by the power of ten
+func niceCeil(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+	exp := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if v <= m*exp {
+			return m * exp
+		}
+	}
+	return 10 * exp
+}
+
+func EncodePNG(img image.Image) ([]byte, error) {
+	buf := bytes.Buffer{}
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
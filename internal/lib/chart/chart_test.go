@@ -0,0 +1,77 @@
+package chart
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestEncodePNG(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		img  *image.RGBA
+	}{
+		{
+			name: "bars",
+			img: Bars("K/D", []Bar{
+				{Label: "first", Value: 2.5, Color: Green, Caption: "2.50"},
+				{Label: "second", Value: 0, Color: Red, Caption: "0.00"},
+			}),
+		},
+		{
+			name: "stacked bars",
+			img: StackedBars("Loadouts", []Legend{{"A", Red}, {"B", Blue}}, []StackedBar{
+				{Label: "first", Values: []float64{1, 3}},
+				{Label: "empty", Values: []float64{0, 0}},
+			}),
+		},
+		{
+			name: "lines",
+			img: Lines("Population", []Series{
+				{Name: "VS", Color: Purple, Points: []Point{{now, 10}, {now.Add(time.Hour), 25}}},
+				{Name: "NC", Color: Blue, Points: []Point{{now, 0}}},
+			}),
+		},
+		{
+			name: "empty lines",
+			img:  Lines("Population", nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncodePNG(tt.img)
+			if err != nil {
+				t.Fatalf("EncodePNG() error = %v", err)
+			}
+			decoded, err := png.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("png.Decode() error = %v", err)
+			}
+			if got, want := decoded.Bounds(), tt.img.Bounds(); got != want {
+				t.Errorf("decoded bounds = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestNiceCeil(t *testing.T) {
+	tests := []struct {
+		v    float64
+		want float64
+	}{
+		{0, 1},
+		{1, 1},
+		{3, 5},
+		{7, 10},
+		{120, 200},
+		{1500, 2000},
+	}
+	for _, tt := range tests {
+		if got := niceCeil(tt.v); got != tt.want {
+			t.Errorf("niceCeil(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
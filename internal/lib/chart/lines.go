@@ -0,0 +1,109 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"strconv"
+	"time"
+)
+
+const (
+	plotHeight = 300
+	yTicks     = 5
+	xTicks     = 5
+)
+
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+type Series struct {
+	Name  string
+	Color color.Color
+	// Sorted by time
+	Points []Point
+}
+
+// Lines of the series over time, the time labels are rendered
+// in the location of the first point
+func Lines(title string, series []Series) *image.RGBA {
+	legend := make([]Legend, 0, len(series))
+	var from, to time.Time
+	maxValue := 0.0
+	for _, s := range series {
+		legend = append(legend, Legend{Name: s.Name, Color: s.Color})
+		for _, p := range s.Points {
+			if from.IsZero() || p.Time.Before(from) {
+				from = p.Time
+			}
+			if to.IsZero() || p.Time.After(to) {
+				to = p.Time
+			}
+			maxValue = max(maxValue, p.Value)
+		}
+	}
+	legendH := legendHeight(legend, width-2*padding)
+	height := padding + lineHeight + legendH + plotHeight + lineHeight + padding
+	img := newImage(height)
+	y := drawTitle(img, title)
+	drawLegend(img, padding, y, width-2*padding, legend)
+	y += legendH
+	top := y + textHeight/2
+	bottom := y + plotHeight
+	yMax := niceCeil(maxValue)
+	labelWidth := textWidth(formatValue(yMax))
+	left := padding + labelWidth + 10
+	right := width - padding
+	for i := range yTicks + 1 {
+		v := yMax * float64(i) / yTicks
+		lineY := bottom - (bottom-top)*i/yTicks
+		fillRect(img, left, lineY, right, lineY+1, Grid)
+		label := formatValue(v)
+		drawText(img, left-10-textWidth(label), lineY-textHeight/2, label, Muted)
+	}
+	span := to.Sub(from)
+	layout := "15:04"
+	if span > 48*time.Hour {
+		layout = "01-02"
+	}
+	for i := range xTicks + 1 {
+		if span == 0 && i > 0 {
+			break
+		}
+		t := from.Add(span * time.Duration(i) / xTicks)
+		x := left + (right-left)*i/xTicks
+		fillRect(img, x, bottom, x+1, bottom+4, Grid)
+		label := t.Format(layout)
+		labelX := min(max(x-textWidth(label)/2, left), right-textWidth(label))
+		drawText(img, labelX, bottom+8, label, Muted)
+	}
+	toX := func(t time.Time) int {
+		if span == 0 {
+			return left
+		}
+		return left + int(float64(right-left)*float64(t.Sub(from))/float64(span))
+	}
+	toY := func(v float64) int {
+		return bottom - int(float64(bottom-top)*v/yMax)
+	}
+	for _, s := range series {
+		for i, p := range s.Points {
+			x, y := toX(p.Time), toY(p.Value)
+			if i == 0 {
+				drawLine(img, x, y, x, y, s.Color)
+				continue
+			}
+			prev := s.Points[i-1]
+			drawLine(img, toX(prev.Time), toY(prev.Value), x, y, s.Color)
+		}
+	}
+	return img
+}
+
+func formatValue(v float64) string {
+	if v == float64(int64(v)) {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
@@ -0,0 +1,54 @@
+package chart
+
+import (
+	"image"
+)
+
+type StackedBar struct {
+	Label string
+	// Values of the legend items in the same order
+	Values []float64
+}
+
+// Horizontal bars of the full width split into shares of the values
+func StackedBars(title string, legend []Legend, bars []StackedBar) *image.RGBA {
+	legendH := legendHeight(legend, width-2*padding)
+	height := padding + lineHeight + legendH + len(bars)*barRowHeight + padding
+	img := newImage(height)
+	y := drawTitle(img, title)
+	drawLegend(img, padding, y, width-2*padding, legend)
+	y += legendH
+	labelWidth := 0
+	for _, bar := range bars {
+		labelWidth = max(labelWidth, textWidth(bar.Label))
+	}
+	labelWidth = min(labelWidth, maxLabelLength*charWidth)
+	barsX := padding + labelWidth + 10
+	barsWidth := width - padding - barsX
+	for _, bar := range bars {
+		drawText(img, padding, y+(barRowHeight-textHeight)/2, fitText(bar.Label, labelWidth), Foreground)
+		barY := y + (barRowHeight-barHeight)/2
+		total := 0.0
+		for _, v := range bar.Values {
+			total += max(v, 0)
+		}
+		if total > 0 {
+			x := barsX
+			sum := 0.0
+			for i, v := range bar.Values {
+				if i >= len(legend) {
+					break
+				}
+				sum += max(v, 0)
+				// Cumulative rounding keeps the full width
+				end := barsX + int(float64(barsWidth)*sum/total)
+				fillRect(img, x, barY, end, barY+barHeight, legend[i].Color)
+				x = end
+			}
+		} else {
+			fillRect(img, barsX, barY, barsX+barsWidth, barY+barHeight, Grid)
+		}
+		y += barRowHeight
+	}
+	return img
+}
@@ -1,6 +1,7 @@
 package containers
 
 import (
+	"iter"
 	"time"
 )
 
@@ -31,11 +32,19 @@ func (l *ExpirationQueue[K]) Has(key K) bool {
 	return ok
 }
 
+func (l *ExpirationQueue[K]) UpdatedAt(key K) (time.Time, bool) {
+	if node, ok := l.nodes[key]; ok {
+		return node.updatedAt, true
+	}
+	return time.Time{}, false
+}
+
 func (l *ExpirationQueue[K]) Push(key K) {
 	now := time.Now()
 
 	if node, ok := l.nodes[key]; ok {
 		if node == l.tail {
+			node.updatedAt = now
 			return
 		}
 		// Should have next since it is not a tail
@@ -70,6 +79,48 @@ func (l *ExpirationQueue[K]) Push(key K) {
 	l.nodes[key] = node
 }
 
+// Inserts a new key with the given update time, does nothing
+// if the key is already present
+func (l *ExpirationQueue[K]) PushAt(key K, updatedAt time.Time) {
+	if _, ok := l.nodes[key]; ok {
+		return
+	}
+	node := &node[K]{key: key, updatedAt: updatedAt}
+	l.nodes[key] = node
+	prev := l.tail
+	for prev != nil && prev.updatedAt.After(updatedAt) {
+		prev = prev.prev
+	}
+	if prev == nil {
+		node.next = l.head
+		if l.head != nil {
+			l.head.prev = node
+		}
+		l.head = node
+	} else {
+		node.next = prev.next
+		node.prev = prev
+		if prev.next != nil {
+			prev.next.prev = node
+		}
+		prev.next = node
+	}
+	if node.next == nil {
+		l.tail = node
+	}
+}
+
+// Iterates over keys with their update time, the oldest first
+func (l *ExpirationQueue[K]) All() iter.Seq2[K, time.Time] {
+	return func(yield func(K, time.Time) bool) {
+		for curr := l.head; curr != nil; curr = curr.next {
+			if !yield(curr.key, curr.updatedAt) {
+				return
+			}
+		}
+	}
+}
+
 func (l *ExpirationQueue[K]) Remove(key K) {
 	if node, ok := l.nodes[key]; ok {
 		if node == l.head {
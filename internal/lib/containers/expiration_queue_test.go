@@ -0,0 +1,142 @@
+package containers
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+type pushAt struct {
+	key string
+	at  int
+}
+
+func keys(q *ExpirationQueue[string]) []string {
+	keys := make([]string, 0, q.Len())
+	for k := range q.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func reversedKeys(q *ExpirationQueue[string]) []string {
+	keys := make([]string, 0, q.Len())
+	for curr := q.tail; curr != nil; curr = curr.prev {
+		keys = append(keys, curr.key)
+	}
+	slices.Reverse(keys)
+	return keys
+}
+
+func TestExpirationQueuePushAt(t *testing.T) {
+	start := time.Now()
+	cases := []struct {
+		name     string
+		pushes   []pushAt
+		expected []string
+		times    []int
+	}{
+		{
+			name:     "empty queue",
+			pushes:   []pushAt{{"a", 1}},
+			expected: []string{"a"},
+			times:    []int{1},
+		},
+		{
+			name:     "insert at head",
+			pushes:   []pushAt{{"b", 2}, {"c", 3}, {"a", 1}},
+			expected: []string{"a", "b", "c"},
+			times:    []int{1, 2, 3},
+		},
+		{
+			name:     "insert in the middle",
+			pushes:   []pushAt{{"a", 1}, {"c", 3}, {"b", 2}},
+			expected: []string{"a", "b", "c"},
+			times:    []int{1, 2, 3},
+		},
+		{
+			name:     "insert at tail",
+			pushes:   []pushAt{{"a", 1}, {"b", 2}, {"c", 3}},
+			expected: []string{"a", "b", "c"},
+			times:    []int{1, 2, 3},
+		},
+		{
+			name:     "same time keeps insertion order",
+			pushes:   []pushAt{{"a", 1}, {"c", 2}, {"b", 2}},
+			expected: []string{"a", "c", "b"},
+			times:    []int{1, 2, 2},
+		},
+		{
+			name:     "duplicate key is ignored",
+			pushes:   []pushAt{{"a", 1}, {"b", 2}, {"a", 3}},
+			expected: []string{"a", "b"},
+			times:    []int{1, 2},
+		},
+		{
+			name:     "duplicate head key is ignored",
+			pushes:   []pushAt{{"b", 2}, {"a", 1}, {"b", 0}},
+			expected: []string{"a", "b"},
+			times:    []int{1, 2},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q := NewExpirationQueue[string]()
+			for _, p := range c.pushes {
+				q.PushAt(p.key, start.Add(time.Duration(p.at)*time.Second))
+			}
+			if q.Len() != len(c.expected) {
+				t.Errorf("expected length %d, got %d", len(c.expected), q.Len())
+			}
+			if got := keys(q); !slices.Equal(got, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, got)
+			}
+			if got := reversedKeys(q); !slices.Equal(got, c.expected) {
+				t.Errorf("expected backward links %v, got %v", c.expected, got)
+			}
+			i := 0
+			for k, at := range q.All() {
+				if expected := start.Add(time.Duration(c.times[i]) * time.Second); !at.Equal(expected) {
+					t.Errorf("expected %q to be updated at %v, got %v", k, expected, at)
+				}
+				i++
+			}
+		})
+	}
+}
+
+func TestExpirationQueuePushAtThenExpire(t *testing.T) {
+	start := time.Now()
+	q := NewExpirationQueue[string]()
+	q.PushAt("c", start.Add(3*time.Second))
+	q.PushAt("a", start.Add(1*time.Second))
+	q.PushAt("b", start.Add(2*time.Second))
+	var removed []string
+	count := q.RemoveExpired(start.Add(2500*time.Millisecond), func(key string) {
+		removed = append(removed, key)
+	})
+	if count != 2 || !slices.Equal(removed, []string{"a", "b"}) {
+		t.Errorf("expected to remove [a b], got %d %v", count, removed)
+	}
+	if got := keys(q); !slices.Equal(got, []string{"c"}) {
+		t.Errorf("expected [c], got %v", got)
+	}
+	q.Push("d")
+	q.Remove("c")
+	if got := reversedKeys(q); !slices.Equal(got, []string{"d"}) {
+		t.Errorf("expected [d], got %v", got)
+	}
+}
+
+func TestExpirationQueuePushRefreshesTail(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	q := NewExpirationQueue[string]()
+	q.PushAt("a", start)
+	q.Push("a")
+	if updatedAt, _ := q.UpdatedAt("a"); !updatedAt.After(start) {
+		t.Errorf("expected the update time of the tail to be refreshed, got %v", updatedAt)
+	}
+	if count := q.RemoveExpired(start.Add(time.Minute), func(string) {}); count != 0 {
+		t.Errorf("expected no expired keys, got %d", count)
+	}
+}
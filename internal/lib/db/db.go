@@ -30,12 +30,42 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deleteChannelOutfitsStmt, err = db.PrepareContext(ctx, deleteChannelOutfits); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteChannelOutfits: %w", err)
 	}
+	if q.deleteCharacterSessionsBeforeStmt, err = db.PrepareContext(ctx, deleteCharacterSessionsBefore); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCharacterSessionsBefore: %w", err)
+	}
+	if q.deleteCharactersTrackerPlayersStmt, err = db.PrepareContext(ctx, deleteCharactersTrackerPlayers); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCharactersTrackerPlayers: %w", err)
+	}
+	if q.deleteKnownCharactersBeforeStmt, err = db.PrepareContext(ctx, deleteKnownCharactersBefore); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteKnownCharactersBefore: %w", err)
+	}
 	if q.deleteOutfitMemberStmt, err = db.PrepareContext(ctx, deleteOutfitMember); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteOutfitMember: %w", err)
 	}
+	if q.deletePopulationSamplesStmt, err = db.PrepareContext(ctx, deletePopulationSamples); err != nil {
+		return nil, fmt.Errorf("error preparing query DeletePopulationSamples: %w", err)
+	}
+	if q.deleteStatsTrackerAutoStartStmt, err = db.PrepareContext(ctx, deleteStatsTrackerAutoStart); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteStatsTrackerAutoStart: %w", err)
+	}
+	if q.deleteUtcStatsTrackerTasksStmt, err = db.PrepareContext(ctx, deleteUtcStatsTrackerTasks); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUtcStatsTrackerTasks: %w", err)
+	}
+	if q.endAllCharacterSessionsStmt, err = db.PrepareContext(ctx, endAllCharacterSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query EndAllCharacterSessions: %w", err)
+	}
+	if q.endCharacterSessionStmt, err = db.PrepareContext(ctx, endCharacterSession); err != nil {
+		return nil, fmt.Errorf("error preparing query EndCharacterSession: %w", err)
+	}
+	if q.endStaleCharacterSessionsStmt, err = db.PrepareContext(ctx, endStaleCharacterSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query EndStaleCharacterSessions: %w", err)
+	}
 	if q.getChannelStmt, err = db.PrepareContext(ctx, getChannel); err != nil {
 		return nil, fmt.Errorf("error preparing query GetChannel: %w", err)
 	}
+	if q.getChannelStatsTrackerSessionStmt, err = db.PrepareContext(ctx, getChannelStatsTrackerSession); err != nil {
+		return nil, fmt.Errorf("error preparing query GetChannelStatsTrackerSession: %w", err)
+	}
 	if q.getCountChannelStatsTrackerTasksStmt, err = db.PrepareContext(ctx, getCountChannelStatsTrackerTasks); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCountChannelStatsTrackerTasks: %w", err)
 	}
@@ -48,6 +78,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getPlatformOutfitSynchronizedAtStmt, err = db.PrepareContext(ctx, getPlatformOutfitSynchronizedAt); err != nil {
 		return nil, fmt.Errorf("error preparing query GetPlatformOutfitSynchronizedAt: %w", err)
 	}
+	if q.getPreviousScheduledStatsTrackerSessionStmt, err = db.PrepareContext(ctx, getPreviousScheduledStatsTrackerSession); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPreviousScheduledStatsTrackerSession: %w", err)
+	}
+	if q.getStatsTrackerAutoStartStmt, err = db.PrepareContext(ctx, getStatsTrackerAutoStart); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStatsTrackerAutoStart: %w", err)
+	}
 	if q.getStatsTrackerTaskStmt, err = db.PrepareContext(ctx, getStatsTrackerTask); err != nil {
 		return nil, fmt.Errorf("error preparing query GetStatsTrackerTask: %w", err)
 	}
@@ -60,6 +96,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.insertChannelStatsTrackerTaskStmt, err = db.PrepareContext(ctx, insertChannelStatsTrackerTask); err != nil {
 		return nil, fmt.Errorf("error preparing query InsertChannelStatsTrackerTask: %w", err)
 	}
+	if q.insertCharacterSessionStmt, err = db.PrepareContext(ctx, insertCharacterSession); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertCharacterSession: %w", err)
+	}
+	if q.insertCharactersTrackerPlayerStmt, err = db.PrepareContext(ctx, insertCharactersTrackerPlayer); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertCharactersTrackerPlayer: %w", err)
+	}
 	if q.insertFacilityStmt, err = db.PrepareContext(ctx, insertFacility); err != nil {
 		return nil, fmt.Errorf("error preparing query InsertFacility: %w", err)
 	}
@@ -69,24 +111,54 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.insertOutfitMemberStmt, err = db.PrepareContext(ctx, insertOutfitMember); err != nil {
 		return nil, fmt.Errorf("error preparing query InsertOutfitMember: %w", err)
 	}
+	if q.insertPopulationSampleStmt, err = db.PrepareContext(ctx, insertPopulationSample); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertPopulationSample: %w", err)
+	}
+	if q.insertStatsTrackerSessionStmt, err = db.PrepareContext(ctx, insertStatsTrackerSession); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertStatsTrackerSession: %w", err)
+	}
+	if q.insertStatsTrackerSessionPlatformStmt, err = db.PrepareContext(ctx, insertStatsTrackerSessionPlatform); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertStatsTrackerSessionPlatform: %w", err)
+	}
+	if q.listActiveStatsTrackerSessionsStmt, err = db.PrepareContext(ctx, listActiveStatsTrackerSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListActiveStatsTrackerSessions: %w", err)
+	}
 	if q.listActiveStatsTrackerTasksStmt, err = db.PrepareContext(ctx, listActiveStatsTrackerTasks); err != nil {
 		return nil, fmt.Errorf("error preparing query ListActiveStatsTrackerTasks: %w", err)
 	}
 	if q.listChannelCharacterIdsForPlatformStmt, err = db.PrepareContext(ctx, listChannelCharacterIdsForPlatform); err != nil {
 		return nil, fmt.Errorf("error preparing query ListChannelCharacterIdsForPlatform: %w", err)
 	}
-	if q.listChannelIntersectingStatsTrackerTasksStmt, err = db.PrepareContext(ctx, listChannelIntersectingStatsTrackerTasks); err != nil {
-		return nil, fmt.Errorf("error preparing query ListChannelIntersectingStatsTrackerTasks: %w", err)
-	}
 	if q.listChannelOutfitIdsForPlatformStmt, err = db.PrepareContext(ctx, listChannelOutfitIdsForPlatform); err != nil {
 		return nil, fmt.Errorf("error preparing query ListChannelOutfitIdsForPlatform: %w", err)
 	}
+	if q.listChannelStatsTrackerSessionsStmt, err = db.PrepareContext(ctx, listChannelStatsTrackerSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListChannelStatsTrackerSessions: %w", err)
+	}
 	if q.listChannelStatsTrackerTasksStmt, err = db.PrepareContext(ctx, listChannelStatsTrackerTasks); err != nil {
 		return nil, fmt.Errorf("error preparing query ListChannelStatsTrackerTasks: %w", err)
 	}
 	if q.listChannelTrackablePlatformsStmt, err = db.PrepareContext(ctx, listChannelTrackablePlatforms); err != nil {
 		return nil, fmt.Errorf("error preparing query ListChannelTrackablePlatforms: %w", err)
 	}
+	if q.listCharacterSessionsStmt, err = db.PrepareContext(ctx, listCharacterSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCharacterSessions: %w", err)
+	}
+	if q.listCharactersTrackerPlayersStmt, err = db.PrepareContext(ctx, listCharactersTrackerPlayers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCharactersTrackerPlayers: %w", err)
+	}
+	if q.listItemsStmt, err = db.PrepareContext(ctx, listItems); err != nil {
+		return nil, fmt.Errorf("error preparing query ListItems: %w", err)
+	}
+	if q.listKnownCharactersStmt, err = db.PrepareContext(ctx, listKnownCharacters); err != nil {
+		return nil, fmt.Errorf("error preparing query ListKnownCharacters: %w", err)
+	}
+	if q.listOutfitActivityReportChannelsStmt, err = db.PrepareContext(ctx, listOutfitActivityReportChannels); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOutfitActivityReportChannels: %w", err)
+	}
+	if q.listOutfitMembersCharacterSessionsStmt, err = db.PrepareContext(ctx, listOutfitMembersCharacterSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOutfitMembersCharacterSessions: %w", err)
+	}
 	if q.listPlatformOutfitMembersStmt, err = db.PrepareContext(ctx, listPlatformOutfitMembers); err != nil {
 		return nil, fmt.Errorf("error preparing query ListPlatformOutfitMembers: %w", err)
 	}
@@ -99,6 +171,39 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listPlatformTrackingChannelsForOutfitStmt, err = db.PrepareContext(ctx, listPlatformTrackingChannelsForOutfit); err != nil {
 		return nil, fmt.Errorf("error preparing query ListPlatformTrackingChannelsForOutfit: %w", err)
 	}
+	if q.listPopulationSamplesStmt, err = db.PrepareContext(ctx, listPopulationSamples); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPopulationSamples: %w", err)
+	}
+	if q.listStatsTrackerAutoStartsStmt, err = db.PrepareContext(ctx, listStatsTrackerAutoStarts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStatsTrackerAutoStarts: %w", err)
+	}
+	if q.listStatsTrackerSessionCharacterAchievementsStmt, err = db.PrepareContext(ctx, listStatsTrackerSessionCharacterAchievements); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStatsTrackerSessionCharacterAchievements: %w", err)
+	}
+	if q.listStatsTrackerSessionCharacterFacilitiesStmt, err = db.PrepareContext(ctx, listStatsTrackerSessionCharacterFacilities); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStatsTrackerSessionCharacterFacilities: %w", err)
+	}
+	if q.listStatsTrackerSessionCharacterLoadoutsStmt, err = db.PrepareContext(ctx, listStatsTrackerSessionCharacterLoadouts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStatsTrackerSessionCharacterLoadouts: %w", err)
+	}
+	if q.listStatsTrackerSessionCharacterRivalsStmt, err = db.PrepareContext(ctx, listStatsTrackerSessionCharacterRivals); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStatsTrackerSessionCharacterRivals: %w", err)
+	}
+	if q.listStatsTrackerSessionCharacterVehiclesStmt, err = db.PrepareContext(ctx, listStatsTrackerSessionCharacterVehicles); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStatsTrackerSessionCharacterVehicles: %w", err)
+	}
+	if q.listStatsTrackerSessionCharacterWeaponsStmt, err = db.PrepareContext(ctx, listStatsTrackerSessionCharacterWeapons); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStatsTrackerSessionCharacterWeapons: %w", err)
+	}
+	if q.listStatsTrackerSessionCharacterZonesStmt, err = db.PrepareContext(ctx, listStatsTrackerSessionCharacterZones); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStatsTrackerSessionCharacterZones: %w", err)
+	}
+	if q.listStatsTrackerSessionCharactersStmt, err = db.PrepareContext(ctx, listStatsTrackerSessionCharacters); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStatsTrackerSessionCharacters: %w", err)
+	}
+	if q.listStatsTrackerSessionPlatformsStmt, err = db.PrepareContext(ctx, listStatsTrackerSessionPlatforms); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStatsTrackerSessionPlatforms: %w", err)
+	}
 	if q.listTrackableCharacterIdsWithDuplicationForPlatformStmt, err = db.PrepareContext(ctx, listTrackableCharacterIdsWithDuplicationForPlatform); err != nil {
 		return nil, fmt.Errorf("error preparing query ListTrackableCharacterIdsWithDuplicationForPlatform: %w", err)
 	}
@@ -108,9 +213,27 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listUniqueTrackableOutfitIdsForPlatformStmt, err = db.PrepareContext(ctx, listUniqueTrackableOutfitIdsForPlatform); err != nil {
 		return nil, fmt.Errorf("error preparing query ListUniqueTrackableOutfitIdsForPlatform: %w", err)
 	}
+	if q.listUtcStatsTrackerTasksStmt, err = db.PrepareContext(ctx, listUtcStatsTrackerTasks); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUtcStatsTrackerTasks: %w", err)
+	}
 	if q.removeChannelStatsTrackerTaskStmt, err = db.PrepareContext(ctx, removeChannelStatsTrackerTask); err != nil {
 		return nil, fmt.Errorf("error preparing query RemoveChannelStatsTrackerTask: %w", err)
 	}
+	if q.updateChannelOutfitActivityReportedAtStmt, err = db.PrepareContext(ctx, updateChannelOutfitActivityReportedAt); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateChannelOutfitActivityReportedAt: %w", err)
+	}
+	if q.updateStatsTrackerSessionLeaderboardMessageStmt, err = db.PrepareContext(ctx, updateStatsTrackerSessionLeaderboardMessage); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateStatsTrackerSessionLeaderboardMessage: %w", err)
+	}
+	if q.updateStatsTrackerSessionStoppedAtStmt, err = db.PrepareContext(ctx, updateStatsTrackerSessionStoppedAt); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateStatsTrackerSessionStoppedAt: %w", err)
+	}
+	if q.updateStatsTrackerTaskLocalTimeStmt, err = db.PrepareContext(ctx, updateStatsTrackerTaskLocalTime); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateStatsTrackerTaskLocalTime: %w", err)
+	}
+	if q.upsertChannelCharacterChangesNotificationsStmt, err = db.PrepareContext(ctx, upsertChannelCharacterChangesNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertChannelCharacterChangesNotifications: %w", err)
+	}
 	if q.upsertChannelCharacterNotificationsStmt, err = db.PrepareContext(ctx, upsertChannelCharacterNotifications); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertChannelCharacterNotifications: %w", err)
 	}
@@ -120,15 +243,63 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.upsertChannelLanguageStmt, err = db.PrepareContext(ctx, upsertChannelLanguage); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertChannelLanguage: %w", err)
 	}
+	if q.upsertChannelOutfitActivityReportStmt, err = db.PrepareContext(ctx, upsertChannelOutfitActivityReport); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertChannelOutfitActivityReport: %w", err)
+	}
 	if q.upsertChannelOutfitNotificationsStmt, err = db.PrepareContext(ctx, upsertChannelOutfitNotifications); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertChannelOutfitNotifications: %w", err)
 	}
+	if q.upsertChannelStatsTrackerChartsStmt, err = db.PrepareContext(ctx, upsertChannelStatsTrackerCharts); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertChannelStatsTrackerCharts: %w", err)
+	}
+	if q.upsertChannelStatsTrackerDetailsStmt, err = db.PrepareContext(ctx, upsertChannelStatsTrackerDetails); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertChannelStatsTrackerDetails: %w", err)
+	}
+	if q.upsertChannelStatsTrackerScoreFormulaStmt, err = db.PrepareContext(ctx, upsertChannelStatsTrackerScoreFormula); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertChannelStatsTrackerScoreFormula: %w", err)
+	}
+	if q.upsertChannelStatsTrackerSortStmt, err = db.PrepareContext(ctx, upsertChannelStatsTrackerSort); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertChannelStatsTrackerSort: %w", err)
+	}
 	if q.upsertChannelTitleUpdatesStmt, err = db.PrepareContext(ctx, upsertChannelTitleUpdates); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertChannelTitleUpdates: %w", err)
 	}
+	if q.upsertItemStmt, err = db.PrepareContext(ctx, upsertItem); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertItem: %w", err)
+	}
+	if q.upsertKnownCharacterStmt, err = db.PrepareContext(ctx, upsertKnownCharacter); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertKnownCharacter: %w", err)
+	}
 	if q.upsertPlatformOutfitSynchronizedAtStmt, err = db.PrepareContext(ctx, upsertPlatformOutfitSynchronizedAt); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertPlatformOutfitSynchronizedAt: %w", err)
 	}
+	if q.upsertStatsTrackerAutoStartStmt, err = db.PrepareContext(ctx, upsertStatsTrackerAutoStart); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertStatsTrackerAutoStart: %w", err)
+	}
+	if q.upsertStatsTrackerCharacterStmt, err = db.PrepareContext(ctx, upsertStatsTrackerCharacter); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertStatsTrackerCharacter: %w", err)
+	}
+	if q.upsertStatsTrackerCharacterAchievementStmt, err = db.PrepareContext(ctx, upsertStatsTrackerCharacterAchievement); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertStatsTrackerCharacterAchievement: %w", err)
+	}
+	if q.upsertStatsTrackerCharacterFacilityStmt, err = db.PrepareContext(ctx, upsertStatsTrackerCharacterFacility); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertStatsTrackerCharacterFacility: %w", err)
+	}
+	if q.upsertStatsTrackerCharacterLoadoutStmt, err = db.PrepareContext(ctx, upsertStatsTrackerCharacterLoadout); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertStatsTrackerCharacterLoadout: %w", err)
+	}
+	if q.upsertStatsTrackerCharacterRivalStmt, err = db.PrepareContext(ctx, upsertStatsTrackerCharacterRival); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertStatsTrackerCharacterRival: %w", err)
+	}
+	if q.upsertStatsTrackerCharacterVehicleStmt, err = db.PrepareContext(ctx, upsertStatsTrackerCharacterVehicle); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertStatsTrackerCharacterVehicle: %w", err)
+	}
+	if q.upsertStatsTrackerCharacterWeaponStmt, err = db.PrepareContext(ctx, upsertStatsTrackerCharacterWeapon); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertStatsTrackerCharacterWeapon: %w", err)
+	}
+	if q.upsertStatsTrackerCharacterZoneStmt, err = db.PrepareContext(ctx, upsertStatsTrackerCharacterZone); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertStatsTrackerCharacterZone: %w", err)
+	}
 	return &q, nil
 }
 
@@ -144,16 +315,66 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteChannelOutfitsStmt: %w", cerr)
 		}
 	}
+	if q.deleteCharacterSessionsBeforeStmt != nil {
+		if cerr := q.deleteCharacterSessionsBeforeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCharacterSessionsBeforeStmt: %w", cerr)
+		}
+	}
+	if q.deleteCharactersTrackerPlayersStmt != nil {
+		if cerr := q.deleteCharactersTrackerPlayersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCharactersTrackerPlayersStmt: %w", cerr)
+		}
+	}
+	if q.deleteKnownCharactersBeforeStmt != nil {
+		if cerr := q.deleteKnownCharactersBeforeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteKnownCharactersBeforeStmt: %w", cerr)
+		}
+	}
 	if q.deleteOutfitMemberStmt != nil {
 		if cerr := q.deleteOutfitMemberStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteOutfitMemberStmt: %w", cerr)
 		}
 	}
+	if q.deletePopulationSamplesStmt != nil {
+		if cerr := q.deletePopulationSamplesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deletePopulationSamplesStmt: %w", cerr)
+		}
+	}
+	if q.deleteStatsTrackerAutoStartStmt != nil {
+		if cerr := q.deleteStatsTrackerAutoStartStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStatsTrackerAutoStartStmt: %w", cerr)
+		}
+	}
+	if q.deleteUtcStatsTrackerTasksStmt != nil {
+		if cerr := q.deleteUtcStatsTrackerTasksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUtcStatsTrackerTasksStmt: %w", cerr)
+		}
+	}
+	if q.endAllCharacterSessionsStmt != nil {
+		if cerr := q.endAllCharacterSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing endAllCharacterSessionsStmt: %w", cerr)
+		}
+	}
+	if q.endCharacterSessionStmt != nil {
+		if cerr := q.endCharacterSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing endCharacterSessionStmt: %w", cerr)
+		}
+	}
+	if q.endStaleCharacterSessionsStmt != nil {
+		if cerr := q.endStaleCharacterSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing endStaleCharacterSessionsStmt: %w", cerr)
+		}
+	}
 	if q.getChannelStmt != nil {
 		if cerr := q.getChannelStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getChannelStmt: %w", cerr)
 		}
 	}
+	if q.getChannelStatsTrackerSessionStmt != nil {
+		if cerr := q.getChannelStatsTrackerSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getChannelStatsTrackerSessionStmt: %w", cerr)
+		}
+	}
 	if q.getCountChannelStatsTrackerTasksStmt != nil {
 		if cerr := q.getCountChannelStatsTrackerTasksStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getCountChannelStatsTrackerTasksStmt: %w", cerr)
@@ -174,6 +395,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getPlatformOutfitSynchronizedAtStmt: %w", cerr)
 		}
 	}
+	if q.getPreviousScheduledStatsTrackerSessionStmt != nil {
+		if cerr := q.getPreviousScheduledStatsTrackerSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPreviousScheduledStatsTrackerSessionStmt: %w", cerr)
+		}
+	}
+	if q.getStatsTrackerAutoStartStmt != nil {
+		if cerr := q.getStatsTrackerAutoStartStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStatsTrackerAutoStartStmt: %w", cerr)
+		}
+	}
 	if q.getStatsTrackerTaskStmt != nil {
 		if cerr := q.getStatsTrackerTaskStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getStatsTrackerTaskStmt: %w", cerr)
@@ -194,6 +425,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing insertChannelStatsTrackerTaskStmt: %w", cerr)
 		}
 	}
+	if q.insertCharacterSessionStmt != nil {
+		if cerr := q.insertCharacterSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertCharacterSessionStmt: %w", cerr)
+		}
+	}
+	if q.insertCharactersTrackerPlayerStmt != nil {
+		if cerr := q.insertCharactersTrackerPlayerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertCharactersTrackerPlayerStmt: %w", cerr)
+		}
+	}
 	if q.insertFacilityStmt != nil {
 		if cerr := q.insertFacilityStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing insertFacilityStmt: %w", cerr)
@@ -209,6 +450,26 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing insertOutfitMemberStmt: %w", cerr)
 		}
 	}
+	if q.insertPopulationSampleStmt != nil {
+		if cerr := q.insertPopulationSampleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertPopulationSampleStmt: %w", cerr)
+		}
+	}
+	if q.insertStatsTrackerSessionStmt != nil {
+		if cerr := q.insertStatsTrackerSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertStatsTrackerSessionStmt: %w", cerr)
+		}
+	}
+	if q.insertStatsTrackerSessionPlatformStmt != nil {
+		if cerr := q.insertStatsTrackerSessionPlatformStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertStatsTrackerSessionPlatformStmt: %w", cerr)
+		}
+	}
+	if q.listActiveStatsTrackerSessionsStmt != nil {
+		if cerr := q.listActiveStatsTrackerSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listActiveStatsTrackerSessionsStmt: %w", cerr)
+		}
+	}
 	if q.listActiveStatsTrackerTasksStmt != nil {
 		if cerr := q.listActiveStatsTrackerTasksStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listActiveStatsTrackerTasksStmt: %w", cerr)
@@ -219,16 +480,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listChannelCharacterIdsForPlatformStmt: %w", cerr)
 		}
 	}
-	if q.listChannelIntersectingStatsTrackerTasksStmt != nil {
-		if cerr := q.listChannelIntersectingStatsTrackerTasksStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing listChannelIntersectingStatsTrackerTasksStmt: %w", cerr)
-		}
-	}
 	if q.listChannelOutfitIdsForPlatformStmt != nil {
 		if cerr := q.listChannelOutfitIdsForPlatformStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listChannelOutfitIdsForPlatformStmt: %w", cerr)
 		}
 	}
+	if q.listChannelStatsTrackerSessionsStmt != nil {
+		if cerr := q.listChannelStatsTrackerSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listChannelStatsTrackerSessionsStmt: %w", cerr)
+		}
+	}
 	if q.listChannelStatsTrackerTasksStmt != nil {
 		if cerr := q.listChannelStatsTrackerTasksStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listChannelStatsTrackerTasksStmt: %w", cerr)
@@ -239,6 +500,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listChannelTrackablePlatformsStmt: %w", cerr)
 		}
 	}
+	if q.listCharacterSessionsStmt != nil {
+		if cerr := q.listCharacterSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCharacterSessionsStmt: %w", cerr)
+		}
+	}
+	if q.listCharactersTrackerPlayersStmt != nil {
+		if cerr := q.listCharactersTrackerPlayersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCharactersTrackerPlayersStmt: %w", cerr)
+		}
+	}
+	if q.listItemsStmt != nil {
+		if cerr := q.listItemsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listItemsStmt: %w", cerr)
+		}
+	}
+	if q.listKnownCharactersStmt != nil {
+		if cerr := q.listKnownCharactersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listKnownCharactersStmt: %w", cerr)
+		}
+	}
+	if q.listOutfitActivityReportChannelsStmt != nil {
+		if cerr := q.listOutfitActivityReportChannelsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOutfitActivityReportChannelsStmt: %w", cerr)
+		}
+	}
+	if q.listOutfitMembersCharacterSessionsStmt != nil {
+		if cerr := q.listOutfitMembersCharacterSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOutfitMembersCharacterSessionsStmt: %w", cerr)
+		}
+	}
 	if q.listPlatformOutfitMembersStmt != nil {
 		if cerr := q.listPlatformOutfitMembersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listPlatformOutfitMembersStmt: %w", cerr)
@@ -259,6 +550,61 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listPlatformTrackingChannelsForOutfitStmt: %w", cerr)
 		}
 	}
+	if q.listPopulationSamplesStmt != nil {
+		if cerr := q.listPopulationSamplesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPopulationSamplesStmt: %w", cerr)
+		}
+	}
+	if q.listStatsTrackerAutoStartsStmt != nil {
+		if cerr := q.listStatsTrackerAutoStartsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStatsTrackerAutoStartsStmt: %w", cerr)
+		}
+	}
+	if q.listStatsTrackerSessionCharacterAchievementsStmt != nil {
+		if cerr := q.listStatsTrackerSessionCharacterAchievementsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStatsTrackerSessionCharacterAchievementsStmt: %w", cerr)
+		}
+	}
+	if q.listStatsTrackerSessionCharacterFacilitiesStmt != nil {
+		if cerr := q.listStatsTrackerSessionCharacterFacilitiesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStatsTrackerSessionCharacterFacilitiesStmt: %w", cerr)
+		}
+	}
+	if q.listStatsTrackerSessionCharacterLoadoutsStmt != nil {
+		if cerr := q.listStatsTrackerSessionCharacterLoadoutsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStatsTrackerSessionCharacterLoadoutsStmt: %w", cerr)
+		}
+	}
+	if q.listStatsTrackerSessionCharacterRivalsStmt != nil {
+		if cerr := q.listStatsTrackerSessionCharacterRivalsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStatsTrackerSessionCharacterRivalsStmt: %w", cerr)
+		}
+	}
+	if q.listStatsTrackerSessionCharacterVehiclesStmt != nil {
+		if cerr := q.listStatsTrackerSessionCharacterVehiclesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStatsTrackerSessionCharacterVehiclesStmt: %w", cerr)
+		}
+	}
+	if q.listStatsTrackerSessionCharacterWeaponsStmt != nil {
+		if cerr := q.listStatsTrackerSessionCharacterWeaponsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStatsTrackerSessionCharacterWeaponsStmt: %w", cerr)
+		}
+	}
+	if q.listStatsTrackerSessionCharacterZonesStmt != nil {
+		if cerr := q.listStatsTrackerSessionCharacterZonesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStatsTrackerSessionCharacterZonesStmt: %w", cerr)
+		}
+	}
+	if q.listStatsTrackerSessionCharactersStmt != nil {
+		if cerr := q.listStatsTrackerSessionCharactersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStatsTrackerSessionCharactersStmt: %w", cerr)
+		}
+	}
+	if q.listStatsTrackerSessionPlatformsStmt != nil {
+		if cerr := q.listStatsTrackerSessionPlatformsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStatsTrackerSessionPlatformsStmt: %w", cerr)
+		}
+	}
 	if q.listTrackableCharacterIdsWithDuplicationForPlatformStmt != nil {
 		if cerr := q.listTrackableCharacterIdsWithDuplicationForPlatformStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listTrackableCharacterIdsWithDuplicationForPlatformStmt: %w", cerr)
@@ -274,11 +620,41 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listUniqueTrackableOutfitIdsForPlatformStmt: %w", cerr)
 		}
 	}
+	if q.listUtcStatsTrackerTasksStmt != nil {
+		if cerr := q.listUtcStatsTrackerTasksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUtcStatsTrackerTasksStmt: %w", cerr)
+		}
+	}
 	if q.removeChannelStatsTrackerTaskStmt != nil {
 		if cerr := q.removeChannelStatsTrackerTaskStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing removeChannelStatsTrackerTaskStmt: %w", cerr)
 		}
 	}
+	if q.updateChannelOutfitActivityReportedAtStmt != nil {
+		if cerr := q.updateChannelOutfitActivityReportedAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateChannelOutfitActivityReportedAtStmt: %w", cerr)
+		}
+	}
+	if q.updateStatsTrackerSessionLeaderboardMessageStmt != nil {
+		if cerr := q.updateStatsTrackerSessionLeaderboardMessageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateStatsTrackerSessionLeaderboardMessageStmt: %w", cerr)
+		}
+	}
+	if q.updateStatsTrackerSessionStoppedAtStmt != nil {
+		if cerr := q.updateStatsTrackerSessionStoppedAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateStatsTrackerSessionStoppedAtStmt: %w", cerr)
+		}
+	}
+	if q.updateStatsTrackerTaskLocalTimeStmt != nil {
+		if cerr := q.updateStatsTrackerTaskLocalTimeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateStatsTrackerTaskLocalTimeStmt: %w", cerr)
+		}
+	}
+	if q.upsertChannelCharacterChangesNotificationsStmt != nil {
+		if cerr := q.upsertChannelCharacterChangesNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertChannelCharacterChangesNotificationsStmt: %w", cerr)
+		}
+	}
 	if q.upsertChannelCharacterNotificationsStmt != nil {
 		if cerr := q.upsertChannelCharacterNotificationsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing upsertChannelCharacterNotificationsStmt: %w", cerr)
@@ -294,21 +670,101 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing upsertChannelLanguageStmt: %w", cerr)
 		}
 	}
+	if q.upsertChannelOutfitActivityReportStmt != nil {
+		if cerr := q.upsertChannelOutfitActivityReportStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertChannelOutfitActivityReportStmt: %w", cerr)
+		}
+	}
 	if q.upsertChannelOutfitNotificationsStmt != nil {
 		if cerr := q.upsertChannelOutfitNotificationsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing upsertChannelOutfitNotificationsStmt: %w", cerr)
 		}
 	}
+	if q.upsertChannelStatsTrackerChartsStmt != nil {
+		if cerr := q.upsertChannelStatsTrackerChartsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertChannelStatsTrackerChartsStmt: %w", cerr)
+		}
+	}
+	if q.upsertChannelStatsTrackerDetailsStmt != nil {
+		if cerr := q.upsertChannelStatsTrackerDetailsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertChannelStatsTrackerDetailsStmt: %w", cerr)
+		}
+	}
+	if q.upsertChannelStatsTrackerScoreFormulaStmt != nil {
+		if cerr := q.upsertChannelStatsTrackerScoreFormulaStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertChannelStatsTrackerScoreFormulaStmt: %w", cerr)
+		}
+	}
+	if q.upsertChannelStatsTrackerSortStmt != nil {
+		if cerr := q.upsertChannelStatsTrackerSortStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertChannelStatsTrackerSortStmt: %w", cerr)
+		}
+	}
 	if q.upsertChannelTitleUpdatesStmt != nil {
 		if cerr := q.upsertChannelTitleUpdatesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing upsertChannelTitleUpdatesStmt: %w", cerr)
 		}
 	}
+	if q.upsertItemStmt != nil {
+		if cerr := q.upsertItemStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertItemStmt: %w", cerr)
+		}
+	}
+	if q.upsertKnownCharacterStmt != nil {
+		if cerr := q.upsertKnownCharacterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertKnownCharacterStmt: %w", cerr)
+		}
+	}
 	if q.upsertPlatformOutfitSynchronizedAtStmt != nil {
 		if cerr := q.upsertPlatformOutfitSynchronizedAtStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing upsertPlatformOutfitSynchronizedAtStmt: %w", cerr)
 		}
 	}
+	if q.upsertStatsTrackerAutoStartStmt != nil {
+		if cerr := q.upsertStatsTrackerAutoStartStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertStatsTrackerAutoStartStmt: %w", cerr)
+		}
+	}
+	if q.upsertStatsTrackerCharacterStmt != nil {
+		if cerr := q.upsertStatsTrackerCharacterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertStatsTrackerCharacterStmt: %w", cerr)
+		}
+	}
+	if q.upsertStatsTrackerCharacterAchievementStmt != nil {
+		if cerr := q.upsertStatsTrackerCharacterAchievementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertStatsTrackerCharacterAchievementStmt: %w", cerr)
+		}
+	}
+	if q.upsertStatsTrackerCharacterFacilityStmt != nil {
+		if cerr := q.upsertStatsTrackerCharacterFacilityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertStatsTrackerCharacterFacilityStmt: %w", cerr)
+		}
+	}
+	if q.upsertStatsTrackerCharacterLoadoutStmt != nil {
+		if cerr := q.upsertStatsTrackerCharacterLoadoutStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertStatsTrackerCharacterLoadoutStmt: %w", cerr)
+		}
+	}
+	if q.upsertStatsTrackerCharacterRivalStmt != nil {
+		if cerr := q.upsertStatsTrackerCharacterRivalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertStatsTrackerCharacterRivalStmt: %w", cerr)
+		}
+	}
+	if q.upsertStatsTrackerCharacterVehicleStmt != nil {
+		if cerr := q.upsertStatsTrackerCharacterVehicleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertStatsTrackerCharacterVehicleStmt: %w", cerr)
+		}
+	}
+	if q.upsertStatsTrackerCharacterWeaponStmt != nil {
+		if cerr := q.upsertStatsTrackerCharacterWeaponStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertStatsTrackerCharacterWeaponStmt: %w", cerr)
+		}
+	}
+	if q.upsertStatsTrackerCharacterZoneStmt != nil {
+		if cerr := q.upsertStatsTrackerCharacterZoneStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertStatsTrackerCharacterZoneStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -350,39 +806,96 @@ type Queries struct {
 	tx                                                      *sql.Tx
 	deleteChannelCharactersStmt                             *sql.Stmt
 	deleteChannelOutfitsStmt                                *sql.Stmt
+	deleteCharacterSessionsBeforeStmt                       *sql.Stmt
+	deleteCharactersTrackerPlayersStmt                      *sql.Stmt
+	deleteKnownCharactersBeforeStmt                         *sql.Stmt
 	deleteOutfitMemberStmt                                  *sql.Stmt
+	deletePopulationSamplesStmt                             *sql.Stmt
+	deleteStatsTrackerAutoStartStmt                         *sql.Stmt
+	deleteUtcStatsTrackerTasksStmt                          *sql.Stmt
+	endAllCharacterSessionsStmt                             *sql.Stmt
+	endCharacterSessionStmt                                 *sql.Stmt
+	endStaleCharacterSessionsStmt                           *sql.Stmt
 	getChannelStmt                                          *sql.Stmt
+	getChannelStatsTrackerSessionStmt                       *sql.Stmt
 	getCountChannelStatsTrackerTasksStmt                    *sql.Stmt
 	getFacilityStmt                                         *sql.Stmt
 	getPlatformOutfitStmt                                   *sql.Stmt
 	getPlatformOutfitSynchronizedAtStmt                     *sql.Stmt
+	getPreviousScheduledStatsTrackerSessionStmt             *sql.Stmt
+	getStatsTrackerAutoStartStmt                            *sql.Stmt
 	getStatsTrackerTaskStmt                                 *sql.Stmt
 	insertChannelCharacterStmt                              *sql.Stmt
 	insertChannelOutfitStmt                                 *sql.Stmt
 	insertChannelStatsTrackerTaskStmt                       *sql.Stmt
+	insertCharacterSessionStmt                              *sql.Stmt
+	insertCharactersTrackerPlayerStmt                       *sql.Stmt
 	insertFacilityStmt                                      *sql.Stmt
 	insertOutfitStmt                                        *sql.Stmt
 	insertOutfitMemberStmt                                  *sql.Stmt
+	insertPopulationSampleStmt                              *sql.Stmt
+	insertStatsTrackerSessionStmt                           *sql.Stmt
+	insertStatsTrackerSessionPlatformStmt                   *sql.Stmt
+	listActiveStatsTrackerSessionsStmt                      *sql.Stmt
 	listActiveStatsTrackerTasksStmt                         *sql.Stmt
 	listChannelCharacterIdsForPlatformStmt                  *sql.Stmt
-	listChannelIntersectingStatsTrackerTasksStmt            *sql.Stmt
 	listChannelOutfitIdsForPlatformStmt                     *sql.Stmt
+	listChannelStatsTrackerSessionsStmt                     *sql.Stmt
 	listChannelStatsTrackerTasksStmt                        *sql.Stmt
 	listChannelTrackablePlatformsStmt                       *sql.Stmt
+	listCharacterSessionsStmt                               *sql.Stmt
+	listCharactersTrackerPlayersStmt                        *sql.Stmt
+	listItemsStmt                                           *sql.Stmt
+	listKnownCharactersStmt                                 *sql.Stmt
+	listOutfitActivityReportChannelsStmt                    *sql.Stmt
+	listOutfitMembersCharacterSessionsStmt                  *sql.Stmt
 	listPlatformOutfitMembersStmt                           *sql.Stmt
 	listPlatformOutfitsStmt                                 *sql.Stmt
 	listPlatformTrackingChannelsForCharacterStmt            *sql.Stmt
 	listPlatformTrackingChannelsForOutfitStmt               *sql.Stmt
+	listPopulationSamplesStmt                               *sql.Stmt
+	listStatsTrackerAutoStartsStmt                          *sql.Stmt
+	listStatsTrackerSessionCharacterAchievementsStmt        *sql.Stmt
+	listStatsTrackerSessionCharacterFacilitiesStmt          *sql.Stmt
+	listStatsTrackerSessionCharacterLoadoutsStmt            *sql.Stmt
+	listStatsTrackerSessionCharacterRivalsStmt              *sql.Stmt
+	listStatsTrackerSessionCharacterVehiclesStmt            *sql.Stmt
+	listStatsTrackerSessionCharacterWeaponsStmt             *sql.Stmt
+	listStatsTrackerSessionCharacterZonesStmt               *sql.Stmt
+	listStatsTrackerSessionCharactersStmt                   *sql.Stmt
+	listStatsTrackerSessionPlatformsStmt                    *sql.Stmt
 	listTrackableCharacterIdsWithDuplicationForPlatformStmt *sql.Stmt
 	listTrackableOutfitIdsWithDuplicationForPlatformStmt    *sql.Stmt
 	listUniqueTrackableOutfitIdsForPlatformStmt             *sql.Stmt
+	listUtcStatsTrackerTasksStmt                            *sql.Stmt
 	removeChannelStatsTrackerTaskStmt                       *sql.Stmt
+	updateChannelOutfitActivityReportedAtStmt               *sql.Stmt
+	updateStatsTrackerSessionLeaderboardMessageStmt         *sql.Stmt
+	updateStatsTrackerSessionStoppedAtStmt                  *sql.Stmt
+	updateStatsTrackerTaskLocalTimeStmt                     *sql.Stmt
+	upsertChannelCharacterChangesNotificationsStmt          *sql.Stmt
 	upsertChannelCharacterNotificationsStmt                 *sql.Stmt
 	upsertChannelDefaultTimezoneStmt                        *sql.Stmt
 	upsertChannelLanguageStmt                               *sql.Stmt
+	upsertChannelOutfitActivityReportStmt                   *sql.Stmt
 	upsertChannelOutfitNotificationsStmt                    *sql.Stmt
+	upsertChannelStatsTrackerChartsStmt                     *sql.Stmt
+	upsertChannelStatsTrackerDetailsStmt                    *sql.Stmt
+	upsertChannelStatsTrackerScoreFormulaStmt               *sql.Stmt
+	upsertChannelStatsTrackerSortStmt                       *sql.Stmt
 	upsertChannelTitleUpdatesStmt                           *sql.Stmt
+	upsertItemStmt                                          *sql.Stmt
+	upsertKnownCharacterStmt                                *sql.Stmt
 	upsertPlatformOutfitSynchronizedAtStmt                  *sql.Stmt
+	upsertStatsTrackerAutoStartStmt                         *sql.Stmt
+	upsertStatsTrackerCharacterStmt                         *sql.Stmt
+	upsertStatsTrackerCharacterAchievementStmt              *sql.Stmt
+	upsertStatsTrackerCharacterFacilityStmt                 *sql.Stmt
+	upsertStatsTrackerCharacterLoadoutStmt                  *sql.Stmt
+	upsertStatsTrackerCharacterRivalStmt                    *sql.Stmt
+	upsertStatsTrackerCharacterVehicleStmt                  *sql.Stmt
+	upsertStatsTrackerCharacterWeaponStmt                   *sql.Stmt
+	upsertStatsTrackerCharacterZoneStmt                     *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
@@ -391,38 +904,95 @@ func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 		tx:                                                      tx,
 		deleteChannelCharactersStmt:                             q.deleteChannelCharactersStmt,
 		deleteChannelOutfitsStmt:                                q.deleteChannelOutfitsStmt,
+		deleteCharacterSessionsBeforeStmt:                       q.deleteCharacterSessionsBeforeStmt,
+		deleteCharactersTrackerPlayersStmt:                      q.deleteCharactersTrackerPlayersStmt,
+		deleteKnownCharactersBeforeStmt:                         q.deleteKnownCharactersBeforeStmt,
 		deleteOutfitMemberStmt:                                  q.deleteOutfitMemberStmt,
+		deletePopulationSamplesStmt:                             q.deletePopulationSamplesStmt,
+		deleteStatsTrackerAutoStartStmt:                         q.deleteStatsTrackerAutoStartStmt,
+		deleteUtcStatsTrackerTasksStmt:                          q.deleteUtcStatsTrackerTasksStmt,
+		endAllCharacterSessionsStmt:                             q.endAllCharacterSessionsStmt,
+		endCharacterSessionStmt:                                 q.endCharacterSessionStmt,
+		endStaleCharacterSessionsStmt:                           q.endStaleCharacterSessionsStmt,
 		getChannelStmt:                                          q.getChannelStmt,
+		getChannelStatsTrackerSessionStmt:                       q.getChannelStatsTrackerSessionStmt,
 		getCountChannelStatsTrackerTasksStmt:                    q.getCountChannelStatsTrackerTasksStmt,
 		getFacilityStmt:                                         q.getFacilityStmt,
 		getPlatformOutfitStmt:                                   q.getPlatformOutfitStmt,
 		getPlatformOutfitSynchronizedAtStmt:                     q.getPlatformOutfitSynchronizedAtStmt,
+		getPreviousScheduledStatsTrackerSessionStmt:             q.getPreviousScheduledStatsTrackerSessionStmt,
+		getStatsTrackerAutoStartStmt:                            q.getStatsTrackerAutoStartStmt,
 		getStatsTrackerTaskStmt:                                 q.getStatsTrackerTaskStmt,
 		insertChannelCharacterStmt:                              q.insertChannelCharacterStmt,
 		insertChannelOutfitStmt:                                 q.insertChannelOutfitStmt,
 		insertChannelStatsTrackerTaskStmt:                       q.insertChannelStatsTrackerTaskStmt,
+		insertCharacterSessionStmt:                              q.insertCharacterSessionStmt,
+		insertCharactersTrackerPlayerStmt:                       q.insertCharactersTrackerPlayerStmt,
 		insertFacilityStmt:                                      q.insertFacilityStmt,
 		insertOutfitStmt:                                        q.insertOutfitStmt,
 		insertOutfitMemberStmt:                                  q.insertOutfitMemberStmt,
+		insertPopulationSampleStmt:                              q.insertPopulationSampleStmt,
+		insertStatsTrackerSessionStmt:                           q.insertStatsTrackerSessionStmt,
+		insertStatsTrackerSessionPlatformStmt:                   q.insertStatsTrackerSessionPlatformStmt,
+		listActiveStatsTrackerSessionsStmt:                      q.listActiveStatsTrackerSessionsStmt,
 		listActiveStatsTrackerTasksStmt:                         q.listActiveStatsTrackerTasksStmt,
 		listChannelCharacterIdsForPlatformStmt:                  q.listChannelCharacterIdsForPlatformStmt,
-		listChannelIntersectingStatsTrackerTasksStmt:            q.listChannelIntersectingStatsTrackerTasksStmt,
 		listChannelOutfitIdsForPlatformStmt:                     q.listChannelOutfitIdsForPlatformStmt,
+		listChannelStatsTrackerSessionsStmt:                     q.listChannelStatsTrackerSessionsStmt,
 		listChannelStatsTrackerTasksStmt:                        q.listChannelStatsTrackerTasksStmt,
 		listChannelTrackablePlatformsStmt:                       q.listChannelTrackablePlatformsStmt,
+		listCharacterSessionsStmt:                               q.listCharacterSessionsStmt,
+		listCharactersTrackerPlayersStmt:                        q.listCharactersTrackerPlayersStmt,
+		listItemsStmt:                                           q.listItemsStmt,
+		listKnownCharactersStmt:                                 q.listKnownCharactersStmt,
+		listOutfitActivityReportChannelsStmt:                    q.listOutfitActivityReportChannelsStmt,
+		listOutfitMembersCharacterSessionsStmt:                  q.listOutfitMembersCharacterSessionsStmt,
 		listPlatformOutfitMembersStmt:                           q.listPlatformOutfitMembersStmt,
 		listPlatformOutfitsStmt:                                 q.listPlatformOutfitsStmt,
 		listPlatformTrackingChannelsForCharacterStmt:            q.listPlatformTrackingChannelsForCharacterStmt,
 		listPlatformTrackingChannelsForOutfitStmt:               q.listPlatformTrackingChannelsForOutfitStmt,
+		listPopulationSamplesStmt:                               q.listPopulationSamplesStmt,
+		listStatsTrackerAutoStartsStmt:                          q.listStatsTrackerAutoStartsStmt,
+		listStatsTrackerSessionCharacterAchievementsStmt:        q.listStatsTrackerSessionCharacterAchievementsStmt,
+		listStatsTrackerSessionCharacterFacilitiesStmt:          q.listStatsTrackerSessionCharacterFacilitiesStmt,
+		listStatsTrackerSessionCharacterLoadoutsStmt:            q.listStatsTrackerSessionCharacterLoadoutsStmt,
+		listStatsTrackerSessionCharacterRivalsStmt:              q.listStatsTrackerSessionCharacterRivalsStmt,
+		listStatsTrackerSessionCharacterVehiclesStmt:            q.listStatsTrackerSessionCharacterVehiclesStmt,
+		listStatsTrackerSessionCharacterWeaponsStmt:             q.listStatsTrackerSessionCharacterWeaponsStmt,
+		listStatsTrackerSessionCharacterZonesStmt:               q.listStatsTrackerSessionCharacterZonesStmt,
+		listStatsTrackerSessionCharactersStmt:                   q.listStatsTrackerSessionCharactersStmt,
+		listStatsTrackerSessionPlatformsStmt:                    q.listStatsTrackerSessionPlatformsStmt,
 		listTrackableCharacterIdsWithDuplicationForPlatformStmt: q.listTrackableCharacterIdsWithDuplicationForPlatformStmt,
 		listTrackableOutfitIdsWithDuplicationForPlatformStmt:    q.listTrackableOutfitIdsWithDuplicationForPlatformStmt,
 		listUniqueTrackableOutfitIdsForPlatformStmt:             q.listUniqueTrackableOutfitIdsForPlatformStmt,
+		listUtcStatsTrackerTasksStmt:                            q.listUtcStatsTrackerTasksStmt,
 		removeChannelStatsTrackerTaskStmt:                       q.removeChannelStatsTrackerTaskStmt,
+		updateChannelOutfitActivityReportedAtStmt:               q.updateChannelOutfitActivityReportedAtStmt,
+		updateStatsTrackerSessionLeaderboardMessageStmt:         q.updateStatsTrackerSessionLeaderboardMessageStmt,
+		updateStatsTrackerSessionStoppedAtStmt:                  q.updateStatsTrackerSessionStoppedAtStmt,
+		updateStatsTrackerTaskLocalTimeStmt:                     q.updateStatsTrackerTaskLocalTimeStmt,
+		upsertChannelCharacterChangesNotificationsStmt:          q.upsertChannelCharacterChangesNotificationsStmt,
 		upsertChannelCharacterNotificationsStmt:                 q.upsertChannelCharacterNotificationsStmt,
 		upsertChannelDefaultTimezoneStmt:                        q.upsertChannelDefaultTimezoneStmt,
 		upsertChannelLanguageStmt:                               q.upsertChannelLanguageStmt,
+		upsertChannelOutfitActivityReportStmt:                   q.upsertChannelOutfitActivityReportStmt,
 		upsertChannelOutfitNotificationsStmt:                    q.upsertChannelOutfitNotificationsStmt,
+		upsertChannelStatsTrackerChartsStmt:                     q.upsertChannelStatsTrackerChartsStmt,
+		upsertChannelStatsTrackerDetailsStmt:                    q.upsertChannelStatsTrackerDetailsStmt,
+		upsertChannelStatsTrackerScoreFormulaStmt:               q.upsertChannelStatsTrackerScoreFormulaStmt,
+		upsertChannelStatsTrackerSortStmt:                       q.upsertChannelStatsTrackerSortStmt,
 		upsertChannelTitleUpdatesStmt:                           q.upsertChannelTitleUpdatesStmt,
+		upsertItemStmt:                                          q.upsertItemStmt,
+		upsertKnownCharacterStmt:                                q.upsertKnownCharacterStmt,
 		upsertPlatformOutfitSynchronizedAtStmt:                  q.upsertPlatformOutfitSynchronizedAtStmt,
+		upsertStatsTrackerAutoStartStmt:                         q.upsertStatsTrackerAutoStartStmt,
+		upsertStatsTrackerCharacterStmt:                         q.upsertStatsTrackerCharacterStmt,
+		upsertStatsTrackerCharacterAchievementStmt:              q.upsertStatsTrackerCharacterAchievementStmt,
+		upsertStatsTrackerCharacterFacilityStmt:                 q.upsertStatsTrackerCharacterFacilityStmt,
+		upsertStatsTrackerCharacterLoadoutStmt:                  q.upsertStatsTrackerCharacterLoadoutStmt,
+		upsertStatsTrackerCharacterRivalStmt:                    q.upsertStatsTrackerCharacterRivalStmt,
+		upsertStatsTrackerCharacterVehicleStmt:                  q.upsertStatsTrackerCharacterVehicleStmt,
+		upsertStatsTrackerCharacterWeaponStmt:                   q.upsertStatsTrackerCharacterWeaponStmt,
+		upsertStatsTrackerCharacterZoneStmt:                     q.upsertStatsTrackerCharacterZoneStmt,
 	}
 }
@@ -5,16 +5,24 @@
 package db
 
 import (
+	"database/sql"
 	"time"
 )
 
 type Channel struct {
-	ChannelID              string
-	Locale                 string
-	CharacterNotifications bool
-	OutfitNotifications    bool
-	TitleUpdates           bool
-	DefaultTimezone        string
+	ChannelID                     string
+	Locale                        string
+	CharacterNotifications        bool
+	OutfitNotifications           bool
+	TitleUpdates                  bool
+	DefaultTimezone               string
+	StatsTrackerDetails           bool
+	StatsTrackerSort              string
+	StatsTrackerCharts            bool
+	StatsTrackerScoreFormula      string
+	OutfitActivityReport          bool
+	OutfitActivityReportedAt      sql.NullTime
+	CharacterChangesNotifications bool
 }
 
 type ChannelToCharacter struct {
@@ -29,6 +37,29 @@ type ChannelToOutfit struct {
 	OutfitID  string
 }
 
+type CharacterSession struct {
+	SessionID   int64
+	Platform    string
+	CharacterID string
+	WorldID     string
+	StartedAt   time.Time
+	EndedAt     sql.NullTime
+}
+
+type CharactersTrackerPlayer struct {
+	Platform      string
+	CharacterID   string
+	WorldID       string
+	ZoneID        string
+	FactionID     string
+	CharacterName string
+	OutfitID      string
+	OutfitTag     string
+	ActiveAt      time.Time
+	Loadout       string
+	OnlineSince   sql.NullTime
+}
+
 type Facility struct {
 	FacilityID   string
 	FacilityName string
@@ -36,6 +67,23 @@ type Facility struct {
 	ZoneID       string
 }
 
+type Item struct {
+	ItemID       string
+	ItemName     string
+	ItemCategory string
+}
+
+type KnownCharacter struct {
+	Platform      string
+	CharacterID   string
+	CharacterName string
+	FactionID     string
+	WorldID       string
+	OutfitID      string
+	OutfitTag     string
+	RefreshedAt   time.Time
+}
+
 type Outfit struct {
 	Platform   string
 	OutfitID   string
@@ -55,11 +103,139 @@ type OutfitToCharacter struct {
 	CharacterID string
 }
 
+type PopulationSample struct {
+	Platform  string
+	WorldID   string
+	SampledAt time.Time
+	Vs        int64
+	Nc        int64
+	Tr        int64
+	Ns        int64
+	Other     int64
+}
+
+type StatsTrackerAutoStart struct {
+	ChannelID string
+	MinOnline int64
+	Duration  int64
+	StopBelow int64
+}
+
+type StatsTrackerCharacter struct {
+	SessionID              int64
+	Platform               string
+	CharacterID            string
+	BodyKills              int64
+	HeadShotsKills         int64
+	TeamKills              int64
+	Deaths                 int64
+	DeathsByRestrictedArea int64
+	Suicides               int64
+	Revives                int64
+	Heals                  int64
+	Repairs                int64
+	Resupplies             int64
+	ShieldRepairs          int64
+	SpotAssists            int64
+	Score                  int64
+	OnlineDuration         int64
+	BattleRankUps          int64
+	BattleRank             int64
+	Prestiges              int64
+}
+
+type StatsTrackerCharacterAchievement struct {
+	SessionID     int64
+	Platform      string
+	CharacterID   string
+	AchievementID string
+	Count         int64
+}
+
+type StatsTrackerCharacterFacility struct {
+	SessionID   int64
+	Platform    string
+	CharacterID string
+	FacilityID  string
+	Captures    int64
+	Defenses    int64
+}
+
+type StatsTrackerCharacterLoadout struct {
+	SessionID   int64
+	Platform    string
+	CharacterID string
+	LoadoutType int64
+	Duration    int64
+}
+
+type StatsTrackerCharacterRival struct {
+	SessionID   int64
+	Platform    string
+	CharacterID string
+	RivalID     string
+	Kills       int64
+	Deaths      int64
+}
+
+type StatsTrackerCharacterVehicle struct {
+	SessionID   int64
+	Platform    string
+	CharacterID string
+	VehicleID   string
+	Destroyed   int64
+	Lost        int64
+}
+
+type StatsTrackerCharacterWeapon struct {
+	SessionID      int64
+	Platform       string
+	CharacterID    string
+	WeaponID       string
+	Kills          int64
+	HeadShotsKills int64
+}
+
+type StatsTrackerCharacterZone struct {
+	SessionID   int64
+	Platform    string
+	CharacterID string
+	ZoneID      string
+	Kills       int64
+	Deaths      int64
+	Duration    int64
+}
+
+type StatsTrackerSession struct {
+	SessionID            int64
+	ChannelID            string
+	StartedAt            time.Time
+	StoppedAt            sql.NullTime
+	LeaderboardMessageID string
+	Origin               string
+	TaskID               sql.NullInt64
+	Filter               string
+}
+
+type StatsTrackerSessionPlatform struct {
+	SessionID int64
+	Platform  string
+}
+
 type StatsTrackerTask struct {
-	TaskID          int64
-	ChannelID       string
-	UtcStartWeekday int64
-	UtcStartTime    int64
-	UtcEndWeekday   int64
-	UtcEndTime      int64
+	TaskID       int64
+	ChannelID    string
+	Timezone     string
+	Recurrence   string
+	Weekday      int64
+	IntervalDays int64
+	Dates        string
+	StartTime    int64
+	Duration     int64
+	ExpiresAt    sql.NullTime
+	Filter       string
+}
+
+type StatsTrackerTaskUtc struct {
+	TaskID int64
 }
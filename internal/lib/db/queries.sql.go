@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"strings"
 	"time"
 )
@@ -73,6 +74,45 @@ func (q *Queries) DeleteChannelOutfits(ctx context.Context, arg DeleteChannelOut
 	return err
 }
 
+const deleteCharacterSessionsBefore = `-- name: DeleteCharacterSessionsBefore :exec
+DELETE FROM character_session
+WHERE
+  ended_at < ?
+`
+
+func (q *Queries) DeleteCharacterSessionsBefore(ctx context.Context, endedAt sql.NullTime) error {
+	_, err := q.exec(ctx, q.deleteCharacterSessionsBeforeStmt, deleteCharacterSessionsBefore, endedAt)
+	return err
+}
+
+const deleteCharactersTrackerPlayers = `-- name: DeleteCharactersTrackerPlayers :exec
+DELETE FROM characters_tracker_player
+WHERE
+  platform = ?
+`
+
+func (q *Queries) DeleteCharactersTrackerPlayers(ctx context.Context, platform string) error {
+	_, err := q.exec(ctx, q.deleteCharactersTrackerPlayersStmt, deleteCharactersTrackerPlayers, platform)
+	return err
+}
+
+const deleteKnownCharactersBefore = `-- name: DeleteKnownCharactersBefore :exec
+DELETE FROM known_character
+WHERE
+  platform = ?
+  AND refreshed_at < ?
+`
+
+type DeleteKnownCharactersBeforeParams struct {
+	Platform    string
+	RefreshedAt time.Time
+}
+
+func (q *Queries) DeleteKnownCharactersBefore(ctx context.Context, arg DeleteKnownCharactersBeforeParams) error {
+	_, err := q.exec(ctx, q.deleteKnownCharactersBeforeStmt, deleteKnownCharactersBefore, arg.Platform, arg.RefreshedAt)
+	return err
+}
+
 const deleteOutfitMember = `-- name: DeleteOutfitMember :exec
 DELETE FROM outfit_to_character
 WHERE
@@ -92,9 +132,87 @@ func (q *Queries) DeleteOutfitMember(ctx context.Context, arg DeleteOutfitMember
 	return err
 }
 
+const deletePopulationSamples = `-- name: DeletePopulationSamples :exec
+DELETE FROM population_sample
+WHERE
+  platform = ?
+`
+
+func (q *Queries) DeletePopulationSamples(ctx context.Context, platform string) error {
+	_, err := q.exec(ctx, q.deletePopulationSamplesStmt, deletePopulationSamples, platform)
+	return err
+}
+
+const deleteStatsTrackerAutoStart = `-- name: DeleteStatsTrackerAutoStart :exec
+DELETE FROM stats_tracker_auto_start
+WHERE
+  channel_id = ?
+`
+
+func (q *Queries) DeleteStatsTrackerAutoStart(ctx context.Context, channelID string) error {
+	_, err := q.exec(ctx, q.deleteStatsTrackerAutoStartStmt, deleteStatsTrackerAutoStart, channelID)
+	return err
+}
+
+const deleteUtcStatsTrackerTasks = `-- name: DeleteUtcStatsTrackerTasks :exec
+DELETE FROM stats_tracker_task_utc
+`
+
+func (q *Queries) DeleteUtcStatsTrackerTasks(ctx context.Context) error {
+	_, err := q.exec(ctx, q.deleteUtcStatsTrackerTasksStmt, deleteUtcStatsTrackerTasks)
+	return err
+}
+
+const endAllCharacterSessions = `-- name: EndAllCharacterSessions :exec
+UPDATE character_session
+SET
+  ended_at = ?
+WHERE
+  ended_at IS NULL
+`
+
+func (q *Queries) EndAllCharacterSessions(ctx context.Context, endedAt sql.NullTime) error {
+	_, err := q.exec(ctx, q.endAllCharacterSessionsStmt, endAllCharacterSessions, endedAt)
+	return err
+}
+
+const endCharacterSession = `-- name: EndCharacterSession :exec
+UPDATE character_session
+SET
+  ended_at = ?
+WHERE
+  platform = ?
+  AND character_id = ?
+  AND ended_at IS NULL
+`
+
+type EndCharacterSessionParams struct {
+	EndedAt     sql.NullTime
+	Platform    string
+	CharacterID string
+}
+
+func (q *Queries) EndCharacterSession(ctx context.Context, arg EndCharacterSessionParams) error {
+	_, err := q.exec(ctx, q.endCharacterSessionStmt, endCharacterSession, arg.EndedAt, arg.Platform, arg.CharacterID)
+	return err
+}
+
+const endStaleCharacterSessions = `-- name: EndStaleCharacterSessions :exec
+UPDATE character_session
+SET
+  ended_at = started_at
+WHERE
+  ended_at IS NULL
+`
+
+func (q *Queries) EndStaleCharacterSessions(ctx context.Context) error {
+	_, err := q.exec(ctx, q.endStaleCharacterSessionsStmt, endStaleCharacterSessions)
+	return err
+}
+
 const getChannel = `-- name: GetChannel :one
 SELECT
-  channel_id, locale, character_notifications, outfit_notifications, title_updates, default_timezone
+  channel_id, locale, character_notifications, outfit_notifications, title_updates, default_timezone, stats_tracker_details, stats_tracker_sort, stats_tracker_charts, stats_tracker_score_formula, outfit_activity_report, outfit_activity_reported_at, character_changes_notifications
 FROM
   channel
 WHERE
@@ -111,6 +229,45 @@ func (q *Queries) GetChannel(ctx context.Context, channelID string) (Channel, er
 		&i.OutfitNotifications,
 		&i.TitleUpdates,
 		&i.DefaultTimezone,
+		&i.StatsTrackerDetails,
+		&i.StatsTrackerSort,
+		&i.StatsTrackerCharts,
+		&i.StatsTrackerScoreFormula,
+		&i.OutfitActivityReport,
+		&i.OutfitActivityReportedAt,
+		&i.CharacterChangesNotifications,
+	)
+	return i, err
+}
+
+const getChannelStatsTrackerSession = `-- name: GetChannelStatsTrackerSession :one
+SELECT
+  session_id, channel_id, started_at, stopped_at, leaderboard_message_id, origin, task_id, filter
+FROM
+  stats_tracker_session
+WHERE
+  session_id = ?
+  AND channel_id = ?
+  AND stopped_at IS NOT NULL
+`
+
+type GetChannelStatsTrackerSessionParams struct {
+	SessionID int64
+	ChannelID string
+}
+
+func (q *Queries) GetChannelStatsTrackerSession(ctx context.Context, arg GetChannelStatsTrackerSessionParams) (StatsTrackerSession, error) {
+	row := q.queryRow(ctx, q.getChannelStatsTrackerSessionStmt, getChannelStatsTrackerSession, arg.SessionID, arg.ChannelID)
+	var i StatsTrackerSession
+	err := row.Scan(
+		&i.SessionID,
+		&i.ChannelID,
+		&i.StartedAt,
+		&i.StoppedAt,
+		&i.LeaderboardMessageID,
+		&i.Origin,
+		&i.TaskID,
+		&i.Filter,
 	)
 	return i, err
 }
@@ -201,9 +358,68 @@ func (q *Queries) GetPlatformOutfitSynchronizedAt(ctx context.Context, arg GetPl
 	return synchronized_at, err
 }
 
+const getPreviousScheduledStatsTrackerSession = `-- name: GetPreviousScheduledStatsTrackerSession :one
+SELECT
+  session_id, channel_id, started_at, stopped_at, leaderboard_message_id, origin, task_id, filter
+FROM
+  stats_tracker_session
+WHERE
+  channel_id = ?
+  AND task_id = ?
+  AND stopped_at IS NOT NULL
+  AND session_id < ?
+ORDER BY
+  session_id DESC
+LIMIT
+  1
+`
+
+type GetPreviousScheduledStatsTrackerSessionParams struct {
+	ChannelID string
+	TaskID    sql.NullInt64
+	SessionID int64
+}
+
+func (q *Queries) GetPreviousScheduledStatsTrackerSession(ctx context.Context, arg GetPreviousScheduledStatsTrackerSessionParams) (StatsTrackerSession, error) {
+	row := q.queryRow(ctx, q.getPreviousScheduledStatsTrackerSessionStmt, getPreviousScheduledStatsTrackerSession, arg.ChannelID, arg.TaskID, arg.SessionID)
+	var i StatsTrackerSession
+	err := row.Scan(
+		&i.SessionID,
+		&i.ChannelID,
+		&i.StartedAt,
+		&i.StoppedAt,
+		&i.LeaderboardMessageID,
+		&i.Origin,
+		&i.TaskID,
+		&i.Filter,
+	)
+	return i, err
+}
+
+const getStatsTrackerAutoStart = `-- name: GetStatsTrackerAutoStart :one
+SELECT
+  channel_id, min_online, duration, stop_below
+FROM
+  stats_tracker_auto_start
+WHERE
+  channel_id = ?
+`
+
+func (q *Queries) GetStatsTrackerAutoStart(ctx context.Context, channelID string) (StatsTrackerAutoStart, error) {
+	row := q.queryRow(ctx, q.getStatsTrackerAutoStartStmt, getStatsTrackerAutoStart, channelID)
+	var i StatsTrackerAutoStart
+	err := row.Scan(
+		&i.ChannelID,
+		&i.MinOnline,
+		&i.Duration,
+		&i.StopBelow,
+	)
+	return i, err
+}
+
 const getStatsTrackerTask = `-- name: GetStatsTrackerTask :one
 SELECT
-  task_id, channel_id, utc_start_weekday, utc_start_time, utc_end_weekday, utc_end_time
+  task_id, channel_id, timezone, recurrence, weekday, interval_days, dates, start_time, duration, expires_at, filter
 FROM
   stats_tracker_task
 WHERE
@@ -216,10 +432,15 @@ func (q *Queries) GetStatsTrackerTask(ctx context.Context, taskID int64) (StatsT
 	err := row.Scan(
 		&i.TaskID,
 		&i.ChannelID,
-		&i.UtcStartWeekday,
-		&i.UtcStartTime,
-		&i.UtcEndWeekday,
-		&i.UtcEndTime,
+		&i.Timezone,
+		&i.Recurrence,
+		&i.Weekday,
+		&i.IntervalDays,
+		&i.Dates,
+		&i.StartTime,
+		&i.Duration,
+		&i.ExpiresAt,
+		&i.Filter,
 	)
 	return i, err
 }
@@ -264,30 +485,119 @@ const insertChannelStatsTrackerTask = `-- name: InsertChannelStatsTrackerTask :e
 INSERT INTO
   stats_tracker_task (
     channel_id,
-    utc_start_weekday,
-    utc_start_time,
-    utc_end_weekday,
-    utc_end_time
+    timezone,
+    recurrence,
+    weekday,
+    interval_days,
+    dates,
+    start_time,
+    duration,
+    expires_at,
+    filter
   )
 VALUES
-  (?, ?, ?, ?, ?)
+  (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type InsertChannelStatsTrackerTaskParams struct {
-	ChannelID       string
-	UtcStartWeekday int64
-	UtcStartTime    int64
-	UtcEndWeekday   int64
-	UtcEndTime      int64
+	ChannelID    string
+	Timezone     string
+	Recurrence   string
+	Weekday      int64
+	IntervalDays int64
+	Dates        string
+	StartTime    int64
+	Duration     int64
+	ExpiresAt    sql.NullTime
+	Filter       string
 }
 
 func (q *Queries) InsertChannelStatsTrackerTask(ctx context.Context, arg InsertChannelStatsTrackerTaskParams) error {
 	_, err := q.exec(ctx, q.insertChannelStatsTrackerTaskStmt, insertChannelStatsTrackerTask,
 		arg.ChannelID,
-		arg.UtcStartWeekday,
-		arg.UtcStartTime,
-		arg.UtcEndWeekday,
-		arg.UtcEndTime,
+		arg.Timezone,
+		arg.Recurrence,
+		arg.Weekday,
+		arg.IntervalDays,
+		arg.Dates,
+		arg.StartTime,
+		arg.Duration,
+		arg.ExpiresAt,
+		arg.Filter,
+	)
+	return err
+}
+
+const insertCharacterSession = `-- name: InsertCharacterSession :exec
+INSERT INTO
+  character_session (platform, character_id, world_id, started_at)
+VALUES
+  (?, ?, ?, ?)
+`
+
+type InsertCharacterSessionParams struct {
+	Platform    string
+	CharacterID string
+	WorldID     string
+	StartedAt   time.Time
+}
+
+func (q *Queries) InsertCharacterSession(ctx context.Context, arg InsertCharacterSessionParams) error {
+	_, err := q.exec(ctx, q.insertCharacterSessionStmt, insertCharacterSession,
+		arg.Platform,
+		arg.CharacterID,
+		arg.WorldID,
+		arg.StartedAt,
+	)
+	return err
+}
+
+const insertCharactersTrackerPlayer = `-- name: InsertCharactersTrackerPlayer :exec
+INSERT INTO
+  characters_tracker_player (
+    platform,
+    character_id,
+    world_id,
+    zone_id,
+    faction_id,
+    character_name,
+    outfit_id,
+    outfit_tag,
+    active_at,
+    loadout,
+    online_since
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type InsertCharactersTrackerPlayerParams struct {
+	Platform      string
+	CharacterID   string
+	WorldID       string
+	ZoneID        string
+	FactionID     string
+	CharacterName string
+	OutfitID      string
+	OutfitTag     string
+	ActiveAt      time.Time
+	Loadout       string
+	OnlineSince   sql.NullTime
+}
+
+func (q *Queries) InsertCharactersTrackerPlayer(ctx context.Context, arg InsertCharactersTrackerPlayerParams) error {
+	_, err := q.exec(ctx, q.insertCharactersTrackerPlayerStmt, insertCharactersTrackerPlayer,
+		arg.Platform,
+		arg.CharacterID,
+		arg.WorldID,
+		arg.ZoneID,
+		arg.FactionID,
+		arg.CharacterName,
+		arg.OutfitID,
+		arg.OutfitTag,
+		arg.ActiveAt,
+		arg.Loadout,
+		arg.OnlineSince,
 	)
 	return err
 }
@@ -363,54 +673,123 @@ func (q *Queries) InsertOutfitMember(ctx context.Context, arg InsertOutfitMember
 	return err
 }
 
-const listActiveStatsTrackerTasks = `-- name: ListActiveStatsTrackerTasks :many
-SELECT
-  channel_id
-FROM
-  stats_tracker_task
-WHERE
-  (
-    (
-      ?1 = utc_start_weekday
-      AND ?2 >= utc_start_time
-    )
-    OR (?1 > utc_start_weekday)
-    OR (
-      ?1 = 0
-      AND utc_start_weekday = 6
-    )
-  )
-  AND (
-    (
-      ?1 = utc_end_weekday
-      AND ?2 < utc_end_time
-    )
-    OR (?1 < utc_end_weekday)
-    OR (
-      ?1 = 6
-      AND utc_end_weekday = 0
-    )
+const insertPopulationSample = `-- name: InsertPopulationSample :exec
+INSERT INTO
+  population_sample (
+    platform,
+    world_id,
+    sampled_at,
+    vs,
+    nc,
+    tr,
+    ns,
+    other
   )
+VALUES
+  (?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type InsertPopulationSampleParams struct {
+	Platform  string
+	WorldID   string
+	SampledAt time.Time
+	Vs        int64
+	Nc        int64
+	Tr        int64
+	Ns        int64
+	Other     int64
+}
+
+func (q *Queries) InsertPopulationSample(ctx context.Context, arg InsertPopulationSampleParams) error {
+	_, err := q.exec(ctx, q.insertPopulationSampleStmt, insertPopulationSample,
+		arg.Platform,
+		arg.WorldID,
+		arg.SampledAt,
+		arg.Vs,
+		arg.Nc,
+		arg.Tr,
+		arg.Ns,
+		arg.Other,
+	)
+	return err
+}
+
+const insertStatsTrackerSession = `-- name: InsertStatsTrackerSession :one
+INSERT INTO
+  stats_tracker_session (channel_id, started_at, origin, filter, task_id)
+VALUES
+  (?, ?, ?, ?, ?) RETURNING session_id
+`
+
+type InsertStatsTrackerSessionParams struct {
+	ChannelID string
+	StartedAt time.Time
+	Origin    string
+	Filter    string
+	TaskID    sql.NullInt64
+}
+
+func (q *Queries) InsertStatsTrackerSession(ctx context.Context, arg InsertStatsTrackerSessionParams) (int64, error) {
+	row := q.queryRow(ctx, q.insertStatsTrackerSessionStmt, insertStatsTrackerSession,
+		arg.ChannelID,
+		arg.StartedAt,
+		arg.Origin,
+		arg.Filter,
+		arg.TaskID,
+	)
+	var session_id int64
+	err := row.Scan(&session_id)
+	return session_id, err
+}
+
+const insertStatsTrackerSessionPlatform = `-- name: InsertStatsTrackerSessionPlatform :exec
+INSERT INTO
+  stats_tracker_session_platform (session_id, platform)
+VALUES
+  (?, ?)
 `
 
-type ListActiveStatsTrackerTasksParams struct {
-	UtcWeekday int64
-	UtcTime    int64
+type InsertStatsTrackerSessionPlatformParams struct {
+	SessionID int64
+	Platform  string
+}
+
+func (q *Queries) InsertStatsTrackerSessionPlatform(ctx context.Context, arg InsertStatsTrackerSessionPlatformParams) error {
+	_, err := q.exec(ctx, q.insertStatsTrackerSessionPlatformStmt, insertStatsTrackerSessionPlatform, arg.SessionID, arg.Platform)
+	return err
 }
 
-func (q *Queries) ListActiveStatsTrackerTasks(ctx context.Context, arg ListActiveStatsTrackerTasksParams) ([]string, error) {
-	rows, err := q.query(ctx, q.listActiveStatsTrackerTasksStmt, listActiveStatsTrackerTasks, arg.UtcWeekday, arg.UtcTime)
+const listActiveStatsTrackerSessions = `-- name: ListActiveStatsTrackerSessions :many
+SELECT
+  session_id, channel_id, started_at, stopped_at, leaderboard_message_id, origin, task_id, filter
+FROM
+  stats_tracker_session
+WHERE
+  stopped_at IS NULL
+`
+
+func (q *Queries) ListActiveStatsTrackerSessions(ctx context.Context) ([]StatsTrackerSession, error) {
+	rows, err := q.query(ctx, q.listActiveStatsTrackerSessionsStmt, listActiveStatsTrackerSessions)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []StatsTrackerSession
 	for rows.Next() {
-		var channel_id string
-		if err := rows.Scan(&channel_id); err != nil {
+		var i StatsTrackerSession
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.ChannelID,
+			&i.StartedAt,
+			&i.StoppedAt,
+			&i.LeaderboardMessageID,
+			&i.Origin,
+			&i.TaskID,
+			&i.Filter,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, channel_id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -421,34 +800,41 @@ func (q *Queries) ListActiveStatsTrackerTasks(ctx context.Context, arg ListActiv
 	return items, nil
 }
 
-const listChannelCharacterIdsForPlatform = `-- name: ListChannelCharacterIdsForPlatform :many
+const listActiveStatsTrackerTasks = `-- name: ListActiveStatsTrackerTasks :many
 SELECT
-  character_id
+  task_id, channel_id, timezone, recurrence, weekday, interval_days, dates, start_time, duration, expires_at, filter
 FROM
-  channel_to_character
+  stats_tracker_task
 WHERE
-  channel_id = ?
-  AND platform = ?
+  expires_at IS NULL
+  OR expires_at > ?
 `
 
-type ListChannelCharacterIdsForPlatformParams struct {
-	ChannelID string
-	Platform  string
-}
-
-func (q *Queries) ListChannelCharacterIdsForPlatform(ctx context.Context, arg ListChannelCharacterIdsForPlatformParams) ([]string, error) {
-	rows, err := q.query(ctx, q.listChannelCharacterIdsForPlatformStmt, listChannelCharacterIdsForPlatform, arg.ChannelID, arg.Platform)
+func (q *Queries) ListActiveStatsTrackerTasks(ctx context.Context, expiresAt sql.NullTime) ([]StatsTrackerTask, error) {
+	rows, err := q.query(ctx, q.listActiveStatsTrackerTasksStmt, listActiveStatsTrackerTasks, expiresAt)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []StatsTrackerTask
 	for rows.Next() {
-		var character_id string
-		if err := rows.Scan(&character_id); err != nil {
+		var i StatsTrackerTask
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.ChannelID,
+			&i.Timezone,
+			&i.Recurrence,
+			&i.Weekday,
+			&i.IntervalDays,
+			&i.Dates,
+			&i.StartTime,
+			&i.Duration,
+			&i.ExpiresAt,
+			&i.Filter,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, character_id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -459,63 +845,34 @@ func (q *Queries) ListChannelCharacterIdsForPlatform(ctx context.Context, arg Li
 	return items, nil
 }
 
-const listChannelIntersectingStatsTrackerTasks = `-- name: ListChannelIntersectingStatsTrackerTasks :many
+const listChannelCharacterIdsForPlatform = `-- name: ListChannelCharacterIdsForPlatform :many
 SELECT
-  task_id, channel_id, utc_start_weekday, utc_start_time, utc_end_weekday, utc_end_time
+  character_id
 FROM
-  stats_tracker_task
+  channel_to_character
 WHERE
   channel_id = ?
-  AND (
-    (?2 - utc_start_weekday IN (1, -6))
-    OR (
-      ?2 = utc_start_weekday
-      AND ?3 > utc_start_time
-    )
-  )
-  AND (
-    (utc_end_weekday - ?4 IN (1, -6))
-    OR (
-      ?4 = utc_end_weekday
-      AND ?5 < utc_end_time
-    )
-  )
+  AND platform = ?
 `
 
-type ListChannelIntersectingStatsTrackerTasksParams struct {
-	ChannelID    string
-	EndWeekday   int64
-	EndTime      int64
-	StartWeekday int64
-	StartTime    int64
+type ListChannelCharacterIdsForPlatformParams struct {
+	ChannelID string
+	Platform  string
 }
 
-func (q *Queries) ListChannelIntersectingStatsTrackerTasks(ctx context.Context, arg ListChannelIntersectingStatsTrackerTasksParams) ([]StatsTrackerTask, error) {
-	rows, err := q.query(ctx, q.listChannelIntersectingStatsTrackerTasksStmt, listChannelIntersectingStatsTrackerTasks,
-		arg.ChannelID,
-		arg.EndWeekday,
-		arg.EndTime,
-		arg.StartWeekday,
-		arg.StartTime,
-	)
+func (q *Queries) ListChannelCharacterIdsForPlatform(ctx context.Context, arg ListChannelCharacterIdsForPlatformParams) ([]string, error) {
+	rows, err := q.query(ctx, q.listChannelCharacterIdsForPlatformStmt, listChannelCharacterIdsForPlatform, arg.ChannelID, arg.Platform)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []StatsTrackerTask
+	var items []string
 	for rows.Next() {
-		var i StatsTrackerTask
-		if err := rows.Scan(
-			&i.TaskID,
-			&i.ChannelID,
-			&i.UtcStartWeekday,
-			&i.UtcStartTime,
-			&i.UtcEndWeekday,
-			&i.UtcEndTime,
-		); err != nil {
+		var character_id string
+		if err := rows.Scan(&character_id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, character_id)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -564,31 +921,87 @@ func (q *Queries) ListChannelOutfitIdsForPlatform(ctx context.Context, arg ListC
 	return items, nil
 }
 
-const listChannelStatsTrackerTasks = `-- name: ListChannelStatsTrackerTasks :many
+const listChannelStatsTrackerSessions = `-- name: ListChannelStatsTrackerSessions :many
 SELECT
-  task_id, channel_id, utc_start_weekday, utc_start_time, utc_end_weekday, utc_end_time
+  session_id, channel_id, started_at, stopped_at, leaderboard_message_id, origin, task_id, filter
 FROM
-  stats_tracker_task
+  stats_tracker_session
 WHERE
   channel_id = ?
+  AND stopped_at IS NOT NULL
+ORDER BY
+  session_id DESC
+LIMIT
+  ?
 `
 
-func (q *Queries) ListChannelStatsTrackerTasks(ctx context.Context, channelID string) ([]StatsTrackerTask, error) {
-	rows, err := q.query(ctx, q.listChannelStatsTrackerTasksStmt, listChannelStatsTrackerTasks, channelID)
+type ListChannelStatsTrackerSessionsParams struct {
+	ChannelID string
+	Limit     int64
+}
+
+func (q *Queries) ListChannelStatsTrackerSessions(ctx context.Context, arg ListChannelStatsTrackerSessionsParams) ([]StatsTrackerSession, error) {
+	rows, err := q.query(ctx, q.listChannelStatsTrackerSessionsStmt, listChannelStatsTrackerSessions, arg.ChannelID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []StatsTrackerTask
+	var items []StatsTrackerSession
+	for rows.Next() {
+		var i StatsTrackerSession
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.ChannelID,
+			&i.StartedAt,
+			&i.StoppedAt,
+			&i.LeaderboardMessageID,
+			&i.Origin,
+			&i.TaskID,
+			&i.Filter,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChannelStatsTrackerTasks = `-- name: ListChannelStatsTrackerTasks :many
+SELECT
+  task_id, channel_id, timezone, recurrence, weekday, interval_days, dates, start_time, duration, expires_at, filter
+FROM
+  stats_tracker_task
+WHERE
+  channel_id = ?
+`
+
+func (q *Queries) ListChannelStatsTrackerTasks(ctx context.Context, channelID string) ([]StatsTrackerTask, error) {
+	rows, err := q.query(ctx, q.listChannelStatsTrackerTasksStmt, listChannelStatsTrackerTasks, channelID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StatsTrackerTask
 	for rows.Next() {
 		var i StatsTrackerTask
 		if err := rows.Scan(
 			&i.TaskID,
 			&i.ChannelID,
-			&i.UtcStartWeekday,
-			&i.UtcStartTime,
-			&i.UtcEndWeekday,
-			&i.UtcEndTime,
+			&i.Timezone,
+			&i.Recurrence,
+			&i.Weekday,
+			&i.IntervalDays,
+			&i.Dates,
+			&i.StartTime,
+			&i.Duration,
+			&i.ExpiresAt,
+			&i.Filter,
 		); err != nil {
 			return nil, err
 		}
@@ -642,6 +1055,292 @@ func (q *Queries) ListChannelTrackablePlatforms(ctx context.Context, channelID s
 	return items, nil
 }
 
+const listCharacterSessions = `-- name: ListCharacterSessions :many
+SELECT
+  session_id, platform, character_id, world_id, started_at, ended_at
+FROM
+  character_session
+WHERE
+  platform = ?
+  AND character_id = ?
+  AND (
+    ended_at IS NULL
+    OR ended_at >= ?
+  )
+ORDER BY
+  started_at DESC
+`
+
+type ListCharacterSessionsParams struct {
+	Platform    string
+	CharacterID string
+	EndedAt     sql.NullTime
+}
+
+func (q *Queries) ListCharacterSessions(ctx context.Context, arg ListCharacterSessionsParams) ([]CharacterSession, error) {
+	rows, err := q.query(ctx, q.listCharacterSessionsStmt, listCharacterSessions, arg.Platform, arg.CharacterID, arg.EndedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CharacterSession
+	for rows.Next() {
+		var i CharacterSession
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.Platform,
+			&i.CharacterID,
+			&i.WorldID,
+			&i.StartedAt,
+			&i.EndedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCharactersTrackerPlayers = `-- name: ListCharactersTrackerPlayers :many
+SELECT
+  platform, character_id, world_id, zone_id, faction_id, character_name, outfit_id, outfit_tag, active_at, loadout, online_since
+FROM
+  characters_tracker_player
+WHERE
+  platform = ?
+ORDER BY
+  active_at
+`
+
+func (q *Queries) ListCharactersTrackerPlayers(ctx context.Context, platform string) ([]CharactersTrackerPlayer, error) {
+	rows, err := q.query(ctx, q.listCharactersTrackerPlayersStmt, listCharactersTrackerPlayers, platform)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CharactersTrackerPlayer
+	for rows.Next() {
+		var i CharactersTrackerPlayer
+		if err := rows.Scan(
+			&i.Platform,
+			&i.CharacterID,
+			&i.WorldID,
+			&i.ZoneID,
+			&i.FactionID,
+			&i.CharacterName,
+			&i.OutfitID,
+			&i.OutfitTag,
+			&i.ActiveAt,
+			&i.Loadout,
+			&i.OnlineSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listItems = `-- name: ListItems :many
+SELECT
+  item_id, item_name, item_category
+FROM
+  item
+WHERE
+  item_id IN (/*SLICE:item_ids*/?)
+`
+
+func (q *Queries) ListItems(ctx context.Context, itemIds []string) ([]Item, error) {
+	query := listItems
+	var queryParams []interface{}
+	if len(itemIds) > 0 {
+		for _, v := range itemIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:item_ids*/?", strings.Repeat(",?", len(itemIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:item_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Item
+	for rows.Next() {
+		var i Item
+		if err := rows.Scan(&i.ItemID, &i.ItemName, &i.ItemCategory); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listKnownCharacters = `-- name: ListKnownCharacters :many
+SELECT
+  platform, character_id, character_name, faction_id, world_id, outfit_id, outfit_tag, refreshed_at
+FROM
+  known_character
+WHERE
+  platform = ?
+`
+
+func (q *Queries) ListKnownCharacters(ctx context.Context, platform string) ([]KnownCharacter, error) {
+	rows, err := q.query(ctx, q.listKnownCharactersStmt, listKnownCharacters, platform)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []KnownCharacter
+	for rows.Next() {
+		var i KnownCharacter
+		if err := rows.Scan(
+			&i.Platform,
+			&i.CharacterID,
+			&i.CharacterName,
+			&i.FactionID,
+			&i.WorldID,
+			&i.OutfitID,
+			&i.OutfitTag,
+			&i.RefreshedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOutfitActivityReportChannels = `-- name: ListOutfitActivityReportChannels :many
+SELECT
+  channel_id, locale, character_notifications, outfit_notifications, title_updates, default_timezone, stats_tracker_details, stats_tracker_sort, stats_tracker_charts, stats_tracker_score_formula, outfit_activity_report, outfit_activity_reported_at, character_changes_notifications
+FROM
+  channel
+WHERE
+  outfit_activity_report = TRUE
+`
+
+func (q *Queries) ListOutfitActivityReportChannels(ctx context.Context) ([]Channel, error) {
+	rows, err := q.query(ctx, q.listOutfitActivityReportChannelsStmt, listOutfitActivityReportChannels)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Channel
+	for rows.Next() {
+		var i Channel
+		if err := rows.Scan(
+			&i.ChannelID,
+			&i.Locale,
+			&i.CharacterNotifications,
+			&i.OutfitNotifications,
+			&i.TitleUpdates,
+			&i.DefaultTimezone,
+			&i.StatsTrackerDetails,
+			&i.StatsTrackerSort,
+			&i.StatsTrackerCharts,
+			&i.StatsTrackerScoreFormula,
+			&i.OutfitActivityReport,
+			&i.OutfitActivityReportedAt,
+			&i.CharacterChangesNotifications,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOutfitMembersCharacterSessions = `-- name: ListOutfitMembersCharacterSessions :many
+SELECT
+  character_session.session_id, character_session.platform, character_session.character_id, character_session.world_id, character_session.started_at, character_session.ended_at
+FROM
+  character_session
+  JOIN outfit_to_character ON outfit_to_character.platform = character_session.platform
+  AND outfit_to_character.character_id = character_session.character_id
+WHERE
+  outfit_to_character.platform = ?
+  AND outfit_to_character.outfit_id = ?
+  AND character_session.started_at < ?
+  AND (
+    character_session.ended_at IS NULL
+    OR character_session.ended_at >= ?
+  )
+`
+
+type ListOutfitMembersCharacterSessionsParams struct {
+	Platform  string
+	OutfitID  string
+	StartedAt time.Time
+	EndedAt   sql.NullTime
+}
+
+func (q *Queries) ListOutfitMembersCharacterSessions(ctx context.Context, arg ListOutfitMembersCharacterSessionsParams) ([]CharacterSession, error) {
+	rows, err := q.query(ctx, q.listOutfitMembersCharacterSessionsStmt, listOutfitMembersCharacterSessions,
+		arg.Platform,
+		arg.OutfitID,
+		arg.StartedAt,
+		arg.EndedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CharacterSession
+	for rows.Next() {
+		var i CharacterSession
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.Platform,
+			&i.CharacterID,
+			&i.WorldID,
+			&i.StartedAt,
+			&i.EndedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listPlatformOutfitMembers = `-- name: ListPlatformOutfitMembers :many
 SELECT
   character_id
@@ -736,7 +1435,7 @@ func (q *Queries) ListPlatformOutfits(ctx context.Context, arg ListPlatformOutfi
 
 const listPlatformTrackingChannelsForCharacter = `-- name: ListPlatformTrackingChannelsForCharacter :many
 SELECT
-  channel_id, locale, character_notifications, outfit_notifications, title_updates, default_timezone
+  channel_id, locale, character_notifications, outfit_notifications, title_updates, default_timezone, stats_tracker_details, stats_tracker_sort, stats_tracker_charts, stats_tracker_score_formula, outfit_activity_report, outfit_activity_reported_at, character_changes_notifications
 FROM
   channel
 WHERE
@@ -781,6 +1480,13 @@ func (q *Queries) ListPlatformTrackingChannelsForCharacter(ctx context.Context,
 			&i.OutfitNotifications,
 			&i.TitleUpdates,
 			&i.DefaultTimezone,
+			&i.StatsTrackerDetails,
+			&i.StatsTrackerSort,
+			&i.StatsTrackerCharts,
+			&i.StatsTrackerScoreFormula,
+			&i.OutfitActivityReport,
+			&i.OutfitActivityReportedAt,
+			&i.CharacterChangesNotifications,
 		); err != nil {
 			return nil, err
 		}
@@ -797,7 +1503,7 @@ func (q *Queries) ListPlatformTrackingChannelsForCharacter(ctx context.Context,
 
 const listPlatformTrackingChannelsForOutfit = `-- name: ListPlatformTrackingChannelsForOutfit :many
 SELECT
-  channel_id, locale, character_notifications, outfit_notifications, title_updates, default_timezone
+  channel_id, locale, character_notifications, outfit_notifications, title_updates, default_timezone, stats_tracker_details, stats_tracker_sort, stats_tracker_charts, stats_tracker_score_formula, outfit_activity_report, outfit_activity_reported_at, character_changes_notifications
 FROM
   channel
 WHERE
@@ -833,6 +1539,13 @@ func (q *Queries) ListPlatformTrackingChannelsForOutfit(ctx context.Context, arg
 			&i.OutfitNotifications,
 			&i.TitleUpdates,
 			&i.DefaultTimezone,
+			&i.StatsTrackerDetails,
+			&i.StatsTrackerSort,
+			&i.StatsTrackerCharts,
+			&i.StatsTrackerScoreFormula,
+			&i.OutfitActivityReport,
+			&i.OutfitActivityReportedAt,
+			&i.CharacterChangesNotifications,
 		); err != nil {
 			return nil, err
 		}
@@ -847,37 +1560,39 @@ func (q *Queries) ListPlatformTrackingChannelsForOutfit(ctx context.Context, arg
 	return items, nil
 }
 
-const listTrackableCharacterIdsWithDuplicationForPlatform = `-- name: ListTrackableCharacterIdsWithDuplicationForPlatform :many
+const listPopulationSamples = `-- name: ListPopulationSamples :many
 SELECT
-  character_id
-FROM
-  channel_to_character
-WHERE
-  channel_to_character.platform = ?1
-UNION ALL
-SELECT
-  character_id
+  platform, world_id, sampled_at, vs, nc, tr, ns, other
 FROM
-  channel_to_outfit
-  JOIN outfit_to_character ON channel_to_outfit.outfit_id = outfit_to_character.outfit_id
-  AND channel_to_outfit.platform = outfit_to_character.platform
+  population_sample
 WHERE
-  channel_to_outfit.platform = ?1
+  platform = ?
+ORDER BY
+  sampled_at
 `
 
-func (q *Queries) ListTrackableCharacterIdsWithDuplicationForPlatform(ctx context.Context, platform string) ([]string, error) {
-	rows, err := q.query(ctx, q.listTrackableCharacterIdsWithDuplicationForPlatformStmt, listTrackableCharacterIdsWithDuplicationForPlatform, platform)
+func (q *Queries) ListPopulationSamples(ctx context.Context, platform string) ([]PopulationSample, error) {
+	rows, err := q.query(ctx, q.listPopulationSamplesStmt, listPopulationSamples, platform)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []PopulationSample
 	for rows.Next() {
-		var character_id string
-		if err := rows.Scan(&character_id); err != nil {
+		var i PopulationSample
+		if err := rows.Scan(
+			&i.Platform,
+			&i.WorldID,
+			&i.SampledAt,
+			&i.Vs,
+			&i.Nc,
+			&i.Tr,
+			&i.Ns,
+			&i.Other,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, character_id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -888,24 +1603,457 @@ func (q *Queries) ListTrackableCharacterIdsWithDuplicationForPlatform(ctx contex
 	return items, nil
 }
 
-const listTrackableOutfitIdsWithDuplicationForPlatform = `-- name: ListTrackableOutfitIdsWithDuplicationForPlatform :many
+const listStatsTrackerAutoStarts = `-- name: ListStatsTrackerAutoStarts :many
 SELECT
-  outfit_id
+  channel_id, min_online, duration, stop_below
 FROM
-  channel_to_outfit
-WHERE
-  platform = ?
+  stats_tracker_auto_start
 `
 
-func (q *Queries) ListTrackableOutfitIdsWithDuplicationForPlatform(ctx context.Context, platform string) ([]string, error) {
-	rows, err := q.query(ctx, q.listTrackableOutfitIdsWithDuplicationForPlatformStmt, listTrackableOutfitIdsWithDuplicationForPlatform, platform)
+func (q *Queries) ListStatsTrackerAutoStarts(ctx context.Context) ([]StatsTrackerAutoStart, error) {
+	rows, err := q.query(ctx, q.listStatsTrackerAutoStartsStmt, listStatsTrackerAutoStarts)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []StatsTrackerAutoStart
 	for rows.Next() {
-		var outfit_id string
+		var i StatsTrackerAutoStart
+		if err := rows.Scan(
+			&i.ChannelID,
+			&i.MinOnline,
+			&i.Duration,
+			&i.StopBelow,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatsTrackerSessionCharacterAchievements = `-- name: ListStatsTrackerSessionCharacterAchievements :many
+SELECT
+  session_id, platform, character_id, achievement_id, count
+FROM
+  stats_tracker_character_achievement
+WHERE
+  session_id = ?
+`
+
+func (q *Queries) ListStatsTrackerSessionCharacterAchievements(ctx context.Context, sessionID int64) ([]StatsTrackerCharacterAchievement, error) {
+	rows, err := q.query(ctx, q.listStatsTrackerSessionCharacterAchievementsStmt, listStatsTrackerSessionCharacterAchievements, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StatsTrackerCharacterAchievement
+	for rows.Next() {
+		var i StatsTrackerCharacterAchievement
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.Platform,
+			&i.CharacterID,
+			&i.AchievementID,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatsTrackerSessionCharacterFacilities = `-- name: ListStatsTrackerSessionCharacterFacilities :many
+SELECT
+  session_id, platform, character_id, facility_id, captures, defenses
+FROM
+  stats_tracker_character_facility
+WHERE
+  session_id = ?
+`
+
+func (q *Queries) ListStatsTrackerSessionCharacterFacilities(ctx context.Context, sessionID int64) ([]StatsTrackerCharacterFacility, error) {
+	rows, err := q.query(ctx, q.listStatsTrackerSessionCharacterFacilitiesStmt, listStatsTrackerSessionCharacterFacilities, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StatsTrackerCharacterFacility
+	for rows.Next() {
+		var i StatsTrackerCharacterFacility
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.Platform,
+			&i.CharacterID,
+			&i.FacilityID,
+			&i.Captures,
+			&i.Defenses,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatsTrackerSessionCharacterLoadouts = `-- name: ListStatsTrackerSessionCharacterLoadouts :many
+SELECT
+  session_id, platform, character_id, loadout_type, duration
+FROM
+  stats_tracker_character_loadout
+WHERE
+  session_id = ?
+`
+
+func (q *Queries) ListStatsTrackerSessionCharacterLoadouts(ctx context.Context, sessionID int64) ([]StatsTrackerCharacterLoadout, error) {
+	rows, err := q.query(ctx, q.listStatsTrackerSessionCharacterLoadoutsStmt, listStatsTrackerSessionCharacterLoadouts, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StatsTrackerCharacterLoadout
+	for rows.Next() {
+		var i StatsTrackerCharacterLoadout
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.Platform,
+			&i.CharacterID,
+			&i.LoadoutType,
+			&i.Duration,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatsTrackerSessionCharacterRivals = `-- name: ListStatsTrackerSessionCharacterRivals :many
+SELECT
+  session_id, platform, character_id, rival_id, kills, deaths
+FROM
+  stats_tracker_character_rival
+WHERE
+  session_id = ?
+`
+
+func (q *Queries) ListStatsTrackerSessionCharacterRivals(ctx context.Context, sessionID int64) ([]StatsTrackerCharacterRival, error) {
+	rows, err := q.query(ctx, q.listStatsTrackerSessionCharacterRivalsStmt, listStatsTrackerSessionCharacterRivals, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StatsTrackerCharacterRival
+	for rows.Next() {
+		var i StatsTrackerCharacterRival
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.Platform,
+			&i.CharacterID,
+			&i.RivalID,
+			&i.Kills,
+			&i.Deaths,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatsTrackerSessionCharacterVehicles = `-- name: ListStatsTrackerSessionCharacterVehicles :many
+SELECT
+  session_id, platform, character_id, vehicle_id, destroyed, lost
+FROM
+  stats_tracker_character_vehicle
+WHERE
+  session_id = ?
+`
+
+func (q *Queries) ListStatsTrackerSessionCharacterVehicles(ctx context.Context, sessionID int64) ([]StatsTrackerCharacterVehicle, error) {
+	rows, err := q.query(ctx, q.listStatsTrackerSessionCharacterVehiclesStmt, listStatsTrackerSessionCharacterVehicles, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StatsTrackerCharacterVehicle
+	for rows.Next() {
+		var i StatsTrackerCharacterVehicle
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.Platform,
+			&i.CharacterID,
+			&i.VehicleID,
+			&i.Destroyed,
+			&i.Lost,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatsTrackerSessionCharacterWeapons = `-- name: ListStatsTrackerSessionCharacterWeapons :many
+SELECT
+  session_id, platform, character_id, weapon_id, kills, head_shots_kills
+FROM
+  stats_tracker_character_weapon
+WHERE
+  session_id = ?
+`
+
+func (q *Queries) ListStatsTrackerSessionCharacterWeapons(ctx context.Context, sessionID int64) ([]StatsTrackerCharacterWeapon, error) {
+	rows, err := q.query(ctx, q.listStatsTrackerSessionCharacterWeaponsStmt, listStatsTrackerSessionCharacterWeapons, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StatsTrackerCharacterWeapon
+	for rows.Next() {
+		var i StatsTrackerCharacterWeapon
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.Platform,
+			&i.CharacterID,
+			&i.WeaponID,
+			&i.Kills,
+			&i.HeadShotsKills,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatsTrackerSessionCharacterZones = `-- name: ListStatsTrackerSessionCharacterZones :many
+SELECT
+  session_id, platform, character_id, zone_id, kills, deaths, duration
+FROM
+  stats_tracker_character_zone
+WHERE
+  session_id = ?
+`
+
+func (q *Queries) ListStatsTrackerSessionCharacterZones(ctx context.Context, sessionID int64) ([]StatsTrackerCharacterZone, error) {
+	rows, err := q.query(ctx, q.listStatsTrackerSessionCharacterZonesStmt, listStatsTrackerSessionCharacterZones, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StatsTrackerCharacterZone
+	for rows.Next() {
+		var i StatsTrackerCharacterZone
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.Platform,
+			&i.CharacterID,
+			&i.ZoneID,
+			&i.Kills,
+			&i.Deaths,
+			&i.Duration,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatsTrackerSessionCharacters = `-- name: ListStatsTrackerSessionCharacters :many
+SELECT
+  session_id, platform, character_id, body_kills, head_shots_kills, team_kills, deaths, deaths_by_restricted_area, suicides, revives, heals, repairs, resupplies, shield_repairs, spot_assists, score, online_duration, battle_rank_ups, battle_rank, prestiges
+FROM
+  stats_tracker_character
+WHERE
+  session_id = ?
+`
+
+func (q *Queries) ListStatsTrackerSessionCharacters(ctx context.Context, sessionID int64) ([]StatsTrackerCharacter, error) {
+	rows, err := q.query(ctx, q.listStatsTrackerSessionCharactersStmt, listStatsTrackerSessionCharacters, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StatsTrackerCharacter
+	for rows.Next() {
+		var i StatsTrackerCharacter
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.Platform,
+			&i.CharacterID,
+			&i.BodyKills,
+			&i.HeadShotsKills,
+			&i.TeamKills,
+			&i.Deaths,
+			&i.DeathsByRestrictedArea,
+			&i.Suicides,
+			&i.Revives,
+			&i.Heals,
+			&i.Repairs,
+			&i.Resupplies,
+			&i.ShieldRepairs,
+			&i.SpotAssists,
+			&i.Score,
+			&i.OnlineDuration,
+			&i.BattleRankUps,
+			&i.BattleRank,
+			&i.Prestiges,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatsTrackerSessionPlatforms = `-- name: ListStatsTrackerSessionPlatforms :many
+SELECT
+  platform
+FROM
+  stats_tracker_session_platform
+WHERE
+  session_id = ?
+`
+
+func (q *Queries) ListStatsTrackerSessionPlatforms(ctx context.Context, sessionID int64) ([]string, error) {
+	rows, err := q.query(ctx, q.listStatsTrackerSessionPlatformsStmt, listStatsTrackerSessionPlatforms, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var platform string
+		if err := rows.Scan(&platform); err != nil {
+			return nil, err
+		}
+		items = append(items, platform)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTrackableCharacterIdsWithDuplicationForPlatform = `-- name: ListTrackableCharacterIdsWithDuplicationForPlatform :many
+SELECT
+  character_id
+FROM
+  channel_to_character
+WHERE
+  channel_to_character.platform = ?1
+UNION ALL
+SELECT
+  character_id
+FROM
+  channel_to_outfit
+  JOIN outfit_to_character ON channel_to_outfit.outfit_id = outfit_to_character.outfit_id
+  AND channel_to_outfit.platform = outfit_to_character.platform
+WHERE
+  channel_to_outfit.platform = ?1
+`
+
+func (q *Queries) ListTrackableCharacterIdsWithDuplicationForPlatform(ctx context.Context, platform string) ([]string, error) {
+	rows, err := q.query(ctx, q.listTrackableCharacterIdsWithDuplicationForPlatformStmt, listTrackableCharacterIdsWithDuplicationForPlatform, platform)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var character_id string
+		if err := rows.Scan(&character_id); err != nil {
+			return nil, err
+		}
+		items = append(items, character_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTrackableOutfitIdsWithDuplicationForPlatform = `-- name: ListTrackableOutfitIdsWithDuplicationForPlatform :many
+SELECT
+  outfit_id
+FROM
+  channel_to_outfit
+WHERE
+  platform = ?
+`
+
+func (q *Queries) ListTrackableOutfitIdsWithDuplicationForPlatform(ctx context.Context, platform string) ([]string, error) {
+	rows, err := q.query(ctx, q.listTrackableOutfitIdsWithDuplicationForPlatformStmt, listTrackableOutfitIdsWithDuplicationForPlatform, platform)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var outfit_id string
 		if err := rows.Scan(&outfit_id); err != nil {
 			return nil, err
 		}
@@ -952,20 +2100,168 @@ func (q *Queries) ListUniqueTrackableOutfitIdsForPlatform(ctx context.Context, p
 	return items, nil
 }
 
-const removeChannelStatsTrackerTask = `-- name: RemoveChannelStatsTrackerTask :exec
-DELETE FROM stats_tracker_task
+const listUtcStatsTrackerTasks = `-- name: ListUtcStatsTrackerTasks :many
+SELECT
+  stats_tracker_task.task_id,
+  stats_tracker_task.weekday,
+  stats_tracker_task.start_time,
+  channel.default_timezone
+FROM
+  stats_tracker_task_utc
+  JOIN stats_tracker_task ON stats_tracker_task.task_id = stats_tracker_task_utc.task_id
+  JOIN channel ON channel.channel_id = stats_tracker_task.channel_id
+`
+
+type ListUtcStatsTrackerTasksRow struct {
+	TaskID          int64
+	Weekday         int64
+	StartTime       int64
+	DefaultTimezone string
+}
+
+func (q *Queries) ListUtcStatsTrackerTasks(ctx context.Context) ([]ListUtcStatsTrackerTasksRow, error) {
+	rows, err := q.query(ctx, q.listUtcStatsTrackerTasksStmt, listUtcStatsTrackerTasks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUtcStatsTrackerTasksRow
+	for rows.Next() {
+		var i ListUtcStatsTrackerTasksRow
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.Weekday,
+			&i.StartTime,
+			&i.DefaultTimezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeChannelStatsTrackerTask = `-- name: RemoveChannelStatsTrackerTask :exec
+DELETE FROM stats_tracker_task
+WHERE
+  task_id = ?
+  AND channel_id = ?
+`
+
+type RemoveChannelStatsTrackerTaskParams struct {
+	TaskID    int64
+	ChannelID string
+}
+
+func (q *Queries) RemoveChannelStatsTrackerTask(ctx context.Context, arg RemoveChannelStatsTrackerTaskParams) error {
+	_, err := q.exec(ctx, q.removeChannelStatsTrackerTaskStmt, removeChannelStatsTrackerTask, arg.TaskID, arg.ChannelID)
+	return err
+}
+
+const updateChannelOutfitActivityReportedAt = `-- name: UpdateChannelOutfitActivityReportedAt :exec
+UPDATE channel
+SET
+  outfit_activity_reported_at = ?
+WHERE
+  channel_id = ?
+`
+
+type UpdateChannelOutfitActivityReportedAtParams struct {
+	OutfitActivityReportedAt sql.NullTime
+	ChannelID                string
+}
+
+func (q *Queries) UpdateChannelOutfitActivityReportedAt(ctx context.Context, arg UpdateChannelOutfitActivityReportedAtParams) error {
+	_, err := q.exec(ctx, q.updateChannelOutfitActivityReportedAtStmt, updateChannelOutfitActivityReportedAt, arg.OutfitActivityReportedAt, arg.ChannelID)
+	return err
+}
+
+const updateStatsTrackerSessionLeaderboardMessage = `-- name: UpdateStatsTrackerSessionLeaderboardMessage :exec
+UPDATE stats_tracker_session
+SET
+  leaderboard_message_id = ?
+WHERE
+  session_id = ?
+`
+
+type UpdateStatsTrackerSessionLeaderboardMessageParams struct {
+	LeaderboardMessageID string
+	SessionID            int64
+}
+
+func (q *Queries) UpdateStatsTrackerSessionLeaderboardMessage(ctx context.Context, arg UpdateStatsTrackerSessionLeaderboardMessageParams) error {
+	_, err := q.exec(ctx, q.updateStatsTrackerSessionLeaderboardMessageStmt, updateStatsTrackerSessionLeaderboardMessage, arg.LeaderboardMessageID, arg.SessionID)
+	return err
+}
+
+const updateStatsTrackerSessionStoppedAt = `-- name: UpdateStatsTrackerSessionStoppedAt :exec
+UPDATE stats_tracker_session
+SET
+  stopped_at = ?
+WHERE
+  session_id = ?
+`
+
+type UpdateStatsTrackerSessionStoppedAtParams struct {
+	StoppedAt sql.NullTime
+	SessionID int64
+}
+
+func (q *Queries) UpdateStatsTrackerSessionStoppedAt(ctx context.Context, arg UpdateStatsTrackerSessionStoppedAtParams) error {
+	_, err := q.exec(ctx, q.updateStatsTrackerSessionStoppedAtStmt, updateStatsTrackerSessionStoppedAt, arg.StoppedAt, arg.SessionID)
+	return err
+}
+
+const updateStatsTrackerTaskLocalTime = `-- name: UpdateStatsTrackerTaskLocalTime :exec
+UPDATE stats_tracker_task
+SET
+  timezone = ?,
+  weekday = ?,
+  start_time = ?
 WHERE
   task_id = ?
-  AND channel_id = ?
 `
 
-type RemoveChannelStatsTrackerTaskParams struct {
+type UpdateStatsTrackerTaskLocalTimeParams struct {
+	Timezone  string
+	Weekday   int64
+	StartTime int64
 	TaskID    int64
-	ChannelID string
 }
 
-func (q *Queries) RemoveChannelStatsTrackerTask(ctx context.Context, arg RemoveChannelStatsTrackerTaskParams) error {
-	_, err := q.exec(ctx, q.removeChannelStatsTrackerTaskStmt, removeChannelStatsTrackerTask, arg.TaskID, arg.ChannelID)
+func (q *Queries) UpdateStatsTrackerTaskLocalTime(ctx context.Context, arg UpdateStatsTrackerTaskLocalTimeParams) error {
+	_, err := q.exec(ctx, q.updateStatsTrackerTaskLocalTimeStmt, updateStatsTrackerTaskLocalTime,
+		arg.Timezone,
+		arg.Weekday,
+		arg.StartTime,
+		arg.TaskID,
+	)
+	return err
+}
+
+const upsertChannelCharacterChangesNotifications = `-- name: UpsertChannelCharacterChangesNotifications :exec
+INSERT INTO
+  channel (channel_id, character_changes_notifications)
+VALUES
+  (?, ?) ON CONFLICT (channel_id) DO
+UPDATE
+SET
+  character_changes_notifications = EXCLUDED.character_changes_notifications
+`
+
+type UpsertChannelCharacterChangesNotificationsParams struct {
+	ChannelID                     string
+	CharacterChangesNotifications bool
+}
+
+func (q *Queries) UpsertChannelCharacterChangesNotifications(ctx context.Context, arg UpsertChannelCharacterChangesNotificationsParams) error {
+	_, err := q.exec(ctx, q.upsertChannelCharacterChangesNotificationsStmt, upsertChannelCharacterChangesNotifications, arg.ChannelID, arg.CharacterChangesNotifications)
 	return err
 }
 
@@ -1029,6 +2325,32 @@ func (q *Queries) UpsertChannelLanguage(ctx context.Context, arg UpsertChannelLa
 	return err
 }
 
+const upsertChannelOutfitActivityReport = `-- name: UpsertChannelOutfitActivityReport :exec
+INSERT INTO
+  channel (
+    channel_id,
+    outfit_activity_report,
+    outfit_activity_reported_at
+  )
+VALUES
+  (?, ?, ?) ON CONFLICT (channel_id) DO
+UPDATE
+SET
+  outfit_activity_report = EXCLUDED.outfit_activity_report,
+  outfit_activity_reported_at = EXCLUDED.outfit_activity_reported_at
+`
+
+type UpsertChannelOutfitActivityReportParams struct {
+	ChannelID                string
+	OutfitActivityReport     bool
+	OutfitActivityReportedAt sql.NullTime
+}
+
+func (q *Queries) UpsertChannelOutfitActivityReport(ctx context.Context, arg UpsertChannelOutfitActivityReportParams) error {
+	_, err := q.exec(ctx, q.upsertChannelOutfitActivityReportStmt, upsertChannelOutfitActivityReport, arg.ChannelID, arg.OutfitActivityReport, arg.OutfitActivityReportedAt)
+	return err
+}
+
 const upsertChannelOutfitNotifications = `-- name: UpsertChannelOutfitNotifications :exec
 INSERT INTO
   channel (channel_id, outfit_notifications)
@@ -1049,6 +2371,86 @@ func (q *Queries) UpsertChannelOutfitNotifications(ctx context.Context, arg Upse
 	return err
 }
 
+const upsertChannelStatsTrackerCharts = `-- name: UpsertChannelStatsTrackerCharts :exec
+INSERT INTO
+  channel (channel_id, stats_tracker_charts)
+VALUES
+  (?, ?) ON CONFLICT (channel_id) DO
+UPDATE
+SET
+  stats_tracker_charts = EXCLUDED.stats_tracker_charts
+`
+
+type UpsertChannelStatsTrackerChartsParams struct {
+	ChannelID          string
+	StatsTrackerCharts bool
+}
+
+func (q *Queries) UpsertChannelStatsTrackerCharts(ctx context.Context, arg UpsertChannelStatsTrackerChartsParams) error {
+	_, err := q.exec(ctx, q.upsertChannelStatsTrackerChartsStmt, upsertChannelStatsTrackerCharts, arg.ChannelID, arg.StatsTrackerCharts)
+	return err
+}
+
+const upsertChannelStatsTrackerDetails = `-- name: UpsertChannelStatsTrackerDetails :exec
+INSERT INTO
+  channel (channel_id, stats_tracker_details)
+VALUES
+  (?, ?) ON CONFLICT (channel_id) DO
+UPDATE
+SET
+  stats_tracker_details = EXCLUDED.stats_tracker_details
+`
+
+type UpsertChannelStatsTrackerDetailsParams struct {
+	ChannelID           string
+	StatsTrackerDetails bool
+}
+
+func (q *Queries) UpsertChannelStatsTrackerDetails(ctx context.Context, arg UpsertChannelStatsTrackerDetailsParams) error {
+	_, err := q.exec(ctx, q.upsertChannelStatsTrackerDetailsStmt, upsertChannelStatsTrackerDetails, arg.ChannelID, arg.StatsTrackerDetails)
+	return err
+}
+
+const upsertChannelStatsTrackerScoreFormula = `-- name: UpsertChannelStatsTrackerScoreFormula :exec
+INSERT INTO
+  channel (channel_id, stats_tracker_score_formula)
+VALUES
+  (?, ?) ON CONFLICT (channel_id) DO
+UPDATE
+SET
+  stats_tracker_score_formula = EXCLUDED.stats_tracker_score_formula
+`
+
+type UpsertChannelStatsTrackerScoreFormulaParams struct {
+	ChannelID                string
+	StatsTrackerScoreFormula string
+}
+
+func (q *Queries) UpsertChannelStatsTrackerScoreFormula(ctx context.Context, arg UpsertChannelStatsTrackerScoreFormulaParams) error {
+	_, err := q.exec(ctx, q.upsertChannelStatsTrackerScoreFormulaStmt, upsertChannelStatsTrackerScoreFormula, arg.ChannelID, arg.StatsTrackerScoreFormula)
+	return err
+}
+
+const upsertChannelStatsTrackerSort = `-- name: UpsertChannelStatsTrackerSort :exec
+INSERT INTO
+  channel (channel_id, stats_tracker_sort)
+VALUES
+  (?, ?) ON CONFLICT (channel_id) DO
+UPDATE
+SET
+  stats_tracker_sort = EXCLUDED.stats_tracker_sort
+`
+
+type UpsertChannelStatsTrackerSortParams struct {
+	ChannelID        string
+	StatsTrackerSort string
+}
+
+func (q *Queries) UpsertChannelStatsTrackerSort(ctx context.Context, arg UpsertChannelStatsTrackerSortParams) error {
+	_, err := q.exec(ctx, q.upsertChannelStatsTrackerSortStmt, upsertChannelStatsTrackerSort, arg.ChannelID, arg.StatsTrackerSort)
+	return err
+}
+
 const upsertChannelTitleUpdates = `-- name: UpsertChannelTitleUpdates :exec
 INSERT INTO
   channel (channel_id, title_updates)
@@ -1069,6 +2471,77 @@ func (q *Queries) UpsertChannelTitleUpdates(ctx context.Context, arg UpsertChann
 	return err
 }
 
+const upsertItem = `-- name: UpsertItem :exec
+INSERT INTO
+  item (item_id, item_name, item_category)
+VALUES
+  (?, ?, ?) ON CONFLICT (item_id) DO
+UPDATE
+SET
+  item_name = EXCLUDED.item_name,
+  item_category = EXCLUDED.item_category
+`
+
+type UpsertItemParams struct {
+	ItemID       string
+	ItemName     string
+	ItemCategory string
+}
+
+func (q *Queries) UpsertItem(ctx context.Context, arg UpsertItemParams) error {
+	_, err := q.exec(ctx, q.upsertItemStmt, upsertItem, arg.ItemID, arg.ItemName, arg.ItemCategory)
+	return err
+}
+
+const upsertKnownCharacter = `-- name: UpsertKnownCharacter :exec
+INSERT INTO
+  known_character (
+    platform,
+    character_id,
+    character_name,
+    faction_id,
+    world_id,
+    outfit_id,
+    outfit_tag,
+    refreshed_at
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (platform, character_id) DO
+UPDATE
+SET
+  character_name = EXCLUDED.character_name,
+  faction_id = EXCLUDED.faction_id,
+  world_id = EXCLUDED.world_id,
+  outfit_id = EXCLUDED.outfit_id,
+  outfit_tag = EXCLUDED.outfit_tag,
+  refreshed_at = EXCLUDED.refreshed_at
+`
+
+type UpsertKnownCharacterParams struct {
+	Platform      string
+	CharacterID   string
+	CharacterName string
+	FactionID     string
+	WorldID       string
+	OutfitID      string
+	OutfitTag     string
+	RefreshedAt   time.Time
+}
+
+func (q *Queries) UpsertKnownCharacter(ctx context.Context, arg UpsertKnownCharacterParams) error {
+	_, err := q.exec(ctx, q.upsertKnownCharacterStmt, upsertKnownCharacter,
+		arg.Platform,
+		arg.CharacterID,
+		arg.CharacterName,
+		arg.FactionID,
+		arg.WorldID,
+		arg.OutfitID,
+		arg.OutfitTag,
+		arg.RefreshedAt,
+	)
+	return err
+}
+
 const upsertPlatformOutfitSynchronizedAt = `-- name: UpsertPlatformOutfitSynchronizedAt :exec
 INSERT INTO
   outfit_synchronization (platform, outfit_id, synchronized_at)
@@ -1089,3 +2562,397 @@ func (q *Queries) UpsertPlatformOutfitSynchronizedAt(ctx context.Context, arg Up
 	_, err := q.exec(ctx, q.upsertPlatformOutfitSynchronizedAtStmt, upsertPlatformOutfitSynchronizedAt, arg.Platform, arg.OutfitID, arg.SynchronizedAt)
 	return err
 }
+
+const upsertStatsTrackerAutoStart = `-- name: UpsertStatsTrackerAutoStart :exec
+INSERT INTO
+  stats_tracker_auto_start (channel_id, min_online, duration, stop_below)
+VALUES
+  (?, ?, ?, ?) ON CONFLICT (channel_id) DO
+UPDATE
+SET
+  min_online = EXCLUDED.min_online,
+  duration = EXCLUDED.duration,
+  stop_below = EXCLUDED.stop_below
+`
+
+type UpsertStatsTrackerAutoStartParams struct {
+	ChannelID string
+	MinOnline int64
+	Duration  int64
+	StopBelow int64
+}
+
+func (q *Queries) UpsertStatsTrackerAutoStart(ctx context.Context, arg UpsertStatsTrackerAutoStartParams) error {
+	_, err := q.exec(ctx, q.upsertStatsTrackerAutoStartStmt, upsertStatsTrackerAutoStart,
+		arg.ChannelID,
+		arg.MinOnline,
+		arg.Duration,
+		arg.StopBelow,
+	)
+	return err
+}
+
+const upsertStatsTrackerCharacter = `-- name: UpsertStatsTrackerCharacter :exec
+INSERT INTO
+  stats_tracker_character (
+    session_id,
+    platform,
+    character_id,
+    body_kills,
+    head_shots_kills,
+    team_kills,
+    deaths,
+    deaths_by_restricted_area,
+    suicides,
+    revives,
+    heals,
+    repairs,
+    resupplies,
+    shield_repairs,
+    spot_assists,
+    score,
+    online_duration,
+    battle_rank_ups,
+    battle_rank,
+    prestiges
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id) DO
+UPDATE
+SET
+  body_kills = EXCLUDED.body_kills,
+  head_shots_kills = EXCLUDED.head_shots_kills,
+  team_kills = EXCLUDED.team_kills,
+  deaths = EXCLUDED.deaths,
+  deaths_by_restricted_area = EXCLUDED.deaths_by_restricted_area,
+  suicides = EXCLUDED.suicides,
+  revives = EXCLUDED.revives,
+  heals = EXCLUDED.heals,
+  repairs = EXCLUDED.repairs,
+  resupplies = EXCLUDED.resupplies,
+  shield_repairs = EXCLUDED.shield_repairs,
+  spot_assists = EXCLUDED.spot_assists,
+  score = EXCLUDED.score,
+  online_duration = EXCLUDED.online_duration,
+  battle_rank_ups = EXCLUDED.battle_rank_ups,
+  battle_rank = EXCLUDED.battle_rank,
+  prestiges = EXCLUDED.prestiges
+`
+
+type UpsertStatsTrackerCharacterParams struct {
+	SessionID              int64
+	Platform               string
+	CharacterID            string
+	BodyKills              int64
+	HeadShotsKills         int64
+	TeamKills              int64
+	Deaths                 int64
+	DeathsByRestrictedArea int64
+	Suicides               int64
+	Revives                int64
+	Heals                  int64
+	Repairs                int64
+	Resupplies             int64
+	ShieldRepairs          int64
+	SpotAssists            int64
+	Score                  int64
+	OnlineDuration         int64
+	BattleRankUps          int64
+	BattleRank             int64
+	Prestiges              int64
+}
+
+func (q *Queries) UpsertStatsTrackerCharacter(ctx context.Context, arg UpsertStatsTrackerCharacterParams) error {
+	_, err := q.exec(ctx, q.upsertStatsTrackerCharacterStmt, upsertStatsTrackerCharacter,
+		arg.SessionID,
+		arg.Platform,
+		arg.CharacterID,
+		arg.BodyKills,
+		arg.HeadShotsKills,
+		arg.TeamKills,
+		arg.Deaths,
+		arg.DeathsByRestrictedArea,
+		arg.Suicides,
+		arg.Revives,
+		arg.Heals,
+		arg.Repairs,
+		arg.Resupplies,
+		arg.ShieldRepairs,
+		arg.SpotAssists,
+		arg.Score,
+		arg.OnlineDuration,
+		arg.BattleRankUps,
+		arg.BattleRank,
+		arg.Prestiges,
+	)
+	return err
+}
+
+const upsertStatsTrackerCharacterAchievement = `-- name: UpsertStatsTrackerCharacterAchievement :exec
+INSERT INTO
+  stats_tracker_character_achievement (
+    session_id,
+    platform,
+    character_id,
+    achievement_id,
+    count
+  )
+VALUES
+  (?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id, achievement_id) DO
+UPDATE
+SET
+  count = EXCLUDED.count
+`
+
+type UpsertStatsTrackerCharacterAchievementParams struct {
+	SessionID     int64
+	Platform      string
+	CharacterID   string
+	AchievementID string
+	Count         int64
+}
+
+func (q *Queries) UpsertStatsTrackerCharacterAchievement(ctx context.Context, arg UpsertStatsTrackerCharacterAchievementParams) error {
+	_, err := q.exec(ctx, q.upsertStatsTrackerCharacterAchievementStmt, upsertStatsTrackerCharacterAchievement,
+		arg.SessionID,
+		arg.Platform,
+		arg.CharacterID,
+		arg.AchievementID,
+		arg.Count,
+	)
+	return err
+}
+
+const upsertStatsTrackerCharacterFacility = `-- name: UpsertStatsTrackerCharacterFacility :exec
+INSERT INTO
+  stats_tracker_character_facility (
+    session_id,
+    platform,
+    character_id,
+    facility_id,
+    captures,
+    defenses
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id, facility_id) DO
+UPDATE
+SET
+  captures = EXCLUDED.captures,
+  defenses = EXCLUDED.defenses
+`
+
+type UpsertStatsTrackerCharacterFacilityParams struct {
+	SessionID   int64
+	Platform    string
+	CharacterID string
+	FacilityID  string
+	Captures    int64
+	Defenses    int64
+}
+
+func (q *Queries) UpsertStatsTrackerCharacterFacility(ctx context.Context, arg UpsertStatsTrackerCharacterFacilityParams) error {
+	_, err := q.exec(ctx, q.upsertStatsTrackerCharacterFacilityStmt, upsertStatsTrackerCharacterFacility,
+		arg.SessionID,
+		arg.Platform,
+		arg.CharacterID,
+		arg.FacilityID,
+		arg.Captures,
+		arg.Defenses,
+	)
+	return err
+}
+
+const upsertStatsTrackerCharacterLoadout = `-- name: UpsertStatsTrackerCharacterLoadout :exec
+INSERT INTO
+  stats_tracker_character_loadout (
+    session_id,
+    platform,
+    character_id,
+    loadout_type,
+    duration
+  )
+VALUES
+  (?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id, loadout_type) DO
+UPDATE
+SET
+  duration = EXCLUDED.duration
+`
+
+type UpsertStatsTrackerCharacterLoadoutParams struct {
+	SessionID   int64
+	Platform    string
+	CharacterID string
+	LoadoutType int64
+	Duration    int64
+}
+
+func (q *Queries) UpsertStatsTrackerCharacterLoadout(ctx context.Context, arg UpsertStatsTrackerCharacterLoadoutParams) error {
+	_, err := q.exec(ctx, q.upsertStatsTrackerCharacterLoadoutStmt, upsertStatsTrackerCharacterLoadout,
+		arg.SessionID,
+		arg.Platform,
+		arg.CharacterID,
+		arg.LoadoutType,
+		arg.Duration,
+	)
+	return err
+}
+
+const upsertStatsTrackerCharacterRival = `-- name: UpsertStatsTrackerCharacterRival :exec
+INSERT INTO
+  stats_tracker_character_rival (
+    session_id,
+    platform,
+    character_id,
+    rival_id,
+    kills,
+    deaths
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id, rival_id) DO
+UPDATE
+SET
+  kills = EXCLUDED.kills,
+  deaths = EXCLUDED.deaths
+`
+
+type UpsertStatsTrackerCharacterRivalParams struct {
+	SessionID   int64
+	Platform    string
+	CharacterID string
+	RivalID     string
+	Kills       int64
+	Deaths      int64
+}
+
+func (q *Queries) UpsertStatsTrackerCharacterRival(ctx context.Context, arg UpsertStatsTrackerCharacterRivalParams) error {
+	_, err := q.exec(ctx, q.upsertStatsTrackerCharacterRivalStmt, upsertStatsTrackerCharacterRival,
+		arg.SessionID,
+		arg.Platform,
+		arg.CharacterID,
+		arg.RivalID,
+		arg.Kills,
+		arg.Deaths,
+	)
+	return err
+}
+
+const upsertStatsTrackerCharacterVehicle = `-- name: UpsertStatsTrackerCharacterVehicle :exec
+INSERT INTO
+  stats_tracker_character_vehicle (
+    session_id,
+    platform,
+    character_id,
+    vehicle_id,
+    destroyed,
+    lost
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id, vehicle_id) DO
+UPDATE
+SET
+  destroyed = EXCLUDED.destroyed,
+  lost = EXCLUDED.lost
+`
+
+type UpsertStatsTrackerCharacterVehicleParams struct {
+	SessionID   int64
+	Platform    string
+	CharacterID string
+	VehicleID   string
+	Destroyed   int64
+	Lost        int64
+}
+
+func (q *Queries) UpsertStatsTrackerCharacterVehicle(ctx context.Context, arg UpsertStatsTrackerCharacterVehicleParams) error {
+	_, err := q.exec(ctx, q.upsertStatsTrackerCharacterVehicleStmt, upsertStatsTrackerCharacterVehicle,
+		arg.SessionID,
+		arg.Platform,
+		arg.CharacterID,
+		arg.VehicleID,
+		arg.Destroyed,
+		arg.Lost,
+	)
+	return err
+}
+
+const upsertStatsTrackerCharacterWeapon = `-- name: UpsertStatsTrackerCharacterWeapon :exec
+INSERT INTO
+  stats_tracker_character_weapon (
+    session_id,
+    platform,
+    character_id,
+    weapon_id,
+    kills,
+    head_shots_kills
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id, weapon_id) DO
+UPDATE
+SET
+  kills = EXCLUDED.kills,
+  head_shots_kills = EXCLUDED.head_shots_kills
+`
+
+type UpsertStatsTrackerCharacterWeaponParams struct {
+	SessionID      int64
+	Platform       string
+	CharacterID    string
+	WeaponID       string
+	Kills          int64
+	HeadShotsKills int64
+}
+
+func (q *Queries) UpsertStatsTrackerCharacterWeapon(ctx context.Context, arg UpsertStatsTrackerCharacterWeaponParams) error {
+	_, err := q.exec(ctx, q.upsertStatsTrackerCharacterWeaponStmt, upsertStatsTrackerCharacterWeapon,
+		arg.SessionID,
+		arg.Platform,
+		arg.CharacterID,
+		arg.WeaponID,
+		arg.Kills,
+		arg.HeadShotsKills,
+	)
+	return err
+}
+
+const upsertStatsTrackerCharacterZone = `-- name: UpsertStatsTrackerCharacterZone :exec
+INSERT INTO
+  stats_tracker_character_zone (
+    session_id,
+    platform,
+    character_id,
+    zone_id,
+    kills,
+    deaths,
+    duration
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?, ?) ON CONFLICT (session_id, platform, character_id, zone_id) DO
+UPDATE
+SET
+  kills = EXCLUDED.kills,
+  deaths = EXCLUDED.deaths,
+  duration = EXCLUDED.duration
+`
+
+type UpsertStatsTrackerCharacterZoneParams struct {
+	SessionID   int64
+	Platform    string
+	CharacterID string
+	ZoneID      string
+	Kills       int64
+	Deaths      int64
+	Duration    int64
+}
+
+func (q *Queries) UpsertStatsTrackerCharacterZone(ctx context.Context, arg UpsertStatsTrackerCharacterZoneParams) error {
+	_, err := q.exec(ctx, q.upsertStatsTrackerCharacterZoneStmt, upsertStatsTrackerCharacterZone,
+		arg.SessionID,
+		arg.Platform,
+		arg.CharacterID,
+		arg.ZoneID,
+		arg.Kills,
+		arg.Deaths,
+		arg.Duration,
+	)
+	return err
+}
@@ -0,0 +1,262 @@
+// Package expr parses arithmetic expressions over named variables.
+//
+// Supported syntax: numbers, variables, unary minus, `+`, `-`, `*`, `/`
+// and parentheses. Division is only allowed by constants, so evaluation
+// never divides by zero.
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidExpression = errors.New("invalid expression")
+
+type SyntaxError struct {
+	Msg string
+	// Byte offset in the source
+	Pos int
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at %d", e.Msg, e.Pos)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return ErrInvalidExpression
+}
+
+func syntaxError(pos int, format string, args ...any) error {
+	return &SyntaxError{Msg: fmt.Sprintf(format, args...), Pos: pos}
+}
+
+type Expr struct {
+	source string
+	eval   func(values []float64) float64
+}
+
+// Evaluates the expression, values are in the order of the parsed variables
+func (e Expr) Eval(values []float64) float64 {
+	if e.eval == nil {
+		return 0
+	}
+	return e.eval(values)
+}
+
+func (e Expr) String() string {
+	return e.source
+}
+
+type tokenKind int
+
+const (
+	numberToken tokenKind = iota
+	identToken
+	opToken
+	endToken
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value float64
+	pos   int
+}
+
+func tokenize(src string) ([]token, error) {
+	tokens := make([]token, 0, len(src)/2)
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case strings.IndexByte("+-*/()", c) >= 0:
+			tokens = append(tokens, token{kind: opToken, text: string(c), pos: i})
+			i++
+		case c >= '0' && c <= '9' || c == '.':
+			start := i
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.') {
+				i++
+			}
+			v, err := strconv.ParseFloat(src[start:i], 64)
+			if err != nil {
+				return nil, syntaxError(start, "invalid number %q", src[start:i])
+			}
+			tokens = append(tokens, token{kind: numberToken, text: src[start:i], value: v, pos: start})
+		case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_':
+			start := i
+			for i < len(src) && (src[i] >= 'a' && src[i] <= 'z' || src[i] >= 'A' && src[i] <= 'Z' || src[i] == '_' || src[i] >= '0' && src[i] <= '9') {
+				i++
+			}
+			tokens = append(tokens, token{kind: identToken, text: strings.ToLower(src[start:i]), pos: start})
+		default:
+			return nil, syntaxError(i, "unexpected character %q", c)
+		}
+	}
+	return append(tokens, token{kind: endToken, pos: len(src)}), nil
+}
+
+type node struct {
+	eval func(values []float64) float64
+	// Whether the node does not depend on variables
+	constant bool
+	value    float64
+}
+
+type parser struct {
+	tokens    []token
+	pos       int
+	variables map[string]int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != endToken {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOp(op string) bool {
+	t := p.peek()
+	return t.kind == opToken && t.text == op
+}
+
+func constant(v float64) node {
+	return node{
+		eval:     func([]float64) float64 { return v },
+		constant: true,
+		value:    v,
+	}
+}
+
+func binary(a, b node, op func(a, b float64) float64) node {
+	if a.constant && b.constant {
+		return constant(op(a.value, b.value))
+	}
+	return node{eval: func(values []float64) float64 {
+		return op(a.eval(values), b.eval(values))
+	}}
+}
+
+// expr = term (("+" | "-") term)*
+func (p *parser) expr() (node, error) {
+	left, err := p.term()
+	if err != nil {
+		return node{}, err
+	}
+	for p.isOp("+") || p.isOp("-") {
+		op := p.next().text
+		right, err := p.term()
+		if err != nil {
+			return node{}, err
+		}
+		if op == "+" {
+			left = binary(left, right, func(a, b float64) float64 { return a + b })
+		} else {
+			left = binary(left, right, func(a, b float64) float64 { return a - b })
+		}
+	}
+	return left, nil
+}
+
+// term = factor (("*" | "/") factor)*
+func (p *parser) term() (node, error) {
+	left, err := p.factor()
+	if err != nil {
+		return node{}, err
+	}
+	for p.isOp("*") || p.isOp("/") {
+		t := p.next()
+		right, err := p.factor()
+		if err != nil {
+			return node{}, err
+		}
+		if t.text == "*" {
+			left = binary(left, right, func(a, b float64) float64 { return a * b })
+			continue
+		}
+		if !right.constant {
+			return node{}, syntaxError(t.pos, "division by a variable")
+		}
+		if right.value == 0 {
+			return node{}, syntaxError(t.pos, "division by zero")
+		}
+		left = binary(left, right, func(a, b float64) float64 { return a / b })
+	}
+	return left, nil
+}
+
+// factor = number | variable | "-" factor | "(" expr ")"
+func (p *parser) factor() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case numberToken:
+		return constant(t.value), nil
+	case identToken:
+		i, ok := p.variables[t.text]
+		if !ok {
+			return node{}, syntaxError(t.pos, "unknown variable %q", t.text)
+		}
+		return node{eval: func(values []float64) float64 {
+			if i < len(values) {
+				return values[i]
+			}
+			return 0
+		}}, nil
+	case opToken:
+		switch t.text {
+		case "-":
+			f, err := p.factor()
+			if err != nil {
+				return node{}, err
+			}
+			return binary(constant(0), f, func(a, b float64) float64 { return a - b }), nil
+		case "(":
+			e, err := p.expr()
+			if err != nil {
+				return node{}, err
+			}
+			if !p.isOp(")") {
+				return node{}, syntaxError(p.peek().pos, "expected \")\"")
+			}
+			p.next()
+			return e, nil
+		}
+	case endToken:
+		return node{}, syntaxError(t.pos, "unexpected end of expression")
+	}
+	return node{}, syntaxError(t.pos, "unexpected %q", t.text)
+}
+
+// Parses the expression over the variables, names are case insensitive
+func Parse(src string, variables []string) (Expr, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return Expr{}, err
+	}
+	p := parser{
+		tokens:    tokens,
+		variables: make(map[string]int, len(variables)),
+	}
+	for i, v := range variables {
+		p.variables[strings.ToLower(v)] = i
+	}
+	n, err := p.expr()
+	if err != nil {
+		return Expr{}, err
+	}
+	if t := p.peek(); t.kind != endToken {
+		return Expr{}, syntaxError(t.pos, "unexpected %q", t.text)
+	}
+	return Expr{
+		source: strings.Join(strings.Fields(src), " "),
+		eval:   n.eval,
+	}, nil
+}
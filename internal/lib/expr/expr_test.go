@@ -0,0 +1,51 @@
+package expr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	variables := []string{"kills", "deaths", "team_kills"}
+	values := []float64{10, 4, 2}
+	tests := []struct {
+		name    string
+		src     string
+		want    float64
+		wantErr bool
+	}{
+		{name: "variable", src: "kills", want: 10},
+		{name: "weights", src: "kills - 0.5 * deaths - 3*team_kills", want: 2},
+		{name: "precedence", src: "1 + 2 * 3", want: 7},
+		{name: "parentheses", src: "(kills + deaths) * 2", want: 28},
+		{name: "unary minus", src: "-deaths + -(-team_kills)", want: -2},
+		{name: "constant division", src: "kills / (2 * 2)", want: 2.5},
+		{name: "case insensitive", src: "KILLS", want: 10},
+		{name: "empty", src: "", wantErr: true},
+		{name: "unknown variable", src: "kills + assists", wantErr: true},
+		{name: "division by variable", src: "kills / deaths", wantErr: true},
+		{name: "division by zero", src: "kills / (1 - 1)", wantErr: true},
+		{name: "unbalanced", src: "(kills + deaths", wantErr: true},
+		{name: "trailing operator", src: "kills +", wantErr: true},
+		{name: "trailing token", src: "kills deaths", wantErr: true},
+		{name: "invalid character", src: "kills % 2", wantErr: true},
+		{name: "invalid number", src: "1.2.3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := Parse(tt.src, variables)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidExpression) {
+					t.Errorf("Parse() error = %v, want %v", err, ErrInvalidExpression)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := e.Eval(values); got != tt.want {
+				t.Errorf("Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/x0k/ps2-spy/internal/characters_refresher"
 	"github.com/x0k/ps2-spy/internal/characters_tracker"
 	"github.com/x0k/ps2-spy/internal/discord"
 	discord_commands "github.com/x0k/ps2-spy/internal/discord/commands"
@@ -17,6 +18,7 @@ import (
 	"github.com/x0k/ps2-spy/internal/lib/logger/sl"
 	"github.com/x0k/ps2-spy/internal/lib/module"
 	"github.com/x0k/ps2-spy/internal/lib/pubsub"
+	"github.com/x0k/ps2-spy/internal/outfit_activity"
 	"github.com/x0k/ps2-spy/internal/ps2"
 	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
 	"github.com/x0k/ps2-spy/internal/stats_tracker"
@@ -34,6 +36,7 @@ func New(
 	messages *discord_messages.Messages,
 	commands *discord_commands.Commands,
 	charactersTrackerSubsManagers map[ps2_platforms.Platform]pubsub.SubscriptionsManager[characters_tracker.EventType],
+	charactersRefresherSubsManagers map[ps2_platforms.Platform]pubsub.SubscriptionsManager[characters_refresher.EventType],
 	trackingManagers map[ps2_platforms.Platform]*tracking.Manager,
 	storageSubs pubsub.SubscriptionsManager[storage.EventType],
 	trackingSubs pubsub.SubscriptionsManager[tracking.EventType],
@@ -44,8 +47,11 @@ func New(
 	facilityLoaders map[ps2_platforms.Platform]loader.Keyed[ps2.FacilityId, ps2.Facility],
 	onlineTrackableEntitiesCountLoader loader.Keyed[discord.ChannelId, int],
 	statsTrackerSubs pubsub.SubscriptionsManager[stats_tracker.EventType],
+	outfitActivitySubs pubsub.SubscriptionsManager[outfit_activity.EventType],
 	channelLoader discord_events.ChannelLoader,
 	trackingSettingsDiffViewLoader discord_event_handlers.TrackingSettingsDiffViewLoader,
+	leaderboardsLoader discord.TrackedMessagesLoader,
+	leaderboardSaver discord_event_handlers.LeaderboardSaver,
 ) (*module.Module, error) {
 	m := module.New(log.Logger, "discord")
 	session, err := discordgo.New("Bot " + token)
@@ -63,6 +69,16 @@ func New(
 		return nil
 	}
 
+	// Discord allows 5 edits per 5 seconds per channel,
+	// so a single edit per minute is far from the limit
+	leaderboardUpdater := discord.NewChannelMessageUpdater(
+		log.With(sl.Component("leaderboard_updater")),
+		session,
+		leaderboardsLoader,
+		time.Minute,
+	)
+	m.AppendVR("discord.leaderboard_updater", leaderboardUpdater.Start)
+
 	m.AppendR("discord.session", sessionStart(
 		log.With(sl.Component("session")),
 		session,
@@ -85,6 +101,8 @@ func New(
 		onlineTrackableEntitiesCountLoader,
 		handlersChannelTitleUpdater,
 		trackingSettingsDiffViewLoader,
+		leaderboardUpdater,
+		leaderboardSaver,
 	) {
 		eventsPubSub.AddHandler(handler)
 	}
@@ -98,7 +116,9 @@ func New(
 	channelTitleUpdates := storage.Subscribe[storage.ChannelTitleUpdatesSaved](m, storageSubs)
 	channelTrackerStarted := stats_tracker.Subscribe[stats_tracker.ChannelTrackerStarted](m, statsTrackerSubs)
 	channelTrackerStopped := stats_tracker.Subscribe[stats_tracker.ChannelTrackerStopped](m, statsTrackerSubs)
+	channelTrackerUpdated := stats_tracker.Subscribe[stats_tracker.ChannelTrackerUpdated](m, statsTrackerSubs)
 	trackingSettingsUpdated := tracking.Subscribe[tracking.TrackingSettingsUpdated](m, trackingSubs)
+	outfitActivityReported := outfit_activity.Subscribe[outfit_activity.OutfitActivityReported](m, outfitActivitySubs)
 	m.AppendVR("discord.events_subscription", func(ctx context.Context) {
 		for {
 			select {
@@ -112,8 +132,12 @@ func New(
 				eventsPublisher.PublishChannelTrackerStarted(ctx, e)
 			case e := <-channelTrackerStopped:
 				eventsPublisher.PublishChannelTrackerStopped(ctx, e)
+			case e := <-channelTrackerUpdated:
+				eventsPublisher.PublishChannelTrackerUpdated(ctx, e)
 			case e := <-trackingSettingsUpdated:
 				eventsPublisher.PublishChannelTrackingSettingsUpdated(ctx, e)
+			case e := <-outfitActivityReported:
+				eventsPublisher.PublishOutfitActivityReported(ctx, e)
 			}
 		}
 	})
@@ -152,6 +176,7 @@ func New(
 		playerLogin := characters_tracker.Subscribe[characters_tracker.PlayerLogin](m, charactersTrackerSubsManagers[platform])
 		playerFakeLogin := characters_tracker.Subscribe[characters_tracker.PlayerFakeLogin](m, charactersTrackerSubsManagers[platform])
 		playerLogout := characters_tracker.Subscribe[characters_tracker.PlayerLogout](m, charactersTrackerSubsManagers[platform])
+		characterUpdated := characters_refresher.Subscribe[characters_refresher.CharacterUpdated](m, charactersRefresherSubsManagers[platform])
 		facilityControl := worlds_tracker.Subscribe[worlds_tracker.FacilityControl](m, worldTrackerSubsMangers[platform])
 		facilityLoss := worlds_tracker.Subscribe[worlds_tracker.FacilityLoss](m, worldTrackerSubsMangers[platform])
 		outfitMembersUpdate := storage.Subscribe[storage.OutfitMembersUpdate](m, storageSubs)
@@ -168,6 +193,8 @@ func New(
 						platformEventsPublisher.PublishPlayerFakeLogin(ctx, e)
 					case e := <-playerLogout:
 						platformEventsPublisher.PublishPlayerLogout(ctx, e)
+					case e := <-characterUpdated:
+						platformEventsPublisher.PublishCharacterUpdated(ctx, e)
 					case e := <-facilityControl:
 						platformEventsPublisher.PublishFacilityControl(ctx, e)
 					case e := <-facilityLoss:
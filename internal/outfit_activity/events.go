@@ -0,0 +1,50 @@
+package outfit_activity
+
+import (
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/discord"
+	"github.com/x0k/ps2-spy/internal/lib/pubsub"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+type EventType string
+
+type Event = pubsub.Event[EventType]
+
+const (
+	OutfitActivityReportedType EventType = "outfit_activity_reported"
+)
+
+type MemberActivity struct {
+	Character ps2.Character
+	Playtime  time.Duration
+	Sessions  int
+}
+
+type DailyPeak struct {
+	// Start of the day in the channel timezone
+	Day    time.Time
+	Online int
+}
+
+type OutfitReport struct {
+	Outfit ps2.Outfit
+	// Sorted by playtime
+	Active []MemberActivity
+	// Sorted by name
+	Inactive []ps2.Character
+	Days     []DailyPeak
+}
+
+type OutfitActivityReported struct {
+	ChannelId discord.ChannelId
+	From      time.Time
+	To        time.Time
+	Platforms map[ps2_platforms.Platform][]OutfitReport
+}
+
+func (e OutfitActivityReported) Type() EventType {
+	return OutfitActivityReportedType
+}
@@ -0,0 +1,134 @@
+package outfit_activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/lib/logger"
+	"github.com/x0k/ps2-spy/internal/lib/logger/sl"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_factions "github.com/x0k/ps2-spy/internal/ps2/factions"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/shared"
+)
+
+var ErrOutfitMembersNotSynchronized = errors.New("outfit members are not synchronized")
+
+type LastLoginsLoader = func(
+	context.Context, ps2_platforms.Platform, []ps2.CharacterId,
+) (map[ps2.CharacterId]time.Time, error)
+
+type InactiveMembersRepo interface {
+	MembersRepo
+	OutfitSynchronizedAt(context.Context, ps2_platforms.Platform, ps2.OutfitId) (time.Time, error)
+}
+
+type InactiveMember struct {
+	Character ps2.Character
+	// Zero value if the character was never seen
+	LastSeen time.Time
+}
+
+// Combines stored sessions of the synchronized outfit members
+// with the last login time from the Census
+type InactiveMembersLoader struct {
+	log               *logger.Logger
+	repo              InactiveMembersRepo
+	charactersLoaders map[ps2_platforms.Platform]CharactersLoader
+	lastLoginsLoader  LastLoginsLoader
+}
+
+func NewInactiveMembersLoader(
+	log *logger.Logger,
+	repo InactiveMembersRepo,
+	charactersLoaders map[ps2_platforms.Platform]CharactersLoader,
+	lastLoginsLoader LastLoginsLoader,
+) *InactiveMembersLoader {
+	return &InactiveMembersLoader{
+		log:               log,
+		repo:              repo,
+		charactersLoaders: charactersLoaders,
+		lastLoginsLoader:  lastLoginsLoader,
+	}
+}
+
+// Returns members that were not seen for `inactiveFor`,
+// the least recently seen first
+func (l *InactiveMembersLoader) Load(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	outfitId ps2.OutfitId,
+	inactiveFor time.Duration,
+) ([]InactiveMember, error) {
+	if _, err := l.repo.OutfitSynchronizedAt(ctx, platform, outfitId); errors.Is(err, shared.ErrNotFound) {
+		return nil, ErrOutfitMembersNotSynchronized
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load synchronization time: %w", err)
+	}
+	memberIds, err := l.repo.OutfitMembers(ctx, platform, outfitId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load members: %w", err)
+	}
+	now := time.Now()
+	// All stored sessions
+	sessions, err := l.repo.OutfitMembersSessions(ctx, platform, outfitId, time.Time{}, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sessions: %w", err)
+	}
+	lastSeen := make(map[ps2.CharacterId]time.Time, len(memberIds))
+	see := func(characterId ps2.CharacterId, t time.Time) {
+		if t.After(lastSeen[characterId]) {
+			lastSeen[characterId] = t
+		}
+	}
+	for _, s := range sessions {
+		if s.IsOngoing() {
+			see(s.CharacterId, now)
+		} else {
+			see(s.CharacterId, s.EndedAt)
+		}
+	}
+	lastLogins, err := l.lastLoginsLoader(ctx, platform, memberIds)
+	if err != nil {
+		l.log.Warn(ctx, "failed to load last logins", slog.String("outfit_id", string(outfitId)), sl.Err(err))
+	}
+	for characterId, t := range lastLogins {
+		see(characterId, t)
+	}
+	threshold := now.Add(-inactiveFor)
+	inactiveIds := make([]ps2.CharacterId, 0, len(memberIds))
+	for _, id := range memberIds {
+		if lastSeen[id].Before(threshold) {
+			inactiveIds = append(inactiveIds, id)
+		}
+	}
+	characters, err := l.charactersLoaders[platform](ctx, inactiveIds)
+	if err != nil {
+		l.log.Warn(ctx, "failed to load characters", slog.String("outfit_id", string(outfitId)), sl.Err(err))
+	}
+	members := make([]InactiveMember, 0, len(inactiveIds))
+	for _, id := range inactiveIds {
+		char, ok := characters[id]
+		if !ok {
+			char = ps2.Character{
+				Id:        id,
+				FactionId: ps2_factions.None,
+				Name:      string(id),
+				OutfitId:  outfitId,
+				Platform:  platform,
+			}
+		}
+		members = append(members, InactiveMember{
+			Character: char,
+			LastSeen:  lastSeen[id],
+		})
+	}
+	slices.SortFunc(members, func(a, b InactiveMember) int {
+		return a.LastSeen.Compare(b.LastSeen)
+	})
+	return members, nil
+}
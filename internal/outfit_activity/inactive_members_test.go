@@ -0,0 +1,119 @@
+package outfit_activity
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/characters_history"
+	"github.com/x0k/ps2-spy/internal/lib/logger"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/shared"
+)
+
+type inactiveMembersRepoMock struct {
+	synchronizedErr error
+	members         []ps2.CharacterId
+	sessions        []characters_history.Session
+}
+
+func (r *inactiveMembersRepoMock) OutfitMembers(context.Context, ps2_platforms.Platform, ps2.OutfitId) ([]ps2.CharacterId, error) {
+	return r.members, nil
+}
+
+func (r *inactiveMembersRepoMock) OutfitMembersSessions(
+	context.Context, ps2_platforms.Platform, ps2.OutfitId, time.Time, time.Time,
+) ([]characters_history.Session, error) {
+	return r.sessions, nil
+}
+
+func (r *inactiveMembersRepoMock) OutfitSynchronizedAt(context.Context, ps2_platforms.Platform, ps2.OutfitId) (time.Time, error) {
+	return time.Time{}, r.synchronizedErr
+}
+
+func newTestInactiveMembersLoader(
+	repo InactiveMembersRepo,
+	lastLogins map[ps2.CharacterId]time.Time,
+) *InactiveMembersLoader {
+	return NewInactiveMembersLoader(
+		logger.New(slog.New(slog.DiscardHandler)),
+		repo,
+		map[ps2_platforms.Platform]CharactersLoader{
+			ps2_platforms.PC: func(_ context.Context, ids []ps2.CharacterId) (map[ps2.CharacterId]ps2.Character, error) {
+				chars := make(map[ps2.CharacterId]ps2.Character, len(ids))
+				for _, id := range ids {
+					if id != "unknown" {
+						chars[id] = ps2.Character{Id: id, Name: "Name" + string(id)}
+					}
+				}
+				return chars, nil
+			},
+		},
+		func(context.Context, ps2_platforms.Platform, []ps2.CharacterId) (map[ps2.CharacterId]time.Time, error) {
+			return lastLogins, nil
+		},
+	)
+}
+
+func TestInactiveMembersLoaderNotSynchronized(t *testing.T) {
+	loader := newTestInactiveMembersLoader(&inactiveMembersRepoMock{
+		synchronizedErr: shared.ErrNotFound,
+		members:         []ps2.CharacterId{"1"},
+	}, nil)
+	_, err := loader.Load(context.Background(), ps2_platforms.PC, "outfit", time.Hour)
+	if !errors.Is(err, ErrOutfitMembersNotSynchronized) {
+		t.Errorf("expected %v, got %v", ErrOutfitMembersNotSynchronized, err)
+	}
+}
+
+func TestInactiveMembersLoaderLoad(t *testing.T) {
+	now := time.Now()
+	daysAgo := func(days int) time.Time {
+		return now.AddDate(0, 0, -days)
+	}
+	repo := &inactiveMembersRepoMock{
+		members: []ps2.CharacterId{"recent", "old", "ongoing", "census", "never", "unknown"},
+		sessions: []characters_history.Session{
+			{CharacterId: "recent", StartedAt: daysAgo(3), EndedAt: daysAgo(2)},
+			{CharacterId: "old", StartedAt: daysAgo(21), EndedAt: daysAgo(20)},
+			{CharacterId: "old", StartedAt: daysAgo(11), EndedAt: daysAgo(10)},
+			{CharacterId: "ongoing", StartedAt: daysAgo(30)},
+			{CharacterId: "census", StartedAt: daysAgo(40), EndedAt: daysAgo(39)},
+			{CharacterId: "unknown", StartedAt: daysAgo(9), EndedAt: daysAgo(8)},
+		},
+	}
+	lastLogins := map[ps2.CharacterId]time.Time{
+		"census": daysAgo(1),
+		"old":    daysAgo(15),
+	}
+	loader := newTestInactiveMembersLoader(repo, lastLogins)
+	members, err := loader.Load(context.Background(), ps2_platforms.PC, "outfit", 7*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make([]ps2.CharacterId, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.Character.Id)
+	}
+	expected := []ps2.CharacterId{"never", "old", "unknown"}
+	if !slices.Equal(ids, expected) {
+		t.Fatalf("expected %v, got %v", expected, ids)
+	}
+	if !members[0].LastSeen.IsZero() {
+		t.Errorf("expected zero last seen time, got %v", members[0].LastSeen)
+	}
+	if !members[1].LastSeen.Equal(daysAgo(10)) {
+		t.Errorf("expected last seen %v, got %v", daysAgo(10), members[1].LastSeen)
+	}
+	if members[1].Character.Name != "Nameold" {
+		t.Errorf("expected loaded name %q, got %q", "Nameold", members[1].Character.Name)
+	}
+	if members[2].Character.Name != "unknown" {
+		t.Errorf("expected name fallback %q, got %q", "unknown", members[2].Character.Name)
+	}
+}
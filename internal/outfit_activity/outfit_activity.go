@@ -0,0 +1,201 @@
+package outfit_activity
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/characters_history"
+	"github.com/x0k/ps2-spy/internal/discord"
+	"github.com/x0k/ps2-spy/internal/lib/loader"
+	"github.com/x0k/ps2-spy/internal/lib/logger"
+	"github.com/x0k/ps2-spy/internal/lib/logger/sl"
+	"github.com/x0k/ps2-spy/internal/lib/pubsub"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_factions "github.com/x0k/ps2-spy/internal/ps2/factions"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/tracking"
+)
+
+type CharactersLoader = loader.Multi[ps2.CharacterId, ps2.Character]
+type OutfitsLoader = loader.Multi[ps2.OutfitId, ps2.Outfit]
+
+type TrackingSettingsLoader = func(
+	context.Context, discord.ChannelId, ps2_platforms.Platform,
+) (tracking.Settings, error)
+
+type ReportChannel struct {
+	discord.Channel
+	// Zero value if the report was never posted
+	ReportedAt time.Time
+}
+
+type MembersRepo interface {
+	OutfitMembers(context.Context, ps2_platforms.Platform, ps2.OutfitId) ([]ps2.CharacterId, error)
+	// Returns sessions of the current outfit members that overlap [from, to)
+	OutfitMembersSessions(
+		ctx context.Context,
+		platform ps2_platforms.Platform,
+		outfitId ps2.OutfitId,
+		from time.Time,
+		to time.Time,
+	) ([]characters_history.Session, error)
+}
+
+type Repo interface {
+	MembersRepo
+	OutfitActivityReportChannels(context.Context) ([]ReportChannel, error)
+	SaveChannelOutfitActivityReportedAt(context.Context, discord.ChannelId, time.Time) error
+}
+
+// Posts a report of the previous week on Mondays
+// in the default timezone of the channel
+type Reporter struct {
+	log                    *logger.Logger
+	publisher              pubsub.Publisher[Event]
+	repo                   Repo
+	trackingSettingsLoader TrackingSettingsLoader
+	charactersLoaders      map[ps2_platforms.Platform]CharactersLoader
+	outfitsLoaders         map[ps2_platforms.Platform]OutfitsLoader
+}
+
+func New(
+	log *logger.Logger,
+	publisher pubsub.Publisher[Event],
+	repo Repo,
+	trackingSettingsLoader TrackingSettingsLoader,
+	charactersLoaders map[ps2_platforms.Platform]CharactersLoader,
+	outfitsLoaders map[ps2_platforms.Platform]OutfitsLoader,
+) *Reporter {
+	return &Reporter{
+		log:                    log,
+		publisher:              publisher,
+		repo:                   repo,
+		trackingSettingsLoader: trackingSettingsLoader,
+		charactersLoaders:      charactersLoaders,
+		outfitsLoaders:         outfitsLoaders,
+	}
+}
+
+func (r *Reporter) Start(ctx context.Context) {
+	r.report(ctx, time.Now())
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.report(ctx, now)
+		}
+	}
+}
+
+func (r *Reporter) report(ctx context.Context, now time.Time) {
+	channels, err := r.repo.OutfitActivityReportChannels(ctx)
+	if err != nil {
+		r.log.Error(ctx, "failed to load channels", sl.Err(err))
+		return
+	}
+	for _, channel := range channels {
+		to := weekStart(now, channel.DefaultTimezone)
+		if !channel.ReportedAt.Before(to) {
+			continue
+		}
+		if err := r.reportChannel(ctx, channel.Id, to.AddDate(0, 0, -7), to); err != nil {
+			r.log.Error(ctx, "failed to report", slog.String("channel_id", string(channel.Id)), sl.Err(err))
+			continue
+		}
+		if err := r.repo.SaveChannelOutfitActivityReportedAt(ctx, channel.Id, now); err != nil {
+			r.log.Error(ctx, "failed to save report time", slog.String("channel_id", string(channel.Id)), sl.Err(err))
+		}
+	}
+}
+
+func (r *Reporter) reportChannel(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	from time.Time,
+	to time.Time,
+) error {
+	platforms := make(map[ps2_platforms.Platform][]OutfitReport, len(ps2_platforms.Platforms))
+	for _, platform := range ps2_platforms.Platforms {
+		settings, err := r.trackingSettingsLoader(ctx, channelId, platform)
+		if err != nil {
+			return fmt.Errorf("failed to load %q tracking settings: %w", platform, err)
+		}
+		if len(settings.Outfits) == 0 {
+			continue
+		}
+		outfits, err := r.outfitsLoaders[platform](ctx, settings.Outfits)
+		if err != nil {
+			r.log.Warn(ctx, "failed to load outfits", slog.String("platform", string(platform)), sl.Err(err))
+		}
+		reports := make([]OutfitReport, 0, len(settings.Outfits))
+		for _, outfitId := range settings.Outfits {
+			outfit, ok := outfits[outfitId]
+			if !ok {
+				outfit = ps2.Outfit{
+					Id:       outfitId,
+					Name:     string(outfitId),
+					Tag:      string(outfitId),
+					Platform: platform,
+				}
+			}
+			report, err := r.outfitReport(ctx, platform, outfit, from, to)
+			if err != nil {
+				return fmt.Errorf("failed to build %q outfit report: %w", outfitId, err)
+			}
+			reports = append(reports, report)
+		}
+		platforms[platform] = reports
+	}
+	if len(platforms) == 0 {
+		return nil
+	}
+	r.publisher.Publish(OutfitActivityReported{
+		ChannelId: channelId,
+		From:      from,
+		To:        to,
+		Platforms: platforms,
+	})
+	return nil
+}
+
+func (r *Reporter) outfitReport(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	outfit ps2.Outfit,
+	from time.Time,
+	to time.Time,
+) (OutfitReport, error) {
+	memberIds, err := r.repo.OutfitMembers(ctx, platform, outfit.Id)
+	if err != nil {
+		return OutfitReport{}, fmt.Errorf("failed to load members: %w", err)
+	}
+	sessions, err := r.repo.OutfitMembersSessions(ctx, platform, outfit.Id, from, to)
+	if err != nil {
+		return OutfitReport{}, fmt.Errorf("failed to load sessions: %w", err)
+	}
+	characters, err := r.charactersLoaders[platform](ctx, memberIds)
+	if len(characters) == 0 && len(memberIds) > 0 && err != nil {
+		return OutfitReport{}, fmt.Errorf("failed to get any character: %w", err)
+	}
+	members := make([]ps2.Character, 0, len(memberIds))
+	for _, id := range memberIds {
+		char, ok := characters[id]
+		if !ok {
+			char = ps2.Character{
+				Id:        id,
+				FactionId: ps2_factions.None,
+				Name:      string(id),
+				OutfitId:  outfit.Id,
+				OutfitTag: outfit.Tag,
+				Platform:  platform,
+			}
+		}
+		members = append(members, char)
+	}
+	return newOutfitReport(outfit, members, sessions, from, to), nil
+}
@@ -0,0 +1,103 @@
+package outfit_activity
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/characters_history"
+	"github.com/x0k/ps2-spy/internal/discord"
+	"github.com/x0k/ps2-spy/internal/lib/logger"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/tracking"
+)
+
+type publisherFunc func(Event)
+
+func (f publisherFunc) Publish(event Event) {
+	f(event)
+}
+
+type repoMock struct {
+	channel ReportChannel
+}
+
+func (r *repoMock) OutfitMembers(context.Context, ps2_platforms.Platform, ps2.OutfitId) ([]ps2.CharacterId, error) {
+	return []ps2.CharacterId{"1"}, nil
+}
+
+func (r *repoMock) OutfitMembersSessions(
+	context.Context, ps2_platforms.Platform, ps2.OutfitId, time.Time, time.Time,
+) ([]characters_history.Session, error) {
+	return nil, nil
+}
+
+func (r *repoMock) OutfitActivityReportChannels(context.Context) ([]ReportChannel, error) {
+	return []ReportChannel{r.channel}, nil
+}
+
+func (r *repoMock) SaveChannelOutfitActivityReportedAt(_ context.Context, _ discord.ChannelId, t time.Time) error {
+	r.channel.ReportedAt = t
+	return nil
+}
+
+func TestReporterReport(t *testing.T) {
+	repo := &repoMock{channel: ReportChannel{Channel: discord.NewDefaultChannel("channel")}}
+	var reports []OutfitActivityReported
+	reporter := New(
+		logger.New(slog.New(slog.DiscardHandler)),
+		publisherFunc(func(e Event) {
+			reports = append(reports, e.(OutfitActivityReported))
+		}),
+		repo,
+		func(_ context.Context, _ discord.ChannelId, platform ps2_platforms.Platform) (tracking.Settings, error) {
+			if platform != ps2_platforms.PC {
+				return tracking.Settings{}, nil
+			}
+			return tracking.Settings{Outfits: []ps2.OutfitId{"outfit"}}, nil
+		},
+		map[ps2_platforms.Platform]CharactersLoader{
+			ps2_platforms.PC: func(context.Context, []ps2.CharacterId) (map[ps2.CharacterId]ps2.Character, error) {
+				return nil, nil
+			},
+		},
+		map[ps2_platforms.Platform]OutfitsLoader{
+			ps2_platforms.PC: func(context.Context, []ps2.OutfitId) (map[ps2.OutfitId]ps2.Outfit, error) {
+				return nil, nil
+			},
+		},
+	)
+	monday := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	steps := []struct {
+		now   time.Time
+		count int
+	}{
+		{now: monday.Add(10 * time.Hour), count: 1},
+		{now: monday.Add(11 * time.Hour), count: 1},
+		{now: monday.AddDate(0, 0, 6), count: 1},
+		{now: monday.AddDate(0, 0, 7), count: 2},
+	}
+	for i, s := range steps {
+		reporter.report(context.Background(), s.now)
+		if len(reports) != s.count {
+			t.Fatalf("step %d: expected %d reports, got %d", i, s.count, len(reports))
+		}
+	}
+
+	first := reports[0]
+	if !first.From.Equal(monday.AddDate(0, 0, -7)) || !first.To.Equal(monday) {
+		t.Errorf("expected report of [%v, %v), got [%v, %v)", monday.AddDate(0, 0, -7), monday, first.From, first.To)
+	}
+	pc := first.Platforms[ps2_platforms.PC]
+	if len(first.Platforms) != 1 || len(pc) != 1 {
+		t.Fatalf("expected a single PC outfit report, got %v", first.Platforms)
+	}
+	if pc[0].Outfit.Tag != "outfit" {
+		t.Errorf("expected outfit tag fallback %q, got %q", "outfit", pc[0].Outfit.Tag)
+	}
+	if len(pc[0].Inactive) != 1 || pc[0].Inactive[0].Name != "1" {
+		t.Errorf("expected an inactive member named after its id, got %v", pc[0].Inactive)
+	}
+}
@@ -0,0 +1,99 @@
+package outfit_activity
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/characters_history"
+	"github.com/x0k/ps2-spy/internal/ps2"
+)
+
+// Returns the start of the week (Monday midnight) containing `t`
+func weekStart(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	days := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-days, 0, 0, 0, 0, loc)
+}
+
+func newOutfitReport(
+	outfit ps2.Outfit,
+	members []ps2.Character,
+	sessions []characters_history.Session,
+	from time.Time,
+	to time.Time,
+) OutfitReport {
+	activity := make(map[ps2.CharacterId]MemberActivity, len(members))
+	for _, s := range sessions {
+		playtime := s.Playtime(from, to)
+		if playtime == 0 {
+			continue
+		}
+		a := activity[s.CharacterId]
+		a.Playtime += playtime
+		a.Sessions++
+		activity[s.CharacterId] = a
+	}
+	report := OutfitReport{
+		Outfit: outfit,
+	}
+	for _, member := range members {
+		a, ok := activity[member.Id]
+		if !ok {
+			report.Inactive = append(report.Inactive, member)
+			continue
+		}
+		a.Character = member
+		report.Active = append(report.Active, a)
+	}
+	slices.SortFunc(report.Active, func(a, b MemberActivity) int {
+		return cmp.Compare(b.Playtime, a.Playtime)
+	})
+	slices.SortFunc(report.Inactive, func(a, b ps2.Character) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		report.Days = append(report.Days, DailyPeak{
+			Day:    day,
+			Online: peakOnline(sessions, day, day.AddDate(0, 0, 1)),
+		})
+	}
+	return report
+}
+
+// Max number of sessions that overlap inside [from, to)
+func peakOnline(sessions []characters_history.Session, from, to time.Time) int {
+	type edge struct {
+		t     time.Time
+		delta int
+	}
+	edges := make([]edge, 0, len(sessions)*2)
+	for _, s := range sessions {
+		if s.Playtime(from, to) == 0 {
+			continue
+		}
+		start := s.StartedAt
+		if start.Before(from) {
+			start = from
+		}
+		end := to
+		if !s.IsOngoing() && s.EndedAt.Before(to) {
+			end = s.EndedAt
+		}
+		edges = append(edges, edge{start, 1}, edge{end, -1})
+	}
+	// Logouts go before logins at the same moment
+	slices.SortFunc(edges, func(a, b edge) int {
+		if c := a.t.Compare(b.t); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.delta, b.delta)
+	})
+	online, peak := 0, 0
+	for _, e := range edges {
+		online += e.delta
+		peak = max(peak, online)
+	}
+	return peak
+}
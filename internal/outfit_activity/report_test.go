@@ -0,0 +1,155 @@
+package outfit_activity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/characters_history"
+	"github.com/x0k/ps2-spy/internal/ps2"
+)
+
+func TestWeekStart(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	cases := []struct {
+		name     string
+		t        time.Time
+		loc      *time.Location
+		expected time.Time
+	}{
+		{
+			name:     "monday",
+			t:        time.Date(2024, 5, 6, 15, 30, 0, 0, time.UTC),
+			loc:      time.UTC,
+			expected: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "sunday",
+			t:        time.Date(2024, 5, 12, 23, 59, 0, 0, time.UTC),
+			loc:      time.UTC,
+			expected: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "sunday in utc is monday in the channel timezone",
+			t:        time.Date(2024, 5, 12, 22, 0, 0, 0, time.UTC),
+			loc:      moscow,
+			expected: time.Date(2024, 5, 13, 0, 0, 0, 0, moscow),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := weekStart(c.t, c.loc); !got.Equal(c.expected) {
+				t.Errorf("weekStart() = %v, want %v", got, c.expected)
+			}
+		})
+	}
+}
+
+func TestPeakOnline(t *testing.T) {
+	from := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	at := func(hour int) time.Time {
+		return from.Add(time.Duration(hour) * time.Hour)
+	}
+	session := func(start, end time.Time) characters_history.Session {
+		return characters_history.Session{StartedAt: start, EndedAt: end}
+	}
+	cases := []struct {
+		name     string
+		sessions []characters_history.Session
+		expected int
+	}{
+		{
+			name:     "no sessions",
+			expected: 0,
+		},
+		{
+			name: "overlapping sessions",
+			sessions: []characters_history.Session{
+				session(at(1), at(5)),
+				session(at(2), at(3)),
+				session(at(4), at(6)),
+			},
+			expected: 2,
+		},
+		{
+			name: "logout and login at the same moment",
+			sessions: []characters_history.Session{
+				session(at(1), at(2)),
+				session(at(2), at(3)),
+			},
+			expected: 1,
+		},
+		{
+			name: "sessions outside of the range",
+			sessions: []characters_history.Session{
+				session(at(-5), at(-1)),
+				session(at(25), at(26)),
+				session(at(-1), time.Time{}),
+			},
+			expected: 1,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := peakOnline(c.sessions, from, to); got != c.expected {
+				t.Errorf("peakOnline() = %d, want %d", got, c.expected)
+			}
+		})
+	}
+}
+
+func TestNewOutfitReport(t *testing.T) {
+	from := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	members := []ps2.Character{
+		{Id: "1", Name: "zulu"},
+		{Id: "2", Name: "Bravo"},
+		{Id: "3", Name: "alpha"},
+		{Id: "4", Name: "Charlie"},
+	}
+	sessions := []characters_history.Session{
+		{CharacterId: "1", StartedAt: from.Add(time.Hour), EndedAt: from.Add(2 * time.Hour)},
+		{CharacterId: "1", StartedAt: from.Add(25 * time.Hour), EndedAt: from.Add(26 * time.Hour)},
+		{CharacterId: "4", StartedAt: from.Add(90 * time.Minute), EndedAt: from.Add(5 * time.Hour)},
+		// Ended before the report period
+		{CharacterId: "2", StartedAt: from.Add(-2 * time.Hour), EndedAt: from.Add(-time.Hour)},
+	}
+	report := newOutfitReport(ps2.Outfit{Id: "1", Tag: "TAG"}, members, sessions, from, to)
+
+	expectedActive := []MemberActivity{
+		{Character: members[3], Playtime: 3*time.Hour + 30*time.Minute, Sessions: 1},
+		{Character: members[0], Playtime: 2 * time.Hour, Sessions: 2},
+	}
+	if len(report.Active) != len(expectedActive) {
+		t.Fatalf("expected %d active members, got %d", len(expectedActive), len(report.Active))
+	}
+	for i, a := range expectedActive {
+		if report.Active[i] != a {
+			t.Errorf("Active[%d] = %+v, want %+v", i, report.Active[i], a)
+		}
+	}
+
+	expectedInactive := []ps2.CharacterId{"3", "2"}
+	if len(report.Inactive) != len(expectedInactive) {
+		t.Fatalf("expected %d inactive members, got %d", len(expectedInactive), len(report.Inactive))
+	}
+	for i, id := range expectedInactive {
+		if report.Inactive[i].Id != id {
+			t.Errorf("Inactive[%d] = %q, want %q", i, report.Inactive[i].Id, id)
+		}
+	}
+
+	expectedDays := []int{2, 1, 0, 0, 0, 0, 0}
+	if len(report.Days) != len(expectedDays) {
+		t.Fatalf("expected %d days, got %d", len(expectedDays), len(report.Days))
+	}
+	for i, online := range expectedDays {
+		day := report.Days[i]
+		if !day.Day.Equal(from.AddDate(0, 0, i)) {
+			t.Errorf("Days[%d].Day = %v, want %v", i, day.Day, from.AddDate(0, 0, i))
+		}
+		if day.Online != online {
+			t.Errorf("Days[%d].Online = %d, want %d", i, day.Online, online)
+		}
+	}
+}
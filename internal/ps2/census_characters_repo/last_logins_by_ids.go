@@ -0,0 +1,60 @@
+package census_characters_repo
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	census2_adapters "github.com/x0k/ps2-spy/internal/adapters/census2"
+	"github.com/x0k/ps2-spy/internal/lib/census2"
+	ps2_collections "github.com/x0k/ps2-spy/internal/lib/census2/collections/ps2"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+// Keeps the query URL short for large outfits
+const lastLoginsBatchSize = 100
+
+func (l *Repository) lastLoginsUrl(ns string, charIds []census2.Str) string {
+	l.lastLoginsMu.Lock()
+	defer l.lastLoginsMu.Unlock()
+	l.lastLoginsQuery.SetNamespace(ns)
+	l.lastLoginsOperand.Set(census2.NewList(charIds, ","))
+	l.lastLoginsQuery.SetLimit(len(charIds))
+	return l.client.ToURL(l.lastLoginsQuery)
+}
+
+func (l *Repository) LastLoginsByIds(
+	ctx context.Context, platform ps2_platforms.Platform, characterIds []ps2.CharacterId,
+) (map[ps2.CharacterId]time.Time, error) {
+	if len(characterIds) == 0 {
+		return nil, nil
+	}
+	ns := ps2_platforms.PlatformNamespace(platform)
+	logins := make(map[ps2.CharacterId]time.Time, len(characterIds))
+	for batch := range slices.Chunk(characterIds, lastLoginsBatchSize) {
+		strCharIds := make([]census2.Str, len(batch))
+		for i, charId := range batch {
+			strCharIds[i] = census2.Str(string(charId))
+		}
+		chars, err := census2_adapters.RetryableExecutePreparedAndDecode[ps2_collections.CharacterItem](
+			ctx,
+			l.log,
+			l.client,
+			ps2_collections.Character,
+			l.lastLoginsUrl(ns, strCharIds),
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, char := range chars {
+			timestamp, err := strconv.ParseInt(char.Times.LastLogin, 10, 64)
+			if err != nil {
+				continue
+			}
+			logins[ps2.CharacterId(char.CharacterId)] = time.Unix(timestamp, 0)
+		}
+	}
+	return logins, nil
+}
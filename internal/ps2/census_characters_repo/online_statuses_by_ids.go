@@ -0,0 +1,49 @@
+package census_characters_repo
+
+import (
+	"context"
+
+	census2_adapters "github.com/x0k/ps2-spy/internal/adapters/census2"
+	"github.com/x0k/ps2-spy/internal/lib/census2"
+	ps2_collections "github.com/x0k/ps2-spy/internal/lib/census2/collections/ps2"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+func (l *Repository) onlineStatusesUrl(ns string, charIds []census2.Str) string {
+	l.onlineStatusesMu.Lock()
+	defer l.onlineStatusesMu.Unlock()
+	l.onlineStatusesQuery.SetNamespace(ns)
+	// The collection does not support `c:limit`
+	l.onlineStatusesOperand.Set(census2.NewList(charIds, ","))
+	return l.client.ToURL(l.onlineStatusesQuery)
+}
+
+// Loads statuses with a single request, characters unknown
+// to the Census are omitted
+func (l *Repository) OnlineStatusesByIds(
+	ctx context.Context, platform ps2_platforms.Platform, characterIds []ps2.CharacterId,
+) (map[ps2.CharacterId]bool, error) {
+	if len(characterIds) == 0 {
+		return nil, nil
+	}
+	strCharIds := make([]census2.Str, len(characterIds))
+	for i, charId := range characterIds {
+		strCharIds[i] = census2.Str(string(charId))
+	}
+	statuses, err := census2_adapters.RetryableExecutePreparedAndDecode[ps2_collections.CharactersOnlineStatusItem](
+		ctx,
+		l.log,
+		l.client,
+		ps2_collections.CharactersOnlineStatus,
+		l.onlineStatusesUrl(ps2_platforms.PlatformNamespace(platform), strCharIds),
+	)
+	if err != nil {
+		return nil, err
+	}
+	online := make(map[ps2.CharacterId]bool, len(statuses))
+	for _, status := range statuses {
+		online[ps2.CharacterId(status.CharacterId)] = status.OnlineStatus != "" && status.OnlineStatus != "0"
+	}
+	return online, nil
+}
@@ -19,6 +19,14 @@ type Repository struct {
 	characterNamesMu      sync.Mutex
 	characterNamesQuery   *census2.Query
 	characterNamesOperand *census2.Ptr[census2.List[census2.Str]]
+
+	lastLoginsMu      sync.Mutex
+	lastLoginsQuery   *census2.Query
+	lastLoginsOperand *census2.Ptr[census2.List[census2.Str]]
+
+	onlineStatusesMu      sync.Mutex
+	onlineStatusesQuery   *census2.Query
+	onlineStatusesOperand *census2.Ptr[census2.List[census2.Str]]
 }
 
 func New(
@@ -27,6 +35,8 @@ func New(
 ) *Repository {
 	characterIdsOperand := census2.NewPtr(census2.StrList())
 	characterNamesOperand := census2.NewPtr(census2.StrList())
+	lastLoginsOperand := census2.NewPtr(census2.StrList())
+	onlineStatusesOperand := census2.NewPtr(census2.StrList())
 	return &Repository{
 		log:    log,
 		client: client,
@@ -38,5 +48,13 @@ func New(
 		characterNamesOperand: &characterNamesOperand,
 		characterNamesQuery: census2.NewQuery(census2.GetQuery, census2.Ps2_v2_NS, ps2_collections.Character).
 			Where(census2.Cond("character_id").Equals(&characterNamesOperand)).Show("character_id", "name.first"),
+
+		lastLoginsOperand: &lastLoginsOperand,
+		lastLoginsQuery: census2.NewQuery(census2.GetQuery, census2.Ps2_v2_NS, ps2_collections.Character).
+			Where(census2.Cond("character_id").Equals(&lastLoginsOperand)).Show("character_id", "times.last_login"),
+
+		onlineStatusesOperand: &onlineStatusesOperand,
+		onlineStatusesQuery: census2.NewQuery(census2.GetQuery, census2.Ps2_v2_NS, ps2_collections.CharactersOnlineStatus).
+			Where(census2.Cond("character_id").Equals(&onlineStatusesOperand)),
 	}
 }
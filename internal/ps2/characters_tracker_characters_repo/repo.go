@@ -24,6 +24,6 @@ func (r *Repository) Online(
 	_ context.Context,
 	platform ps2_platforms.Platform,
 	characterIds []ps2.CharacterId,
-) (map[ps2.CharacterId]ps2.Character, error) {
+) (map[ps2.CharacterId]ps2.OnlineCharacter, error) {
 	return r.trackers[platform].CharactersOnline(characterIds), nil
 }
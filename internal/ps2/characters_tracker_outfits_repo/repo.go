@@ -24,6 +24,6 @@ func (r *Repository) MembersOnline(
 	_ context.Context,
 	platform ps2_platforms.Platform,
 	outfitIds []ps2.OutfitId,
-) (map[ps2.OutfitId]map[ps2.CharacterId]ps2.Character, error) {
+) (map[ps2.OutfitId]map[ps2.CharacterId]ps2.OnlineCharacter, error) {
 	return r.trackers[platform].OutfitMembersOnline(outfitIds), nil
 }
@@ -2,9 +2,11 @@ package ps2
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	ps2_factions "github.com/x0k/ps2-spy/internal/ps2/factions"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
 	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
 )
 
@@ -73,6 +75,36 @@ type WorldsPopulation struct {
 	Worlds []WorldPopulation
 }
 
+type PopulationSample struct {
+	At time.Time
+	StatPerFactions
+}
+
+// Sums samples with the same time, the result is sorted by time
+func MergePopulationHistories(histories ...[]PopulationSample) []PopulationSample {
+	totals := make(map[time.Time]StatPerFactions)
+	for _, history := range histories {
+		for _, sample := range history {
+			t := totals[sample.At]
+			t.All += sample.All
+			t.VS += sample.VS
+			t.NC += sample.NC
+			t.TR += sample.TR
+			t.NS += sample.NS
+			t.Other += sample.Other
+			totals[sample.At] = t
+		}
+	}
+	merged := make([]PopulationSample, 0, len(totals))
+	for at, stat := range totals {
+		merged = append(merged, PopulationSample{At: at, StatPerFactions: stat})
+	}
+	slices.SortFunc(merged, func(a, b PopulationSample) int {
+		return a.At.Compare(b.At)
+	})
+	return merged
+}
+
 type MetagameEventId string
 
 type MetagameEvent struct {
@@ -137,6 +169,15 @@ type Character struct {
 	Platform  ps2_platforms.Platform
 }
 
+type OnlineCharacter struct {
+	Character
+	// Empty if the character was not seen in any zone
+	ZoneId ZoneId
+	// Empty if the character loadout is unknown
+	Loadout     ps2_loadout.Loadout
+	OnlineSince time.Time
+}
+
 type OutfitId string
 
 func OutfitIdToString(id OutfitId) string {
@@ -163,6 +204,34 @@ type Facility struct {
 	ZoneId ZoneId
 }
 
+type VehicleId string
+
+const NoVehicleId VehicleId = "0"
+
+type Vehicle struct {
+	Id   VehicleId
+	Name string
+	Type string
+}
+
+type ItemId string
+
+const NoItemId ItemId = "0"
+
+type Item struct {
+	Id       ItemId
+	Name     string
+	Category string
+}
+
+type AchievementId string
+
+type Achievement struct {
+	Id          AchievementId
+	Name        string
+	Description string
+}
+
 type ZoneMap struct {
 	Id         ZoneId
 	Facilities map[FacilityId]ps2_factions.Id
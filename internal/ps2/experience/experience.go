@@ -0,0 +1,73 @@
+package ps2_experience
+
+type Id string
+
+type Action int
+
+const (
+	Revive Action = iota
+	Heal
+	Repair
+	Resupply
+	ShieldRepair
+	SpotAssist
+	ActionCount
+)
+
+var toAction = map[Id]Action{
+	// Revive
+	"7":  Revive,
+	"53": Revive,
+	// Heal Player
+	"4":  Heal,
+	"51": Heal,
+	// MAX Repair
+	"6":   Repair,
+	"142": Repair,
+	// Vehicle Repair
+	"31":  Repair,
+	"87":  Repair,
+	"88":  Repair,
+	"89":  Repair,
+	"90":  Repair,
+	"91":  Repair,
+	"92":  Repair,
+	"93":  Repair,
+	"94":  Repair,
+	"95":  Repair,
+	"96":  Repair,
+	"97":  Repair,
+	"98":  Repair,
+	"99":  Repair,
+	"100": Repair,
+	"129": Repair,
+	"130": Repair,
+	"131": Repair,
+	"132": Repair,
+	"133": Repair,
+	"134": Repair,
+	"135": Repair,
+	"136": Repair,
+	"137": Repair,
+	"138": Repair,
+	"139": Repair,
+	"140": Repair,
+	"141": Repair,
+	// Resupply Player
+	"34": Resupply,
+	"55": Resupply,
+	// Vehicle Resupply
+	"240": Resupply,
+	"241": Resupply,
+	// Shield Repair
+	"438": ShieldRepair,
+	"439": ShieldRepair,
+	// Spot Kill
+	"36": SpotAssist,
+	"54": SpotAssist,
+}
+
+func GetAction(id Id) (Action, bool) {
+	action, ok := toAction[id]
+	return action, ok
+}
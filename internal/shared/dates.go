@@ -0,0 +1,63 @@
+package shared
+
+import (
+	"time"
+)
+
+const DateLayout = "2006-01-02"
+
+// Calendar date that is resolved to the concrete time
+// only with a location, so it is not affected by DST changes
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+func (d Date) String() string {
+	return d.utc().Format(DateLayout)
+}
+
+func (d Date) utc() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+func (d Date) Weekday() time.Weekday {
+	return d.utc().Weekday()
+}
+
+func (d Date) AddDays(days int) Date {
+	return DateOf(d.utc().AddDate(0, 0, days))
+}
+
+func (d Date) DaysSince(other Date) int {
+	return int(d.utc().Sub(other.utc()) / (24 * time.Hour))
+}
+
+func (d Date) Before(other Date) bool {
+	return d.utc().Before(other.utc())
+}
+
+// Wall clock time of the day in the given location, non-existent
+// times are normalized by `time.Date`
+func (d Date) At(timeOfDay time.Duration, loc *time.Location) time.Time {
+	return time.Date(
+		d.Year, d.Month, d.Day,
+		int(timeOfDay/time.Hour),
+		int((timeOfDay%time.Hour)/time.Minute),
+		0, 0, loc,
+	)
+}
@@ -0,0 +1,72 @@
+package shared
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateAt(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("timezone database is not available")
+	}
+	tests := []struct {
+		name string
+		date Date
+		want time.Time
+	}{
+		{
+			name: "summer time",
+			date: Date{Year: 2024, Month: time.October, Day: 26},
+			want: time.Date(2024, time.October, 26, 18, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "winter time",
+			date: Date{Year: 2024, Month: time.October, Day: 27},
+			want: time.Date(2024, time.October, 27, 19, 30, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.date.At(20*time.Hour+30*time.Minute, berlin)
+			if !got.Equal(tt.want) {
+				t.Errorf("Date.At() = %v, want %v", got.UTC(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDateDaysSince(t *testing.T) {
+	tests := []struct {
+		name  string
+		date  Date
+		other Date
+		want  int
+	}{
+		{
+			name:  "same date",
+			date:  Date{Year: 2024, Month: time.March, Day: 31},
+			other: Date{Year: 2024, Month: time.March, Day: 31},
+			want:  0,
+		},
+		{
+			name:  "across months",
+			date:  Date{Year: 2024, Month: time.April, Day: 2},
+			other: Date{Year: 2024, Month: time.March, Day: 30},
+			want:  3,
+		},
+		{
+			name:  "before",
+			date:  Date{Year: 2023, Month: time.December, Day: 31},
+			other: Date{Year: 2024, Month: time.January, Day: 1},
+			want:  -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.date.DaysSince(tt.other); got != tt.want {
+				t.Errorf("Date.DaysSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
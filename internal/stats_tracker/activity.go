@@ -0,0 +1,95 @@
+package stats_tracker
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
+)
+
+type SortOrder string
+
+const (
+	SortByKills      SortOrder = "kills"
+	SortByKPM        SortOrder = "kpm"
+	SortByDPM        SortOrder = "dpm"
+	SortByActiveTime SortOrder = "active_time"
+)
+
+var SortOrders = []SortOrder{
+	SortByKills,
+	SortByKPM,
+	SortByDPM,
+	SortByActiveTime,
+}
+
+// Online time is unknown for sessions recorded before it was tracked,
+// so the time spent in loadouts is used instead
+func activeTime(
+	onlineDuration time.Duration,
+	distribution [ps2_loadout.LoadoutTypeCount]time.Duration,
+) time.Duration {
+	if onlineDuration > 0 {
+		return onlineDuration
+	}
+	var total time.Duration
+	for _, d := range distribution {
+		total += d
+	}
+	return total
+}
+
+func perMinute(count uint, d time.Duration) float64 {
+	if d < time.Minute {
+		return float64(count)
+	}
+	return float64(count) / d.Minutes()
+}
+
+func (s CharacterStats) ActiveTime() time.Duration {
+	return activeTime(s.OnlineDuration, s.LoadoutsDistribution)
+}
+
+// Kills per minute of active time
+func (s CharacterStats) KPM() float64 {
+	return perMinute(s.BodyKills+s.HeadShotsKills, s.ActiveTime())
+}
+
+// Deaths per minute of active time
+func (s CharacterStats) DPM() float64 {
+	return perMinute(s.Deaths+s.Suicides, s.ActiveTime())
+}
+
+// Unknown orders are sorted by kills
+func ParseSortOrder(order string) SortOrder {
+	if slices.Contains(SortOrders, SortOrder(order)) {
+		return SortOrder(order)
+	}
+	return SortByKills
+}
+
+// Compares characters in descending order of the given metric,
+// kills are compared by the caller
+func compareByOrder(a, b CharacterStats, order SortOrder) int {
+	switch order {
+	case SortByKPM:
+		return cmp.Compare(b.KPM(), a.KPM())
+	case SortByDPM:
+		return cmp.Compare(b.DPM(), a.DPM())
+	case SortByActiveTime:
+		return cmp.Compare(b.ActiveTime(), a.ActiveTime())
+	}
+	return 0
+}
+
+// Sorts characters in descending order of the given metric,
+// ties are broken by kills
+func SortCharacters(characters []CharacterStats, order SortOrder) {
+	slices.SortStableFunc(characters, func(a, b CharacterStats) int {
+		return cmp.Or(
+			compareByOrder(a, b, order),
+			cmp.Compare(b.BodyKills+b.HeadShotsKills, a.BodyKills+a.HeadShotsKills),
+		)
+	})
+}
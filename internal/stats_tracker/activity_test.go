@@ -0,0 +1,100 @@
+package stats_tracker
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/ps2"
+)
+
+func TestRankCharacters(t *testing.T) {
+	characters := []CharacterStats{
+		{
+			Character:      ps2.Character{Name: "a"},
+			BodyKills:      10,
+			Deaths:         1,
+			OnlineDuration: time.Hour,
+		},
+		{
+			Character:      ps2.Character{Name: "b"},
+			BodyKills:      5,
+			Deaths:         10,
+			Revives:        3,
+			OnlineDuration: 10 * time.Minute,
+		},
+		{
+			Character:      ps2.Character{Name: "c"},
+			BodyKills:      8,
+			Deaths:         2,
+			Revives:        1,
+			OnlineDuration: 2 * time.Hour,
+		},
+	}
+	cases := []struct {
+		name     string
+		formula  string
+		order    SortOrder
+		expected []string
+	}{
+		{
+			name:     "kills",
+			order:    SortByKills,
+			expected: []string{"a", "c", "b"},
+		},
+		{
+			name:     "kills per minute",
+			order:    SortByKPM,
+			expected: []string{"b", "a", "c"},
+		},
+		{
+			name:     "deaths per minute with ties broken by kills",
+			order:    SortByDPM,
+			expected: []string{"b", "a", "c"},
+		},
+		{
+			name:     "active time",
+			order:    SortByActiveTime,
+			expected: []string{"c", "a", "b"},
+		},
+		{
+			name:     "score replaces kills",
+			formula:  "revives",
+			order:    SortByKills,
+			expected: []string{"b", "c", "a"},
+		},
+		{
+			name:     "score breaks ties of the sort order",
+			formula:  "deaths",
+			order:    SortByDPM,
+			expected: []string{"b", "c", "a"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			formula, err := ParseScoreFormula(c.formula)
+			if err != nil {
+				t.Fatalf("ParseScoreFormula() error = %v", err)
+			}
+			ranked := RankCharacters(characters, formula, c.order)
+			names := make([]string, 0, len(ranked))
+			for _, char := range ranked {
+				names = append(names, char.Character.Name)
+			}
+			if !slices.Equal(names, c.expected) {
+				t.Errorf("RankCharacters() = %v, want %v", names, c.expected)
+			}
+		})
+	}
+}
+
+func TestParseSortOrder(t *testing.T) {
+	for _, order := range SortOrders {
+		if got := ParseSortOrder(string(order)); got != order {
+			t.Errorf("ParseSortOrder(%q) = %q, want %q", order, got, order)
+		}
+	}
+	if got := ParseSortOrder("unknown"); got != SortByKills {
+		t.Errorf("ParseSortOrder(%q) = %q, want %q", "unknown", got, SortByKills)
+	}
+}
@@ -0,0 +1,98 @@
+package stats_tracker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/discord"
+	"github.com/x0k/ps2-spy/internal/lib/logger/sl"
+)
+
+type autoStartState struct {
+	// Since the online count crossed the threshold
+	// (start one for the stopped tracker and stop one for the started)
+	since time.Time
+	// Session of the auto started tracker
+	sessionId SessionId
+	started   bool
+}
+
+func (s *StatsTracker) invalidateAutoStartedTrackers(ctx context.Context, now time.Time) {
+	autoStarts, err := s.autoStartsLoader(ctx)
+	if err != nil {
+		s.log.Error(ctx, "error loading auto starts", sl.Err(err))
+		return
+	}
+	states := make(map[discord.ChannelId]autoStartState, len(autoStarts))
+	for _, autoStart := range autoStarts {
+		count, err := s.onlineCharactersCountLoader(ctx, autoStart.ChannelId)
+		if err != nil {
+			s.log.Warn(ctx, "failed to count online characters", slog.String("channel_id", string(autoStart.ChannelId)), sl.Err(err))
+			continue
+		}
+		states[autoStart.ChannelId] = s.invalidateAutoStartedTracker(
+			ctx,
+			autoStart,
+			s.autoStarts[autoStart.ChannelId],
+			count,
+			now,
+		)
+	}
+	s.autoStarts = states
+}
+
+func (s *StatsTracker) invalidateAutoStartedTracker(
+	ctx context.Context,
+	autoStart discord.StatsTrackerAutoStart,
+	state autoStartState,
+	count int,
+	now time.Time,
+) autoStartState {
+	channelId := autoStart.ChannelId
+	// Stopped manually or by schedule
+	if state.started {
+		if sessionId, ok := s.runningSessionId(channelId); !ok || sessionId != state.sessionId {
+			state = autoStartState{}
+		}
+	}
+	crossed := count >= autoStart.MinOnline
+	if state.started {
+		crossed = count < autoStart.StopBelow
+	}
+	if !crossed {
+		state.since = time.Time{}
+		return state
+	}
+	if state.since.IsZero() {
+		state.since = now
+	}
+	if now.Sub(state.since) < autoStart.Duration {
+		return state
+	}
+	if state.started {
+		if err := s.stopChannelTracker(ctx, channelId, false); err != nil {
+			s.log.Error(ctx, "failed to stop auto started channel tracker", slog.String("channel_id", string(channelId)), sl.Err(err))
+		}
+		return autoStartState{}
+	}
+	if s.isRunning(channelId) {
+		return state
+	}
+	if err := s.startChannelTracker(ctx, channelId, AutoSession, 0, nil); err != nil {
+		s.log.Error(ctx, "failed to auto start channel tracker", slog.String("channel_id", string(channelId)), sl.Err(err))
+		return state
+	}
+	// Could be skipped after the manual stop
+	if sessionId, ok := s.runningSessionId(channelId); ok {
+		return autoStartState{sessionId: sessionId, started: true}
+	}
+	return state
+}
+
+func (s *StatsTracker) runningSessionId(channelId discord.ChannelId) (SessionId, bool) {
+	s.trackersMu.RLock()
+	defer s.trackersMu.RUnlock()
+	t, ok := s.trackers[channelId]
+	return t.sessionId, ok
+}
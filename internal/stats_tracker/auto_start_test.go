@@ -0,0 +1,110 @@
+package stats_tracker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/discord"
+)
+
+type autoStartStep struct {
+	at    time.Duration
+	count int
+	// Stops the tracker before the tick
+	stop    bool
+	running bool
+}
+
+func TestStatsTrackerAutoStart(t *testing.T) {
+	const channelId discord.ChannelId = "channel"
+	autoStart := discord.StatsTrackerAutoStart{
+		ChannelId: channelId,
+		MinOnline: 5,
+		Duration:  10 * time.Minute,
+		StopBelow: 2,
+	}
+	cases := []struct {
+		name   string
+		manual bool
+		steps  []autoStartStep
+	}{
+		{
+			name: "started when the count holds for the duration",
+			steps: []autoStartStep{
+				{at: 0, count: 5},
+				{at: 5 * time.Minute, count: 6},
+				{at: 10 * time.Minute, count: 5, running: true},
+			},
+		},
+		{
+			name: "count drop resets the duration",
+			steps: []autoStartStep{
+				{at: 0, count: 5},
+				{at: 5 * time.Minute, count: 4},
+				{at: 10 * time.Minute, count: 5},
+				{at: 15 * time.Minute, count: 5},
+				{at: 20 * time.Minute, count: 5, running: true},
+			},
+		},
+		{
+			name: "stopped when the count stays below the threshold",
+			steps: []autoStartStep{
+				{at: 0, count: 5},
+				{at: 10 * time.Minute, count: 5, running: true},
+				{at: 20 * time.Minute, count: 2, running: true},
+				{at: 25 * time.Minute, count: 1, running: true},
+				{at: 35 * time.Minute, count: 1},
+			},
+		},
+		{
+			name:   "manual session is not stopped",
+			manual: true,
+			steps: []autoStartStep{
+				{at: 0, count: 0, running: true},
+				{at: 20 * time.Minute, count: 0, running: true},
+			},
+		},
+		{
+			name: "manual stop suppresses the auto start",
+			steps: []autoStartStep{
+				{at: 0, count: 5},
+				{at: 10 * time.Minute, count: 5, running: true},
+				{at: 11 * time.Minute, count: 5, stop: true},
+				{at: 30 * time.Minute, count: 5},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var tasks []discord.StatsTrackerTask
+			s := newTestStatsTracker(&sessionsRepoMock{}, &tasks)
+			count := 0
+			s.autoStartsLoader = func(context.Context) ([]discord.StatsTrackerAutoStart, error) {
+				return []discord.StatsTrackerAutoStart{autoStart}, nil
+			}
+			s.onlineCharactersCountLoader = func(context.Context, discord.ChannelId) (int, error) {
+				return count, nil
+			}
+			ctx := context.Background()
+			if c.manual {
+				if err := s.StartChannelTracker(ctx, channelId, nil); err != nil {
+					t.Fatalf("StartChannelTracker() error = %v", err)
+				}
+			}
+			start := time.Now()
+			for i, step := range c.steps {
+				if step.stop {
+					if err := s.StopChannelTracker(ctx, channelId); err != nil {
+						t.Fatalf("step %d: StopChannelTracker() error = %v", i, err)
+					}
+				}
+				count = step.count
+				s.invalidateAutoStartedTrackers(ctx, start.Add(step.at))
+				if running := s.isRunning(channelId); running != step.running {
+					t.Errorf("step %d: isRunning() = %v, want %v", i, running, step.running)
+				}
+			}
+		})
+	}
+}
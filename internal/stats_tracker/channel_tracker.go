@@ -1,12 +1,129 @@
 package stats_tracker
 
 import (
+	"slices"
+	"sync"
 	"time"
 
+	"github.com/x0k/ps2-spy/internal/discord"
+	"github.com/x0k/ps2-spy/internal/ps2"
 	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/tracking"
 )
 
+type platformSession struct {
+	mu sync.Mutex
+	// Characters tracked by the channel during the session
+	characterIds map[ps2.CharacterId]struct{}
+	// Stats of the closed windows, including the stats collected before the restart
+	totals *platformTracker
+	// Entities the session is limited to, nil if the session
+	// tracks everything the channel tracks
+	filter *tracking.Settings
+}
+
+func newPlatformSession(totals *platformTracker, filter *tracking.Settings) *platformSession {
+	return &platformSession{
+		characterIds: make(map[ps2.CharacterId]struct{}),
+		totals:       totals,
+		filter:       filter,
+	}
+}
+
+// The outfit of the character is required to apply the filter
+// only until the character joins the session
+func (p *platformSession) requiresOutfit(characterId ps2.CharacterId) bool {
+	if p.filter == nil || len(p.filter.Outfits) == 0 {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.characterIds[characterId]
+	return !ok
+}
+
+// Returns false if the character is rejected by the filter,
+// `joined` is set if the character is already joined to the session
+func (p *platformSession) accepts(characterId ps2.CharacterId, outfitId ps2.OutfitId) (accepted bool, joined bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.characterIds[characterId]; ok {
+		return true, true
+	}
+	if p.filter != nil &&
+		!slices.Contains(p.filter.Characters, characterId) &&
+		(outfitId == "" || !slices.Contains(p.filter.Outfits, outfitId)) {
+		return false, false
+	}
+	return true, false
+}
+
+// Should be called when the current window is closed for the character,
+// so the session does not get counters from before the join
+func (p *platformSession) join(characterId ps2.CharacterId) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.characterIds[characterId] = struct{}{}
+}
+
+// Adds the closed window to the totals of the session
+func (p *platformSession) fold(closed *platformTracker, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	closed.addTo(p.totals, p.characterIds, now)
+}
+
+// Adds the closed window of the character if it is joined to the session
+func (p *platformSession) foldCharacter(characterId ps2.CharacterId, closed *characterTracker) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.characterIds[characterId]; ok {
+		p.totals.addCharacter(characterId, closed)
+	}
+}
+
+// Sums the totals of the session with the current window
+func (p *platformSession) tracker(windows *platformWindows, now time.Time) *platformTracker {
+	tracker := windows.newTracker()
+	windows.view(func(current *platformTracker) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.totals.addTo(tracker, p.characterIds, now)
+		if current != nil {
+			current.addTo(tracker, p.characterIds, now)
+		}
+	})
+	return tracker
+}
+
 type channelTracker struct {
-	trackers  map[ps2_platforms.Platform]*platformTracker
+	sessionId SessionId
+	platforms map[ps2_platforms.Platform]*platformSession
 	startedAt time.Time
+	origin    SessionOrigin
+	taskId    discord.StatsTrackerTaskId
+	filter    discord.StatsTrackerFilter
+}
+
+func (c channelTracker) toSession(
+	channelId discord.ChannelId,
+	trackers map[ps2_platforms.Platform]*platformTracker,
+	now time.Time,
+) Session {
+	platforms := make([]ps2_platforms.Platform, 0, len(trackers))
+	characters := make([]CharacterSnapshot, 0)
+	for platform, tracker := range trackers {
+		platforms = append(platforms, platform)
+		characters = append(characters, tracker.toSnapshots(now)...)
+	}
+	return Session{
+		Id:         c.sessionId,
+		ChannelId:  channelId,
+		StartedAt:  c.startedAt,
+		Origin:     c.origin,
+		TaskId:     c.taskId,
+		Filter:     c.filter,
+		Platforms:  platforms,
+		Characters: characters,
+	}
 }
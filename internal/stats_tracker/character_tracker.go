@@ -1,12 +1,31 @@
 package stats_tracker
 
 import (
+	"cmp"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/x0k/ps2-spy/internal/ps2"
 	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
 )
 
+const topRivalsCount = 3
+
+func topRivals(rivals []RivalStats, count func(RivalStats) uint) []RivalStats {
+	top := make([]RivalStats, 0)
+	for _, r := range rivals {
+		if count(r) > 0 {
+			top = append(top, r)
+		}
+	}
+	slices.SortFunc(top, func(a, b RivalStats) int {
+		return int(count(b)) - int(count(a))
+	})
+	return top[:min(len(top), topRivalsCount)]
+}
+
 type characterTracker struct {
 	// Kills
 	bodyKills      uint
@@ -16,12 +35,201 @@ type characterTracker struct {
 	deaths                 uint
 	deathsByRestrictedArea uint
 	suicides               uint
+	// Support
+	revives       uint
+	heals         uint
+	repairs       uint
+	resupplies    uint
+	shieldRepairs uint
+	spotAssists   uint
+	score         uint
+	// Vehicles
+	vehiclesDestroyed uint
+	vehiclesLost      uint
+	vehicles          map[ps2.VehicleId]VehicleCounts
+	// Weapons
+	weapons map[ps2.ItemId]WeaponCounts
+	// Facilities
+	captures   uint
+	defenses   uint
+	facilities map[ps2.FacilityId]FacilityCounts
+	// Rivals
+	rivals map[ps2.CharacterId]RivalCounts
+	// Continents
+	zones          map[ps2.ZoneId]ZoneCounts
+	lastZoneId     ps2.ZoneId
+	lastZoneUpdate time.Time
+	// Online
+	onlineDuration time.Duration
+	onlineSince    time.Time
+	// Highlights
+	battleRankUps uint
+	// Last known battle rank
+	battleRank   uint
+	prestiges    uint
+	achievements map[ps2.AchievementId]uint
 
 	LoadoutsDistribution [ps2_loadout.LoadoutTypeCount]time.Duration
 	lastLoadoutType      ps2_loadout.LoadoutType
 	lastLoadoutUpdate    time.Time
 }
 
+// Loadout, zone and online segments are not restored, so the downtime
+// between snapshot and restore is not counted
+func newCharacterTrackerFromSnapshot(snapshot CharacterSnapshot) *characterTracker {
+	c := &characterTracker{
+		bodyKills:              snapshot.BodyKills,
+		headShotsKills:         snapshot.HeadShotsKills,
+		teamKills:              snapshot.TeamKills,
+		deaths:                 snapshot.Deaths,
+		deathsByRestrictedArea: snapshot.DeathsByRestrictedArea,
+		suicides:               snapshot.Suicides,
+		revives:                snapshot.Revives,
+		heals:                  snapshot.Heals,
+		repairs:                snapshot.Repairs,
+		resupplies:             snapshot.Resupplies,
+		shieldRepairs:          snapshot.ShieldRepairs,
+		spotAssists:            snapshot.SpotAssists,
+		score:                  snapshot.Score,
+		LoadoutsDistribution:   snapshot.LoadoutsDistribution,
+		weapons:                maps.Clone(snapshot.Weapons),
+		rivals:                 maps.Clone(snapshot.Rivals),
+		zones:                  maps.Clone(snapshot.Zones),
+		onlineDuration:         snapshot.OnlineDuration,
+		battleRankUps:          snapshot.BattleRankUps,
+		battleRank:             snapshot.BattleRank,
+		prestiges:              snapshot.Prestiges,
+		achievements:           maps.Clone(snapshot.Achievements),
+	}
+	for vehicleId, counts := range snapshot.Vehicles {
+		c.vehiclesDestroyed += counts.Destroyed
+		c.vehiclesLost += counts.Lost
+		c.vehicleCounts(vehicleId)
+		c.vehicles[vehicleId] = counts
+	}
+	for facilityId, counts := range snapshot.Facilities {
+		c.captures += counts.Captures
+		c.defenses += counts.Defenses
+		c.facilityCounts(facilityId)
+		c.facilities[facilityId] = counts
+	}
+	return c
+}
+
+func (c *characterTracker) vehicleCounts(vehicleId ps2.VehicleId) VehicleCounts {
+	if c.vehicles == nil {
+		c.vehicles = make(map[ps2.VehicleId]VehicleCounts)
+	}
+	return c.vehicles[vehicleId]
+}
+
+func (c *characterTracker) addVehicleDestroyed(vehicleId ps2.VehicleId) {
+	counts := c.vehicleCounts(vehicleId)
+	counts.Destroyed++
+	c.vehicles[vehicleId] = counts
+	c.vehiclesDestroyed++
+}
+
+func (c *characterTracker) addVehicleLost(vehicleId ps2.VehicleId) {
+	counts := c.vehicleCounts(vehicleId)
+	counts.Lost++
+	c.vehicles[vehicleId] = counts
+	c.vehiclesLost++
+}
+
+func (c *characterTracker) facilityCounts(facilityId ps2.FacilityId) FacilityCounts {
+	if c.facilities == nil {
+		c.facilities = make(map[ps2.FacilityId]FacilityCounts)
+	}
+	return c.facilities[facilityId]
+}
+
+func (c *characterTracker) addFacilityCapture(facilityId ps2.FacilityId) {
+	counts := c.facilityCounts(facilityId)
+	counts.Captures++
+	c.facilities[facilityId] = counts
+	c.captures++
+}
+
+func (c *characterTracker) addFacilityDefense(facilityId ps2.FacilityId) {
+	counts := c.facilityCounts(facilityId)
+	counts.Defenses++
+	c.facilities[facilityId] = counts
+	c.defenses++
+}
+
+func (c *characterTracker) rivalCounts(rivalId ps2.CharacterId) RivalCounts {
+	if c.rivals == nil {
+		c.rivals = make(map[ps2.CharacterId]RivalCounts)
+	}
+	return c.rivals[rivalId]
+}
+
+func (c *characterTracker) addRivalKill(rivalId ps2.CharacterId) {
+	counts := c.rivalCounts(rivalId)
+	counts.Kills++
+	c.rivals[rivalId] = counts
+}
+
+func (c *characterTracker) addRivalDeath(rivalId ps2.CharacterId) {
+	counts := c.rivalCounts(rivalId)
+	counts.Deaths++
+	c.rivals[rivalId] = counts
+}
+
+func (c *characterTracker) zoneCounts(zoneId ps2.ZoneId) ZoneCounts {
+	if c.zones == nil {
+		c.zones = make(map[ps2.ZoneId]ZoneCounts)
+	}
+	return c.zones[zoneId]
+}
+
+func (c *characterTracker) addZoneKill(zoneId ps2.ZoneId) {
+	counts := c.zoneCounts(zoneId)
+	counts.Kills++
+	c.zones[zoneId] = counts
+}
+
+func (c *characterTracker) addZoneDeath(zoneId ps2.ZoneId) {
+	counts := c.zoneCounts(zoneId)
+	counts.Deaths++
+	c.zones[zoneId] = counts
+}
+
+func (c *characterTracker) addWeaponKill(weaponId ps2.ItemId, isHeadShot bool) {
+	if weaponId == ps2.NoItemId {
+		return
+	}
+	if c.weapons == nil {
+		c.weapons = make(map[ps2.ItemId]WeaponCounts)
+	}
+	counts := c.weapons[weaponId]
+	counts.Kills++
+	if isHeadShot {
+		counts.HeadShotsKills++
+	}
+	c.weapons[weaponId] = counts
+}
+
+// The rank is reset to the first one after the ASP or prestige,
+// the event does not carry the prestige level so it is inferred
+// from the rank decrease (the event of the first rank may be missed)
+func (c *characterTracker) addBattleRankUp(battleRank uint) {
+	if battleRank == 1 || battleRank < c.battleRank {
+		c.prestiges++
+	} else {
+		c.battleRankUps++
+	}
+	c.battleRank = battleRank
+}
+
+func (c *characterTracker) addAchievement(achievementId ps2.AchievementId) {
+	if c.achievements == nil {
+		c.achievements = make(map[ps2.AchievementId]uint)
+	}
+	c.achievements[achievementId]++
+}
+
 func (c *characterTracker) updateLoadout(loadout ps2_loadout.LoadoutType) {
 	now := time.Now()
 	if c.lastLoadoutUpdate.IsZero() {
@@ -32,15 +240,310 @@ func (c *characterTracker) updateLoadout(loadout ps2_loadout.LoadoutType) {
 	if c.lastLoadoutType == loadout {
 		return
 	}
+	c.flushLoadout(now)
+	c.lastLoadoutType = loadout
+}
+
+func (c *characterTracker) flushLoadout(now time.Time) {
+	// Shared windows are flushed by several sessions
+	if c.lastLoadoutUpdate.IsZero() || now.Before(c.lastLoadoutUpdate) {
+		return
+	}
 	c.LoadoutsDistribution[c.lastLoadoutType] += now.Sub(c.lastLoadoutUpdate)
 	c.lastLoadoutUpdate = now
-	c.lastLoadoutType = loadout
 }
 
-func (c *characterTracker) toStats(stoppedAt time.Time, character ps2.Character) CharacterStats {
+func (c *characterTracker) updateZone(zoneId ps2.ZoneId) {
+	if zoneId == "" {
+		return
+	}
+	now := time.Now()
+	if c.lastZoneUpdate.IsZero() {
+		c.lastZoneUpdate = now
+		c.lastZoneId = zoneId
+		return
+	}
+	if c.lastZoneId == zoneId {
+		return
+	}
+	c.flushZone(now)
+	c.lastZoneId = zoneId
+}
+
+func (c *characterTracker) flushZone(now time.Time) {
+	if c.lastZoneUpdate.IsZero() || now.Before(c.lastZoneUpdate) {
+		return
+	}
+	counts := c.zoneCounts(c.lastZoneId)
+	counts.Duration += now.Sub(c.lastZoneUpdate)
+	c.zones[c.lastZoneId] = counts
+	c.lastZoneUpdate = now
+}
+
+func (c *characterTracker) login(now time.Time) {
+	if c.onlineSince.IsZero() {
+		c.onlineSince = now
+	}
+}
+
+// Closes all segments, so the offline time is not counted
+// until the next login and loadout or zone update
+func (c *characterTracker) logout(now time.Time) {
+	c.flush(now)
+	c.lastLoadoutUpdate = time.Time{}
+	c.lastZoneUpdate = time.Time{}
+	c.onlineSince = time.Time{}
+}
+
+func (c *characterTracker) flushOnline(now time.Time) {
+	if c.onlineSince.IsZero() || now.Before(c.onlineSince) {
+		return
+	}
+	c.onlineDuration += now.Sub(c.onlineSince)
+	c.onlineSince = now
+}
+
+func (c *characterTracker) flush(now time.Time) {
+	c.flushLoadout(now)
+	c.flushZone(now)
+	c.flushOnline(now)
+}
+
+// Adds counters of the tracker from another window, current loadout,
+// zone and online segments are not merged since windows are flushed
+// at their boundaries
+func (c *characterTracker) add(o *characterTracker) {
+	c.bodyKills += o.bodyKills
+	c.headShotsKills += o.headShotsKills
+	c.teamKills += o.teamKills
+	c.deaths += o.deaths
+	c.deathsByRestrictedArea += o.deathsByRestrictedArea
+	c.suicides += o.suicides
+	c.revives += o.revives
+	c.heals += o.heals
+	c.repairs += o.repairs
+	c.resupplies += o.resupplies
+	c.shieldRepairs += o.shieldRepairs
+	c.spotAssists += o.spotAssists
+	c.score += o.score
+	c.vehiclesDestroyed += o.vehiclesDestroyed
+	c.vehiclesLost += o.vehiclesLost
+	for vehicleId, counts := range o.vehicles {
+		sum := c.vehicleCounts(vehicleId)
+		sum.Destroyed += counts.Destroyed
+		sum.Lost += counts.Lost
+		c.vehicles[vehicleId] = sum
+	}
+	if len(o.weapons) > 0 && c.weapons == nil {
+		c.weapons = make(map[ps2.ItemId]WeaponCounts, len(o.weapons))
+	}
+	for weaponId, counts := range o.weapons {
+		sum := c.weapons[weaponId]
+		sum.Kills += counts.Kills
+		sum.HeadShotsKills += counts.HeadShotsKills
+		c.weapons[weaponId] = sum
+	}
+	c.captures += o.captures
+	c.defenses += o.defenses
+	for facilityId, counts := range o.facilities {
+		sum := c.facilityCounts(facilityId)
+		sum.Captures += counts.Captures
+		sum.Defenses += counts.Defenses
+		c.facilities[facilityId] = sum
+	}
+	for rivalId, counts := range o.rivals {
+		sum := c.rivalCounts(rivalId)
+		sum.Kills += counts.Kills
+		sum.Deaths += counts.Deaths
+		c.rivals[rivalId] = sum
+	}
+	for zoneId, counts := range o.zones {
+		sum := c.zoneCounts(zoneId)
+		sum.Kills += counts.Kills
+		sum.Deaths += counts.Deaths
+		sum.Duration += counts.Duration
+		c.zones[zoneId] = sum
+	}
+	c.onlineDuration += o.onlineDuration
+	c.battleRankUps += o.battleRankUps
+	c.prestiges += o.prestiges
+	// Windows are added in chronological order
+	if o.battleRank > 0 {
+		c.battleRank = o.battleRank
+	}
+	if len(o.achievements) > 0 && c.achievements == nil {
+		c.achievements = make(map[ps2.AchievementId]uint, len(o.achievements))
+	}
+	for achievementId, count := range o.achievements {
+		c.achievements[achievementId] += count
+	}
+	for i, d := range o.LoadoutsDistribution {
+		c.LoadoutsDistribution[i] += d
+	}
+}
+
+// Continues current loadout, zone and online segments in the next window
+func (c *characterTracker) next(now time.Time) (*characterTracker, bool) {
+	c.flush(now)
+	if c.lastLoadoutUpdate.IsZero() && c.lastZoneUpdate.IsZero() && c.onlineSince.IsZero() {
+		return nil, false
+	}
+	next := &characterTracker{
+		lastLoadoutType: c.lastLoadoutType,
+		lastZoneId:      c.lastZoneId,
+		battleRank:      c.battleRank,
+	}
 	if !c.lastLoadoutUpdate.IsZero() {
-		c.LoadoutsDistribution[c.lastLoadoutType] += stoppedAt.Sub(c.lastLoadoutUpdate)
+		next.lastLoadoutUpdate = now
+	}
+	if !c.lastZoneUpdate.IsZero() {
+		next.lastZoneUpdate = now
+	}
+	if !c.onlineSince.IsZero() {
+		next.onlineSince = now
+	}
+	// The segments are continued by the next window
+	c.lastLoadoutUpdate = time.Time{}
+	c.lastZoneUpdate = time.Time{}
+	c.onlineSince = time.Time{}
+	return next, true
+}
+
+func (c *characterTracker) toSnapshot(
+	platform ps2_platforms.Platform,
+	characterId ps2.CharacterId,
+) CharacterSnapshot {
+	return CharacterSnapshot{
+		Platform:               platform,
+		CharacterId:            characterId,
+		BodyKills:              c.bodyKills,
+		HeadShotsKills:         c.headShotsKills,
+		TeamKills:              c.teamKills,
+		Deaths:                 c.deaths,
+		DeathsByRestrictedArea: c.deathsByRestrictedArea,
+		Suicides:               c.suicides,
+		Revives:                c.revives,
+		Heals:                  c.heals,
+		Repairs:                c.repairs,
+		Resupplies:             c.resupplies,
+		ShieldRepairs:          c.shieldRepairs,
+		SpotAssists:            c.spotAssists,
+		Score:                  c.score,
+		Vehicles:               maps.Clone(c.vehicles),
+		Weapons:                maps.Clone(c.weapons),
+		Facilities:             maps.Clone(c.facilities),
+		Rivals:                 maps.Clone(c.rivals),
+		Zones:                  maps.Clone(c.zones),
+		OnlineDuration:         c.onlineDuration,
+		BattleRankUps:          c.battleRankUps,
+		BattleRank:             c.battleRank,
+		Prestiges:              c.prestiges,
+		Achievements:           maps.Clone(c.achievements),
+		LoadoutsDistribution:   c.LoadoutsDistribution,
+	}
+}
+
+func (c *characterTracker) toStats(
+	character ps2.Character,
+	vehicles map[ps2.VehicleId]ps2.Vehicle,
+	weapons map[ps2.ItemId]ps2.Item,
+	facilities map[ps2.FacilityId]ps2.Facility,
+	rivals map[ps2.CharacterId]ps2.Character,
+	achievements map[ps2.AchievementId]ps2.Achievement,
+) CharacterStats {
+	facilitiesStats := make([]FacilityStats, 0, len(c.facilities))
+	for facilityId, counts := range c.facilities {
+		facility, ok := facilities[facilityId]
+		if !ok {
+			facility = ps2.Facility{
+				Id:   facilityId,
+				Name: string(facilityId),
+			}
+		}
+		facilitiesStats = append(facilitiesStats, FacilityStats{
+			Facility: facility,
+			Captures: counts.Captures,
+			Defenses: counts.Defenses,
+		})
+	}
+	sortFacilitiesStats(facilitiesStats)
+	weaponsStats := make([]WeaponStats, 0, len(c.weapons))
+	for weaponId, counts := range c.weapons {
+		weapon, ok := weapons[weaponId]
+		if !ok {
+			weapon = ps2.Item{
+				Id:   weaponId,
+				Name: string(weaponId),
+			}
+		}
+		weaponsStats = append(weaponsStats, WeaponStats{
+			Weapon:         weapon,
+			Kills:          counts.Kills,
+			HeadShotsKills: counts.HeadShotsKills,
+		})
+	}
+	slices.SortFunc(weaponsStats, func(a, b WeaponStats) int {
+		return int(b.Kills) - int(a.Kills)
+	})
+	vehiclesStats := make([]VehicleStats, 0, len(c.vehicles))
+	for vehicleId, counts := range c.vehicles {
+		vehicle, ok := vehicles[vehicleId]
+		if !ok {
+			vehicle = ps2.Vehicle{
+				Id:   vehicleId,
+				Name: string(vehicleId),
+			}
+		}
+		vehiclesStats = append(vehiclesStats, VehicleStats{
+			Vehicle:   vehicle,
+			Destroyed: counts.Destroyed,
+			Lost:      counts.Lost,
+		})
+	}
+	slices.SortFunc(vehiclesStats, func(a, b VehicleStats) int {
+		return int(b.Destroyed+b.Lost) - int(a.Destroyed+a.Lost)
+	})
+	rivalsStats := make([]RivalStats, 0, len(c.rivals))
+	for rivalId, counts := range c.rivals {
+		rival, ok := rivals[rivalId]
+		if !ok {
+			rival = ps2.Character{
+				Id:       rivalId,
+				Name:     string(rivalId),
+				Platform: character.Platform,
+			}
+		}
+		rivalsStats = append(rivalsStats, RivalStats{
+			Character: rival,
+			Kills:     counts.Kills,
+			Deaths:    counts.Deaths,
+		})
+	}
+	zonesStats := make([]ZoneStats, 0, len(c.zones))
+	for zoneId, counts := range c.zones {
+		zonesStats = append(zonesStats, ZoneStats{
+			ZoneId:     zoneId,
+			ZoneCounts: counts,
+		})
+	}
+	sortZonesStats(zonesStats)
+	achievementsStats := make([]AchievementStats, 0, len(c.achievements))
+	for achievementId, count := range c.achievements {
+		achievement, ok := achievements[achievementId]
+		if !ok {
+			achievement = ps2.Achievement{
+				Id:   achievementId,
+				Name: string(achievementId),
+			}
+		}
+		achievementsStats = append(achievementsStats, AchievementStats{
+			Achievement: achievement,
+			Count:       count,
+		})
 	}
+	slices.SortFunc(achievementsStats, func(a, b AchievementStats) int {
+		return cmp.Or(int(b.Count)-int(a.Count), cmp.Compare(a.Achievement.Name, b.Achievement.Name))
+	})
 	return CharacterStats{
 		Character:              character,
 		BodyKills:              c.bodyKills,
@@ -49,6 +552,28 @@ func (c *characterTracker) toStats(stoppedAt time.Time, character ps2.Character)
 		Deaths:                 c.deaths,
 		DeathsByRestrictedArea: c.deathsByRestrictedArea,
 		Suicides:               c.suicides,
+		Revives:                c.revives,
+		Heals:                  c.heals,
+		Repairs:                c.repairs,
+		Resupplies:             c.resupplies,
+		ShieldRepairs:          c.shieldRepairs,
+		SpotAssists:            c.spotAssists,
+		Score:                  c.score,
+		VehiclesDestroyed:      c.vehiclesDestroyed,
+		VehiclesLost:           c.vehiclesLost,
+		Vehicles:               vehiclesStats,
+		Weapons:                weaponsStats,
+		Captures:               c.captures,
+		Defenses:               c.defenses,
+		Facilities:             facilitiesStats,
+		Victims:                topRivals(rivalsStats, RivalStats.kills),
+		Nemeses:                topRivals(rivalsStats, RivalStats.deaths),
+		Zones:                  zonesStats,
+		OnlineDuration:         c.onlineDuration,
+		BattleRankUps:          c.battleRankUps,
+		BattleRank:             c.battleRank,
+		Prestiges:              c.prestiges,
+		Achievements:           achievementsStats,
 		LoadoutsDistribution:   c.LoadoutsDistribution,
 	}
 }
@@ -0,0 +1,241 @@
+package stats_tracker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_experience "github.com/x0k/ps2-spy/internal/ps2/experience"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+func TestCharacterTrackerAddBattleRankUp(t *testing.T) {
+	cases := []struct {
+		name          string
+		initialRank   uint
+		ranks         []uint
+		battleRankUps uint
+		prestiges     uint
+		battleRank    uint
+	}{
+		{
+			name:          "unknown initial rank",
+			ranks:         []uint{42},
+			battleRankUps: 1,
+			battleRank:    42,
+		},
+		{
+			name:          "consecutive rank ups",
+			initialRank:   98,
+			ranks:         []uint{99, 100},
+			battleRankUps: 2,
+			battleRank:    100,
+		},
+		{
+			name:        "prestige resets the rank to the first one",
+			initialRank: 100,
+			ranks:       []uint{1},
+			prestiges:   1,
+			battleRank:  1,
+		},
+		{
+			name:       "first rank with unknown initial rank",
+			ranks:      []uint{1},
+			prestiges:  1,
+			battleRank: 1,
+		},
+		{
+			name:          "missed event of the first rank",
+			initialRank:   100,
+			ranks:         []uint{2, 3},
+			battleRankUps: 1,
+			prestiges:     1,
+			battleRank:    3,
+		},
+		{
+			name:          "rank ups after the prestige",
+			initialRank:   99,
+			ranks:         []uint{100, 1, 2},
+			battleRankUps: 2,
+			prestiges:     1,
+			battleRank:    2,
+		},
+		{
+			name:          "repeated rank is not a prestige",
+			initialRank:   50,
+			ranks:         []uint{50},
+			battleRankUps: 1,
+			battleRank:    50,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tracker := &characterTracker{battleRank: c.initialRank}
+			for _, rank := range c.ranks {
+				tracker.addBattleRankUp(rank)
+			}
+			if tracker.battleRankUps != c.battleRankUps {
+				t.Errorf("expected %d battle rank ups, got %d", c.battleRankUps, tracker.battleRankUps)
+			}
+			if tracker.prestiges != c.prestiges {
+				t.Errorf("expected %d prestiges, got %d", c.prestiges, tracker.prestiges)
+			}
+			if tracker.battleRank != c.battleRank {
+				t.Errorf("expected battle rank %d, got %d", c.battleRank, tracker.battleRank)
+			}
+		})
+	}
+}
+
+func TestCharacterTrackerBattleRankAcrossWindows(t *testing.T) {
+	now := time.Now()
+	first := &characterTracker{}
+	first.login(now)
+	first.addBattleRankUp(100)
+	second, ok := first.next(now.Add(time.Minute))
+	if !ok {
+		t.Fatal("expected the online segment to be continued")
+	}
+	second.addBattleRankUp(1)
+	sum := &characterTracker{}
+	sum.add(first)
+	sum.add(second)
+	if sum.battleRankUps != 1 || sum.prestiges != 1 {
+		t.Errorf("expected 1 rank up and 1 prestige, got %d and %d", sum.battleRankUps, sum.prestiges)
+	}
+	if sum.battleRank != 1 {
+		t.Errorf("expected battle rank 1, got %d", sum.battleRank)
+	}
+}
+
+func TestPlatformTrackerLogoutClosesSegments(t *testing.T) {
+	const characterId ps2.CharacterId = "character"
+	const zoneId ps2.ZoneId = "2"
+	tracker := newPlatformTracker(
+		ps2_platforms.PC,
+		emptyCharactersLoader,
+		func(context.Context, []ps2.VehicleId) (map[ps2.VehicleId]ps2.Vehicle, error) {
+			return nil, nil
+		},
+		func(context.Context, []ps2.ItemId) (map[ps2.ItemId]ps2.Item, error) {
+			return nil, nil
+		},
+		func(context.Context, ps2.FacilityId) (ps2.Facility, error) {
+			return ps2.Facility{}, nil
+		},
+		func(context.Context, []ps2.OutfitId) (map[ps2.OutfitId]ps2.Outfit, error) {
+			return nil, nil
+		},
+		func(context.Context, []ps2.AchievementId) (map[ps2.AchievementId]ps2.Achievement, error) {
+			return nil, nil
+		},
+	)
+	now := time.Now()
+	tracker.login(characterId, now)
+	tracker.updateCharacter(characterId, func(c *characterTracker) {
+		c.updateZone(zoneId)
+		c.updateLoadout(ps2_loadout.Medic)
+	})
+	tracker.logout(characterId, now.Add(time.Minute))
+	stats, err := tracker.toStats(context.Background(), now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("toStats() error = %v", err)
+	}
+	if len(stats.Characters) != 1 {
+		t.Fatalf("expected 1 character, got %d", len(stats.Characters))
+	}
+	character := stats.Characters[0]
+	if character.OnlineDuration != time.Minute {
+		t.Errorf("OnlineDuration = %s, want %s", character.OnlineDuration, time.Minute)
+	}
+	if len(character.Zones) != 1 || character.Zones[0].ZoneId != zoneId {
+		t.Fatalf("expected the zone %q, got %+v", zoneId, character.Zones)
+	}
+	if d := character.Zones[0].Duration; d > time.Minute {
+		t.Errorf("zone duration = %s, want at most %s", d, time.Minute)
+	}
+	if d := character.LoadoutsDistribution[ps2_loadout.Medic]; d > time.Minute {
+		t.Errorf("loadout duration = %s, want at most %s", d, time.Minute)
+	}
+}
+
+type supportStats struct {
+	Revives       uint
+	Heals         uint
+	Repairs       uint
+	Resupplies    uint
+	ShieldRepairs uint
+	SpotAssists   uint
+	Score         uint
+}
+
+func TestGainExperience(t *testing.T) {
+	cases := []struct {
+		name         string
+		experienceId ps2_experience.Id
+		want         supportStats
+	}{
+		{
+			name:         "revive",
+			experienceId: "7",
+			want:         supportStats{Revives: 1, Score: 10},
+		},
+		{
+			name:         "squad heal",
+			experienceId: "51",
+			want:         supportStats{Heals: 1, Score: 10},
+		},
+		{
+			name:         "MAX repair",
+			experienceId: "6",
+			want:         supportStats{Repairs: 1, Score: 10},
+		},
+		{
+			name:         "vehicle repair",
+			experienceId: "90",
+			want:         supportStats{Repairs: 1, Score: 10},
+		},
+		{
+			name:         "vehicle resupply",
+			experienceId: "240",
+			want:         supportStats{Resupplies: 1, Score: 10},
+		},
+		{
+			name:         "shield repair",
+			experienceId: "438",
+			want:         supportStats{ShieldRepairs: 1, Score: 10},
+		},
+		{
+			name:         "spot assist",
+			experienceId: "36",
+			want:         supportStats{SpotAssists: 1, Score: 10},
+		},
+		{
+			name:         "not a support action adds only the score",
+			experienceId: "1",
+			want:         supportStats{Score: 10},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tracker := &characterTracker{}
+			action, isSupport := ps2_experience.GetAction(c.experienceId)
+			gainExperience(10, action, isSupport)(tracker)
+			stats := tracker.toStats(ps2.Character{}, nil, nil, nil, nil, nil)
+			got := supportStats{
+				Revives:       stats.Revives,
+				Heals:         stats.Heals,
+				Repairs:       stats.Repairs,
+				Resupplies:    stats.Resupplies,
+				ShieldRepairs: stats.ShieldRepairs,
+				SpotAssists:   stats.SpotAssists,
+				Score:         stats.Score,
+			}
+			if got != c.want {
+				t.Errorf("expected %+v, got %+v", c.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package stats_tracker
+
+import (
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+// Totals is a compact summary of the performance used to compare sessions
+type Totals struct {
+	Kills          uint
+	HeadShotsKills uint
+	Deaths         uint
+	Playtime       time.Duration
+}
+
+func (t Totals) KD() float64 {
+	if t.Deaths == 0 {
+		return float64(t.Kills)
+	}
+	return float64(t.Kills) / float64(t.Deaths)
+}
+
+func (t Totals) HSR() float64 {
+	if t.Kills == 0 {
+		return 0
+	}
+	return float64(t.HeadShotsKills) / float64(t.Kills)
+}
+
+func (t Totals) Add(other Totals) Totals {
+	return Totals{
+		Kills:          t.Kills + other.Kills,
+		HeadShotsKills: t.HeadShotsKills + other.HeadShotsKills,
+		Deaths:         t.Deaths + other.Deaths,
+		Playtime:       t.Playtime + other.Playtime,
+	}
+}
+
+func newTotals(
+	bodyKills, headShotsKills, deaths, suicides uint,
+	onlineDuration time.Duration,
+	distribution [ps2_loadout.LoadoutTypeCount]time.Duration,
+) Totals {
+	return Totals{
+		Kills:          bodyKills + headShotsKills,
+		HeadShotsKills: headShotsKills,
+		Deaths:         deaths + suicides,
+		Playtime:       activeTime(onlineDuration, distribution),
+	}
+}
+
+func (s CharacterStats) Totals() Totals {
+	return newTotals(s.BodyKills, s.HeadShotsKills, s.Deaths, s.Suicides, s.OnlineDuration, s.LoadoutsDistribution)
+}
+
+func (s CharacterSnapshot) Totals() Totals {
+	return newTotals(s.BodyKills, s.HeadShotsKills, s.Deaths, s.Suicides, s.OnlineDuration, s.LoadoutsDistribution)
+}
+
+func (s Session) Totals() Totals {
+	var t Totals
+	for _, c := range s.Characters {
+		t = t.Add(c.Totals())
+	}
+	return t
+}
+
+type PreviousPlatformStats struct {
+	Characters map[ps2.CharacterId]Totals
+	// Grouped by the current outfit of the characters
+	Outfits map[ps2.OutfitId]Totals
+}
+
+// PreviousSession contains totals of the previous session
+// from the same schedule
+type PreviousSession struct {
+	Id        SessionId
+	StartedAt time.Time
+	StoppedAt time.Time
+	Platforms map[ps2_platforms.Platform]PreviousPlatformStats
+}
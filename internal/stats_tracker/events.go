@@ -17,9 +17,11 @@ type Event = pubsub.Event[EventType]
 const (
 	ChannelTrackerStartedType EventType = "start_stats_tracker"
 	ChannelTrackerStoppedType EventType = "stop_stats_tracker"
+	ChannelTrackerUpdatedType EventType = "update_stats_tracker"
 )
 
 type ChannelTrackerStarted struct {
+	SessionId SessionId
 	ChannelId discord.ChannelId
 	StartedAt time.Time
 }
@@ -28,6 +30,55 @@ func (e ChannelTrackerStarted) Type() EventType {
 	return ChannelTrackerStartedType
 }
 
+type VehicleStats struct {
+	Vehicle   ps2.Vehicle
+	Destroyed uint
+	Lost      uint
+}
+
+type WeaponStats struct {
+	Weapon         ps2.Item
+	Kills          uint
+	HeadShotsKills uint
+}
+
+type FacilityStats struct {
+	Facility ps2.Facility
+	Captures uint
+	Defenses uint
+}
+
+type RivalStats struct {
+	Character ps2.Character
+	Kills     uint
+	Deaths    uint
+}
+
+func (s RivalStats) kills() uint {
+	return s.Kills
+}
+
+func (s RivalStats) deaths() uint {
+	return s.Deaths
+}
+
+type ZoneStats struct {
+	ZoneId ps2.ZoneId
+	ZoneCounts
+}
+
+type AchievementStats struct {
+	Achievement ps2.Achievement
+	Count       uint
+}
+
+type OutfitRivalryStats struct {
+	Outfit      ps2.Outfit
+	EnemyOutfit ps2.Outfit
+	Kills       uint
+	Deaths      uint
+}
+
 type CharacterStats struct {
 	Character ps2.Character
 	// Kills
@@ -38,21 +89,91 @@ type CharacterStats struct {
 	Deaths                 uint
 	DeathsByRestrictedArea uint
 	Suicides               uint
+	// Support
+	Revives       uint
+	Heals         uint
+	Repairs       uint
+	Resupplies    uint
+	ShieldRepairs uint
+	SpotAssists   uint
+	Score         uint
+	// Vehicles
+	VehiclesDestroyed uint
+	VehiclesLost      uint
+	// Sorted by total of destroyed and lost
+	Vehicles []VehicleStats
+	// Sorted by kills
+	Weapons []WeaponStats
+	// Facilities
+	Captures uint
+	Defenses uint
+	// Sorted by total of captures and defenses
+	Facilities []FacilityStats
+	// Top rivals killed by the character, sorted by kills
+	Victims []RivalStats
+	// Top rivals who killed the character, sorted by deaths
+	Nemeses []RivalStats
+	// Sorted by duration
+	Zones []ZoneStats
+	// Time between login and logout inside the tracking window
+	OnlineDuration time.Duration
+	// Highlights
+	BattleRankUps uint
+	// Last known battle rank, zero if unknown
+	BattleRank uint
+	// ASP and prestige rank resets
+	Prestiges uint
+	// Sorted by count
+	Achievements []AchievementStats
 
 	LoadoutsDistribution [ps2_loadout.LoadoutTypeCount]time.Duration
 }
 
+func (s CharacterStats) HasSupportActions() bool {
+	return s.Revives+s.Heals+s.Repairs+s.Resupplies+s.ShieldRepairs+s.SpotAssists > 0
+}
+
+func (s CharacterStats) HasRivals() bool {
+	return len(s.Victims)+len(s.Nemeses) > 0
+}
+
+func (s CharacterStats) HasHighlights() bool {
+	return s.BattleRankUps+s.Prestiges > 0 || len(s.Achievements) > 0
+}
+
 type PlatformStats struct {
 	Characters []CharacterStats
+	// Contributions of all tracked characters,
+	// sorted by total of captures and defenses
+	Facilities []FacilityStats
+	// Kills between outfits of tracked characters and enemy outfits,
+	// sorted by total of kills and deaths
+	Outfits []OutfitRivalryStats
+	// Totals of all tracked characters, sorted by duration
+	Zones []ZoneStats
 }
 
 type ChannelTrackerStopped struct {
+	SessionId SessionId
 	ChannelId discord.ChannelId
 	StartedAt time.Time
 	StoppedAt time.Time
 	Platforms map[ps2_platforms.Platform]PlatformStats
+	// Nil if the session was not scheduled or there is no previous session of the task
+	Previous *PreviousSession
 }
 
 func (e ChannelTrackerStopped) Type() EventType {
 	return ChannelTrackerStoppedType
 }
+
+type ChannelTrackerUpdated struct {
+	ChannelId discord.ChannelId
+	StartedAt time.Time
+	UpdatedAt time.Time
+	Platforms map[ps2_platforms.Platform]PlatformStats
+}
+
+func (e ChannelTrackerUpdated) Type() EventType {
+	return ChannelTrackerUpdatedType
+}
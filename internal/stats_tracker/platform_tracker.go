@@ -1,7 +1,9 @@
 package stats_tracker
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"maps"
 	"slices"
@@ -10,31 +12,77 @@ import (
 
 	"github.com/x0k/ps2-spy/internal/lib/loader"
 	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_experience "github.com/x0k/ps2-spy/internal/ps2/experience"
 	ps2_factions "github.com/x0k/ps2-spy/internal/ps2/factions"
 	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
 	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
 )
 
 type CharactersLoader = loader.Multi[ps2.CharacterId, ps2.Character]
+type VehiclesLoader = loader.Multi[ps2.VehicleId, ps2.Vehicle]
+type ItemsLoader = loader.Multi[ps2.ItemId, ps2.Item]
+type FacilityLoader = loader.Keyed[ps2.FacilityId, ps2.Facility]
+type OutfitsLoader = loader.Multi[ps2.OutfitId, ps2.Outfit]
+type AchievementsLoader = loader.Multi[ps2.AchievementId, ps2.Achievement]
 
 type platformTracker struct {
-	platform         ps2_platforms.Platform
-	mu               sync.Mutex
-	characters       map[ps2.CharacterId]*characterTracker
-	charactersLoader CharactersLoader
+	platform           ps2_platforms.Platform
+	mu                 sync.Mutex
+	characters         map[ps2.CharacterId]*characterTracker
+	charactersLoader   CharactersLoader
+	vehiclesLoader     VehiclesLoader
+	itemsLoader        ItemsLoader
+	facilityLoader     FacilityLoader
+	outfitsLoader      OutfitsLoader
+	achievementsLoader AchievementsLoader
 }
 
 func newPlatformTracker(
 	platform ps2_platforms.Platform,
 	charactersLoader CharactersLoader,
+	vehiclesLoader VehiclesLoader,
+	itemsLoader ItemsLoader,
+	facilityLoader FacilityLoader,
+	outfitsLoader OutfitsLoader,
+	achievementsLoader AchievementsLoader,
 ) *platformTracker {
 	return &platformTracker{
-		platform:         platform,
-		characters:       make(map[ps2.CharacterId]*characterTracker),
-		charactersLoader: charactersLoader,
+		platform:           platform,
+		characters:         make(map[ps2.CharacterId]*characterTracker),
+		charactersLoader:   charactersLoader,
+		vehiclesLoader:     vehiclesLoader,
+		itemsLoader:        itemsLoader,
+		facilityLoader:     facilityLoader,
+		outfitsLoader:      outfitsLoader,
+		achievementsLoader: achievementsLoader,
 	}
 }
 
+func newPlatformTrackerFromSnapshots(
+	platform ps2_platforms.Platform,
+	charactersLoader CharactersLoader,
+	vehiclesLoader VehiclesLoader,
+	itemsLoader ItemsLoader,
+	facilityLoader FacilityLoader,
+	outfitsLoader OutfitsLoader,
+	achievementsLoader AchievementsLoader,
+	snapshots []CharacterSnapshot,
+) *platformTracker {
+	tracker := newPlatformTracker(
+		platform,
+		charactersLoader,
+		vehiclesLoader,
+		itemsLoader,
+		facilityLoader,
+		outfitsLoader,
+		achievementsLoader,
+	)
+	for _, snapshot := range snapshots {
+		tracker.characters[snapshot.CharacterId] = newCharacterTrackerFromSnapshot(snapshot)
+	}
+	return tracker
+}
+
 func (c *platformTracker) characterTracker(characterId ps2.CharacterId) *characterTracker {
 	character, ok := c.characters[characterId]
 	if !ok {
@@ -47,6 +95,7 @@ func (c *platformTracker) characterTracker(characterId ps2.CharacterId) *charact
 func (c *platformTracker) handleCharacterEvent(
 	characterId ps2.CharacterId,
 	loadout ps2_loadout.LoadoutType,
+	zoneId ps2.ZoneId,
 	update func(*characterTracker),
 ) {
 	c.mu.Lock()
@@ -54,16 +103,175 @@ func (c *platformTracker) handleCharacterEvent(
 	character := c.characterTracker(characterId)
 	update(character)
 	character.updateLoadout(loadout)
+	character.updateZone(zoneId)
+	// Activity of characters that were online before the tracking
+	// started is the only evidence of their online status
+	character.login(time.Now())
+}
+
+func (c *platformTracker) login(characterId ps2.CharacterId, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.characterTracker(characterId).login(now)
+}
+
+func (c *platformTracker) logout(characterId ps2.CharacterId, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if character, ok := c.characters[characterId]; ok {
+		character.logout(now)
+	}
+}
+
+// For events without information about the character loadout
+func (c *platformTracker) updateCharacter(
+	characterId ps2.CharacterId,
+	update func(*characterTracker),
+) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	update(c.characterTracker(characterId))
+}
+
+// Creates the tracker of the next window
+func (c *platformTracker) next(now time.Time) *platformTracker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	next := newPlatformTracker(
+		c.platform,
+		c.charactersLoader,
+		c.vehiclesLoader,
+		c.itemsLoader,
+		c.facilityLoader,
+		c.outfitsLoader,
+		c.achievementsLoader,
+	)
+	for characterId, tracker := range c.characters {
+		if character, ok := tracker.next(now); ok {
+			next.characters[characterId] = character
+		}
+	}
+	return next
+}
+
+// Removes counters of the character from the window,
+// its segments are continued by the new tracker
+func (c *platformTracker) splitCharacter(characterId ps2.CharacterId, now time.Time) *characterTracker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	closed, ok := c.characters[characterId]
+	if !ok {
+		return nil
+	}
+	if next, ok := closed.next(now); ok {
+		c.characters[characterId] = next
+	} else {
+		delete(c.characters, characterId)
+	}
+	return closed
+}
+
+func (c *platformTracker) addCharacter(characterId ps2.CharacterId, tracker *characterTracker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.characterTracker(characterId).add(tracker)
+}
+
+func (c *platformTracker) addTo(
+	dst *platformTracker,
+	characterIds map[ps2.CharacterId]struct{},
+	now time.Time,
+) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for characterId := range characterIds {
+		if tracker, ok := c.characters[characterId]; ok {
+			tracker.flush(now)
+			dst.characterTracker(characterId).add(tracker)
+		}
+	}
+}
+
+func (c *platformTracker) toSnapshots(now time.Time) []CharacterSnapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snapshots := make([]CharacterSnapshot, 0, len(c.characters))
+	for characterId, tracker := range c.characters {
+		tracker.flush(now)
+		snapshots = append(snapshots, tracker.toSnapshot(c.platform, characterId))
+	}
+	return snapshots
 }
 
+// Safe to call on a running tracker, characters that appear
+// during loading will be rendered with fallback names
 func (c *platformTracker) toStats(
 	ctx context.Context,
-	stoppedAt time.Time,
+	now time.Time,
 ) (PlatformStats, error) {
-	characters, err := c.charactersLoader(ctx, slices.Collect(maps.Keys(c.characters)))
+	vehicleIds := make(map[ps2.VehicleId]struct{})
+	weaponIds := make(map[ps2.ItemId]struct{})
+	facilityIds := make(map[ps2.FacilityId]struct{})
+	rivalIds := make(map[ps2.CharacterId]struct{})
+	achievementIds := make(map[ps2.AchievementId]struct{})
+	c.mu.Lock()
+	characterIds := slices.Collect(maps.Keys(c.characters))
+	for _, tracker := range c.characters {
+		tracker.flush(now)
+		for vehicleId := range tracker.vehicles {
+			vehicleIds[vehicleId] = struct{}{}
+		}
+		for weaponId := range tracker.weapons {
+			weaponIds[weaponId] = struct{}{}
+		}
+		for facilityId := range tracker.facilities {
+			facilityIds[facilityId] = struct{}{}
+		}
+		for rivalId := range tracker.rivals {
+			rivalIds[rivalId] = struct{}{}
+		}
+		for achievementId := range tracker.achievements {
+			achievementIds[achievementId] = struct{}{}
+		}
+	}
+	c.mu.Unlock()
+	characters, err := c.charactersLoader(ctx, characterIds)
 	if len(characters) == 0 && err != nil {
 		return PlatformStats{}, fmt.Errorf("failed to get any character: %w", err)
 	}
+	var errs []error
+	vehicles, err := c.vehiclesLoader(ctx, slices.Collect(maps.Keys(vehicleIds)))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to get vehicles: %w", err))
+	}
+	weapons, err := c.itemsLoader(ctx, slices.Collect(maps.Keys(weaponIds)))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to get weapons: %w", err))
+	}
+	facilities := make(map[ps2.FacilityId]ps2.Facility, len(facilityIds))
+	for facilityId := range facilityIds {
+		facility, err := c.facilityLoader(ctx, facilityId)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get facility %q: %w", facilityId, err))
+			facility = ps2.Facility{
+				Id:   facilityId,
+				Name: string(facilityId),
+			}
+		}
+		facilities[facilityId] = facility
+	}
+	rivals, err := c.charactersLoader(ctx, slices.Collect(maps.Keys(rivalIds)))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to get rivals: %w", err))
+	}
+	achievements, err := c.achievementsLoader(ctx, slices.Collect(maps.Keys(achievementIds)))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to get achievements: %w", err))
+	}
+	groupFacilities := make(map[ps2.FacilityId]FacilityCounts, len(facilityIds))
+	groupZones := make(map[ps2.ZoneId]ZoneCounts)
+	outfitRivalries := make(map[outfitRivalryKey]RivalCounts)
+	c.mu.Lock()
 	chars := make([]CharacterStats, 0, len(c.characters))
 	for characterId, tracker := range c.characters {
 		char, ok := characters[characterId]
@@ -78,14 +286,146 @@ func (c *platformTracker) toStats(
 				Platform:  c.platform,
 			}
 		}
-		chars = append(chars, tracker.toStats(stoppedAt, char))
+		chars = append(chars, tracker.toStats(char, vehicles, weapons, facilities, rivals, achievements))
+		if char.OutfitId != "" {
+			for rivalId, counts := range tracker.rivals {
+				rival, ok := rivals[rivalId]
+				if !ok || rival.OutfitId == "" {
+					continue
+				}
+				key := outfitRivalryKey{char.OutfitId, rival.OutfitId}
+				group := outfitRivalries[key]
+				group.Kills += counts.Kills
+				group.Deaths += counts.Deaths
+				outfitRivalries[key] = group
+			}
+		}
+		for zoneId, counts := range tracker.zones {
+			group := groupZones[zoneId]
+			group.Kills += counts.Kills
+			group.Deaths += counts.Deaths
+			group.Duration += counts.Duration
+			groupZones[zoneId] = group
+		}
+		for facilityId, counts := range tracker.facilities {
+			group := groupFacilities[facilityId]
+			group.Captures += counts.Captures
+			group.Defenses += counts.Defenses
+			groupFacilities[facilityId] = group
+		}
+	}
+	c.mu.Unlock()
+	facilitiesStats := make([]FacilityStats, 0, len(groupFacilities))
+	for facilityId, counts := range groupFacilities {
+		facilitiesStats = append(facilitiesStats, FacilityStats{
+			Facility: facilities[facilityId],
+			Captures: counts.Captures,
+			Defenses: counts.Defenses,
+		})
+	}
+	sortFacilitiesStats(facilitiesStats)
+	zonesStats := make([]ZoneStats, 0, len(groupZones))
+	for zoneId, counts := range groupZones {
+		zonesStats = append(zonesStats, ZoneStats{
+			ZoneId:     zoneId,
+			ZoneCounts: counts,
+		})
+	}
+	sortZonesStats(zonesStats)
+	outfitsStats, err := c.outfitRivalriesStats(ctx, outfitRivalries, chars, rivals)
+	if err != nil {
+		errs = append(errs, err)
 	}
 	slices.SortFunc(chars, func(a, b CharacterStats) int {
 		return int(b.BodyKills+b.HeadShotsKills) - int(a.BodyKills+a.HeadShotsKills)
 	})
 	return PlatformStats{
 		Characters: chars,
-	}, nil
+		Facilities: facilitiesStats,
+		Outfits:    outfitsStats,
+		Zones:      zonesStats,
+	}, errors.Join(errs...)
+}
+
+type outfitRivalryKey struct {
+	outfitId      ps2.OutfitId
+	enemyOutfitId ps2.OutfitId
+}
+
+func (c *platformTracker) outfitRivalriesStats(
+	ctx context.Context,
+	rivalries map[outfitRivalryKey]RivalCounts,
+	chars []CharacterStats,
+	rivals map[ps2.CharacterId]ps2.Character,
+) ([]OutfitRivalryStats, error) {
+	if len(rivalries) == 0 {
+		return nil, nil
+	}
+	// Tags are used if outfits cannot be loaded
+	tags := make(map[ps2.OutfitId]string)
+	for _, char := range chars {
+		tags[char.Character.OutfitId] = char.Character.OutfitTag
+	}
+	for _, rival := range rivals {
+		tags[rival.OutfitId] = rival.OutfitTag
+	}
+	outfitIds := make(map[ps2.OutfitId]struct{}, len(rivalries))
+	for key := range rivalries {
+		outfitIds[key.outfitId] = struct{}{}
+		outfitIds[key.enemyOutfitId] = struct{}{}
+	}
+	outfits, err := c.outfitsLoader(ctx, slices.Collect(maps.Keys(outfitIds)))
+	if err != nil {
+		err = fmt.Errorf("failed to get outfits: %w", err)
+	}
+	outfit := func(outfitId ps2.OutfitId) ps2.Outfit {
+		if o, ok := outfits[outfitId]; ok {
+			return o
+		}
+		return ps2.Outfit{
+			Id:       outfitId,
+			Name:     tags[outfitId],
+			Tag:      tags[outfitId],
+			Platform: c.platform,
+		}
+	}
+	stats := make([]OutfitRivalryStats, 0, len(rivalries))
+	for key, counts := range rivalries {
+		stats = append(stats, OutfitRivalryStats{
+			Outfit:      outfit(key.outfitId),
+			EnemyOutfit: outfit(key.enemyOutfitId),
+			Kills:       counts.Kills,
+			Deaths:      counts.Deaths,
+		})
+	}
+	slices.SortFunc(stats, func(a, b OutfitRivalryStats) int {
+		return int(b.Kills+b.Deaths) - int(a.Kills+a.Deaths)
+	})
+	return stats, err
+}
+
+func sortFacilitiesStats(stats []FacilityStats) {
+	slices.SortFunc(stats, func(a, b FacilityStats) int {
+		return int(b.Captures+b.Defenses) - int(a.Captures+a.Defenses)
+	})
+}
+
+func sortZonesStats(stats []ZoneStats) {
+	slices.SortFunc(stats, func(a, b ZoneStats) int {
+		return cmp.Compare(b.Duration, a.Duration)
+	})
+}
+
+func addZoneKill(zoneId ps2.ZoneId) func(*characterTracker) {
+	return func(character *characterTracker) {
+		character.addZoneKill(zoneId)
+	}
+}
+
+func addZoneDeath(zoneId ps2.ZoneId) func(*characterTracker) {
+	return func(character *characterTracker) {
+		character.addZoneDeath(zoneId)
+	}
 }
 
 func addDeath(character *characterTracker) {
@@ -100,16 +440,99 @@ func addSuicide(character *characterTracker) {
 	character.suicides++
 }
 
-func addBodyKill(character *characterTracker) {
-	character.bodyKills++
+func addBodyKill(weaponId ps2.ItemId) func(*characterTracker) {
+	return func(character *characterTracker) {
+		character.bodyKills++
+		character.addWeaponKill(weaponId, false)
+	}
 }
 
-func addHeadShotKill(character *characterTracker) {
-	character.headShotsKills++
+func addHeadShotKill(weaponId ps2.ItemId) func(*characterTracker) {
+	return func(character *characterTracker) {
+		character.headShotsKills++
+		character.addWeaponKill(weaponId, true)
+	}
+}
+
+func addRivalKill(rivalId ps2.CharacterId) func(*characterTracker) {
+	return func(character *characterTracker) {
+		character.addRivalKill(rivalId)
+	}
+}
+
+func addRivalDeath(rivalId ps2.CharacterId) func(*characterTracker) {
+	return func(character *characterTracker) {
+		character.addRivalDeath(rivalId)
+	}
+}
+
+func combineUpdates(updates ...func(*characterTracker)) func(*characterTracker) {
+	return func(character *characterTracker) {
+		for _, update := range updates {
+			update(character)
+		}
+	}
 }
 
 func addTeamKill(character *characterTracker) {
 	character.teamKills++
 }
 
-func updateLoadout(character *characterTracker) {}
+func addVehicleDestroyed(vehicleId ps2.VehicleId) func(*characterTracker) {
+	return func(character *characterTracker) {
+		character.addVehicleDestroyed(vehicleId)
+	}
+}
+
+func addVehicleLost(vehicleId ps2.VehicleId) func(*characterTracker) {
+	return func(character *characterTracker) {
+		character.addVehicleLost(vehicleId)
+	}
+}
+
+func addFacilityCapture(facilityId ps2.FacilityId) func(*characterTracker) {
+	return func(character *characterTracker) {
+		character.addFacilityCapture(facilityId)
+	}
+}
+
+func addFacilityDefense(facilityId ps2.FacilityId) func(*characterTracker) {
+	return func(character *characterTracker) {
+		character.addFacilityDefense(facilityId)
+	}
+}
+
+func gainExperience(amount uint, action ps2_experience.Action, isSupport bool) func(*characterTracker) {
+	return func(character *characterTracker) {
+		character.score += amount
+		if !isSupport {
+			return
+		}
+		switch action {
+		case ps2_experience.Revive:
+			character.revives++
+		case ps2_experience.Heal:
+			character.heals++
+		case ps2_experience.Repair:
+			character.repairs++
+		case ps2_experience.Resupply:
+			character.resupplies++
+		case ps2_experience.ShieldRepair:
+			character.shieldRepairs++
+		case ps2_experience.SpotAssist:
+			character.spotAssists++
+		}
+	}
+}
+
+func addBattleRankUp(battleRank uint) func(*characterTracker) {
+	return func(character *characterTracker) {
+		character.addBattleRankUp(battleRank)
+	}
+}
+
+func addAchievement(achievementId ps2.AchievementId) func(*characterTracker) {
+	return func(character *characterTracker) {
+		character.addAchievement(achievementId)
+	}
+}
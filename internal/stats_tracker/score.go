@@ -0,0 +1,85 @@
+package stats_tracker
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/x0k/ps2-spy/internal/lib/expr"
+)
+
+var ErrInvalidScoreFormula = errors.New("invalid score formula")
+
+const MaxScoreFormulaLength = 200
+
+// Counters available in score formulas
+var ScoreVariables = []string{
+	"kills",
+	"headshots",
+	"deaths",
+	"team_kills",
+	"suicides",
+	"restricted_deaths",
+	"revives",
+}
+
+// Weighted expression over the character counters,
+// the zero value ranks characters by kills
+type ScoreFormula struct {
+	expr expr.Expr
+}
+
+// Empty formula is the default one
+func ParseScoreFormula(formula string) (ScoreFormula, error) {
+	if formula == "" {
+		return ScoreFormula{}, nil
+	}
+	if len(formula) > MaxScoreFormulaLength {
+		return ScoreFormula{}, fmt.Errorf("%w: longer than %d characters", ErrInvalidScoreFormula, MaxScoreFormulaLength)
+	}
+	e, err := expr.Parse(formula, ScoreVariables)
+	if err != nil {
+		return ScoreFormula{}, fmt.Errorf("%w: %w", ErrInvalidScoreFormula, err)
+	}
+	return ScoreFormula{expr: e}, nil
+}
+
+func (f ScoreFormula) IsDefault() bool {
+	return f.expr.String() == ""
+}
+
+func (f ScoreFormula) String() string {
+	return f.expr.String()
+}
+
+func (f ScoreFormula) Score(s CharacterStats) float64 {
+	kills := s.BodyKills + s.HeadShotsKills
+	if f.IsDefault() {
+		return float64(kills)
+	}
+	return f.expr.Eval([]float64{
+		float64(kills),
+		float64(s.HeadShotsKills),
+		float64(s.Deaths),
+		float64(s.TeamKills),
+		float64(s.Suicides),
+		float64(s.DeathsByRestrictedArea),
+		float64(s.Revives),
+	})
+}
+
+// Returns characters sorted in descending order of the metric of
+// the sort order, the score replaces kills and breaks ties of other
+// metrics, remaining ties are broken by kills
+func RankCharacters(characters []CharacterStats, formula ScoreFormula, order SortOrder) []CharacterStats {
+	ranked := slices.Clone(characters)
+	slices.SortStableFunc(ranked, func(a, b CharacterStats) int {
+		return cmp.Or(
+			compareByOrder(a, b, order),
+			cmp.Compare(formula.Score(b), formula.Score(a)),
+			cmp.Compare(b.BodyKills+b.HeadShotsKills, a.BodyKills+a.HeadShotsKills),
+		)
+	})
+	return ranked
+}
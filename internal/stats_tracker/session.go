@@ -0,0 +1,122 @@
+package stats_tracker
+
+import (
+	"context"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/discord"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+type SessionId int64
+
+type SessionOrigin string
+
+const (
+	ManualSession    SessionOrigin = "manual"
+	ScheduledSession SessionOrigin = "scheduled"
+	AutoSession      SessionOrigin = "auto"
+)
+
+type VehicleCounts struct {
+	Destroyed uint
+	Lost      uint
+}
+
+type FacilityCounts struct {
+	Captures uint
+	Defenses uint
+}
+
+type WeaponCounts struct {
+	Kills          uint
+	HeadShotsKills uint
+}
+
+type RivalCounts struct {
+	// Killed by the character
+	Kills uint
+	// Killed the character
+	Deaths uint
+}
+
+type ZoneCounts struct {
+	Kills    uint
+	Deaths   uint
+	Duration time.Duration
+}
+
+type CharacterSnapshot struct {
+	Platform    ps2_platforms.Platform
+	CharacterId ps2.CharacterId
+	// Kills
+	BodyKills      uint
+	HeadShotsKills uint
+	TeamKills      uint
+	// Deaths
+	Deaths                 uint
+	DeathsByRestrictedArea uint
+	Suicides               uint
+	// Support
+	Revives       uint
+	Heals         uint
+	Repairs       uint
+	Resupplies    uint
+	ShieldRepairs uint
+	SpotAssists   uint
+	Score         uint
+	// Vehicles
+	Vehicles map[ps2.VehicleId]VehicleCounts
+	// Weapons
+	Weapons map[ps2.ItemId]WeaponCounts
+	// Facilities
+	Facilities map[ps2.FacilityId]FacilityCounts
+	// Rivals
+	Rivals map[ps2.CharacterId]RivalCounts
+	// Continents
+	Zones map[ps2.ZoneId]ZoneCounts
+	// Online
+	OnlineDuration time.Duration
+	// Highlights
+	BattleRankUps uint
+	BattleRank    uint
+	Prestiges     uint
+	Achievements  map[ps2.AchievementId]uint
+
+	LoadoutsDistribution [ps2_loadout.LoadoutTypeCount]time.Duration
+}
+
+type Session struct {
+	Id        SessionId
+	ChannelId discord.ChannelId
+	StartedAt time.Time
+	// Zero value for running sessions
+	StoppedAt time.Time
+	Origin    SessionOrigin
+	// Task that started the session, zero if it was not scheduled
+	TaskId discord.StatsTrackerTaskId
+	// Empty for sessions that track everything the channel tracks
+	Filter     discord.StatsTrackerFilter
+	Platforms  []ps2_platforms.Platform
+	Characters []CharacterSnapshot
+}
+
+type SessionsRepo interface {
+	CreateStatsTrackerSession(
+		ctx context.Context,
+		channelId discord.ChannelId,
+		platforms []ps2_platforms.Platform,
+		startedAt time.Time,
+		origin SessionOrigin,
+		taskId discord.StatsTrackerTaskId,
+		filter discord.StatsTrackerFilter,
+	) (SessionId, error)
+	SaveStatsTrackerSession(context.Context, Session) error
+	ActiveStatsTrackerSessions(context.Context) ([]Session, error)
+	// Returns `shared.ErrNotFound` if there is no previous session of the task
+	PreviousScheduledStatsTrackerSession(
+		context.Context, discord.ChannelId, discord.StatsTrackerTaskId, SessionId,
+	) (Session, error)
+}
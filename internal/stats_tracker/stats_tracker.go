@@ -5,6 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,37 +21,75 @@ import (
 	"github.com/x0k/ps2-spy/internal/lib/logger/sl"
 	"github.com/x0k/ps2-spy/internal/lib/pubsub"
 	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_experience "github.com/x0k/ps2-spy/internal/ps2/experience"
 	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
 	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/shared"
+	"github.com/x0k/ps2-spy/internal/tracking"
 )
 
 var ErrNothingToTrack = errors.New("nothing to track")
 var ErrNoChannelTrackerToStop = errors.New("no channel tracker to stop")
 var ErrChannelStatsTrackerIsAlreadyStarted = errors.New("channel stats tracker is already started")
 
+type ErrUnknownFilterNames struct {
+	Names []string
+}
+
+func (e ErrUnknownFilterNames) Error() string {
+	return fmt.Sprintf("filter names are not tracked by the channel: %s", strings.Join(e.Names, ", "))
+}
+
 type TrackablePlatformsLoader = loader.Keyed[discord.ChannelId, []ps2_platforms.Platform]
-type StatsTasksLoader = loader.Keyed[time.Time, []discord.ChannelId]
+type StatsTasksLoader = loader.Keyed[time.Time, []discord.StatsTrackerTask]
+type AutoStartsLoader = loader.Simple[[]discord.StatsTrackerAutoStart]
+type OnlineCharactersCountLoader = loader.Keyed[discord.ChannelId, int]
 
 type CharacterTrackingChannelsLoader = func(
 	context.Context, ps2_platforms.Platform, ps2.CharacterId,
 ) ([]discord.ChannelId, error)
 
+// Returns tracked entities matching the filter per platform
+// and names that are not tracked by the channel
+type FilterLoader = func(
+	context.Context, discord.ChannelId, discord.StatsTrackerFilter,
+) (map[ps2_platforms.Platform]tracking.Settings, []string, error)
+
 type StatsTracker struct {
-	trackersMu               sync.RWMutex
-	wg                       sync.WaitGroup
-	log                      *logger.Logger
-	trackers                 map[discord.ChannelId]channelTracker
+	trackersMu sync.RWMutex
+	wg         sync.WaitGroup
+	log        *logger.Logger
+	trackers   map[discord.ChannelId]channelTracker
+	// Channels with the session being created
+	starting                 map[discord.ChannelId]struct{}
+	windows                  map[ps2_platforms.Platform]*platformWindows
 	publisher                pubsub.Publisher[Event]
 	channelsLoader           CharacterTrackingChannelsLoader
 	maxTrackingDuration      time.Duration
+	updateInterval           time.Duration
 	trackablePlatformsLoader TrackablePlatformsLoader
+	filterLoader             FilterLoader
 	charactersLoaders        map[ps2_platforms.Platform]CharactersLoader
+	vehiclesLoaders          map[ps2_platforms.Platform]VehiclesLoader
+	itemsLoaders             map[ps2_platforms.Platform]ItemsLoader
+	facilityLoaders          map[ps2_platforms.Platform]FacilityLoader
+	outfitsLoaders           map[ps2_platforms.Platform]OutfitsLoader
+	achievementsLoaders      map[ps2_platforms.Platform]AchievementsLoader
+	sessionsRepo             SessionsRepo
+
+	// Serializes saves of the running sessions with the final save
+	// of the stopped one, so it is not overwritten by the stale stats
+	savesMu sync.Mutex
 
 	tasksLoader       StatsTasksLoader
 	scheduledTrackers []discord.ChannelId
 	forceMu           sync.RWMutex
 	forceStarted      *containers.ExpirationQueue[discord.ChannelId]
 	forceStopped      *containers.ExpirationQueue[discord.ChannelId]
+
+	autoStartsLoader            AutoStartsLoader
+	onlineCharactersCountLoader OnlineCharactersCountLoader
+	autoStarts                  map[discord.ChannelId]autoStartState
 }
 
 func New(
@@ -55,45 +97,119 @@ func New(
 	publisher pubsub.Publisher[Event],
 	channelsLoader CharacterTrackingChannelsLoader,
 	trackablePlatformsLoader TrackablePlatformsLoader,
+	filterLoader FilterLoader,
 	tasksLoader StatsTasksLoader,
+	autoStartsLoader AutoStartsLoader,
+	onlineCharactersCountLoader OnlineCharactersCountLoader,
 	charactersLoaders map[ps2_platforms.Platform]CharactersLoader,
+	vehiclesLoaders map[ps2_platforms.Platform]VehiclesLoader,
+	itemsLoaders map[ps2_platforms.Platform]ItemsLoader,
+	facilityLoaders map[ps2_platforms.Platform]FacilityLoader,
+	outfitsLoaders map[ps2_platforms.Platform]OutfitsLoader,
+	achievementsLoaders map[ps2_platforms.Platform]AchievementsLoader,
+	sessionsRepo SessionsRepo,
 	maxTrackingDuration time.Duration,
+	updateInterval time.Duration,
 ) *StatsTracker {
-	return &StatsTracker{
+	s := &StatsTracker{
 		log:                      log,
 		trackers:                 make(map[discord.ChannelId]channelTracker),
+		starting:                 make(map[discord.ChannelId]struct{}),
+		windows:                  make(map[ps2_platforms.Platform]*platformWindows, len(ps2_platforms.Platforms)),
 		publisher:                publisher,
 		channelsLoader:           channelsLoader,
 		charactersLoaders:        charactersLoaders,
+		vehiclesLoaders:          vehiclesLoaders,
+		itemsLoaders:             itemsLoaders,
+		facilityLoaders:          facilityLoaders,
+		outfitsLoaders:           outfitsLoaders,
+		achievementsLoaders:      achievementsLoaders,
+		sessionsRepo:             sessionsRepo,
 		maxTrackingDuration:      maxTrackingDuration,
+		updateInterval:           updateInterval,
 		trackablePlatformsLoader: trackablePlatformsLoader,
+		filterLoader:             filterLoader,
 
 		tasksLoader:  tasksLoader,
 		forceStarted: containers.NewExpirationQueue[discord.ChannelId](),
 		forceStopped: containers.NewExpirationQueue[discord.ChannelId](),
+
+		autoStartsLoader:            autoStartsLoader,
+		onlineCharactersCountLoader: onlineCharactersCountLoader,
+		autoStarts:                  make(map[discord.ChannelId]autoStartState),
+	}
+	// Loaders are resolved on use since the maps
+	// are filled after the tracker is created
+	for _, platform := range ps2_platforms.Platforms {
+		s.windows[platform] = newPlatformWindows(func() *platformTracker {
+			return newPlatformTracker(
+				platform,
+				s.charactersLoaders[platform],
+				s.vehiclesLoaders[platform],
+				s.itemsLoaders[platform],
+				s.facilityLoaders[platform],
+				s.outfitsLoaders[platform],
+				s.achievementsLoaders[platform],
+			)
+		})
 	}
+	return s
 }
 
 func (s *StatsTracker) Start(ctx context.Context) {
+	if err := s.restoreSessions(ctx); err != nil {
+		s.log.Error(ctx, "failed to restore sessions", sl.Err(err))
+	}
 	ticker := time.NewTicker(1 * time.Minute)
 	defer ticker.Stop()
+	updateTicker := time.NewTicker(s.updateInterval)
+	defer updateTicker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			s.wg.Wait()
 			return
+		case now := <-updateTicker.C:
+			s.publishUpdates(ctx, now)
 		case now := <-ticker.C:
 			if err := s.handleTrackersOvertime(ctx); err != nil {
 				s.log.Error(ctx, "error during handleTrackersOvertime", sl.Err(err))
 			}
 			s.invalidateForceTrackers(now)
 			s.invalidateStatsTrackers(ctx, now)
+			s.invalidateAutoStartedTrackers(ctx, now)
+			if err := s.SaveSessions(ctx); err != nil {
+				s.log.Error(ctx, "failed to save sessions", sl.Err(err))
+			}
 		}
 	}
 }
 
-func (s *StatsTracker) StartChannelTracker(ctx context.Context, channelId discord.ChannelId) error {
-	return s.startChannelTracker(ctx, channelId, true)
+func (s *StatsTracker) SaveSessions(ctx context.Context) error {
+	s.savesMu.Lock()
+	defer s.savesMu.Unlock()
+	now := time.Now()
+	s.trackersMu.RLock()
+	sessions := make([]Session, 0, len(s.trackers))
+	for channelId, tracker := range s.trackers {
+		sessions = append(sessions, tracker.toSession(channelId, s.channelTrackers(tracker, now), now))
+	}
+	s.trackersMu.RUnlock()
+	var errs []error
+	for _, session := range sessions {
+		if err := s.sessionsRepo.SaveStatsTrackerSession(ctx, session); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save session %d: %w", session.Id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *StatsTracker) StartChannelTracker(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	filter discord.StatsTrackerFilter,
+) error {
+	return s.startChannelTracker(ctx, channelId, ManualSession, 0, filter)
 }
 
 func (s *StatsTracker) StopChannelTracker(ctx context.Context, channelId discord.ChannelId) error {
@@ -120,76 +236,476 @@ func (s *StatsTracker) HandleGainExperienceEvent(ctx context.Context, platform p
 	}()
 }
 
-func (s *StatsTracker) startChannelTracker(ctx context.Context, channelId discord.ChannelId, force bool) error {
+func (s *StatsTracker) HandleVehicleDestroyEvent(ctx context.Context, platform ps2_platforms.Platform, event events.VehicleDestroy) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.handleVehicleDestroyEvent(ctx, platform, event); err != nil {
+			s.log.Error(ctx, "error during handleVehicleDestroyEvent", sl.Err(err))
+		}
+	}()
+}
+
+func (s *StatsTracker) HandlePlayerFacilityCaptureEvent(ctx context.Context, platform ps2_platforms.Platform, event events.PlayerFacilityCapture) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.handleCharacterUpdate(
+			ctx,
+			platform,
+			ps2.CharacterId(event.CharacterID),
+			addFacilityCapture(ps2.FacilityId(event.FacilityID)),
+		); err != nil {
+			s.log.Error(ctx, "error during handlePlayerFacilityCaptureEvent", sl.Err(err))
+		}
+	}()
+}
+
+func (s *StatsTracker) HandlePlayerFacilityDefendEvent(ctx context.Context, platform ps2_platforms.Platform, event events.PlayerFacilityDefend) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.handleCharacterUpdate(
+			ctx,
+			platform,
+			ps2.CharacterId(event.CharacterID),
+			addFacilityDefense(ps2.FacilityId(event.FacilityID)),
+		); err != nil {
+			s.log.Error(ctx, "error during handlePlayerFacilityDefendEvent", sl.Err(err))
+		}
+	}()
+}
+
+func (s *StatsTracker) HandleBattleRankUpEvent(ctx context.Context, platform ps2_platforms.Platform, event events.BattleRankUp) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.handleBattleRankUpEvent(ctx, platform, event); err != nil {
+			s.log.Error(ctx, "error during handleBattleRankUpEvent", sl.Err(err))
+		}
+	}()
+}
+
+func (s *StatsTracker) HandleAchievementEarnedEvent(ctx context.Context, platform ps2_platforms.Platform, event events.AchievementEarned) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.handleCharacterUpdate(
+			ctx,
+			platform,
+			ps2.CharacterId(event.CharacterID),
+			addAchievement(ps2.AchievementId(event.AchievementID)),
+		); err != nil {
+			s.log.Error(ctx, "error during handleAchievementEarnedEvent", sl.Err(err))
+		}
+	}()
+}
+
+func (s *StatsTracker) HandlePlayerLogin(ctx context.Context, platform ps2_platforms.Platform, characterId ps2.CharacterId) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.handleOnlineStatusChange(ctx, platform, characterId, (*platformTracker).login); err != nil {
+			s.log.Error(ctx, "error during handlePlayerLogin", sl.Err(err))
+		}
+	}()
+}
+
+func (s *StatsTracker) HandlePlayerLogout(ctx context.Context, platform ps2_platforms.Platform, characterId ps2.CharacterId) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.handleOnlineStatusChange(ctx, platform, characterId, (*platformTracker).logout); err != nil {
+			s.log.Error(ctx, "error during handlePlayerLogout", sl.Err(err))
+		}
+	}()
+}
+
+func (s *StatsTracker) startChannelTracker(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	origin SessionOrigin,
+	taskId discord.StatsTrackerTaskId,
+	filter discord.StatsTrackerFilter,
+) error {
+	force := origin == ManualSession
 	// Started manually before scheduled task
 	if !force && s.isForceStopped(channelId) {
 		return nil
 	}
-	if s.isRunning(channelId) {
+	if !s.reserveChannel(channelId) {
 		return ErrChannelStatsTrackerIsAlreadyStarted
 	}
-	trackablePlatforms, err := s.trackablePlatformsLoader(ctx, channelId)
+	defer s.releaseChannel(channelId)
+	trackablePlatforms, filters, err := s.trackablePlatforms(ctx, channelId, filter, force)
 	if err != nil {
 		return err
 	}
 	if len(trackablePlatforms) == 0 {
 		return ErrNothingToTrack
 	}
-	trackers := make(map[ps2_platforms.Platform]*platformTracker, len(trackablePlatforms))
-	for _, platform := range trackablePlatforms {
-		trackers[platform] = newPlatformTracker(platform, s.charactersLoaders[platform])
-	}
 	now := time.Now()
+	sessionId, err := s.sessionsRepo.CreateStatsTrackerSession(ctx, channelId, trackablePlatforms, now, origin, taskId, filter)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
 	s.trackersMu.Lock()
+	platforms := make(map[ps2_platforms.Platform]*platformSession, len(trackablePlatforms))
+	for _, platform := range trackablePlatforms {
+		if windows, ok := s.windows[platform]; ok {
+			s.splitWindow(platform, now)
+			platforms[platform] = newPlatformSession(windows.newTracker(), filters[platform])
+		}
+	}
 	s.trackers[channelId] = channelTracker{
+		sessionId: sessionId,
 		startedAt: now,
-		trackers:  trackers,
+		origin:    origin,
+		taskId:    taskId,
+		filter:    filter,
+		platforms: platforms,
 	}
 	s.trackersMu.Unlock()
 	s.addStarted(channelId, force)
 	s.publisher.Publish(ChannelTrackerStarted{
+		SessionId: sessionId,
 		ChannelId: channelId,
 		StartedAt: now,
 	})
 	return nil
 }
 
+// Without the filter the session tracks every platform of the channel.
+// Unknown names of the filter are rejected only when `strict` is set,
+// so scheduled sessions are started with the rest of the filter
+func (s *StatsTracker) trackablePlatforms(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	filter discord.StatsTrackerFilter,
+	strict bool,
+) ([]ps2_platforms.Platform, map[ps2_platforms.Platform]*tracking.Settings, error) {
+	if len(filter) == 0 {
+		platforms, err := s.trackablePlatformsLoader(ctx, channelId)
+		return platforms, nil, err
+	}
+	settings, unknown, err := s.filterLoader(ctx, channelId, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load filter: %w", err)
+	}
+	if len(unknown) > 0 {
+		if strict {
+			return nil, nil, ErrUnknownFilterNames{Names: unknown}
+		}
+		s.log.Warn(
+			ctx,
+			"filter names are not tracked by the channel",
+			slog.String("channel_id", string(channelId)),
+			slog.Any("names", unknown),
+		)
+	}
+	platforms := make([]ps2_platforms.Platform, 0, len(settings))
+	filters := make(map[ps2_platforms.Platform]*tracking.Settings, len(settings))
+	for platform, platformSettings := range settings {
+		platforms = append(platforms, platform)
+		filters[platform] = &platformSettings
+	}
+	return platforms, filters, nil
+}
+
 func (s *StatsTracker) stopChannelTracker(ctx context.Context, channelId discord.ChannelId, force bool) error {
 	if !force && s.isForceStarted(channelId) {
 		return nil
 	}
 	s.addStopped(channelId, force)
-	pt, ok := s.popTracker(channelId)
+	stoppedAt := time.Now()
+	s.savesMu.Lock()
+	pt, trackers, ok := s.popTracker(channelId, stoppedAt)
+	if ok {
+		session := pt.toSession(channelId, trackers, stoppedAt)
+		session.StoppedAt = stoppedAt
+		if err := s.sessionsRepo.SaveStatsTrackerSession(ctx, session); err != nil {
+			s.log.Error(ctx, "failed to save session", slog.String("channel_id", string(channelId)), sl.Err(err))
+		}
+	}
+	s.savesMu.Unlock()
 	if !ok {
 		return ErrNoChannelTrackerToStop
 	}
-	stoppedAt := time.Now()
-	stats := make(map[ps2_platforms.Platform]PlatformStats, len(pt.trackers))
-	for platform, tracker := range pt.trackers {
+	stats := make(map[ps2_platforms.Platform]PlatformStats, len(trackers))
+	for platform, tracker := range trackers {
 		var err error
 		stats[platform], err = tracker.toStats(ctx, stoppedAt)
 		if err != nil {
 			s.log.Warn(ctx, "failed to get stats for platform", slog.String("channel_id", string(channelId)), slog.String("platform", string(platform)), sl.Err(err))
 		}
 	}
+	var previous *PreviousSession
+	if pt.taskId != 0 {
+		var err error
+		previous, err = s.previousSession(ctx, channelId, pt.taskId, pt.sessionId)
+		if err != nil && !errors.Is(err, shared.ErrNotFound) {
+			s.log.Warn(ctx, "failed to load previous session", slog.String("channel_id", string(channelId)), sl.Err(err))
+		}
+	}
 	s.publisher.Publish(ChannelTrackerStopped{
+		SessionId: pt.sessionId,
 		ChannelId: channelId,
 		StartedAt: pt.startedAt,
 		StoppedAt: stoppedAt,
 		Platforms: stats,
+		Previous:  previous,
 	})
 	return nil
 }
 
-func (s *StatsTracker) popTracker(channelId discord.ChannelId) (channelTracker, bool) {
+// Computes stats of the stored session
+func (s *StatsTracker) SessionStats(
+	ctx context.Context,
+	session Session,
+) (map[ps2_platforms.Platform]PlatformStats, error) {
+	snapshots := make(map[ps2_platforms.Platform][]CharacterSnapshot, len(session.Platforms))
+	for _, snapshot := range session.Characters {
+		snapshots[snapshot.Platform] = append(snapshots[snapshot.Platform], snapshot)
+	}
+	stats := make(map[ps2_platforms.Platform]PlatformStats, len(session.Platforms))
+	var errs []error
+	for _, platform := range session.Platforms {
+		tracker := newPlatformTrackerFromSnapshots(
+			platform,
+			s.charactersLoaders[platform],
+			s.vehiclesLoaders[platform],
+			s.itemsLoaders[platform],
+			s.facilityLoaders[platform],
+			s.outfitsLoaders[platform],
+			s.achievementsLoaders[platform],
+			snapshots[platform],
+		)
+		var err error
+		stats[platform], err = tracker.toStats(ctx, session.StoppedAt)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get stats for platform %q: %w", platform, err))
+		}
+	}
+	return stats, errors.Join(errs...)
+}
+
+func (s *StatsTracker) previousSession(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	taskId discord.StatsTrackerTaskId,
+	sessionId SessionId,
+) (*PreviousSession, error) {
+	session, err := s.sessionsRepo.PreviousScheduledStatsTrackerSession(ctx, channelId, taskId, sessionId)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[ps2_platforms.Platform][]ps2.CharacterId, len(session.Platforms))
+	for _, c := range session.Characters {
+		ids[c.Platform] = append(ids[c.Platform], c.CharacterId)
+	}
+	previous := &PreviousSession{
+		Id:        session.Id,
+		StartedAt: session.StartedAt,
+		StoppedAt: session.StoppedAt,
+		Platforms: make(map[ps2_platforms.Platform]PreviousPlatformStats, len(session.Platforms)),
+	}
+	var errs []error
+	characters := make(map[ps2_platforms.Platform]map[ps2.CharacterId]ps2.Character, len(ids))
+	for platform, platformIds := range ids {
+		charactersLoader, ok := s.charactersLoaders[platform]
+		if !ok {
+			continue
+		}
+		chars, err := charactersLoader(ctx, platformIds)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to load %q characters: %w", platform, err))
+		}
+		characters[platform] = chars
+	}
+	for _, c := range session.Characters {
+		stats, ok := previous.Platforms[c.Platform]
+		if !ok {
+			stats = PreviousPlatformStats{
+				Characters: make(map[ps2.CharacterId]Totals),
+				Outfits:    make(map[ps2.OutfitId]Totals),
+			}
+			previous.Platforms[c.Platform] = stats
+		}
+		totals := c.Totals()
+		stats.Characters[c.CharacterId] = totals
+		if char, ok := characters[c.Platform][c.CharacterId]; ok && char.OutfitId != "" {
+			stats.Outfits[char.OutfitId] = stats.Outfits[char.OutfitId].Add(totals)
+		}
+	}
+	return previous, errors.Join(errs...)
+}
+
+func (s *StatsTracker) restoreSessions(ctx context.Context) error {
+	sessions, err := s.sessionsRepo.ActiveStatsTrackerSessions(ctx)
+	if err != nil {
+		return err
+	}
+	filters := make(map[SessionId]map[ps2_platforms.Platform]*tracking.Settings, len(sessions))
+	for _, session := range sessions {
+		if len(session.Filter) == 0 {
+			continue
+		}
+		_, sessionFilters, err := s.trackablePlatforms(ctx, session.ChannelId, session.Filter, false)
+		if err != nil {
+			s.log.Warn(ctx, "failed to restore session filter", slog.Int64("session_id", int64(session.Id)), sl.Err(err))
+		}
+		filters[session.Id] = sessionFilters
+	}
+	now := time.Now()
+	s.trackersMu.Lock()
+	defer s.trackersMu.Unlock()
+	for _, session := range sessions {
+		snapshots := make(map[ps2_platforms.Platform][]CharacterSnapshot, len(session.Platforms))
+		for _, snapshot := range session.Characters {
+			snapshots[snapshot.Platform] = append(snapshots[snapshot.Platform], snapshot)
+		}
+		platforms := make(map[ps2_platforms.Platform]*platformSession, len(session.Platforms))
+		for _, platform := range session.Platforms {
+			if _, ok := s.windows[platform]; !ok {
+				continue
+			}
+			var filter *tracking.Settings
+			if len(session.Filter) > 0 {
+				// Only already joined characters are tracked
+				// if the filter is no longer matching the platform
+				filter = &tracking.Settings{}
+				if f, ok := filters[session.Id][platform]; ok {
+					filter = f
+				}
+			}
+			s.splitWindow(platform, now)
+			ps := newPlatformSession(newPlatformTrackerFromSnapshots(
+				platform,
+				s.charactersLoaders[platform],
+				s.vehiclesLoaders[platform],
+				s.itemsLoaders[platform],
+				s.facilityLoaders[platform],
+				s.outfitsLoaders[platform],
+				s.achievementsLoaders[platform],
+				snapshots[platform],
+			), filter)
+			for _, snapshot := range snapshots[platform] {
+				ps.characterIds[snapshot.CharacterId] = struct{}{}
+			}
+			platforms[platform] = ps
+		}
+		s.trackers[session.ChannelId] = channelTracker{
+			sessionId: session.Id,
+			startedAt: session.StartedAt,
+			origin:    session.Origin,
+			taskId:    session.TaskId,
+			filter:    session.Filter,
+			platforms: platforms,
+		}
+		switch session.Origin {
+		case ScheduledSession:
+			// Stopped with the next invalidation if the task
+			// is no longer active, otherwise it is not started again
+			s.scheduledTrackers = append(s.scheduledTrackers, session.ChannelId)
+		case AutoSession:
+			// Restored sessions are stopped by the auto start once the online drops
+			s.autoStarts[session.ChannelId] = autoStartState{
+				sessionId: session.Id,
+				started:   true,
+			}
+		case ManualSession:
+			s.restoreStarted(session.ChannelId, session.StartedAt)
+		}
+		s.log.Info(
+			ctx,
+			"session restored",
+			slog.String("channel_id", string(session.ChannelId)),
+			slog.Int64("session_id", int64(session.Id)),
+		)
+	}
+	return nil
+}
+
+func (s *StatsTracker) publishUpdates(ctx context.Context, now time.Time) {
+	s.trackersMu.RLock()
+	channelTrackers := maps.Clone(s.trackers)
+	trackers := make(map[discord.ChannelId]map[ps2_platforms.Platform]*platformTracker, len(channelTrackers))
+	for channelId, ct := range channelTrackers {
+		trackers[channelId] = s.channelTrackers(ct, now)
+	}
+	s.trackersMu.RUnlock()
+	for channelId, ct := range channelTrackers {
+		stats := make(map[ps2_platforms.Platform]PlatformStats, len(trackers[channelId]))
+		for platform, tracker := range trackers[channelId] {
+			var err error
+			stats[platform], err = tracker.toStats(ctx, now)
+			if err != nil {
+				s.log.Warn(ctx, "failed to get stats for platform", slog.String("channel_id", string(channelId)), slog.String("platform", string(platform)), sl.Err(err))
+			}
+		}
+		s.publisher.Publish(ChannelTrackerUpdated{
+			ChannelId: channelId,
+			StartedAt: ct.startedAt,
+			UpdatedAt: now,
+			Platforms: stats,
+		})
+	}
+}
+
+// Stats of the running session, should be called under the trackers lock
+func (s *StatsTracker) channelTrackers(
+	ct channelTracker,
+	now time.Time,
+) map[ps2_platforms.Platform]*platformTracker {
+	trackers := make(map[ps2_platforms.Platform]*platformTracker, len(ct.platforms))
+	for platform, ps := range ct.platforms {
+		trackers[platform] = ps.tracker(s.windows[platform], now)
+	}
+	return trackers
+}
+
+// Closes windows of the tracker and returns its final stats
+func (s *StatsTracker) popTracker(
+	channelId discord.ChannelId,
+	now time.Time,
+) (channelTracker, map[ps2_platforms.Platform]*platformTracker, bool) {
 	s.trackersMu.Lock()
 	defer s.trackersMu.Unlock()
 	t, ok := s.trackers[channelId]
-	if ok {
-		delete(s.trackers, channelId)
-		return t, true
+	if !ok {
+		return channelTracker{}, nil, false
 	}
-	return channelTracker{}, false
+	trackers := make(map[ps2_platforms.Platform]*platformTracker, len(t.platforms))
+	for platform, ps := range t.platforms {
+		windows := s.windows[platform]
+		s.splitWindow(platform, now)
+		trackers[platform] = ps.tracker(windows, now)
+	}
+	delete(s.trackers, channelId)
+	for platform := range t.platforms {
+		used := false
+		for _, other := range s.trackers {
+			if _, ok := other.platforms[platform]; ok {
+				used = true
+				break
+			}
+		}
+		if !used {
+			s.windows[platform].reset()
+		}
+	}
+	return t, trackers, true
+}
+
+// Closes the current window of the platform, should be called under the trackers lock
+func (s *StatsTracker) splitWindow(platform ps2_platforms.Platform, now time.Time) {
+	s.windows[platform].split(now, func(closed *platformTracker) {
+		for _, ct := range s.trackers {
+			if ps, ok := ct.platforms[platform]; ok {
+				ps.fold(closed, now)
+			}
+		}
+	})
 }
 
 func (s *StatsTracker) handleTrackersOvertime(ctx context.Context) error {
@@ -212,6 +728,13 @@ func (s *StatsTracker) handleTrackersOvertime(ctx context.Context) error {
 
 func (s *StatsTracker) handleGainExperienceEvent(ctx context.Context, platform ps2_platforms.Platform, event events.GainExperience) error {
 	charId := ps2.CharacterId(event.CharacterID)
+	// Support actions are still counted without the score
+	amount, err := strconv.ParseUint(event.Amount, 10, 64)
+	if err != nil {
+		s.log.Warn(ctx, "cannot parse experience amount", slog.String("character_id", event.CharacterID), slog.String("amount", event.Amount), sl.Err(err))
+		amount = 0
+	}
+	action, isSupport := ps2_experience.GetAction(ps2_experience.Id(event.ExperienceID))
 	if channels, err := s.channelsLoader(ctx, platform, charId); err == nil {
 		s.handleCharacterEvent(
 			ctx,
@@ -219,7 +742,8 @@ func (s *StatsTracker) handleGainExperienceEvent(ctx context.Context, platform p
 			platform,
 			charId,
 			ps2_loadout.Loadout(event.LoadoutID),
-			updateLoadout,
+			ps2.ZoneId(event.ZoneID),
+			gainExperience(uint(amount), action, isSupport),
 		)
 	} else {
 		return fmt.Errorf("cannot get channels for character %q: %w", charId, err)
@@ -232,11 +756,19 @@ func (s *StatsTracker) handleDeathEvent(ctx context.Context, platform ps2_platfo
 	charId := ps2.CharacterId(event.CharacterID)
 	isDeathByRestrictedArea := event.AttackerCharacterID == "0"
 	isSuicide := event.AttackerCharacterID == event.CharacterID
-	deathAdder := addDeath
+	isTeamKill := event.AttackerTeamID == event.TeamID
+	zoneId := ps2.ZoneId(event.ZoneID)
+	deathAdder := combineUpdates(addDeath, addZoneDeath(zoneId))
 	if isSuicide {
-		deathAdder = addSuicide
+		deathAdder = combineUpdates(addSuicide, addZoneDeath(zoneId))
 	} else if isDeathByRestrictedArea {
-		deathAdder = addDeathByRestrictedArea
+		deathAdder = combineUpdates(addDeathByRestrictedArea, addZoneDeath(zoneId))
+	} else if !isTeamKill {
+		deathAdder = combineUpdates(
+			addDeath,
+			addZoneDeath(zoneId),
+			addRivalDeath(ps2.CharacterId(event.AttackerCharacterID)),
+		)
 	}
 	if channels, err := s.channelsLoader(ctx, platform, charId); err == nil {
 		s.handleCharacterEvent(
@@ -245,6 +777,7 @@ func (s *StatsTracker) handleDeathEvent(ctx context.Context, platform ps2_platfo
 			platform,
 			charId,
 			ps2_loadout.Loadout(event.CharacterLoadoutID),
+			zoneId,
 			deathAdder,
 		)
 	} else {
@@ -253,12 +786,14 @@ func (s *StatsTracker) handleDeathEvent(ctx context.Context, platform ps2_platfo
 	if isSuicide || isDeathByRestrictedArea {
 		return errors.Join(errs...)
 	}
+	victimId := charId
 	charId = ps2.CharacterId(event.AttackerCharacterID)
-	killAdder := addBodyKill
-	if event.AttackerTeamID == event.TeamID {
+	weaponId := ps2.ItemId(event.AttackerWeaponID)
+	killAdder := combineUpdates(addBodyKill(weaponId), addZoneKill(zoneId), addRivalKill(victimId))
+	if isTeamKill {
 		killAdder = addTeamKill
 	} else if event.IsHeadshot == "1" {
-		killAdder = addHeadShotKill
+		killAdder = combineUpdates(addHeadShotKill(weaponId), addZoneKill(zoneId), addRivalKill(victimId))
 	}
 	if channels, err := s.channelsLoader(ctx, platform, charId); err == nil {
 		s.handleCharacterEvent(
@@ -267,6 +802,7 @@ func (s *StatsTracker) handleDeathEvent(ctx context.Context, platform ps2_platfo
 			platform,
 			charId,
 			ps2_loadout.Loadout(event.AttackerLoadoutID),
+			zoneId,
 			killAdder,
 		)
 	} else {
@@ -275,12 +811,98 @@ func (s *StatsTracker) handleDeathEvent(ctx context.Context, platform ps2_platfo
 	return errors.Join(errs...)
 }
 
+func (s *StatsTracker) handleVehicleDestroyEvent(ctx context.Context, platform ps2_platforms.Platform, event events.VehicleDestroy) error {
+	vehicleId := ps2.VehicleId(event.VehicleID)
+	if vehicleId == ps2.NoVehicleId {
+		return nil
+	}
+	var errs []error
+	charId := ps2.CharacterId(event.CharacterID)
+	// Victim loadout is not provided by the event
+	if channels, err := s.channelsLoader(ctx, platform, charId); err == nil {
+		s.updatePlatformTracker(ctx, channels, platform, charId, func(tracker *platformTracker) {
+			tracker.updateCharacter(charId, addVehicleLost(vehicleId))
+		})
+	} else {
+		errs = append(errs, fmt.Errorf("cannot get channels for character %q: %w", charId, err))
+	}
+	if event.AttackerCharacterID == "0" ||
+		event.AttackerCharacterID == event.CharacterID ||
+		event.AttackerTeamID == event.TeamID {
+		return errors.Join(errs...)
+	}
+	charId = ps2.CharacterId(event.AttackerCharacterID)
+	if channels, err := s.channelsLoader(ctx, platform, charId); err == nil {
+		s.handleCharacterEvent(
+			ctx,
+			channels,
+			platform,
+			charId,
+			ps2_loadout.Loadout(event.AttackerLoadoutID),
+			ps2.ZoneId(event.ZoneID),
+			addVehicleDestroyed(vehicleId),
+		)
+	} else {
+		errs = append(errs, fmt.Errorf("cannot get channels for character %q: %w", charId, err))
+	}
+	return errors.Join(errs...)
+}
+
+func (s *StatsTracker) handleBattleRankUpEvent(ctx context.Context, platform ps2_platforms.Platform, event events.BattleRankUp) error {
+	battleRank, err := strconv.ParseUint(event.BattleRank, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse battle rank %q: %w", event.BattleRank, err)
+	}
+	return s.handleCharacterUpdate(
+		ctx,
+		platform,
+		ps2.CharacterId(event.CharacterID),
+		addBattleRankUp(uint(battleRank)),
+	)
+}
+
+func (s *StatsTracker) handleCharacterUpdate(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	charId ps2.CharacterId,
+	update func(*characterTracker),
+) error {
+	channels, err := s.channelsLoader(ctx, platform, charId)
+	if err != nil {
+		return fmt.Errorf("cannot get channels for character %q: %w", charId, err)
+	}
+	s.updatePlatformTracker(ctx, channels, platform, charId, func(tracker *platformTracker) {
+		tracker.updateCharacter(charId, update)
+	})
+	return nil
+}
+
+// Event times are not used since the tracker time is
+// the reference for the tracking window
+func (s *StatsTracker) handleOnlineStatusChange(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	charId ps2.CharacterId,
+	change func(*platformTracker, ps2.CharacterId, time.Time),
+) error {
+	channels, err := s.channelsLoader(ctx, platform, charId)
+	if err != nil {
+		return fmt.Errorf("cannot get channels for character %q: %w", charId, err)
+	}
+	now := time.Now()
+	s.updatePlatformTracker(ctx, channels, platform, charId, func(tracker *platformTracker) {
+		change(tracker, charId, now)
+	})
+	return nil
+}
+
 func (s *StatsTracker) handleCharacterEvent(
 	ctx context.Context,
 	channels []discord.ChannelId,
 	platform ps2_platforms.Platform,
 	characterId ps2.CharacterId,
 	loadout ps2_loadout.Loadout,
+	zoneId ps2.ZoneId,
 	update func(*characterTracker),
 ) {
 	if len(channels) == 0 {
@@ -291,23 +913,100 @@ func (s *StatsTracker) handleCharacterEvent(
 		s.log.Warn(ctx, "cannot get loadout type, falling back to heavy assault", sl.Err(err))
 		loadoutType = ps2_loadout.HeavyAssault
 	}
+	s.updatePlatformTracker(ctx, channels, platform, characterId, func(tracker *platformTracker) {
+		tracker.handleCharacterEvent(characterId, loadoutType, zoneId, update)
+	})
+}
+
+// The shared window is updated once for all channels
+// that are tracking the character
+func (s *StatsTracker) updatePlatformTracker(
+	ctx context.Context,
+	channels []discord.ChannelId,
+	platform ps2_platforms.Platform,
+	characterId ps2.CharacterId,
+	fn func(*platformTracker),
+) {
+	if len(channels) == 0 {
+		return
+	}
+	outfitId, err := s.characterOutfit(ctx, channels, platform, characterId)
+	if err != nil {
+		s.log.Warn(ctx, "failed to load character outfit", slog.String("character_id", string(characterId)), sl.Err(err))
+	}
 	s.trackersMu.RLock()
 	defer s.trackersMu.RUnlock()
+	tracked := false
+	var joining []*platformSession
 	for _, channelId := range channels {
-		if platformsTracker, ok := s.trackers[channelId]; ok {
-			if tracker, ok := platformsTracker.trackers[platform]; ok {
-				tracker.handleCharacterEvent(characterId, loadoutType, update)
+		if ct, ok := s.trackers[channelId]; ok {
+			if ps, ok := ct.platforms[platform]; ok {
+				accepted, joined := ps.accepts(characterId, outfitId)
+				tracked = tracked || accepted
+				if accepted && !joined {
+					joining = append(joining, ps)
+				}
 			}
 		}
 	}
+	if !tracked {
+		return
+	}
+	windows := s.windows[platform]
+	if len(joining) > 0 {
+		windows.splitCharacter(characterId, time.Now(), func(closed *characterTracker) {
+			// Counters before the join belong only to the sessions
+			// the character was already joined to
+			if closed != nil {
+				for _, ct := range s.trackers {
+					if ps, ok := ct.platforms[platform]; ok {
+						ps.foldCharacter(characterId, closed)
+					}
+				}
+			}
+			for _, ps := range joining {
+				ps.join(characterId)
+			}
+		})
+	}
+	windows.update(fn)
+}
+
+// The outfit is loaded only if it is required by the filter of the session
+func (s *StatsTracker) characterOutfit(
+	ctx context.Context,
+	channels []discord.ChannelId,
+	platform ps2_platforms.Platform,
+	characterId ps2.CharacterId,
+) (ps2.OutfitId, error) {
+	s.trackersMu.RLock()
+	required := slices.ContainsFunc(channels, func(channelId discord.ChannelId) bool {
+		ps, ok := s.trackers[channelId].platforms[platform]
+		return ok && ps.requiresOutfit(characterId)
+	})
+	s.trackersMu.RUnlock()
+	if !required {
+		return "", nil
+	}
+	characters, err := s.charactersLoaders[platform](ctx, []ps2.CharacterId{characterId})
+	if err != nil {
+		return "", err
+	}
+	return characters[characterId].OutfitId, nil
 }
 
 func (s *StatsTracker) invalidateStatsTrackers(ctx context.Context, now time.Time) {
-	newTasks, err := s.tasksLoader(ctx, now)
+	tasks, err := s.tasksLoader(ctx, now)
 	if err != nil {
 		s.log.Error(ctx, "error loading tasks", sl.Err(err))
 		return
 	}
+	newTasks := make([]discord.ChannelId, 0, len(tasks))
+	channelTasks := make(map[discord.ChannelId]discord.StatsTrackerTask, len(tasks))
+	for _, task := range tasks {
+		newTasks = append(newTasks, task.ChannelId)
+		channelTasks[task.ChannelId] = task
+	}
 	d := diff.SlicesDiff(s.scheduledTrackers, newTasks)
 	for _, channelId := range d.ToDel {
 		if err := s.stopChannelTracker(ctx, channelId, false); err != nil {
@@ -315,13 +1014,34 @@ func (s *StatsTracker) invalidateStatsTrackers(ctx context.Context, now time.Tim
 		}
 	}
 	for _, channelId := range d.ToAdd {
-		if err := s.startChannelTracker(ctx, channelId, false); err != nil {
+		task := channelTasks[channelId]
+		if err := s.startChannelTracker(ctx, channelId, ScheduledSession, task.Id, task.Filter); err != nil {
 			s.log.Error(ctx, "failed to start channel tracker", sl.Err(err))
 		}
 	}
 	s.scheduledTrackers = newTasks
 }
 
+// Prevents concurrent starts from creating several sessions of the channel
+func (s *StatsTracker) reserveChannel(channelId discord.ChannelId) bool {
+	s.trackersMu.Lock()
+	defer s.trackersMu.Unlock()
+	if _, ok := s.trackers[channelId]; ok {
+		return false
+	}
+	if _, ok := s.starting[channelId]; ok {
+		return false
+	}
+	s.starting[channelId] = struct{}{}
+	return true
+}
+
+func (s *StatsTracker) releaseChannel(channelId discord.ChannelId) {
+	s.trackersMu.Lock()
+	defer s.trackersMu.Unlock()
+	delete(s.starting, channelId)
+}
+
 func (s *StatsTracker) isRunning(channelId discord.ChannelId) bool {
 	s.trackersMu.RLock()
 	defer s.trackersMu.RUnlock()
@@ -346,6 +1066,14 @@ func (s *StatsTracker) addStarted(channelId discord.ChannelId, force bool) {
 	s.forceStopped.Remove(channelId)
 }
 
+// Manually started sessions are not stopped by the schedule
+// until the flag expires as it would without the restart
+func (s *StatsTracker) restoreStarted(channelId discord.ChannelId, startedAt time.Time) {
+	s.forceMu.Lock()
+	defer s.forceMu.Unlock()
+	s.forceStarted.PushAt(channelId, startedAt)
+}
+
 func (s *StatsTracker) isForceStopped(channelId discord.ChannelId) bool {
 	s.forceMu.RLock()
 	defer s.forceMu.RUnlock()
@@ -0,0 +1,762 @@
+package stats_tracker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/discord"
+	"github.com/x0k/ps2-spy/internal/lib/census2/streaming/events"
+	"github.com/x0k/ps2-spy/internal/lib/logger"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/shared"
+)
+
+type publisherFunc func(Event)
+
+func (f publisherFunc) Publish(event Event) {
+	f(event)
+}
+
+type sessionsRepoMock struct {
+	mu      sync.Mutex
+	active  []Session
+	created int
+	saved   []Session
+	// Zero value if there is no previous session
+	previous Session
+}
+
+func (r *sessionsRepoMock) CreateStatsTrackerSession(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	platforms []ps2_platforms.Platform,
+	startedAt time.Time,
+	origin SessionOrigin,
+	taskId discord.StatsTrackerTaskId,
+	filter discord.StatsTrackerFilter,
+) (SessionId, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.created++
+	return SessionId(100 + r.created), nil
+}
+
+func (r *sessionsRepoMock) SaveStatsTrackerSession(ctx context.Context, session Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.saved = append(r.saved, session)
+	return nil
+}
+
+func (r *sessionsRepoMock) ActiveStatsTrackerSessions(ctx context.Context) ([]Session, error) {
+	return r.active, nil
+}
+
+func (r *sessionsRepoMock) PreviousScheduledStatsTrackerSession(
+	context.Context, discord.ChannelId, discord.StatsTrackerTaskId, SessionId,
+) (Session, error) {
+	if r.previous.Id == 0 {
+		return Session{}, shared.ErrNotFound
+	}
+	return r.previous, nil
+}
+
+func emptyCharactersLoader(context.Context, []ps2.CharacterId) (map[ps2.CharacterId]ps2.Character, error) {
+	return map[ps2.CharacterId]ps2.Character{}, nil
+}
+
+func emptyVehiclesLoader(context.Context, []ps2.VehicleId) (map[ps2.VehicleId]ps2.Vehicle, error) {
+	return map[ps2.VehicleId]ps2.Vehicle{}, nil
+}
+
+func emptyItemsLoader(context.Context, []ps2.ItemId) (map[ps2.ItemId]ps2.Item, error) {
+	return map[ps2.ItemId]ps2.Item{}, nil
+}
+
+func namedFacilityLoader(_ context.Context, facilityId ps2.FacilityId) (ps2.Facility, error) {
+	return ps2.Facility{Id: facilityId, Name: "Facility " + string(facilityId)}, nil
+}
+
+func emptyOutfitsLoader(context.Context, []ps2.OutfitId) (map[ps2.OutfitId]ps2.Outfit, error) {
+	return map[ps2.OutfitId]ps2.Outfit{}, nil
+}
+
+func emptyAchievementsLoader(context.Context, []ps2.AchievementId) (map[ps2.AchievementId]ps2.Achievement, error) {
+	return map[ps2.AchievementId]ps2.Achievement{}, nil
+}
+
+// Loaders are added after the tracker is created like in the app wiring
+func newTestStatsTracker(repo SessionsRepo, tasks *[]discord.StatsTrackerTask) *StatsTracker {
+	charactersLoaders := make(map[ps2_platforms.Platform]CharactersLoader)
+	vehiclesLoaders := make(map[ps2_platforms.Platform]VehiclesLoader)
+	itemsLoaders := make(map[ps2_platforms.Platform]ItemsLoader)
+	facilityLoaders := make(map[ps2_platforms.Platform]FacilityLoader)
+	outfitsLoaders := make(map[ps2_platforms.Platform]OutfitsLoader)
+	achievementsLoaders := make(map[ps2_platforms.Platform]AchievementsLoader)
+	s := New(
+		logger.New(slog.New(slog.DiscardHandler)),
+		publisherFunc(func(Event) {}),
+		nil,
+		func(context.Context, discord.ChannelId) ([]ps2_platforms.Platform, error) {
+			return []ps2_platforms.Platform{ps2_platforms.PC}, nil
+		},
+		nil,
+		func(context.Context, time.Time) ([]discord.StatsTrackerTask, error) {
+			return *tasks, nil
+		},
+		nil,
+		nil,
+		charactersLoaders,
+		vehiclesLoaders,
+		itemsLoaders,
+		facilityLoaders,
+		outfitsLoaders,
+		achievementsLoaders,
+		repo,
+		time.Hour,
+		time.Minute,
+	)
+	for _, platform := range ps2_platforms.Platforms {
+		charactersLoaders[platform] = emptyCharactersLoader
+		vehiclesLoaders[platform] = emptyVehiclesLoader
+		itemsLoaders[platform] = emptyItemsLoader
+		facilityLoaders[platform] = namedFacilityLoader
+		outfitsLoaders[platform] = emptyOutfitsLoader
+		achievementsLoaders[platform] = emptyAchievementsLoader
+	}
+	return s
+}
+
+func TestStatsTrackerRestoreSessions(t *testing.T) {
+	const channelId discord.ChannelId = "channel"
+	task := discord.StatsTrackerTask{Id: 1, ChannelId: channelId}
+	startedAt := time.Now().Add(-10 * time.Minute)
+	cases := []struct {
+		name    string
+		origin  SessionOrigin
+		ticks   [][]discord.StatsTrackerTask
+		running bool
+		stopped int
+		created int
+	}{
+		{
+			name:    "scheduled session of the ended task is stopped",
+			origin:  ScheduledSession,
+			ticks:   [][]discord.StatsTrackerTask{nil},
+			running: false,
+			stopped: 1,
+		},
+		{
+			name:    "scheduled session of the active task keeps running",
+			origin:  ScheduledSession,
+			ticks:   [][]discord.StatsTrackerTask{{task}, {task}},
+			running: true,
+		},
+		{
+			name:    "scheduled session is stopped when the task ends",
+			origin:  ScheduledSession,
+			ticks:   [][]discord.StatsTrackerTask{{task}, nil},
+			running: false,
+			stopped: 1,
+		},
+		{
+			name:    "manual session is not stopped by the schedule",
+			origin:  ManualSession,
+			ticks:   [][]discord.StatsTrackerTask{{task}, nil},
+			running: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			repo := &sessionsRepoMock{
+				active: []Session{{
+					Id:        1,
+					ChannelId: channelId,
+					StartedAt: startedAt,
+					Origin:    c.origin,
+					TaskId:    task.Id,
+				}},
+			}
+			var tasks []discord.StatsTrackerTask
+			s := newTestStatsTracker(repo, &tasks)
+			ctx := context.Background()
+			if err := s.restoreSessions(ctx); err != nil {
+				t.Fatalf("restoreSessions() error = %v", err)
+			}
+			for _, tick := range c.ticks {
+				tasks = tick
+				s.invalidateStatsTrackers(ctx, time.Now())
+			}
+			if running := s.isRunning(channelId); running != c.running {
+				t.Errorf("isRunning() = %v, want %v", running, c.running)
+			}
+			stopped := 0
+			for _, session := range repo.saved {
+				if !session.StoppedAt.IsZero() {
+					stopped++
+				}
+			}
+			if stopped != c.stopped {
+				t.Errorf("stopped sessions = %d, want %d", stopped, c.stopped)
+			}
+			if repo.created != c.created {
+				t.Errorf("created sessions = %d, want %d", repo.created, c.created)
+			}
+		})
+	}
+}
+
+func TestStatsTrackerSaveSessionsAfterStop(t *testing.T) {
+	const channelId discord.ChannelId = "channel"
+	repo := &sessionsRepoMock{
+		active: []Session{{
+			Id:        1,
+			ChannelId: channelId,
+			StartedAt: time.Now(),
+			Origin:    ManualSession,
+		}},
+	}
+	var tasks []discord.StatsTrackerTask
+	s := newTestStatsTracker(repo, &tasks)
+	ctx := context.Background()
+	if err := s.restoreSessions(ctx); err != nil {
+		t.Fatalf("restoreSessions() error = %v", err)
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := s.SaveSessions(ctx); err != nil {
+			t.Errorf("SaveSessions() error = %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := s.StopChannelTracker(ctx, channelId); err != nil {
+			t.Errorf("StopChannelTracker() error = %v", err)
+		}
+	}()
+	wg.Wait()
+	if len(repo.saved) == 0 {
+		t.Fatal("expected the session to be saved")
+	}
+	if last := repo.saved[len(repo.saved)-1]; last.StoppedAt.IsZero() {
+		t.Errorf("the final save is overwritten by the running session")
+	}
+}
+
+func TestStatsTrackerTracksPlatformsWithLoadersAddedAfterNew(t *testing.T) {
+	const channelId discord.ChannelId = "channel"
+	const characterId ps2.CharacterId = "character"
+	var tasks []discord.StatsTrackerTask
+	s := newTestStatsTracker(&sessionsRepoMock{}, &tasks)
+	s.trackablePlatformsLoader = func(context.Context, discord.ChannelId) ([]ps2_platforms.Platform, error) {
+		return ps2_platforms.Platforms, nil
+	}
+	ctx := context.Background()
+	if err := s.StartChannelTracker(ctx, channelId, nil); err != nil {
+		t.Fatalf("StartChannelTracker() error = %v", err)
+	}
+	for _, platform := range ps2_platforms.Platforms {
+		s.updatePlatformTracker(ctx, []discord.ChannelId{channelId}, platform, characterId, func(tracker *platformTracker) {
+			tracker.updateCharacter(characterId, addBodyKill("weapon"))
+		})
+	}
+	_, trackers, ok := s.popTracker(channelId, time.Now())
+	if !ok {
+		t.Fatal("expected the tracker to be running")
+	}
+	for _, platform := range ps2_platforms.Platforms {
+		tracker, ok := trackers[platform]
+		if !ok {
+			t.Errorf("platform %q is not tracked", platform)
+			continue
+		}
+		if character, ok := tracker.characters[characterId]; !ok || character.bodyKills != 1 {
+			t.Errorf("platform %q: expected 1 kill of the character", platform)
+		}
+		if tracker.charactersLoader == nil {
+			t.Errorf("platform %q: characters loader is not resolved", platform)
+		}
+	}
+}
+
+// Blocks the session creation until the test releases it
+type blockingSessionsRepo struct {
+	sessionsRepoMock
+	creating chan struct{}
+	release  chan struct{}
+}
+
+func (r *blockingSessionsRepo) CreateStatsTrackerSession(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	platforms []ps2_platforms.Platform,
+	startedAt time.Time,
+	origin SessionOrigin,
+	taskId discord.StatsTrackerTaskId,
+	filter discord.StatsTrackerFilter,
+) (SessionId, error) {
+	r.creating <- struct{}{}
+	<-r.release
+	return r.sessionsRepoMock.CreateStatsTrackerSession(ctx, channelId, platforms, startedAt, origin, taskId, filter)
+}
+
+func TestStatsTrackerConcurrentStarts(t *testing.T) {
+	const channelId discord.ChannelId = "channel"
+	repo := &blockingSessionsRepo{
+		creating: make(chan struct{}),
+		release:  make(chan struct{}),
+	}
+	var tasks []discord.StatsTrackerTask
+	s := newTestStatsTracker(repo, &tasks)
+	ctx := context.Background()
+	first := make(chan error, 1)
+	go func() {
+		first <- s.StartChannelTracker(ctx, channelId, nil)
+	}()
+	<-repo.creating
+	second := make(chan error, 1)
+	go func() {
+		second <- s.StartChannelTracker(ctx, channelId, nil)
+	}()
+	select {
+	case err := <-second:
+		if err != ErrChannelStatsTrackerIsAlreadyStarted {
+			t.Errorf("second StartChannelTracker() error = %v, want %v", err, ErrChannelStatsTrackerIsAlreadyStarted)
+		}
+	case <-repo.creating:
+		t.Error("expected the second start to be rejected before the session is created")
+		close(repo.release)
+		<-first
+		<-second
+		return
+	}
+	close(repo.release)
+	if err := <-first; err != nil {
+		t.Fatalf("first StartChannelTracker() error = %v", err)
+	}
+	if repo.created != 1 {
+		t.Errorf("created sessions = %d, want 1", repo.created)
+	}
+	if !s.isRunning(channelId) {
+		t.Error("expected the tracker to be running")
+	}
+}
+
+func TestStatsTrackerReleasesChannelOnStartError(t *testing.T) {
+	const channelId discord.ChannelId = "channel"
+	var tasks []discord.StatsTrackerTask
+	s := newTestStatsTracker(&sessionsRepoMock{}, &tasks)
+	loadErr := errors.New("failed")
+	s.trackablePlatformsLoader = func(context.Context, discord.ChannelId) ([]ps2_platforms.Platform, error) {
+		return nil, loadErr
+	}
+	ctx := context.Background()
+	if err := s.StartChannelTracker(ctx, channelId, nil); !errors.Is(err, loadErr) {
+		t.Fatalf("StartChannelTracker() error = %v, want %v", err, loadErr)
+	}
+	s.trackablePlatformsLoader = func(context.Context, discord.ChannelId) ([]ps2_platforms.Platform, error) {
+		return []ps2_platforms.Platform{ps2_platforms.PC}, nil
+	}
+	if err := s.StartChannelTracker(ctx, channelId, nil); err != nil {
+		t.Errorf("StartChannelTracker() after the failed start error = %v", err)
+	}
+}
+
+// Starts a tracker of the channel that tracks every character
+func newTrackingStatsTracker(t *testing.T, channelId discord.ChannelId) *StatsTracker {
+	t.Helper()
+	var tasks []discord.StatsTrackerTask
+	s := newTestStatsTracker(&sessionsRepoMock{}, &tasks)
+	s.channelsLoader = func(context.Context, ps2_platforms.Platform, ps2.CharacterId) ([]discord.ChannelId, error) {
+		return []discord.ChannelId{channelId}, nil
+	}
+	if err := s.StartChannelTracker(context.Background(), channelId, nil); err != nil {
+		t.Fatalf("StartChannelTracker() error = %v", err)
+	}
+	return s
+}
+
+// Stops the tracker of the channel and returns its platform tracker
+func stopTrackingStatsTracker(t *testing.T, s *StatsTracker, channelId discord.ChannelId) *platformTracker {
+	t.Helper()
+	_, trackers, ok := s.popTracker(channelId, time.Now())
+	if !ok {
+		t.Fatal("expected the tracker to be running")
+	}
+	tracker, ok := trackers[ps2_platforms.PC]
+	if !ok {
+		t.Fatal("expected the platform to be tracked")
+	}
+	return tracker
+}
+
+func TestStatsTrackerHandleVehicleDestroyEvent(t *testing.T) {
+	const channelId discord.ChannelId = "channel"
+	const vehicleId ps2.VehicleId = "4"
+	cases := []struct {
+		name      string
+		event     events.VehicleDestroy
+		lost      uint
+		destroyed uint
+	}{
+		{
+			name: "enemy vehicle",
+			event: events.VehicleDestroy{
+				AttackerCharacterID: "attacker",
+				AttackerTeamID:      "1",
+				CharacterID:         "victim",
+				TeamID:              "2",
+				VehicleID:           string(vehicleId),
+			},
+			lost:      1,
+			destroyed: 1,
+		},
+		{
+			name: "own vehicle",
+			event: events.VehicleDestroy{
+				AttackerCharacterID: "victim",
+				AttackerTeamID:      "2",
+				CharacterID:         "victim",
+				TeamID:              "2",
+				VehicleID:           string(vehicleId),
+			},
+			lost: 1,
+		},
+		{
+			name: "allied vehicle",
+			event: events.VehicleDestroy{
+				AttackerCharacterID: "attacker",
+				AttackerTeamID:      "2",
+				CharacterID:         "victim",
+				TeamID:              "2",
+				VehicleID:           string(vehicleId),
+			},
+			lost: 1,
+		},
+		{
+			name: "no vehicle",
+			event: events.VehicleDestroy{
+				AttackerCharacterID: "attacker",
+				AttackerTeamID:      "1",
+				CharacterID:         "victim",
+				TeamID:              "2",
+				VehicleID:           string(ps2.NoVehicleId),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := newTrackingStatsTracker(t, channelId)
+			if err := s.handleVehicleDestroyEvent(context.Background(), ps2_platforms.PC, c.event); err != nil {
+				t.Fatalf("handleVehicleDestroyEvent() error = %v", err)
+			}
+			characters := stopTrackingStatsTracker(t, s, channelId).characters
+			var lost, destroyed uint
+			if victim, ok := characters["victim"]; ok {
+				lost = victim.vehicleCounts(vehicleId).Lost
+			}
+			if attacker, ok := characters["attacker"]; ok {
+				destroyed = attacker.vehicleCounts(vehicleId).Destroyed
+			}
+			if lost != c.lost {
+				t.Errorf("lost = %d, want %d", lost, c.lost)
+			}
+			if destroyed != c.destroyed {
+				t.Errorf("destroyed = %d, want %d", destroyed, c.destroyed)
+			}
+		})
+	}
+}
+
+// Kill of the victim by the attacker from the enemy team
+func enemyKill(weaponId ps2.ItemId, isHeadshot bool) events.Death {
+	headshot := "0"
+	if isHeadshot {
+		headshot = "1"
+	}
+	return events.Death{
+		AttackerCharacterID: "attacker",
+		AttackerTeamID:      "1",
+		AttackerWeaponID:    string(weaponId),
+		CharacterID:         "victim",
+		TeamID:              "2",
+		IsHeadshot:          headshot,
+		ZoneID:              "2",
+	}
+}
+
+func TestStatsTrackerWeaponKills(t *testing.T) {
+	const channelId discord.ChannelId = "channel"
+	const weaponId ps2.ItemId = "80"
+	teamKill := enemyKill(weaponId, false)
+	teamKill.AttackerTeamID = teamKill.TeamID
+	noWeapon := enemyKill(ps2.NoItemId, false)
+	cases := []struct {
+		name   string
+		events []events.Death
+		want   WeaponCounts
+	}{
+		{
+			name:   "body shot and head shot kills",
+			events: []events.Death{enemyKill(weaponId, false), enemyKill(weaponId, true)},
+			want:   WeaponCounts{Kills: 2, HeadShotsKills: 1},
+		},
+		{
+			name:   "team kills are not counted",
+			events: []events.Death{teamKill},
+		},
+		{
+			name:   "kills without a weapon are not counted",
+			events: []events.Death{noWeapon},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := newTrackingStatsTracker(t, channelId)
+			for _, event := range c.events {
+				if err := s.handleDeathEvent(context.Background(), ps2_platforms.PC, event); err != nil {
+					t.Fatalf("handleDeathEvent() error = %v", err)
+				}
+			}
+			characters := stopTrackingStatsTracker(t, s, channelId).characters
+			var got WeaponCounts
+			if attacker, ok := characters["attacker"]; ok {
+				got = attacker.weapons[weaponId]
+				if weapons := len(attacker.weapons); weapons > 1 {
+					t.Errorf("expected at most one weapon, got %d", weapons)
+				}
+			}
+			if got != c.want {
+				t.Errorf("weapon counts = %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestStatsTrackerFacilityContributions(t *testing.T) {
+	const channelId discord.ChannelId = "channel"
+	s := newTrackingStatsTracker(t, channelId)
+	ctx := context.Background()
+	s.HandlePlayerFacilityCaptureEvent(ctx, ps2_platforms.PC, events.PlayerFacilityCapture{CharacterID: "a", FacilityID: "1"})
+	s.HandlePlayerFacilityCaptureEvent(ctx, ps2_platforms.PC, events.PlayerFacilityCapture{CharacterID: "b", FacilityID: "1"})
+	s.HandlePlayerFacilityDefendEvent(ctx, ps2_platforms.PC, events.PlayerFacilityDefend{CharacterID: "a", FacilityID: "2"})
+	s.wg.Wait()
+	stats, err := stopTrackingStatsTracker(t, s, channelId).toStats(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("toStats() error = %v", err)
+	}
+	want := []FacilityStats{
+		{Facility: ps2.Facility{Id: "1", Name: "Facility 1"}, Captures: 2},
+		{Facility: ps2.Facility{Id: "2", Name: "Facility 2"}, Defenses: 1},
+	}
+	if !slices.Equal(stats.Facilities, want) {
+		t.Errorf("facilities = %+v, want %+v", stats.Facilities, want)
+	}
+	if len(stats.Characters) != 2 {
+		t.Fatalf("expected 2 characters, got %d", len(stats.Characters))
+	}
+	for _, character := range stats.Characters {
+		var captures, defenses uint
+		for _, facility := range character.Facilities {
+			captures += facility.Captures
+			defenses += facility.Defenses
+		}
+		wantDefenses := uint(0)
+		if character.Character.Id == "a" {
+			wantDefenses = 1
+		}
+		if captures != 1 || defenses != wantDefenses {
+			t.Errorf("%q: captures = %d, defenses = %d, want 1 and %d", character.Character.Id, captures, defenses, wantDefenses)
+		}
+	}
+}
+
+func TestStatsTrackerPreviousSession(t *testing.T) {
+	const channelId discord.ChannelId = "channel"
+	startedAt := time.Now().Add(-24 * time.Hour)
+	var distribution [ps2_loadout.LoadoutTypeCount]time.Duration
+	distribution[ps2_loadout.Medic] = 30 * time.Minute
+	repo := &sessionsRepoMock{
+		previous: Session{
+			Id:        1,
+			ChannelId: channelId,
+			StartedAt: startedAt,
+			StoppedAt: startedAt.Add(time.Hour),
+			Platforms: []ps2_platforms.Platform{ps2_platforms.PC},
+			Characters: []CharacterSnapshot{
+				{Platform: ps2_platforms.PC, CharacterId: "a", BodyKills: 6, HeadShotsKills: 4, Deaths: 4, Suicides: 1, OnlineDuration: time.Hour},
+				{Platform: ps2_platforms.PC, CharacterId: "b", BodyKills: 2, Deaths: 1, LoadoutsDistribution: distribution},
+				{Platform: ps2_platforms.PC, CharacterId: "c", BodyKills: 1},
+			},
+		},
+	}
+	var tasks []discord.StatsTrackerTask
+	s := newTestStatsTracker(repo, &tasks)
+	s.charactersLoaders[ps2_platforms.PC] = func(_ context.Context, ids []ps2.CharacterId) (map[ps2.CharacterId]ps2.Character, error) {
+		characters := make(map[ps2.CharacterId]ps2.Character, len(ids))
+		for _, id := range ids {
+			outfitId := ps2.OutfitId("outfit")
+			if id == "c" {
+				outfitId = ""
+			}
+			characters[id] = ps2.Character{Id: id, OutfitId: outfitId}
+		}
+		return characters, nil
+	}
+	previous, err := s.previousSession(context.Background(), channelId, 1, 2)
+	if err != nil {
+		t.Fatalf("previousSession() error = %v", err)
+	}
+	if previous.Id != 1 || !previous.StartedAt.Equal(startedAt) {
+		t.Errorf("previous session = %d started at %v, want 1 started at %v", previous.Id, previous.StartedAt, startedAt)
+	}
+	stats := previous.Platforms[ps2_platforms.PC]
+	wantCharacters := map[ps2.CharacterId]Totals{
+		"a": {Kills: 10, HeadShotsKills: 4, Deaths: 5, Playtime: time.Hour},
+		"b": {Kills: 2, Deaths: 1, Playtime: 30 * time.Minute},
+		"c": {Kills: 1},
+	}
+	if !maps.Equal(stats.Characters, wantCharacters) {
+		t.Errorf("characters = %+v, want %+v", stats.Characters, wantCharacters)
+	}
+	// Characters without an outfit are not grouped
+	wantOutfits := map[ps2.OutfitId]Totals{
+		"outfit": {Kills: 12, HeadShotsKills: 4, Deaths: 6, Playtime: 90 * time.Minute},
+	}
+	if !maps.Equal(stats.Outfits, wantOutfits) {
+		t.Errorf("outfits = %+v, want %+v", stats.Outfits, wantOutfits)
+	}
+	if kd := stats.Outfits["outfit"].KD(); kd != 2 {
+		t.Errorf("outfit KD = %v, want 2", kd)
+	}
+}
+
+func TestStatsTrackerRivals(t *testing.T) {
+	const channelId discord.ChannelId = "channel"
+	kill := func(attacker, victim ps2.CharacterId, teamKill bool) events.Death {
+		event := events.Death{
+			AttackerCharacterID: string(attacker),
+			AttackerTeamID:      "1",
+			AttackerWeaponID:    "80",
+			CharacterID:         string(victim),
+			TeamID:              "2",
+		}
+		if teamKill {
+			event.TeamID = event.AttackerTeamID
+		}
+		return event
+	}
+	outfits := map[ps2.CharacterId]ps2.OutfitId{"a": "A", "e1": "E", "e2": "E", "t": "A"}
+	s := newTrackingStatsTracker(t, channelId)
+	ctx := context.Background()
+	for _, event := range []events.Death{
+		kill("a", "e1", false),
+		kill("a", "e1", false),
+		kill("a", "e2", false),
+		kill("e1", "a", false),
+		kill("a", "t", true),
+	} {
+		if err := s.handleDeathEvent(ctx, ps2_platforms.PC, event); err != nil {
+			t.Fatalf("handleDeathEvent() error = %v", err)
+		}
+	}
+	tracker := stopTrackingStatsTracker(t, s, channelId)
+	tracker.charactersLoader = func(_ context.Context, ids []ps2.CharacterId) (map[ps2.CharacterId]ps2.Character, error) {
+		characters := make(map[ps2.CharacterId]ps2.Character, len(ids))
+		for _, id := range ids {
+			characters[id] = ps2.Character{Id: id, Name: string(id), OutfitId: outfits[id], OutfitTag: string(outfits[id])}
+		}
+		return characters, nil
+	}
+	stats, err := tracker.toStats(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("toStats() error = %v", err)
+	}
+	i := slices.IndexFunc(stats.Characters, func(c CharacterStats) bool {
+		return c.Character.Id == "a"
+	})
+	if i < 0 {
+		t.Fatal("expected the stats of the attacker")
+	}
+	rivalIds := func(rivals []RivalStats) []ps2.CharacterId {
+		ids := make([]ps2.CharacterId, 0, len(rivals))
+		for _, r := range rivals {
+			ids = append(ids, r.Character.Id)
+		}
+		return ids
+	}
+	// Team kills are not rivalries
+	if victims := rivalIds(stats.Characters[i].Victims); !slices.Equal(victims, []ps2.CharacterId{"e1", "e2"}) {
+		t.Errorf("victims = %v, want [e1 e2]", victims)
+	}
+	if nemeses := rivalIds(stats.Characters[i].Nemeses); !slices.Equal(nemeses, []ps2.CharacterId{"e1"}) {
+		t.Errorf("nemeses = %v, want [e1]", nemeses)
+	}
+	var rivalry *OutfitRivalryStats
+	for j := range stats.Outfits {
+		if stats.Outfits[j].Outfit.Id == "A" && stats.Outfits[j].EnemyOutfit.Id == "E" {
+			rivalry = &stats.Outfits[j]
+		}
+	}
+	if rivalry == nil {
+		t.Fatalf("expected the rivalry of outfits, got %+v", stats.Outfits)
+	}
+	if rivalry.Kills != 3 || rivalry.Deaths != 1 {
+		t.Errorf("outfit rivalry kills = %d, deaths = %d, want 3 and 1", rivalry.Kills, rivalry.Deaths)
+	}
+}
+
+func TestStatsTrackerZoneCounts(t *testing.T) {
+	const channelId discord.ChannelId = "channel"
+	s := newTrackingStatsTracker(t, channelId)
+	ctx := context.Background()
+	inZone := func(event events.Death, zoneId ps2.ZoneId) events.Death {
+		event.ZoneID = string(zoneId)
+		return event
+	}
+	suicide := enemyKill("80", false)
+	suicide.AttackerCharacterID = suicide.CharacterID
+	for _, event := range []events.Death{
+		inZone(enemyKill("80", false), "2"),
+		inZone(enemyKill("80", true), "2"),
+		inZone(enemyKill("80", false), "4"),
+		inZone(suicide, "4"),
+	} {
+		if err := s.handleDeathEvent(ctx, ps2_platforms.PC, event); err != nil {
+			t.Fatalf("handleDeathEvent() error = %v", err)
+		}
+	}
+	characters := stopTrackingStatsTracker(t, s, channelId).characters
+	cases := []struct {
+		characterId ps2.CharacterId
+		zoneId      ps2.ZoneId
+		kills       uint
+		deaths      uint
+	}{
+		{"attacker", "2", 2, 0},
+		{"attacker", "4", 1, 0},
+		{"victim", "2", 0, 2},
+		// Suicides are deaths in the zone
+		{"victim", "4", 0, 2},
+	}
+	for _, c := range cases {
+		character, ok := characters[c.characterId]
+		if !ok {
+			t.Fatalf("%q is not tracked", c.characterId)
+		}
+		counts := character.zoneCounts(c.zoneId)
+		if counts.Kills != c.kills || counts.Deaths != c.deaths {
+			t.Errorf("%q in zone %q: kills = %d, deaths = %d, want %d and %d", c.characterId, c.zoneId, counts.Kills, counts.Deaths, c.kills, c.deaths)
+		}
+	}
+}
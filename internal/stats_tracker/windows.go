@@ -0,0 +1,79 @@
+package stats_tracker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/ps2"
+)
+
+// Counters of characters are shared between the channel sessions,
+// so each event is processed once regardless of the amount of channels.
+// The current window is closed at the session starts and stops and for
+// a single character when it joins a session, so the set of sessions of
+// the character is the same during a window. Closed windows are added
+// to the running totals of the sessions the characters are joined to.
+type platformWindows struct {
+	mu         sync.RWMutex
+	newTracker func() *platformTracker
+	// Nil until the first session is started
+	current *platformTracker
+}
+
+func newPlatformWindows(newTracker func() *platformTracker) *platformWindows {
+	return &platformWindows{
+		newTracker: newTracker,
+	}
+}
+
+// Closes the current window, `fold` should add it to the sessions
+// before the next window becomes visible to them
+func (w *platformWindows) split(now time.Time, fold func(closed *platformTracker)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.current == nil {
+		w.current = w.newTracker()
+		return
+	}
+	closed := w.current
+	w.current = closed.next(now)
+	fold(closed)
+}
+
+// Closes the current window for the character, `fold` is called
+// with nil if the character has no counters in the current window
+func (w *platformWindows) splitCharacter(
+	characterId ps2.CharacterId,
+	now time.Time,
+	fold func(closed *characterTracker),
+) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var closed *characterTracker
+	if w.current != nil {
+		closed = w.current.splitCharacter(characterId, now)
+	}
+	fold(closed)
+}
+
+// Removes the current window when there are no sessions left
+func (w *platformWindows) reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.current = nil
+}
+
+func (w *platformWindows) update(fn func(*platformTracker)) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.current != nil {
+		fn(w.current)
+	}
+}
+
+// The current window is not closed until `fn` returns
+func (w *platformWindows) view(fn func(current *platformTracker)) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	fn(w.current)
+}
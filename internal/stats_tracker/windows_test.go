@@ -0,0 +1,197 @@
+package stats_tracker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/discord"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+type windowsStep struct {
+	start discord.ChannelId
+	stop  discord.ChannelId
+	// Kill of the character tracked by the channels
+	kill []discord.ChannelId
+	// Expected kills of the running sessions
+	running map[discord.ChannelId]uint
+}
+
+func TestStatsTrackerOverlappingSessions(t *testing.T) {
+	const characterId ps2.CharacterId = "character"
+	cases := []struct {
+		name  string
+		steps []windowsStep
+		// Kills of the stopped sessions
+		kills map[discord.ChannelId]uint
+	}{
+		{
+			name: "staggered start and stop",
+			steps: []windowsStep{
+				{start: "a"},
+				{kill: []discord.ChannelId{"a", "b"}},
+				{start: "b"},
+				{kill: []discord.ChannelId{"a", "b"}},
+				{running: map[discord.ChannelId]uint{"a": 2, "b": 1}},
+				{stop: "a"},
+				{kill: []discord.ChannelId{"a", "b"}},
+				{stop: "b"},
+			},
+			kills: map[discord.ChannelId]uint{"a": 2, "b": 2},
+		},
+		{
+			name: "nested sessions",
+			steps: []windowsStep{
+				{start: "a"},
+				{kill: []discord.ChannelId{"a", "b"}},
+				{start: "b"},
+				{kill: []discord.ChannelId{"a", "b"}},
+				{stop: "b"},
+				{kill: []discord.ChannelId{"a", "b"}},
+				{stop: "a"},
+			},
+			kills: map[discord.ChannelId]uint{"a": 3, "b": 1},
+		},
+		{
+			name: "late joiner does not inherit counters of the current window",
+			steps: []windowsStep{
+				{start: "a"},
+				{start: "b"},
+				{kill: []discord.ChannelId{"a"}},
+				{kill: []discord.ChannelId{"a"}},
+				{kill: []discord.ChannelId{"a", "b"}},
+				{running: map[discord.ChannelId]uint{"a": 3, "b": 1}},
+				{stop: "a"},
+				{stop: "b"},
+			},
+			kills: map[discord.ChannelId]uint{"a": 3, "b": 1},
+		},
+		{
+			name: "late joiner does not inherit counters of the closed windows",
+			steps: []windowsStep{
+				{start: "a"},
+				{start: "b"},
+				{kill: []discord.ChannelId{"a"}},
+				{start: "c"},
+				{kill: []discord.ChannelId{"a"}},
+				{stop: "c"},
+				{kill: []discord.ChannelId{"a", "b"}},
+				{kill: []discord.ChannelId{"a", "b"}},
+				{stop: "a"},
+				{kill: []discord.ChannelId{"b"}},
+				{stop: "b"},
+			},
+			kills: map[discord.ChannelId]uint{"a": 4, "b": 3, "c": 0},
+		},
+		{
+			name: "restarted session",
+			steps: []windowsStep{
+				{start: "a"},
+				{start: "b"},
+				{kill: []discord.ChannelId{"a", "b"}},
+				{stop: "a"},
+				{kill: []discord.ChannelId{"a", "b"}},
+				{start: "a"},
+				{kill: []discord.ChannelId{"a", "b"}},
+				{running: map[discord.ChannelId]uint{"a": 1, "b": 3}},
+				{stop: "b"},
+				{stop: "a"},
+			},
+			kills: map[discord.ChannelId]uint{"a": 1, "b": 3},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var tasks []discord.StatsTrackerTask
+			s := newTestStatsTracker(&sessionsRepoMock{}, &tasks)
+			ctx := context.Background()
+			kills := make(map[discord.ChannelId]uint)
+			for i, step := range c.steps {
+				switch {
+				case step.start != "":
+					if err := s.startChannelTracker(ctx, step.start, ManualSession, 0, nil); err != nil {
+						t.Fatalf("step %d: startChannelTracker() error = %v", i, err)
+					}
+				case step.stop != "":
+					_, trackers, ok := s.popTracker(step.stop, time.Now())
+					if !ok {
+						t.Fatalf("step %d: no tracker to stop", i)
+					}
+					kills[step.stop] = sessionKills(trackers, characterId)
+				case step.kill != nil:
+					s.updatePlatformTracker(ctx, step.kill, ps2_platforms.PC, characterId, func(tracker *platformTracker) {
+						tracker.updateCharacter(characterId, addBodyKill("weapon"))
+					})
+				default:
+					s.trackersMu.RLock()
+					for channelId, want := range step.running {
+						if got := sessionKills(s.channelTrackers(s.trackers[channelId], time.Now()), characterId); got != want {
+							t.Errorf("step %d: running %q kills = %d, want %d", i, channelId, got, want)
+						}
+					}
+					s.trackersMu.RUnlock()
+				}
+			}
+			for channelId, want := range c.kills {
+				if got := kills[channelId]; got != want {
+					t.Errorf("%q kills = %d, want %d", channelId, got, want)
+				}
+			}
+			if s.windows[ps2_platforms.PC].current != nil {
+				t.Errorf("expected the window to be removed after the last session")
+			}
+		})
+	}
+}
+
+func sessionKills(trackers map[ps2_platforms.Platform]*platformTracker, characterId ps2.CharacterId) uint {
+	tracker, ok := trackers[ps2_platforms.PC]
+	if !ok {
+		return 0
+	}
+	if character, ok := tracker.characters[characterId]; ok {
+		return character.bodyKills
+	}
+	return 0
+}
+
+func TestPlatformWindowsSplitCharacter(t *testing.T) {
+	now := time.Now()
+	windows := newPlatformWindows(func() *platformTracker {
+		return newPlatformTracker(ps2_platforms.PC, nil, nil, nil, nil, nil, nil)
+	})
+	windows.split(now, func(*platformTracker) {
+		t.Error("the first split should not close a window")
+	})
+	windows.update(func(tracker *platformTracker) {
+		tracker.login("online", now)
+		tracker.updateCharacter("online", addBodyKill("weapon"))
+		tracker.updateCharacter("offline", addBodyKill("weapon"))
+	})
+	later := now.Add(time.Minute)
+	for _, c := range []struct {
+		characterId ps2.CharacterId
+		continued   bool
+	}{
+		{"online", true},
+		{"offline", false},
+		{"unknown", false},
+	} {
+		var closed *characterTracker
+		windows.splitCharacter(c.characterId, later, func(ct *characterTracker) {
+			closed = ct
+		})
+		if c.characterId != "unknown" && (closed == nil || closed.bodyKills != 1) {
+			t.Errorf("%q: expected the closed counters, got %+v", c.characterId, closed)
+		}
+		_, continued := windows.current.characters[c.characterId]
+		if continued != c.continued {
+			t.Errorf("%q: continued = %v, want %v", c.characterId, continued, c.continued)
+		}
+	}
+	if next := windows.current.characters["online"]; next.bodyKills != 0 || !next.onlineSince.Equal(later) {
+		t.Errorf("expected the online segment to be continued without counters, got %+v", next)
+	}
+}
@@ -16,16 +16,22 @@ type EventType string
 type Event = pubsub.Event[EventType]
 
 const (
-	OutfitMembersInitType                  EventType = "outfit_members_init"
-	OutfitMembersUpdateType                EventType = "outfit_members_update"
-	OutfitMemberSavedType                  EventType = "outfit_member_saved"
-	OutfitMemberDeletedType                EventType = "outfit_member_deleted"
-	OutfitSynchronizedType                 EventType = "outfit_synchronized"
-	ChannelLanguageSavedType               EventType = "channel_language_saved"
-	ChannelCharacterNotificationsSavedType EventType = "channel_character_notifications_saved"
-	ChannelOutfitNotificationsSavedType    EventType = "channel_outfit_notifications_saved"
-	ChannelTitleUpdatesSavedType           EventType = "channel_title_updates_saved"
-	ChannelDefaultTimezoneSavedType        EventType = "channel_default_timezone_saved"
+	OutfitMembersInitType                         EventType = "outfit_members_init"
+	OutfitMembersUpdateType                       EventType = "outfit_members_update"
+	OutfitMemberSavedType                         EventType = "outfit_member_saved"
+	OutfitMemberDeletedType                       EventType = "outfit_member_deleted"
+	OutfitSynchronizedType                        EventType = "outfit_synchronized"
+	ChannelLanguageSavedType                      EventType = "channel_language_saved"
+	ChannelCharacterNotificationsSavedType        EventType = "channel_character_notifications_saved"
+	ChannelOutfitNotificationsSavedType           EventType = "channel_outfit_notifications_saved"
+	ChannelTitleUpdatesSavedType                  EventType = "channel_title_updates_saved"
+	ChannelDefaultTimezoneSavedType               EventType = "channel_default_timezone_saved"
+	ChannelStatsTrackerDetailsSavedType           EventType = "channel_stats_tracker_details_saved"
+	ChannelStatsTrackerSortSavedType              EventType = "channel_stats_tracker_sort_saved"
+	ChannelStatsTrackerChartsSavedType            EventType = "channel_stats_tracker_charts_saved"
+	ChannelStatsTrackerScoreFormulaSavedType      EventType = "channel_stats_tracker_score_formula_saved"
+	ChannelOutfitActivityReportSavedType          EventType = "channel_outfit_activity_report_saved"
+	ChannelCharacterChangesNotificationsSavedType EventType = "channel_character_changes_notifications_saved"
 )
 
 type OutfitMembersInit struct {
@@ -122,3 +128,57 @@ type ChannelDefaultTimezoneSaved struct {
 func (e ChannelDefaultTimezoneSaved) Type() EventType {
 	return ChannelDefaultTimezoneSavedType
 }
+
+type ChannelStatsTrackerDetailsSaved struct {
+	ChannelId discord.ChannelId
+	Enabled   bool
+}
+
+func (e ChannelStatsTrackerDetailsSaved) Type() EventType {
+	return ChannelStatsTrackerDetailsSavedType
+}
+
+type ChannelStatsTrackerSortSaved struct {
+	ChannelId discord.ChannelId
+	Order     string
+}
+
+func (e ChannelStatsTrackerSortSaved) Type() EventType {
+	return ChannelStatsTrackerSortSavedType
+}
+
+type ChannelStatsTrackerChartsSaved struct {
+	ChannelId discord.ChannelId
+	Enabled   bool
+}
+
+func (e ChannelStatsTrackerChartsSaved) Type() EventType {
+	return ChannelStatsTrackerChartsSavedType
+}
+
+type ChannelStatsTrackerScoreFormulaSaved struct {
+	ChannelId discord.ChannelId
+	Formula   string
+}
+
+func (e ChannelStatsTrackerScoreFormulaSaved) Type() EventType {
+	return ChannelStatsTrackerScoreFormulaSavedType
+}
+
+type ChannelOutfitActivityReportSaved struct {
+	ChannelId discord.ChannelId
+	Enabled   bool
+}
+
+func (e ChannelOutfitActivityReportSaved) Type() EventType {
+	return ChannelOutfitActivityReportSavedType
+}
+
+type ChannelCharacterChangesNotificationsSaved struct {
+	ChannelId discord.ChannelId
+	Enabled   bool
+}
+
+func (e ChannelCharacterChangesNotificationsSaved) Type() EventType {
+	return ChannelCharacterChangesNotificationsSavedType
+}
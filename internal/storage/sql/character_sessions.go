@@ -0,0 +1,129 @@
+package sql_storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/characters_history"
+	"github.com/x0k/ps2-spy/internal/lib/db"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+// A missed logout ends the previous session at the new login
+func (s *Storage) StartCharacterSession(ctx context.Context, session characters_history.Session) error {
+	return s.Begin(ctx, 0, func(s *Storage) error {
+		if err := s.queries.EndCharacterSession(ctx, db.EndCharacterSessionParams{
+			EndedAt:     sql.NullTime{Time: session.StartedAt, Valid: true},
+			Platform:    string(session.Platform),
+			CharacterID: string(session.CharacterId),
+		}); err != nil {
+			return fmt.Errorf("failed to end previous session: %w", err)
+		}
+		if err := s.queries.InsertCharacterSession(ctx, db.InsertCharacterSessionParams{
+			Platform:    string(session.Platform),
+			CharacterID: string(session.CharacterId),
+			WorldID:     string(session.WorldId),
+			StartedAt:   session.StartedAt,
+		}); err != nil {
+			return fmt.Errorf("failed to insert session: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *Storage) EndCharacterSession(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	characterId ps2.CharacterId,
+	endedAt time.Time,
+) error {
+	if err := s.queries.EndCharacterSession(ctx, db.EndCharacterSessionParams{
+		EndedAt:     sql.NullTime{Time: endedAt, Valid: true},
+		Platform:    string(platform),
+		CharacterID: string(characterId),
+	}); err != nil {
+		return fmt.Errorf("failed to end character %q session: %w", characterId, err)
+	}
+	return nil
+}
+
+func (s *Storage) EndAllCharacterSessions(ctx context.Context, endedAt time.Time) error {
+	if err := s.queries.EndAllCharacterSessions(ctx, sql.NullTime{Time: endedAt, Valid: true}); err != nil {
+		return fmt.Errorf("failed to end character sessions: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) EndStaleCharacterSessions(ctx context.Context) error {
+	if err := s.queries.EndStaleCharacterSessions(ctx); err != nil {
+		return fmt.Errorf("failed to end stale character sessions: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) CharacterSessions(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	characterId ps2.CharacterId,
+	since time.Time,
+) ([]characters_history.Session, error) {
+	data, err := s.queries.ListCharacterSessions(ctx, db.ListCharacterSessionsParams{
+		Platform:    string(platform),
+		CharacterID: string(characterId),
+		EndedAt:     sql.NullTime{Time: since, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list character %q sessions: %w", characterId, err)
+	}
+	sessions := make([]characters_history.Session, 0, len(data))
+	for _, dto := range data {
+		sessions = append(sessions, characterSessionFromDTO(dto))
+	}
+	return sessions, nil
+}
+
+func (s *Storage) OutfitMembersSessions(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	outfitId ps2.OutfitId,
+	from time.Time,
+	to time.Time,
+) ([]characters_history.Session, error) {
+	data, err := s.queries.ListOutfitMembersCharacterSessions(ctx, db.ListOutfitMembersCharacterSessionsParams{
+		Platform:  string(platform),
+		OutfitID:  string(outfitId),
+		StartedAt: to,
+		EndedAt:   sql.NullTime{Time: from, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outfit %q members sessions: %w", outfitId, err)
+	}
+	sessions := make([]characters_history.Session, 0, len(data))
+	for _, dto := range data {
+		sessions = append(sessions, characterSessionFromDTO(dto))
+	}
+	return sessions, nil
+}
+
+func (s *Storage) RemoveCharacterSessionsBefore(ctx context.Context, t time.Time) error {
+	if err := s.queries.DeleteCharacterSessionsBefore(ctx, sql.NullTime{Time: t, Valid: true}); err != nil {
+		return fmt.Errorf("failed to remove character sessions: %w", err)
+	}
+	return nil
+}
+
+func characterSessionFromDTO(dto db.CharacterSession) characters_history.Session {
+	session := characters_history.Session{
+		Platform:    ps2_platforms.Platform(dto.Platform),
+		CharacterId: ps2.CharacterId(dto.CharacterID),
+		WorldId:     ps2.WorldId(dto.WorldID),
+		StartedAt:   dto.StartedAt,
+	}
+	if dto.EndedAt.Valid {
+		session.EndedAt = dto.EndedAt.Time
+	}
+	return session
+}
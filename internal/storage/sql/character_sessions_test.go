@@ -0,0 +1,71 @@
+package sql_storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/characters_history"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+func TestCharacterSessions(t *testing.T) {
+	const characterId ps2.CharacterId = "character"
+	s, _ := newTestStorage(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	session := func(startedAt time.Duration) characters_history.Session {
+		return characters_history.Session{
+			Platform:    ps2_platforms.PC,
+			CharacterId: characterId,
+			WorldId:     "1",
+			StartedAt:   now.Add(startedAt),
+		}
+	}
+	// Expired session
+	if err := s.StartCharacterSession(ctx, session(-50*time.Hour)); err != nil {
+		t.Fatalf("StartCharacterSession() error = %v", err)
+	}
+	if err := s.EndCharacterSession(ctx, ps2_platforms.PC, characterId, now.Add(-49*time.Hour)); err != nil {
+		t.Fatalf("EndCharacterSession() error = %v", err)
+	}
+	// The logout is missed
+	if err := s.StartCharacterSession(ctx, session(-3*time.Hour)); err != nil {
+		t.Fatalf("StartCharacterSession() error = %v", err)
+	}
+	if err := s.StartCharacterSession(ctx, session(-time.Hour)); err != nil {
+		t.Fatalf("StartCharacterSession() error = %v", err)
+	}
+	sessions, err := s.CharacterSessions(ctx, ps2_platforms.PC, characterId, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("CharacterSessions() error = %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %+v", sessions)
+	}
+	if latest := sessions[0]; !latest.StartedAt.Equal(now.Add(-time.Hour)) || !latest.IsOngoing() {
+		t.Errorf("expected the ongoing session first, got %+v", latest)
+	}
+	if previous := sessions[1]; !previous.EndedAt.Equal(now.Add(-time.Hour)) {
+		t.Errorf("expected the previous session to be ended at the next login, got %+v", previous)
+	}
+
+	if err := s.RemoveCharacterSessionsBefore(ctx, now.Add(-24*time.Hour)); err != nil {
+		t.Fatalf("RemoveCharacterSessionsBefore() error = %v", err)
+	}
+	if err := s.EndStaleCharacterSessions(ctx); err != nil {
+		t.Fatalf("EndStaleCharacterSessions() error = %v", err)
+	}
+	sessions, err = s.CharacterSessions(ctx, ps2_platforms.PC, characterId, now.Add(-100*time.Hour))
+	if err != nil {
+		t.Fatalf("CharacterSessions() error = %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected the expired session to be removed, got %+v", sessions)
+	}
+	// Duration of the stale session is unknown
+	if stale := sessions[0]; stale.IsOngoing() || !stale.EndedAt.Equal(stale.StartedAt) {
+		t.Errorf("expected the stale session to be ended at its start, got %+v", stale)
+	}
+}
@@ -0,0 +1,136 @@
+package sql_storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/x0k/ps2-spy/internal/characters_tracker"
+	"github.com/x0k/ps2-spy/internal/lib/db"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_factions "github.com/x0k/ps2-spy/internal/ps2/factions"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+func (s *Storage) CharactersTrackerPlayers(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+) ([]characters_tracker.PlayerSnapshot, error) {
+	players, err := s.queries.ListCharactersTrackerPlayers(ctx, string(platform))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list characters tracker players: %w", err)
+	}
+	snapshots := make([]characters_tracker.PlayerSnapshot, 0, len(players))
+	for _, p := range players {
+		// Snapshots without the session start
+		onlineSince := p.ActiveAt
+		if p.OnlineSince.Valid {
+			onlineSince = p.OnlineSince.Time
+		}
+		snapshots = append(snapshots, characters_tracker.PlayerSnapshot{
+			Character: ps2.OnlineCharacter{
+				Character: ps2.Character{
+					Id:        ps2.CharacterId(p.CharacterID),
+					FactionId: ps2_factions.Id(p.FactionID),
+					Name:      p.CharacterName,
+					OutfitId:  ps2.OutfitId(p.OutfitID),
+					OutfitTag: p.OutfitTag,
+					WorldId:   ps2.WorldId(p.WorldID),
+					Platform:  platform,
+				},
+				ZoneId:      ps2.ZoneId(p.ZoneID),
+				Loadout:     ps2_loadout.Loadout(p.Loadout),
+				OnlineSince: onlineSince,
+			},
+			ActiveAt: p.ActiveAt,
+		})
+	}
+	return snapshots, nil
+}
+
+func (s *Storage) SaveCharactersTrackerPlayers(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	snapshots []characters_tracker.PlayerSnapshot,
+) error {
+	return s.Begin(ctx, 0, func(s *Storage) error {
+		if err := s.queries.DeleteCharactersTrackerPlayers(ctx, string(platform)); err != nil {
+			return fmt.Errorf("failed to delete characters tracker players: %w", err)
+		}
+		for _, snapshot := range snapshots {
+			if err := s.queries.InsertCharactersTrackerPlayer(ctx, db.InsertCharactersTrackerPlayerParams{
+				Platform:      string(platform),
+				CharacterID:   string(snapshot.Character.Id),
+				WorldID:       string(snapshot.Character.WorldId),
+				ZoneID:        string(snapshot.Character.ZoneId),
+				FactionID:     string(snapshot.Character.FactionId),
+				CharacterName: snapshot.Character.Name,
+				OutfitID:      string(snapshot.Character.OutfitId),
+				OutfitTag:     snapshot.Character.OutfitTag,
+				ActiveAt:      snapshot.ActiveAt,
+				Loadout:       string(snapshot.Character.Loadout),
+				OnlineSince:   sql.NullTime{Time: snapshot.Character.OnlineSince, Valid: true},
+			}); err != nil {
+				return fmt.Errorf("failed to save character %q: %w", snapshot.Character.Id, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Storage) PopulationHistory(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+) (map[ps2.WorldId][]ps2.PopulationSample, error) {
+	samples, err := s.queries.ListPopulationSamples(ctx, string(platform))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list population samples: %w", err)
+	}
+	history := make(map[ps2.WorldId][]ps2.PopulationSample)
+	for _, sample := range samples {
+		worldId := ps2.WorldId(sample.WorldID)
+		vs, nc, tr, ns, other := int(sample.Vs), int(sample.Nc), int(sample.Tr), int(sample.Ns), int(sample.Other)
+		history[worldId] = append(history[worldId], ps2.PopulationSample{
+			At: sample.SampledAt,
+			StatPerFactions: ps2.StatPerFactions{
+				All:   vs + nc + tr + ns + other,
+				VS:    vs,
+				NC:    nc,
+				TR:    tr,
+				NS:    ns,
+				Other: other,
+			},
+		})
+	}
+	return history, nil
+}
+
+func (s *Storage) SavePopulationHistory(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	history map[ps2.WorldId][]ps2.PopulationSample,
+) error {
+	return s.Begin(ctx, 0, func(s *Storage) error {
+		if err := s.queries.DeletePopulationSamples(ctx, string(platform)); err != nil {
+			return fmt.Errorf("failed to delete population samples: %w", err)
+		}
+		for worldId, samples := range history {
+			for _, sample := range samples {
+				if err := s.queries.InsertPopulationSample(ctx, db.InsertPopulationSampleParams{
+					Platform:  string(platform),
+					WorldID:   string(worldId),
+					SampledAt: sample.At,
+					Vs:        int64(sample.VS),
+					Nc:        int64(sample.NC),
+					Tr:        int64(sample.TR),
+					Ns:        int64(sample.NS),
+					Other:     int64(sample.Other),
+				}); err != nil {
+					return fmt.Errorf("failed to save population sample of world %q: %w", worldId, err)
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+package sql_storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/characters_refresher"
+	"github.com/x0k/ps2-spy/internal/lib/db"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_factions "github.com/x0k/ps2-spy/internal/ps2/factions"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+)
+
+func (s *Storage) KnownCharacters(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+) (map[ps2.CharacterId]characters_refresher.KnownCharacter, error) {
+	list, err := s.queries.ListKnownCharacters(ctx, string(platform))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list known characters: %w", err)
+	}
+	characters := make(map[ps2.CharacterId]characters_refresher.KnownCharacter, len(list))
+	for _, c := range list {
+		id := ps2.CharacterId(c.CharacterID)
+		characters[id] = characters_refresher.KnownCharacter{
+			Character: ps2.Character{
+				Id:        id,
+				FactionId: ps2_factions.Id(c.FactionID),
+				Name:      c.CharacterName,
+				OutfitId:  ps2.OutfitId(c.OutfitID),
+				OutfitTag: c.OutfitTag,
+				WorldId:   ps2.WorldId(c.WorldID),
+				Platform:  platform,
+			},
+			RefreshedAt: c.RefreshedAt,
+		}
+	}
+	return characters, nil
+}
+
+func (s *Storage) SaveKnownCharacters(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	characters []ps2.Character,
+	refreshedAt time.Time,
+) error {
+	return s.Begin(ctx, 0, func(s *Storage) error {
+		for _, c := range characters {
+			if err := s.queries.UpsertKnownCharacter(ctx, db.UpsertKnownCharacterParams{
+				Platform:      string(platform),
+				CharacterID:   string(c.Id),
+				CharacterName: c.Name,
+				FactionID:     string(c.FactionId),
+				WorldID:       string(c.WorldId),
+				OutfitID:      string(c.OutfitId),
+				OutfitTag:     c.OutfitTag,
+				RefreshedAt:   refreshedAt,
+			}); err != nil {
+				return fmt.Errorf("failed to save character %q: %w", c.Id, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Storage) RemoveKnownCharactersBefore(
+	ctx context.Context,
+	platform ps2_platforms.Platform,
+	refreshedAt time.Time,
+) error {
+	if err := s.queries.DeleteKnownCharactersBefore(ctx, db.DeleteKnownCharactersBeforeParams{
+		Platform:    string(platform),
+		RefreshedAt: refreshedAt,
+	}); err != nil {
+		return fmt.Errorf("failed to delete known characters: %w", err)
+	}
+	return nil
+}
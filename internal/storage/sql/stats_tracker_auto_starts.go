@@ -0,0 +1,76 @@
+package sql_storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/discord"
+	"github.com/x0k/ps2-spy/internal/lib/db"
+	"github.com/x0k/ps2-spy/internal/shared"
+)
+
+func (s *Storage) StatsTrackerAutoStarts(ctx context.Context) ([]discord.StatsTrackerAutoStart, error) {
+	data, err := s.queries.ListStatsTrackerAutoStarts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stats tracker auto starts: %w", err)
+	}
+	autoStarts := make([]discord.StatsTrackerAutoStart, 0, len(data))
+	for _, a := range data {
+		autoStarts = append(autoStarts, statsTrackerAutoStartFromDTO(a))
+	}
+	return autoStarts, nil
+}
+
+func (s *Storage) ChannelStatsTrackerAutoStart(
+	ctx context.Context,
+	channelId discord.ChannelId,
+) (discord.StatsTrackerAutoStart, error) {
+	a, err := s.queries.GetStatsTrackerAutoStart(ctx, string(channelId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return discord.StatsTrackerAutoStart{}, shared.ErrNotFound
+	}
+	if err != nil {
+		return discord.StatsTrackerAutoStart{}, fmt.Errorf("failed to get channel %q stats tracker auto start: %w", string(channelId), err)
+	}
+	return statsTrackerAutoStartFromDTO(a), nil
+}
+
+func (s *Storage) SaveChannelStatsTrackerAutoStart(
+	ctx context.Context,
+	autoStart discord.StatsTrackerAutoStart,
+) error {
+	if err := autoStart.Validate(); err != nil {
+		return err
+	}
+	if err := s.queries.UpsertStatsTrackerAutoStart(ctx, db.UpsertStatsTrackerAutoStartParams{
+		ChannelID: string(autoStart.ChannelId),
+		MinOnline: int64(autoStart.MinOnline),
+		Duration:  int64(autoStart.Duration),
+		StopBelow: int64(autoStart.StopBelow),
+	}); err != nil {
+		return fmt.Errorf("failed to save channel %q stats tracker auto start: %w", string(autoStart.ChannelId), err)
+	}
+	return nil
+}
+
+func (s *Storage) RemoveChannelStatsTrackerAutoStart(
+	ctx context.Context,
+	channelId discord.ChannelId,
+) error {
+	if err := s.queries.DeleteStatsTrackerAutoStart(ctx, string(channelId)); err != nil {
+		return fmt.Errorf("failed to remove channel %q stats tracker auto start: %w", string(channelId), err)
+	}
+	return nil
+}
+
+func statsTrackerAutoStartFromDTO(a db.StatsTrackerAutoStart) discord.StatsTrackerAutoStart {
+	return discord.StatsTrackerAutoStart{
+		ChannelId: discord.ChannelId(a.ChannelID),
+		MinOnline: int(a.MinOnline),
+		Duration:  time.Duration(a.Duration),
+		StopBelow: int(a.StopBelow),
+	}
+}
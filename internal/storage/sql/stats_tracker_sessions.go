@@ -0,0 +1,472 @@
+package sql_storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/discord"
+	"github.com/x0k/ps2-spy/internal/lib/db"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/shared"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
+)
+
+func (s *Storage) CreateStatsTrackerSession(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	platforms []ps2_platforms.Platform,
+	startedAt time.Time,
+	origin stats_tracker.SessionOrigin,
+	taskId discord.StatsTrackerTaskId,
+	filter discord.StatsTrackerFilter,
+) (stats_tracker.SessionId, error) {
+	var sessionId int64
+	err := s.Begin(ctx, 0, func(s *Storage) error {
+		var err error
+		sessionId, err = s.queries.InsertStatsTrackerSession(ctx, db.InsertStatsTrackerSessionParams{
+			ChannelID: string(channelId),
+			StartedAt: startedAt,
+			Origin:    string(origin),
+			Filter:    strings.Join(filter, ","),
+			TaskID: sql.NullInt64{
+				Int64: int64(taskId),
+				Valid: taskId != 0,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert stats tracker session: %w", err)
+		}
+		for _, platform := range platforms {
+			if err := s.queries.InsertStatsTrackerSessionPlatform(ctx, db.InsertStatsTrackerSessionPlatformParams{
+				SessionID: sessionId,
+				Platform:  string(platform),
+			}); err != nil {
+				return fmt.Errorf("failed to insert stats tracker session platform %q: %w", platform, err)
+			}
+		}
+		return nil
+	})
+	return stats_tracker.SessionId(sessionId), err
+}
+
+func (s *Storage) SaveStatsTrackerSession(ctx context.Context, session stats_tracker.Session) error {
+	sessionId := int64(session.Id)
+	return s.Begin(ctx, 0, func(s *Storage) error {
+		for _, c := range session.Characters {
+			if err := s.queries.UpsertStatsTrackerCharacter(ctx, db.UpsertStatsTrackerCharacterParams{
+				SessionID:              sessionId,
+				Platform:               string(c.Platform),
+				CharacterID:            string(c.CharacterId),
+				BodyKills:              int64(c.BodyKills),
+				HeadShotsKills:         int64(c.HeadShotsKills),
+				TeamKills:              int64(c.TeamKills),
+				Deaths:                 int64(c.Deaths),
+				DeathsByRestrictedArea: int64(c.DeathsByRestrictedArea),
+				Suicides:               int64(c.Suicides),
+				Revives:                int64(c.Revives),
+				Heals:                  int64(c.Heals),
+				Repairs:                int64(c.Repairs),
+				Resupplies:             int64(c.Resupplies),
+				ShieldRepairs:          int64(c.ShieldRepairs),
+				SpotAssists:            int64(c.SpotAssists),
+				Score:                  int64(c.Score),
+				OnlineDuration:         int64(c.OnlineDuration),
+				BattleRankUps:          int64(c.BattleRankUps),
+				BattleRank:             int64(c.BattleRank),
+				Prestiges:              int64(c.Prestiges),
+			}); err != nil {
+				return fmt.Errorf("failed to save character %q: %w", c.CharacterId, err)
+			}
+			for loadoutType, duration := range c.LoadoutsDistribution {
+				if duration == 0 {
+					continue
+				}
+				if err := s.queries.UpsertStatsTrackerCharacterLoadout(ctx, db.UpsertStatsTrackerCharacterLoadoutParams{
+					SessionID:   sessionId,
+					Platform:    string(c.Platform),
+					CharacterID: string(c.CharacterId),
+					LoadoutType: int64(loadoutType),
+					Duration:    int64(duration),
+				}); err != nil {
+					return fmt.Errorf("failed to save character %q loadout: %w", c.CharacterId, err)
+				}
+			}
+			for vehicleId, counts := range c.Vehicles {
+				if err := s.queries.UpsertStatsTrackerCharacterVehicle(ctx, db.UpsertStatsTrackerCharacterVehicleParams{
+					SessionID:   sessionId,
+					Platform:    string(c.Platform),
+					CharacterID: string(c.CharacterId),
+					VehicleID:   string(vehicleId),
+					Destroyed:   int64(counts.Destroyed),
+					Lost:        int64(counts.Lost),
+				}); err != nil {
+					return fmt.Errorf("failed to save character %q vehicle %q: %w", c.CharacterId, vehicleId, err)
+				}
+			}
+			for weaponId, counts := range c.Weapons {
+				if err := s.queries.UpsertStatsTrackerCharacterWeapon(ctx, db.UpsertStatsTrackerCharacterWeaponParams{
+					SessionID:      sessionId,
+					Platform:       string(c.Platform),
+					CharacterID:    string(c.CharacterId),
+					WeaponID:       string(weaponId),
+					Kills:          int64(counts.Kills),
+					HeadShotsKills: int64(counts.HeadShotsKills),
+				}); err != nil {
+					return fmt.Errorf("failed to save character %q weapon %q: %w", c.CharacterId, weaponId, err)
+				}
+			}
+			for facilityId, counts := range c.Facilities {
+				if err := s.queries.UpsertStatsTrackerCharacterFacility(ctx, db.UpsertStatsTrackerCharacterFacilityParams{
+					SessionID:   sessionId,
+					Platform:    string(c.Platform),
+					CharacterID: string(c.CharacterId),
+					FacilityID:  string(facilityId),
+					Captures:    int64(counts.Captures),
+					Defenses:    int64(counts.Defenses),
+				}); err != nil {
+					return fmt.Errorf("failed to save character %q facility %q: %w", c.CharacterId, facilityId, err)
+				}
+			}
+			for rivalId, counts := range c.Rivals {
+				if err := s.queries.UpsertStatsTrackerCharacterRival(ctx, db.UpsertStatsTrackerCharacterRivalParams{
+					SessionID:   sessionId,
+					Platform:    string(c.Platform),
+					CharacterID: string(c.CharacterId),
+					RivalID:     string(rivalId),
+					Kills:       int64(counts.Kills),
+					Deaths:      int64(counts.Deaths),
+				}); err != nil {
+					return fmt.Errorf("failed to save character %q rival %q: %w", c.CharacterId, rivalId, err)
+				}
+			}
+			for achievementId, count := range c.Achievements {
+				if err := s.queries.UpsertStatsTrackerCharacterAchievement(ctx, db.UpsertStatsTrackerCharacterAchievementParams{
+					SessionID:     sessionId,
+					Platform:      string(c.Platform),
+					CharacterID:   string(c.CharacterId),
+					AchievementID: string(achievementId),
+					Count:         int64(count),
+				}); err != nil {
+					return fmt.Errorf("failed to save character %q achievement %q: %w", c.CharacterId, achievementId, err)
+				}
+			}
+			for zoneId, counts := range c.Zones {
+				if err := s.queries.UpsertStatsTrackerCharacterZone(ctx, db.UpsertStatsTrackerCharacterZoneParams{
+					SessionID:   sessionId,
+					Platform:    string(c.Platform),
+					CharacterID: string(c.CharacterId),
+					ZoneID:      string(zoneId),
+					Kills:       int64(counts.Kills),
+					Deaths:      int64(counts.Deaths),
+					Duration:    int64(counts.Duration),
+				}); err != nil {
+					return fmt.Errorf("failed to save character %q zone %q: %w", c.CharacterId, zoneId, err)
+				}
+			}
+		}
+		if session.StoppedAt.IsZero() {
+			return nil
+		}
+		if err := s.queries.UpdateStatsTrackerSessionStoppedAt(ctx, db.UpdateStatsTrackerSessionStoppedAtParams{
+			StoppedAt: sql.NullTime{Time: session.StoppedAt, Valid: true},
+			SessionID: sessionId,
+		}); err != nil {
+			return fmt.Errorf("failed to stop stats tracker session: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *Storage) ActiveStatsTrackerSessions(ctx context.Context) ([]stats_tracker.Session, error) {
+	data, err := s.queries.ListActiveStatsTrackerSessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active stats tracker sessions: %w", err)
+	}
+	sessions := make([]stats_tracker.Session, 0, len(data))
+	for _, dto := range data {
+		session, err := s.statsTrackerSessionFromDTO(ctx, dto)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func (s *Storage) SaveStatsTrackerSessionLeaderboard(
+	ctx context.Context,
+	sessionId stats_tracker.SessionId,
+	messageId discord.MessageId,
+) error {
+	if err := s.queries.UpdateStatsTrackerSessionLeaderboardMessage(ctx, db.UpdateStatsTrackerSessionLeaderboardMessageParams{
+		LeaderboardMessageID: string(messageId),
+		SessionID:            int64(sessionId),
+	}); err != nil {
+		return fmt.Errorf("failed to save stats tracker session %d leaderboard: %w", sessionId, err)
+	}
+	return nil
+}
+
+// Leaderboard messages of the running sessions
+func (s *Storage) ActiveStatsTrackerLeaderboards(ctx context.Context) (map[discord.ChannelId]discord.MessageId, error) {
+	data, err := s.queries.ListActiveStatsTrackerSessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active stats tracker sessions: %w", err)
+	}
+	leaderboards := make(map[discord.ChannelId]discord.MessageId, len(data))
+	for _, dto := range data {
+		if dto.LeaderboardMessageID != "" {
+			leaderboards[discord.ChannelId(dto.ChannelID)] = discord.MessageId(dto.LeaderboardMessageID)
+		}
+	}
+	return leaderboards, nil
+}
+
+func (s *Storage) PreviousScheduledStatsTrackerSession(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	taskId discord.StatsTrackerTaskId,
+	sessionId stats_tracker.SessionId,
+) (stats_tracker.Session, error) {
+	dto, err := s.queries.GetPreviousScheduledStatsTrackerSession(ctx, db.GetPreviousScheduledStatsTrackerSessionParams{
+		ChannelID: string(channelId),
+		TaskID: sql.NullInt64{
+			Int64: int64(taskId),
+			Valid: true,
+		},
+		SessionID: int64(sessionId),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return stats_tracker.Session{}, shared.ErrNotFound
+		}
+		return stats_tracker.Session{}, fmt.Errorf("failed to get previous scheduled stats tracker session: %w", err)
+	}
+	return s.statsTrackerSessionFromDTO(ctx, dto)
+}
+
+func (s *Storage) ChannelStatsTrackerSession(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	sessionId stats_tracker.SessionId,
+) (stats_tracker.Session, error) {
+	dto, err := s.queries.GetChannelStatsTrackerSession(ctx, db.GetChannelStatsTrackerSessionParams{
+		SessionID: int64(sessionId),
+		ChannelID: string(channelId),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return stats_tracker.Session{}, shared.ErrNotFound
+		}
+		return stats_tracker.Session{}, fmt.Errorf("failed to get stats tracker session %d: %w", sessionId, err)
+	}
+	return s.statsTrackerSessionFromDTO(ctx, dto)
+}
+
+func (s *Storage) ChannelStatsTrackerSessions(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	limit int,
+) ([]stats_tracker.Session, error) {
+	data, err := s.queries.ListChannelStatsTrackerSessions(ctx, db.ListChannelStatsTrackerSessionsParams{
+		ChannelID: string(channelId),
+		Limit:     int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channel stats tracker sessions: %w", err)
+	}
+	sessions := make([]stats_tracker.Session, 0, len(data))
+	for _, dto := range data {
+		session, err := s.statsTrackerSessionFromDTO(ctx, dto)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func (s *Storage) statsTrackerSessionFromDTO(
+	ctx context.Context,
+	dto db.StatsTrackerSession,
+) (stats_tracker.Session, error) {
+	platforms, err := s.queries.ListStatsTrackerSessionPlatforms(ctx, dto.SessionID)
+	if err != nil {
+		return stats_tracker.Session{}, fmt.Errorf("failed to list session %d platforms: %w", dto.SessionID, err)
+	}
+	characters, err := s.queries.ListStatsTrackerSessionCharacters(ctx, dto.SessionID)
+	if err != nil {
+		return stats_tracker.Session{}, fmt.Errorf("failed to list session %d characters: %w", dto.SessionID, err)
+	}
+	loadouts, err := s.queries.ListStatsTrackerSessionCharacterLoadouts(ctx, dto.SessionID)
+	if err != nil {
+		return stats_tracker.Session{}, fmt.Errorf("failed to list session %d loadouts: %w", dto.SessionID, err)
+	}
+	vehicles, err := s.queries.ListStatsTrackerSessionCharacterVehicles(ctx, dto.SessionID)
+	if err != nil {
+		return stats_tracker.Session{}, fmt.Errorf("failed to list session %d vehicles: %w", dto.SessionID, err)
+	}
+	weapons, err := s.queries.ListStatsTrackerSessionCharacterWeapons(ctx, dto.SessionID)
+	if err != nil {
+		return stats_tracker.Session{}, fmt.Errorf("failed to list session %d weapons: %w", dto.SessionID, err)
+	}
+	facilities, err := s.queries.ListStatsTrackerSessionCharacterFacilities(ctx, dto.SessionID)
+	if err != nil {
+		return stats_tracker.Session{}, fmt.Errorf("failed to list session %d facilities: %w", dto.SessionID, err)
+	}
+	rivals, err := s.queries.ListStatsTrackerSessionCharacterRivals(ctx, dto.SessionID)
+	if err != nil {
+		return stats_tracker.Session{}, fmt.Errorf("failed to list session %d rivals: %w", dto.SessionID, err)
+	}
+	achievements, err := s.queries.ListStatsTrackerSessionCharacterAchievements(ctx, dto.SessionID)
+	if err != nil {
+		return stats_tracker.Session{}, fmt.Errorf("failed to list session %d achievements: %w", dto.SessionID, err)
+	}
+	zones, err := s.queries.ListStatsTrackerSessionCharacterZones(ctx, dto.SessionID)
+	if err != nil {
+		return stats_tracker.Session{}, fmt.Errorf("failed to list session %d zones: %w", dto.SessionID, err)
+	}
+	type characterKey struct {
+		platform    string
+		characterId string
+	}
+	distributions := make(map[characterKey][ps2_loadout.LoadoutTypeCount]time.Duration, len(characters))
+	for _, l := range loadouts {
+		if l.LoadoutType < 0 || l.LoadoutType >= int64(ps2_loadout.LoadoutTypeCount) {
+			continue
+		}
+		key := characterKey{l.Platform, l.CharacterID}
+		d := distributions[key]
+		d[l.LoadoutType] = time.Duration(l.Duration)
+		distributions[key] = d
+	}
+	charactersVehicles := make(map[characterKey]map[ps2.VehicleId]stats_tracker.VehicleCounts)
+	for _, v := range vehicles {
+		key := characterKey{v.Platform, v.CharacterID}
+		counts, ok := charactersVehicles[key]
+		if !ok {
+			counts = make(map[ps2.VehicleId]stats_tracker.VehicleCounts)
+			charactersVehicles[key] = counts
+		}
+		counts[ps2.VehicleId(v.VehicleID)] = stats_tracker.VehicleCounts{
+			Destroyed: uint(v.Destroyed),
+			Lost:      uint(v.Lost),
+		}
+	}
+	charactersWeapons := make(map[characterKey]map[ps2.ItemId]stats_tracker.WeaponCounts)
+	for _, w := range weapons {
+		key := characterKey{w.Platform, w.CharacterID}
+		counts, ok := charactersWeapons[key]
+		if !ok {
+			counts = make(map[ps2.ItemId]stats_tracker.WeaponCounts)
+			charactersWeapons[key] = counts
+		}
+		counts[ps2.ItemId(w.WeaponID)] = stats_tracker.WeaponCounts{
+			Kills:          uint(w.Kills),
+			HeadShotsKills: uint(w.HeadShotsKills),
+		}
+	}
+	charactersFacilities := make(map[characterKey]map[ps2.FacilityId]stats_tracker.FacilityCounts)
+	for _, f := range facilities {
+		key := characterKey{f.Platform, f.CharacterID}
+		counts, ok := charactersFacilities[key]
+		if !ok {
+			counts = make(map[ps2.FacilityId]stats_tracker.FacilityCounts)
+			charactersFacilities[key] = counts
+		}
+		counts[ps2.FacilityId(f.FacilityID)] = stats_tracker.FacilityCounts{
+			Captures: uint(f.Captures),
+			Defenses: uint(f.Defenses),
+		}
+	}
+	charactersRivals := make(map[characterKey]map[ps2.CharacterId]stats_tracker.RivalCounts)
+	for _, r := range rivals {
+		key := characterKey{r.Platform, r.CharacterID}
+		counts, ok := charactersRivals[key]
+		if !ok {
+			counts = make(map[ps2.CharacterId]stats_tracker.RivalCounts)
+			charactersRivals[key] = counts
+		}
+		counts[ps2.CharacterId(r.RivalID)] = stats_tracker.RivalCounts{
+			Kills:  uint(r.Kills),
+			Deaths: uint(r.Deaths),
+		}
+	}
+	charactersAchievements := make(map[characterKey]map[ps2.AchievementId]uint)
+	for _, a := range achievements {
+		key := characterKey{a.Platform, a.CharacterID}
+		counts, ok := charactersAchievements[key]
+		if !ok {
+			counts = make(map[ps2.AchievementId]uint)
+			charactersAchievements[key] = counts
+		}
+		counts[ps2.AchievementId(a.AchievementID)] = uint(a.Count)
+	}
+	charactersZones := make(map[characterKey]map[ps2.ZoneId]stats_tracker.ZoneCounts)
+	for _, z := range zones {
+		key := characterKey{z.Platform, z.CharacterID}
+		counts, ok := charactersZones[key]
+		if !ok {
+			counts = make(map[ps2.ZoneId]stats_tracker.ZoneCounts)
+			charactersZones[key] = counts
+		}
+		counts[ps2.ZoneId(z.ZoneID)] = stats_tracker.ZoneCounts{
+			Kills:    uint(z.Kills),
+			Deaths:   uint(z.Deaths),
+			Duration: time.Duration(z.Duration),
+		}
+	}
+	session := stats_tracker.Session{
+		Id:         stats_tracker.SessionId(dto.SessionID),
+		ChannelId:  discord.ChannelId(dto.ChannelID),
+		StartedAt:  dto.StartedAt,
+		Origin:     stats_tracker.SessionOrigin(dto.Origin),
+		TaskId:     discord.StatsTrackerTaskId(dto.TaskID.Int64),
+		Filter:     statsTrackerFilterFromDTO(dto.Filter),
+		Platforms:  make([]ps2_platforms.Platform, 0, len(platforms)),
+		Characters: make([]stats_tracker.CharacterSnapshot, 0, len(characters)),
+	}
+	if dto.StoppedAt.Valid {
+		session.StoppedAt = dto.StoppedAt.Time
+	}
+	for _, p := range platforms {
+		session.Platforms = append(session.Platforms, ps2_platforms.Platform(p))
+	}
+	for _, c := range characters {
+		session.Characters = append(session.Characters, stats_tracker.CharacterSnapshot{
+			Platform:               ps2_platforms.Platform(c.Platform),
+			CharacterId:            ps2.CharacterId(c.CharacterID),
+			BodyKills:              uint(c.BodyKills),
+			HeadShotsKills:         uint(c.HeadShotsKills),
+			TeamKills:              uint(c.TeamKills),
+			Deaths:                 uint(c.Deaths),
+			DeathsByRestrictedArea: uint(c.DeathsByRestrictedArea),
+			Suicides:               uint(c.Suicides),
+			Revives:                uint(c.Revives),
+			Heals:                  uint(c.Heals),
+			Repairs:                uint(c.Repairs),
+			Resupplies:             uint(c.Resupplies),
+			ShieldRepairs:          uint(c.ShieldRepairs),
+			SpotAssists:            uint(c.SpotAssists),
+			Score:                  uint(c.Score),
+			Vehicles:               charactersVehicles[characterKey{c.Platform, c.CharacterID}],
+			Weapons:                charactersWeapons[characterKey{c.Platform, c.CharacterID}],
+			Facilities:             charactersFacilities[characterKey{c.Platform, c.CharacterID}],
+			Rivals:                 charactersRivals[characterKey{c.Platform, c.CharacterID}],
+			Zones:                  charactersZones[characterKey{c.Platform, c.CharacterID}],
+			OnlineDuration:         time.Duration(c.OnlineDuration),
+			BattleRankUps:          uint(c.BattleRankUps),
+			BattleRank:             uint(c.BattleRank),
+			Prestiges:              uint(c.Prestiges),
+			Achievements:           charactersAchievements[characterKey{c.Platform, c.CharacterID}],
+			LoadoutsDistribution:   distributions[characterKey{c.Platform, c.CharacterID}],
+		})
+	}
+	return session, nil
+}
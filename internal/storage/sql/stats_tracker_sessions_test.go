@@ -0,0 +1,173 @@
+package sql_storage
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/discord"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_loadout "github.com/x0k/ps2-spy/internal/ps2/loadout"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/shared"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
+)
+
+func TestStatsTrackerSessionPersistence(t *testing.T) {
+	const channelId discord.ChannelId = "channel"
+	s, _ := newTestStorage(t)
+	ctx := context.Background()
+	startedAt := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	platforms := []ps2_platforms.Platform{ps2_platforms.PC}
+	filter := discord.StatsTrackerFilter{"TAG"}
+	id, err := s.CreateStatsTrackerSession(ctx, channelId, platforms, startedAt, stats_tracker.ScheduledSession, 7, filter)
+	if err != nil {
+		t.Fatalf("CreateStatsTrackerSession() error = %v", err)
+	}
+	var distribution [ps2_loadout.LoadoutTypeCount]time.Duration
+	distribution[0] = 20 * time.Minute
+	character := stats_tracker.CharacterSnapshot{
+		Platform:               ps2_platforms.PC,
+		CharacterId:            "character",
+		BodyKills:              10,
+		HeadShotsKills:         4,
+		TeamKills:              1,
+		Deaths:                 5,
+		DeathsByRestrictedArea: 1,
+		Suicides:               1,
+		Revives:                3,
+		Heals:                  2,
+		Repairs:                1,
+		Resupplies:             1,
+		ShieldRepairs:          1,
+		SpotAssists:            2,
+		Score:                  1500,
+		Vehicles: map[ps2.VehicleId]stats_tracker.VehicleCounts{
+			"1": {Destroyed: 2, Lost: 1},
+		},
+		Weapons: map[ps2.ItemId]stats_tracker.WeaponCounts{
+			"80": {Kills: 8, HeadShotsKills: 3},
+		},
+		Facilities: map[ps2.FacilityId]stats_tracker.FacilityCounts{
+			"222280": {Captures: 1, Defenses: 2},
+		},
+		Rivals: map[ps2.CharacterId]stats_tracker.RivalCounts{
+			"rival": {Kills: 2, Deaths: 1},
+		},
+		Zones: map[ps2.ZoneId]stats_tracker.ZoneCounts{
+			"2": {Kills: 10, Deaths: 5, Duration: 30 * time.Minute},
+		},
+		OnlineDuration:       35 * time.Minute,
+		BattleRankUps:        1,
+		BattleRank:           42,
+		Achievements:         map[ps2.AchievementId]uint{"90039": 2},
+		LoadoutsDistribution: distribution,
+	}
+	session := stats_tracker.Session{
+		Id:         id,
+		ChannelId:  channelId,
+		StartedAt:  startedAt,
+		Origin:     stats_tracker.ScheduledSession,
+		TaskId:     7,
+		Filter:     filter,
+		Platforms:  platforms,
+		Characters: []stats_tracker.CharacterSnapshot{character},
+	}
+	if err := s.SaveStatsTrackerSession(ctx, session); err != nil {
+		t.Fatalf("SaveStatsTrackerSession() error = %v", err)
+	}
+	active, err := s.ActiveStatsTrackerSessions(ctx)
+	if err != nil {
+		t.Fatalf("ActiveStatsTrackerSessions() error = %v", err)
+	}
+	if len(active) != 1 {
+		t.Fatalf("expected 1 active session, got %d", len(active))
+	}
+	assertSession(t, active[0], session)
+
+	// Counters are replaced on the next save
+	session.Characters[0].BodyKills = 12
+	session.StoppedAt = startedAt.Add(time.Hour)
+	if err := s.SaveStatsTrackerSession(ctx, session); err != nil {
+		t.Fatalf("SaveStatsTrackerSession() error = %v", err)
+	}
+	if active, err := s.ActiveStatsTrackerSessions(ctx); err != nil || len(active) != 0 {
+		t.Errorf("expected no active sessions after the stop, got %v, %v", active, err)
+	}
+	stored, err := s.ChannelStatsTrackerSession(ctx, channelId, id)
+	if err != nil {
+		t.Fatalf("ChannelStatsTrackerSession() error = %v", err)
+	}
+	assertSession(t, stored, session)
+	if _, err := s.ChannelStatsTrackerSession(ctx, "other", id); !errors.Is(err, shared.ErrNotFound) {
+		t.Errorf("expected the session of another channel to be not found, got %v", err)
+	}
+
+	nextId, err := s.CreateStatsTrackerSession(ctx, channelId, platforms, startedAt.Add(24*time.Hour), stats_tracker.ScheduledSession, 7, filter)
+	if err != nil {
+		t.Fatalf("CreateStatsTrackerSession() error = %v", err)
+	}
+	previous, err := s.PreviousScheduledStatsTrackerSession(ctx, channelId, 7, nextId)
+	if err != nil {
+		t.Fatalf("PreviousScheduledStatsTrackerSession() error = %v", err)
+	}
+	if previous.Id != id {
+		t.Errorf("previous session = %d, want %d", previous.Id, id)
+	}
+	if _, err := s.PreviousScheduledStatsTrackerSession(ctx, channelId, 8, nextId); !errors.Is(err, shared.ErrNotFound) {
+		t.Errorf("expected no previous session of another task, got %v", err)
+	}
+}
+
+func assertSession(t *testing.T, got, want stats_tracker.Session) {
+	t.Helper()
+	if !got.StartedAt.Equal(want.StartedAt) || !got.StoppedAt.Equal(want.StoppedAt) {
+		t.Errorf("session time = %v - %v, want %v - %v", got.StartedAt, got.StoppedAt, want.StartedAt, want.StoppedAt)
+	}
+	got.StartedAt, got.StoppedAt = want.StartedAt, want.StoppedAt
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("session = %+v, want %+v", got, want)
+	}
+}
+
+func TestStatsTrackerSessionLeaderboards(t *testing.T) {
+	s, _ := newTestStorage(t)
+	ctx := context.Background()
+	startedAt := time.Now()
+	platforms := []ps2_platforms.Platform{ps2_platforms.PC}
+	running, err := s.CreateStatsTrackerSession(ctx, "running", platforms, startedAt, stats_tracker.ManualSession, 0, nil)
+	if err != nil {
+		t.Fatalf("CreateStatsTrackerSession() error = %v", err)
+	}
+	stopped, err := s.CreateStatsTrackerSession(ctx, "stopped", platforms, startedAt, stats_tracker.ManualSession, 0, nil)
+	if err != nil {
+		t.Fatalf("CreateStatsTrackerSession() error = %v", err)
+	}
+	if _, err := s.CreateStatsTrackerSession(ctx, "without", platforms, startedAt, stats_tracker.ManualSession, 0, nil); err != nil {
+		t.Fatalf("CreateStatsTrackerSession() error = %v", err)
+	}
+	for id, messageId := range map[stats_tracker.SessionId]discord.MessageId{running: "1", stopped: "2"} {
+		if err := s.SaveStatsTrackerSessionLeaderboard(ctx, id, messageId); err != nil {
+			t.Fatalf("SaveStatsTrackerSessionLeaderboard() error = %v", err)
+		}
+	}
+	if err := s.SaveStatsTrackerSession(ctx, stats_tracker.Session{
+		Id:        stopped,
+		ChannelId: "stopped",
+		StartedAt: startedAt,
+		StoppedAt: startedAt.Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("SaveStatsTrackerSession() error = %v", err)
+	}
+	leaderboards, err := s.ActiveStatsTrackerLeaderboards(ctx)
+	if err != nil {
+		t.Fatalf("ActiveStatsTrackerLeaderboards() error = %v", err)
+	}
+	want := map[discord.ChannelId]discord.MessageId{"running": "1"}
+	if !maps.Equal(leaderboards, want) {
+		t.Errorf("leaderboards = %v, want %v", leaderboards, want)
+	}
+}
@@ -2,29 +2,44 @@ package sql_storage
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/x0k/ps2-spy/internal/discord"
 	"github.com/x0k/ps2-spy/internal/lib/db"
+	"github.com/x0k/ps2-spy/internal/lib/logger/sl"
 	"github.com/x0k/ps2-spy/internal/shared"
 )
 
-func (s *Storage) ActiveStatsTrackerTasks(ctx context.Context, now time.Time) ([]discord.ChannelId, error) {
-	utc := now.UTC()
-	utcTime := utc.Hour()*int(time.Hour) + utc.Minute()*int(time.Minute)
-	data, err := s.queries.ListActiveStatsTrackerTasks(ctx, db.ListActiveStatsTrackerTasksParams{
-		UtcWeekday: int64(utc.Weekday()),
-		UtcTime:    int64(utcTime),
+// Tasks are stored in local time, so activity is resolved
+// with the timezone database instead of the query
+// Returns a single task per channel since the tasks of the channel can't overlap
+func (s *Storage) ActiveStatsTrackerTasks(ctx context.Context, now time.Time) ([]discord.StatsTrackerTask, error) {
+	data, err := s.queries.ListActiveStatsTrackerTasks(ctx, sql.NullTime{
+		Time:  now.UTC(),
+		Valid: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list active stats tracker tasks: %w", err)
 	}
-	var channelIds []discord.ChannelId
-	for _, channelId := range data {
-		channelIds = append(channelIds, discord.ChannelId(channelId))
+	var tasks []discord.StatsTrackerTask
+	for _, t := range data {
+		task, err := statsTrackerTaskFromDTO(t)
+		if err != nil {
+			s.log.Warn(ctx, "failed to parse stats tracker task", slog.Int64("task_id", t.TaskID), sl.Err(err))
+			continue
+		}
+		if task.IsActive(now) && !slices.ContainsFunc(tasks, func(active discord.StatsTrackerTask) bool {
+			return active.ChannelId == task.ChannelId
+		}) {
+			tasks = append(tasks, task)
+		}
 	}
-	return channelIds, nil
+	return tasks, nil
 }
 
 func (s *Storage) ChannelStatsTrackerTasks(
@@ -37,7 +52,11 @@ func (s *Storage) ChannelStatsTrackerTasks(
 	}
 	tasks := make([]discord.StatsTrackerTask, 0, len(data))
 	for _, t := range data {
-		tasks = append(tasks, statsTrackerTaskFromDTO(t))
+		task, err := statsTrackerTaskFromDTO(t)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
 	}
 	return tasks, nil
 }
@@ -47,7 +66,7 @@ func (s *Storage) StatsTrackerTask(ctx context.Context, taskId discord.StatsTrac
 	if err != nil {
 		return discord.StatsTrackerTask{}, fmt.Errorf("failed to get stats tracker task %d: %w", taskId, err)
 	}
-	return statsTrackerTaskFromDTO(data), nil
+	return statsTrackerTaskFromDTO(data)
 }
 
 func (s *Storage) CreateStatsTrackerTask(
@@ -55,9 +74,8 @@ func (s *Storage) CreateStatsTrackerTask(
 	channelId discord.ChannelId,
 	task discord.StatsTrackerTaskState,
 ) error {
-	offset := newUtcOffset(task.Timezone)
 	return s.Begin(ctx, 0, func(s *Storage) error {
-		return createStatsTrackerTask(ctx, s, channelId, offset, task)
+		return createStatsTrackerTask(ctx, s, channelId, task)
 	})
 }
 
@@ -73,7 +91,6 @@ func (s *Storage) UpdateStatsTrackerTask(
 	channelId discord.ChannelId,
 	task discord.StatsTrackerTaskState,
 ) error {
-	offset := newUtcOffset(task.Timezone)
 	return s.Begin(ctx, 0, func(s *Storage) error {
 		if err := s.queries.RemoveChannelStatsTrackerTask(ctx, db.RemoveChannelStatsTrackerTaskParams{
 			ChannelID: string(channelId),
@@ -81,33 +98,28 @@ func (s *Storage) UpdateStatsTrackerTask(
 		}); err != nil {
 			return fmt.Errorf("failed to remove stats tracker task: %w", err)
 		}
-		return createStatsTrackerTask(ctx, s, channelId, offset, task)
+		return createStatsTrackerTask(ctx, s, channelId, task)
 	})
 }
 
-func newUtcOffset(timezone *time.Location) time.Duration {
-	_, offsetInSeconds := time.Now().In(timezone).Zone()
-	return -(time.Duration(offsetInSeconds) * time.Second)
-}
-
 func createStatsTrackerTask(
 	ctx context.Context,
 	s *Storage,
 	channelId discord.ChannelId,
-	offset time.Duration,
-	task discord.StatsTrackerTaskState,
+	state discord.StatsTrackerTaskState,
 ) error {
-	if task.Duration > s.maxTrackingDuration {
+	if state.Duration > s.maxTrackingDuration {
 		return discord.ErrStatsTrackerTaskDurationTooLong{
 			MaxDuration: s.maxTrackingDuration,
-			GotDuration: task.Duration,
+			GotDuration: state.Duration,
 		}
 	}
-	count, err := s.queries.GetCountChannelStatsTrackerTasks(ctx, string(channelId))
+	existingTasks, err := s.ChannelStatsTrackerTasks(ctx, channelId)
 	if err != nil {
-		return fmt.Errorf("failed to get count of channel %q stats tracker tasks: %w", string(channelId), err)
+		return err
 	}
-	finalCount := int(count) + len(task.LocalWeekdays)
+	tasks := state.Tasks(channelId)
+	finalCount := len(existingTasks) + len(tasks)
 	if finalCount > discord.MAX_AMOUNT_OF_TASKS_PER_CHANNEL {
 		return fmt.Errorf(
 			"%w: expected %d, got %d",
@@ -116,51 +128,119 @@ func createStatsTrackerTask(
 			finalCount,
 		)
 	}
-	for _, localWeekday := range task.LocalWeekdays {
-		localStart := time.Duration(task.LocalStartHour)*time.Hour + time.Duration(task.LocalStartMin)*time.Minute
-		utcStartWeekday, utcStartTime := shared.NormalizeDate(localWeekday, localStart+offset)
-		utcEndWeekday, utcEndTime := shared.NormalizeDate(localWeekday, localStart+offset+task.Duration)
-		if rawTasks, err := s.queries.ListChannelIntersectingStatsTrackerTasks(ctx, db.ListChannelIntersectingStatsTrackerTasksParams{
-			ChannelID:    string(channelId),
-			StartWeekday: int64(utcStartWeekday),
-			StartTime:    int64(utcStartTime),
-			EndWeekday:   int64(utcEndWeekday),
-			EndTime:      int64(utcEndTime),
-		}); err != nil {
-			return fmt.Errorf("failed to list intersecting stats tracker tasks: %w", err)
-		} else if len(rawTasks) > 0 {
-			tasks := make([]discord.StatsTrackerTask, len(rawTasks))
-			for i, rawTask := range rawTasks {
-				tasks[i] = statsTrackerTaskFromDTO(rawTask)
+	now := time.Now()
+	for _, task := range tasks {
+		var overlapping []discord.StatsTrackerTask
+		for _, existingTask := range existingTasks {
+			if task.Overlaps(existingTask, now) {
+				overlapping = append(overlapping, existingTask)
 			}
+		}
+		if len(overlapping) > 0 {
 			return discord.ErrOverlappingTasks{
-				LocalWeekday:   localWeekday,
-				LocalStartTime: localStart,
-				Duration:       task.Duration,
-				Offset:         offset,
-				Tasks:          tasks,
+				Task:  task,
+				Tasks: overlapping,
 			}
 		}
+		expiresAt, expires := task.ExpiresAt()
+		dates := make([]string, 0, len(task.Dates))
+		for _, d := range task.Dates {
+			dates = append(dates, d.String())
+		}
 		if err := s.queries.InsertChannelStatsTrackerTask(ctx, db.InsertChannelStatsTrackerTaskParams{
-			ChannelID:       string(channelId),
-			UtcStartWeekday: int64(utcStartWeekday),
-			UtcStartTime:    int64(utcStartTime),
-			UtcEndWeekday:   int64(utcEndWeekday),
-			UtcEndTime:      int64(utcEndTime),
+			ChannelID:    string(channelId),
+			Timezone:     task.Timezone.String(),
+			Recurrence:   string(task.Recurrence),
+			Weekday:      int64(task.Weekday),
+			IntervalDays: int64(task.IntervalDays),
+			Dates:        strings.Join(dates, ","),
+			StartTime:    int64(task.StartTime),
+			Duration:     int64(task.Duration),
+			ExpiresAt: sql.NullTime{
+				Time:  expiresAt.UTC(),
+				Valid: expires,
+			},
+			Filter: strings.Join(task.Filter, ","),
 		}); err != nil {
 			return fmt.Errorf("failed to create stats tracker task: %w", err)
 		}
+		existingTasks = append(existingTasks, task)
 	}
 	return nil
 }
 
-func statsTrackerTaskFromDTO(task db.StatsTrackerTask) discord.StatsTrackerTask {
+// Tasks created before the local time support are stored in UTC,
+// they are converted with the current offset of the channel default
+// timezone, the same way they were displayed to the channel
+func (s *Storage) ConvertUtcStatsTrackerTasks(ctx context.Context) error {
+	return s.Begin(ctx, 0, func(s *Storage) error {
+		tasks, err := s.queries.ListUtcStatsTrackerTasks(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list utc stats tracker tasks: %w", err)
+		}
+		now := time.Now()
+		for _, t := range tasks {
+			timezone, err := time.LoadLocation(t.DefaultTimezone)
+			if err != nil {
+				s.log.Warn(ctx, "failed to load channel timezone", slog.Int64("task_id", t.TaskID), sl.Err(err))
+				continue
+			}
+			_, offsetInSeconds := now.In(timezone).Zone()
+			weekday, startTime := shared.NormalizeDate(
+				time.Weekday(t.Weekday),
+				time.Duration(t.StartTime)+time.Duration(offsetInSeconds)*time.Second,
+			)
+			if err := s.queries.UpdateStatsTrackerTaskLocalTime(ctx, db.UpdateStatsTrackerTaskLocalTimeParams{
+				Timezone:  timezone.String(),
+				Weekday:   int64(weekday),
+				StartTime: int64(startTime),
+				TaskID:    t.TaskID,
+			}); err != nil {
+				return fmt.Errorf("failed to convert stats tracker task %d: %w", t.TaskID, err)
+			}
+		}
+		if err := s.queries.DeleteUtcStatsTrackerTasks(ctx); err != nil {
+			return fmt.Errorf("failed to delete utc stats tracker tasks: %w", err)
+		}
+		if len(tasks) > 0 {
+			s.log.Info(ctx, "utc stats tracker tasks converted", slog.Int("count", len(tasks)))
+		}
+		return nil
+	})
+}
+
+func statsTrackerTaskFromDTO(task db.StatsTrackerTask) (discord.StatsTrackerTask, error) {
+	timezone, err := time.LoadLocation(task.Timezone)
+	if err != nil {
+		return discord.StatsTrackerTask{}, fmt.Errorf("failed to load task %d timezone: %w", task.TaskID, err)
+	}
+	var dates []shared.Date
+	if task.Dates != "" {
+		for _, raw := range strings.Split(task.Dates, ",") {
+			d, err := shared.ParseDate(raw)
+			if err != nil {
+				return discord.StatsTrackerTask{}, fmt.Errorf("failed to parse task %d date: %w", task.TaskID, err)
+			}
+			dates = append(dates, d)
+		}
+	}
 	return discord.StatsTrackerTask{
-		Id:              discord.StatsTrackerTaskId(task.TaskID),
-		ChannelId:       discord.ChannelId(task.ChannelID),
-		UtcStartWeekday: time.Weekday(task.UtcStartWeekday),
-		UtcStartTime:    time.Duration(task.UtcStartTime),
-		UtcEndWeekday:   time.Weekday(task.UtcEndWeekday),
-		UtcEndTime:      time.Duration(task.UtcEndTime),
+		Id:           discord.StatsTrackerTaskId(task.TaskID),
+		ChannelId:    discord.ChannelId(task.ChannelID),
+		Timezone:     timezone,
+		Recurrence:   discord.StatsTrackerTaskRecurrence(task.Recurrence),
+		Weekday:      time.Weekday(task.Weekday),
+		IntervalDays: int(task.IntervalDays),
+		Dates:        dates,
+		StartTime:    time.Duration(task.StartTime),
+		Duration:     time.Duration(task.Duration),
+		Filter:       statsTrackerFilterFromDTO(task.Filter),
+	}, nil
+}
+
+func statsTrackerFilterFromDTO(filter string) discord.StatsTrackerFilter {
+	if filter == "" {
+		return nil
 	}
+	return strings.Split(filter, ",")
 }
@@ -14,9 +14,11 @@ import (
 	"github.com/x0k/ps2-spy/internal/lib/logger"
 	"github.com/x0k/ps2-spy/internal/lib/logger/sl"
 	"github.com/x0k/ps2-spy/internal/lib/pubsub"
+	"github.com/x0k/ps2-spy/internal/outfit_activity"
 	"github.com/x0k/ps2-spy/internal/ps2"
 	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
 	"github.com/x0k/ps2-spy/internal/shared"
+	"github.com/x0k/ps2-spy/internal/stats_tracker"
 	"github.com/x0k/ps2-spy/internal/storage"
 	"golang.org/x/text/language"
 
@@ -396,6 +398,42 @@ func (s *Storage) SaveFacility(ctx context.Context, facility ps2.Facility) error
 	})
 }
 
+func (s *Storage) Items(ctx context.Context, itemIds []ps2.ItemId) (map[ps2.ItemId]ps2.Item, error) {
+	ids := make([]string, len(itemIds))
+	for i, id := range itemIds {
+		ids[i] = string(id)
+	}
+	items, err := s.queries.ListItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[ps2.ItemId]ps2.Item, len(items))
+	for _, item := range items {
+		itemId := ps2.ItemId(item.ItemID)
+		res[itemId] = ps2.Item{
+			Id:       itemId,
+			Name:     item.ItemName,
+			Category: item.ItemCategory,
+		}
+	}
+	return res, nil
+}
+
+func (s *Storage) SaveItems(ctx context.Context, items map[ps2.ItemId]ps2.Item) error {
+	return s.Begin(ctx, 0, func(s *Storage) error {
+		for _, item := range items {
+			if err := s.queries.UpsertItem(ctx, db.UpsertItemParams{
+				ItemID:       string(item.Id),
+				ItemName:     item.Name,
+				ItemCategory: item.Category,
+			}); err != nil {
+				return fmt.Errorf("failed to save item %q: %w", item.Id, err)
+			}
+		}
+		return nil
+	})
+}
+
 func (s *Storage) Channel(
 	ctx context.Context,
 	channelId discord.ChannelId,
@@ -485,6 +523,135 @@ func (s *Storage) SaveChannelDefaultTimezone(
 	})
 }
 
+func (s *Storage) SaveChannelStatsTrackerDetails(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	enabled bool,
+) error {
+	err := s.queries.UpsertChannelStatsTrackerDetails(ctx, db.UpsertChannelStatsTrackerDetailsParams{
+		ChannelID:           string(channelId),
+		StatsTrackerDetails: enabled,
+	})
+	return s.publish(err, storage.ChannelStatsTrackerDetailsSaved{
+		ChannelId: channelId,
+		Enabled:   enabled,
+	})
+}
+
+func (s *Storage) SaveChannelStatsTrackerSort(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	order stats_tracker.SortOrder,
+) error {
+	err := s.queries.UpsertChannelStatsTrackerSort(ctx, db.UpsertChannelStatsTrackerSortParams{
+		ChannelID:        string(channelId),
+		StatsTrackerSort: string(order),
+	})
+	return s.publish(err, storage.ChannelStatsTrackerSortSaved{
+		ChannelId: channelId,
+		Order:     string(order),
+	})
+}
+
+func (s *Storage) SaveChannelStatsTrackerCharts(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	enabled bool,
+) error {
+	err := s.queries.UpsertChannelStatsTrackerCharts(ctx, db.UpsertChannelStatsTrackerChartsParams{
+		ChannelID:          string(channelId),
+		StatsTrackerCharts: enabled,
+	})
+	return s.publish(err, storage.ChannelStatsTrackerChartsSaved{
+		ChannelId: channelId,
+		Enabled:   enabled,
+	})
+}
+
+// Empty formula resets the ranking to the default one
+func (s *Storage) SaveChannelStatsTrackerScoreFormula(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	formula string,
+) error {
+	parsed, err := stats_tracker.ParseScoreFormula(formula)
+	if err != nil {
+		return err
+	}
+	err = s.queries.UpsertChannelStatsTrackerScoreFormula(ctx, db.UpsertChannelStatsTrackerScoreFormulaParams{
+		ChannelID:                string(channelId),
+		StatsTrackerScoreFormula: parsed.String(),
+	})
+	return s.publish(err, storage.ChannelStatsTrackerScoreFormulaSaved{
+		ChannelId: channelId,
+		Formula:   parsed.String(),
+	})
+}
+
+func (s *Storage) SaveChannelCharacterChangesNotifications(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	enabled bool,
+) error {
+	err := s.queries.UpsertChannelCharacterChangesNotifications(ctx, db.UpsertChannelCharacterChangesNotificationsParams{
+		ChannelID:                     string(channelId),
+		CharacterChangesNotifications: enabled,
+	})
+	return s.publish(err, storage.ChannelCharacterChangesNotificationsSaved{
+		ChannelId: channelId,
+		Enabled:   enabled,
+	})
+}
+
+// Enabling the report postpones the first one to the next week
+func (s *Storage) SaveChannelOutfitActivityReport(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	enabled bool,
+) error {
+	err := s.queries.UpsertChannelOutfitActivityReport(ctx, db.UpsertChannelOutfitActivityReportParams{
+		ChannelID:                string(channelId),
+		OutfitActivityReport:     enabled,
+		OutfitActivityReportedAt: sql.NullTime{Time: time.Now(), Valid: enabled},
+	})
+	return s.publish(err, storage.ChannelOutfitActivityReportSaved{
+		ChannelId: channelId,
+		Enabled:   enabled,
+	})
+}
+
+func (s *Storage) SaveChannelOutfitActivityReportedAt(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	reportedAt time.Time,
+) error {
+	if err := s.queries.UpdateChannelOutfitActivityReportedAt(ctx, db.UpdateChannelOutfitActivityReportedAtParams{
+		OutfitActivityReportedAt: sql.NullTime{Time: reportedAt, Valid: true},
+		ChannelID:                string(channelId),
+	}); err != nil {
+		return fmt.Errorf("failed to save channel %q outfit activity report time: %w", channelId, err)
+	}
+	return nil
+}
+
+func (s *Storage) OutfitActivityReportChannels(ctx context.Context) ([]outfit_activity.ReportChannel, error) {
+	data, err := s.queries.ListOutfitActivityReportChannels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outfit activity report channels: %w", err)
+	}
+	channels := make([]outfit_activity.ReportChannel, 0, len(data))
+	for _, dto := range data {
+		channel := outfit_activity.ReportChannel{
+			Channel: s.dtoToChannel(ctx, dto),
+		}
+		if dto.OutfitActivityReportedAt.Valid {
+			channel.ReportedAt = dto.OutfitActivityReportedAt.Time
+		}
+		channels = append(channels, channel)
+	}
+	return channels, nil
+}
+
 func (s *Storage) publish(err error, event storage.Event) error {
 	if errors.Is(err, sql.ErrNoRows) {
 		return shared.ErrNotFound
@@ -515,5 +682,11 @@ func (s *Storage) dtoToChannel(ctx context.Context, dto db.Channel) discord.Chan
 		dto.OutfitNotifications,
 		dto.TitleUpdates,
 		loc,
+		dto.StatsTrackerDetails,
+		dto.StatsTrackerSort,
+		dto.StatsTrackerCharts,
+		dto.StatsTrackerScoreFormula,
+		dto.OutfitActivityReport,
+		dto.CharacterChangesNotifications,
 	)
 }
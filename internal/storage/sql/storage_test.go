@@ -0,0 +1,47 @@
+package sql_storage
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/x0k/ps2-spy/internal/lib/logger"
+	"github.com/x0k/ps2-spy/internal/lib/migrator"
+	"github.com/x0k/ps2-spy/internal/storage"
+
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+type eventsRecorder []storage.Event
+
+func (r *eventsRecorder) Publish(event storage.Event) {
+	*r = append(*r, event)
+}
+
+// Opens a storage with the applied migrations
+func newTestStorage(t *testing.T) (*Storage, *eventsRecorder) {
+	t.Helper()
+	ctx := context.Background()
+	path := "sqlite://" + filepath.Join(t.TempDir(), "storage.db")
+	if err := migrator.New(
+		slog.New(slog.DiscardHandler),
+		path,
+		"file://../../../db/migrations",
+	).Migrate(ctx); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	events := &eventsRecorder{}
+	s := New(logger.New(slog.New(slog.DiscardHandler)), path, time.Hour, events)
+	if err := s.Open(ctx); err != nil {
+		t.Fatalf("failed to open storage: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := s.Close(ctx); err != nil {
+			t.Errorf("failed to close storage: %v", err)
+		}
+	})
+	return s, events
+}
@@ -40,8 +40,8 @@ type Settings = settings[[]ps2.CharacterId, []ps2.OutfitId]
 type SettingsView = settings[[]string, []string]
 
 type SettingsData = settings[
-	[]ps2.Character,
-	map[ps2.OutfitId][]ps2.Character,
+	[]ps2.OnlineCharacter,
+	map[ps2.OutfitId][]ps2.OnlineCharacter,
 ]
 
 type settingsDiff[C any, O any] settings[diff.Diff[C], diff.Diff[O]]
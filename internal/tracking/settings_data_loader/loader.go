@@ -16,11 +16,11 @@ type SettingsRepo interface {
 }
 
 type OutfitsRepo interface {
-	MembersOnline(context.Context, ps2_platforms.Platform, []ps2.OutfitId) (map[ps2.OutfitId]map[ps2.CharacterId]ps2.Character, error)
+	MembersOnline(context.Context, ps2_platforms.Platform, []ps2.OutfitId) (map[ps2.OutfitId]map[ps2.CharacterId]ps2.OnlineCharacter, error)
 }
 
 type CharactersRepo interface {
-	Online(context.Context, ps2_platforms.Platform, []ps2.CharacterId) (map[ps2.CharacterId]ps2.Character, error)
+	Online(context.Context, ps2_platforms.Platform, []ps2.CharacterId) (map[ps2.CharacterId]ps2.OnlineCharacter, error)
 }
 
 type DataLoader struct {
@@ -52,7 +52,7 @@ func (l *DataLoader) Load(
 	if err != nil {
 		return tracking.SettingsData{}, fmt.Errorf("failed to load characters: %w", err)
 	}
-	outfits := make(map[ps2.OutfitId][]ps2.Character, len(members))
+	outfits := make(map[ps2.OutfitId][]ps2.OnlineCharacter, len(members))
 	for outfitId, members := range members {
 		outfits[outfitId] = mapx.Values(members)
 	}
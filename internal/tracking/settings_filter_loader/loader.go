@@ -0,0 +1,96 @@
+package tracking_settings_filter_loader
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/x0k/ps2-spy/internal/discord"
+	"github.com/x0k/ps2-spy/internal/ps2"
+	ps2_platforms "github.com/x0k/ps2-spy/internal/ps2/platforms"
+	"github.com/x0k/ps2-spy/internal/tracking"
+)
+
+type SettingsRepo interface {
+	Get(context.Context, discord.ChannelId, ps2_platforms.Platform) (tracking.Settings, error)
+}
+
+type OutfitsRepo interface {
+	OutfitTagsByIds(context.Context, ps2_platforms.Platform, []ps2.OutfitId) (map[ps2.OutfitId]string, error)
+}
+
+type CharactersRepo interface {
+	CharacterNamesByIds(context.Context, ps2_platforms.Platform, []ps2.CharacterId) (map[ps2.CharacterId]string, error)
+}
+
+// Resolves outfit tags and character names of the filter
+// into the entities tracked by the channel
+type FilterLoader struct {
+	repo           SettingsRepo
+	outfitsRepo    OutfitsRepo
+	charactersRepo CharactersRepo
+}
+
+func New(repo SettingsRepo, outfitsRepo OutfitsRepo, charactersRepo CharactersRepo) *FilterLoader {
+	return &FilterLoader{
+		repo:           repo,
+		outfitsRepo:    outfitsRepo,
+		charactersRepo: charactersRepo,
+	}
+}
+
+// Returns settings per platform and names that are not tracked by the channel
+func (l *FilterLoader) Load(
+	ctx context.Context,
+	channelId discord.ChannelId,
+	filter discord.StatsTrackerFilter,
+) (map[ps2_platforms.Platform]tracking.Settings, []string, error) {
+	found := make([]bool, len(filter))
+	matches := func(name string) bool {
+		i := slices.IndexFunc(filter, func(n string) bool {
+			return strings.EqualFold(n, name)
+		})
+		if i < 0 {
+			return false
+		}
+		found[i] = true
+		return true
+	}
+	settings := make(map[ps2_platforms.Platform]tracking.Settings, len(ps2_platforms.Platforms))
+	for _, platform := range ps2_platforms.Platforms {
+		tracked, err := l.repo.Get(ctx, channelId, platform)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load %q settings: %w", platform, err)
+		}
+		outfits, err := l.outfitsRepo.OutfitTagsByIds(ctx, platform, tracked.Outfits)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load %q outfits: %w", platform, err)
+		}
+		characters, err := l.charactersRepo.CharacterNamesByIds(ctx, platform, tracked.Characters)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load %q characters: %w", platform, err)
+		}
+		var s tracking.Settings
+		for outfitId, tag := range outfits {
+			if matches(tag) {
+				s.Outfits = append(s.Outfits, outfitId)
+			}
+		}
+		for characterId, name := range characters {
+			if matches(name) {
+				s.Characters = append(s.Characters, characterId)
+			}
+		}
+		if len(s.Outfits) > 0 || len(s.Characters) > 0 {
+			settings[platform] = s
+		}
+	}
+	var unknown []string
+	for i, name := range filter {
+		if !found[i] {
+			unknown = append(unknown, name)
+		}
+	}
+	return settings, unknown, nil
+}